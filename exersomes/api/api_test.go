@@ -0,0 +1,274 @@
+package api
+
+import (
+	"encoding/json"
+	"exersomes/components/metabolic/liver"
+	"exersomes/graphql"
+	"exersomes/molecular_types"
+	"exersomes/network"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func testServer() *Server {
+	catalog := network.MergeLigandEntries([]network.LigandEntry{
+		{Name: "Interleukin-6", Category: "Myokine", SourceTissues: []string{"Muscle"},
+			TargetTissues: []string{"Liver"}, Receptors: []string{"IL6R"}, Regulation: "Up"},
+		{Name: "IL-6", Category: "Myokine", SourceTissues: []string{"Immune"}},
+		{Name: "FGF21", Category: "Hepatokine", SourceTissues: []string{"Liver"},
+			TargetTissues: []string{"Adipose"}, Regulation: "Up"},
+		{Name: "Leptin", Category: "Adipokine", SourceTissues: []string{"Adipose"}, Regulation: "Down"},
+	})
+	net := molecular_types.ExerkineNetwork{
+		Nodes: []molecular_types.Exerkine{{Name: "Interleukin-6"}, {Name: "FGF21"}},
+		Interactions: []molecular_types.Interaction{
+			{Ligand: "Interleukin-6", Receptor: "IL6R", SourceTissue: "Muscle", TargetTissue: "Liver"},
+			{Ligand: "FGF21", Receptor: "FGFR1/KLB", SourceTissue: "Liver", TargetTissue: "Adipose"},
+		},
+	}
+	return NewServer(catalog, net)
+}
+
+func do(t *testing.T, s *Server, method, target, body string, out any) int {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: invalid JSON response: %v", method, target, err)
+		}
+	}
+	return rec.Code
+}
+
+// Test filtering, pagination and lookup by alias
+func TestExerkines(t *testing.T) {
+	s := testServer()
+
+	var list ExerkineList
+	if code := do(t, s, "GET", "/v1/exerkines?tissue=liver", "", &list); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if list.Pagination.Total != 2 {
+		t.Errorf("Expected IL-6 and FGF21 to touch the liver, got %+v", list.Items)
+	}
+
+	do(t, s, "GET", "/v1/exerkines?page_size=2&page=2", "", &list)
+	if len(list.Items) != 1 || list.Pagination.TotalPages != 2 {
+		t.Errorf("Expected last page of 3 merged exerkines to hold 1, got %+v", list)
+	}
+
+	// Pages past the last are empty, however large the page number
+	for _, target := range []string{"/v1/exerkines?page=3&page_size=2", "/v1/exerkines?page=922337203685477581"} {
+		list = ExerkineList{}
+		if code := do(t, s, "GET", target, "", &list); code != http.StatusOK || len(list.Items) != 0 || list.Pagination.Total != 3 {
+			t.Errorf("%s: expected an empty page of 3, got %d %+v", target, code, list)
+		}
+	}
+	var edges InteractionList
+	if code := do(t, s, "GET", "/v1/interactions?page=922337203685477581&page_size=100", "", &edges); code != http.StatusOK || len(edges.Items) != 0 {
+		t.Errorf("Expected an empty page of interactions, got %d %+v", code, edges)
+	}
+
+	var e Exerkine
+	if code := do(t, s, "GET", "/v1/exerkines/Interleukin%206", "", &e); code != http.StatusOK || e.ID != "il6" {
+		t.Errorf("Expected alias lookup of IL-6, got %d %+v", code, e)
+	}
+	if code := do(t, s, "GET", "/v1/exerkines/nope", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown exerkine, got %d", code)
+	}
+
+	var apiErr Error
+	if code := do(t, s, "GET", "/v1/exerkines?page_size=500", "", &apiErr); code != http.StatusBadRequest ||
+		len(apiErr.Fields) != 1 || apiErr.Fields[0].Field != "page_size" {
+		t.Errorf("Expected page_size validation error, got %d %+v", code, apiErr)
+	}
+}
+
+// Test interaction queries and tissue networks
+func TestNetworkRoutes(t *testing.T) {
+	s := testServer()
+
+	var edges InteractionList
+	do(t, s, "GET", "/v1/interactions?tissue=Liver&role=source", "", &edges)
+	if len(edges.Items) != 1 || edges.Items[0].Ligand != "FGF21" {
+		t.Errorf("Expected FGF21 as the only liver-sourced edge, got %+v", edges.Items)
+	}
+
+	var net molecular_types.ExerkineNetwork
+	if code := do(t, s, "GET", "/v1/networks/Adipose", "", &net); code != http.StatusOK || len(net.Nodes) != 1 {
+		t.Errorf("Expected adipose network with FGF21, got %d %+v", code, net)
+	}
+	if code := do(t, s, "GET", "/v1/networks/Kidney", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for tissue without edges, got %d", code)
+	}
+}
+
+// Test predictions from standard protocols and validation of custom prescriptions
+func TestPredictions(t *testing.T) {
+	s := testServer()
+
+	var bone BonePrediction
+	code := do(t, s, "POST", "/v1/predictions/bone",
+		`{"Protocol": "Bone Mineral Density Protocol", "Weeks": 16}`, &bone)
+	if code != http.StatusOK || len(bone.Response.FormationMetrics) == 0 {
+		t.Fatalf("Expected bone prediction, got %d %+v", code, bone)
+	}
+
+	var apiErr Error
+	code = do(t, s, "POST", "/v1/predictions/liver",
+		`{"Prescription": {"Name": "Custom", "PrimaryType": "Yoga", "IntensityPercent": 150,
+		  "DurationMinutes": 30, "FrequencyPerWeek": 3}, "Weeks": 0}`, &apiErr)
+	if code != http.StatusBadRequest || len(apiErr.Fields) != 3 {
+		t.Errorf("Expected 3 field errors, got %d %+v", code, apiErr)
+	}
+
+	var adipose AdiposePrediction
+	code = do(t, s, "POST", "/v1/predictions/adipose",
+		`{"Protocol": "Metabolic Health", "Weeks": 12, "Profile": {"BodyMassKg": 100, "BodyFatPercent": 30}}`, &adipose)
+	if code != http.StatusOK || adipose.Response["Fat mass change (kg)"] >= 0 {
+		t.Errorf("Expected adipose prediction for the profile, got %d %+v", code, adipose)
+	}
+	code = do(t, s, "POST", "/v1/predictions/bone",
+		`{"Protocol": "Bone Mineral Density Protocol", "Weeks": 16, "HighResorption": true, "Profile": {"Sex": "Other"}}`, &apiErr)
+	if code != http.StatusBadRequest || len(apiErr.Fields) != 2 {
+		t.Errorf("Expected conflicting and invalid profile errors, got %d %+v", code, apiErr)
+	}
+
+	if code := do(t, s, "POST", "/v1/predictions/adipose", `{"Unknown": 1}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected unknown fields to be rejected, got %d", code)
+	}
+}
+
+// Test the OpenAPI document covers every route and resolves its references
+func TestOpenAPI(t *testing.T) {
+	s := testServer()
+
+	var doc map[string]any
+	if code := do(t, s, "GET", "/v1/openapi.json", "", &doc); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	paths := doc["paths"].(map[string]any)
+	for _, r := range s.Routes() {
+		item, ok := paths[r.Path].(map[string]any)
+		if !ok || item[strings.ToLower(r.Method)] == nil {
+			t.Errorf("Missing %s %s in OpenAPI paths", r.Method, r.Path)
+		}
+	}
+
+	raw, _ := json.Marshal(doc)
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	for _, name := range []string{"BonePredictionRequest", "bone.ExercisePrescription", "molecular_types.Interaction"} {
+		if schemas[name] == nil {
+			t.Errorf("Missing schema %s", name)
+		}
+		if !strings.Contains(string(raw), `"#/components/schemas/`+name+`"`) {
+			t.Errorf("Schema %s is never referenced", name)
+		}
+	}
+}
+
+// Test GraphQL queries backed by the component condition catalogs
+func TestGraphQL(t *testing.T) {
+	s := testServer()
+
+	var out struct {
+		Data struct {
+			Exerkines []struct{ ID string }
+			Tissue    struct {
+				Receptors     []struct{ Name string }
+				Prescriptions []struct{ Name, ExerciseType string }
+			}
+		}
+		Errors []graphql.Error
+	}
+	body, _ := json.Marshal(map[string]any{
+		"query": `query($r: Regulation) {
+			exerkines(tissue: "Liver", regulation: $r) { id }
+			tissue(name: "hepatocyte") {
+				receptors(condition: "NAFLD") { name }
+				prescriptions(condition: "Fibrosis") { name exerciseType }
+			}
+		}`,
+		"variables": map[string]any{"r": "UP"},
+	})
+	code := do(t, s, "POST", "/v1/graphql", string(body), &out)
+	if code != http.StatusOK || len(out.Errors) > 0 {
+		t.Fatalf("Expected query to succeed, got %d %+v", code, out.Errors)
+	}
+	if len(out.Data.Exerkines) != 2 {
+		t.Errorf("Expected IL-6 and FGF21 as up-regulated liver exerkines, got %+v", out.Data.Exerkines)
+	}
+	if want := liver.GetReceptorsByLiverCondition("NAFLD"); len(out.Data.Tissue.Receptors) != len(want) {
+		t.Errorf("Expected %d NAFLD receptors, got %+v", len(want), out.Data.Tissue.Receptors)
+	}
+	if ps := out.Data.Tissue.Prescriptions; len(ps) != 1 || ps[0].Name != liver.LiverFibrosis.Name {
+		t.Errorf("Expected the fibrosis prescription, got %+v", ps)
+	}
+
+	out.Errors = nil
+	do(t, s, "GET", "/v1/graphql?query="+url.QueryEscape(`{ receptors(tissue: "Bone", condition: "NAFLD") { name } }`), "", &out)
+	if len(out.Errors) != 1 {
+		t.Errorf("Expected an error for a condition on a tissue without condition data, got %+v", out)
+	}
+
+	var edges struct {
+		Data struct {
+			Tissue struct {
+				Receptors []struct{ Name string }
+			}
+			Receptors []struct {
+				Name string
+				Up   []struct{ ID string }
+				Down []struct{ ID string }
+			}
+			Exerkine struct {
+				Receptors []struct {
+					Name   string
+					Tissue *struct{ Name string }
+				}
+			}
+		}
+		Errors []graphql.Error
+	}
+	do(t, s, "GET", "/v1/graphql?query="+url.QueryEscape(`{
+		tissue(name: "Liver") { receptors { name } }
+		receptors(tissue: "Liver", exerciseType: HIIT) {
+			name
+			up: ligands(exerciseType: HIIT, regulation: UP) { id }
+			down: ligands(exerciseType: HIIT, regulation: DOWN) { id }
+		}
+		exerkine(id: "IL-6") { receptors { name tissue { name } } }
+	}`), "", &edges)
+	if len(edges.Errors) > 0 {
+		t.Fatalf("Expected the network receptor query to succeed, got %+v", edges.Errors)
+	}
+	found := false
+	for _, r := range edges.Data.Tissue.Receptors {
+		found = found || r.Name == "IL6R"
+	}
+	if !found {
+		t.Errorf("Expected the IL-6 receptor from the network edge among liver receptors, got %+v", edges.Data.Tissue.Receptors)
+	}
+	if rs := edges.Data.Receptors; len(rs) != 1 || rs[0].Name != "IL6R" || len(rs[0].Up) != 1 || rs[0].Up[0].ID != "il6" || len(rs[0].Down) != 0 {
+		t.Errorf("Expected IL6R as the liver receptor for HIIT with IL-6 rising, got %+v", rs)
+	}
+	if rs := edges.Data.Exerkine.Receptors; len(rs) != 1 || rs[0].Tissue == nil || rs[0].Tissue.Name != "Liver" {
+		t.Errorf("Expected IL-6's receptor in the liver, got %+v", rs)
+	}
+
+	for path, want := range map[string]string{"/v1/graphql/schema": "type Exerkine {", "/v1/graphql/playground": "<textarea"} {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("Expected %s to contain %q, got %d", path, want, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,760 @@
+package api
+
+import (
+	"exersomes/components/bone"
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/cardiovascular/heart"
+	"exersomes/components/metabolic/adipose"
+	"exersomes/components/metabolic/liver"
+	"exersomes/components/metabolic/pancreas"
+	"exersomes/graphql"
+	"exersomes/molecular_types"
+	"exersomes/network"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GraphQLRequest is the body of a POST to the GraphQL endpoint
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// playgroundExample is the query the playground opens with
+const playgroundExample = `# Exerkines raised by HIIT that reach the liver
+{
+  exerkines(exerciseType: HIIT, regulation: UP, tissue: "Liver") {
+    name
+    category
+    response(exerciseType: HIIT)
+    receptors { name tissue { name } }
+  }
+  tissue(name: "Liver") {
+    receptors(condition: "NAFLD") { name regulation pathways { name } }
+    prescriptions(condition: "NAFLD") { name exerciseType intensityPercent durationMinutes frequencyPerWeek }
+  }
+}
+`
+
+// Receptor is a receptor expressed in one tissue
+type Receptor struct {
+	Name       string
+	Tissue     string
+	Ligands    []string
+	Pathways   []string
+	Regulation string // Exercise effect on expression, if the component records one
+}
+
+// Prescription is a component exercise prescription reduced to the fields
+// shared across tissues
+type Prescription struct {
+	Name              string
+	Tissue            string
+	Description       string
+	ExerciseType      string
+	IntensityPercent  int
+	DurationMinutes   int
+	FrequencyPerWeek  int
+	Targets           []string // Targeted exerkines or receptors
+	ExpectedBenefits  []string
+	Indications       []string
+	Contraindications []string
+}
+
+// exerciseTypes maps GraphQL enum names to the exercise type strings the
+// component predictors use
+var exerciseTypes = []graphql.EnumValue{
+	{Name: "AEROBIC", Value: "Aerobic", Description: "Continuous moderate-intensity endurance exercise"},
+	{Name: "HIIT", Value: "HIIT", Description: "High-intensity interval training"},
+	{Name: "SPRINT", Value: "Sprint", Description: "Sprint interval training"},
+	{Name: "RESISTANCE", Value: "Resistance", Description: "Resistance or strength training"},
+}
+
+// normalizeRegulation maps catalog regulation strings onto the Regulation enum values
+func normalizeRegulation(regulation string) string {
+	switch strings.ToLower(strings.TrimSpace(regulation)) {
+	case "up", "increased", "upregulated":
+		return "Up"
+	case "down", "decreased", "downregulated":
+		return "Down"
+	case "biphasic":
+		return "Biphasic"
+	case "no change", "unchanged":
+		return "No change"
+	}
+	return ""
+}
+
+// catalogReceptors collects receptors from the component packages with the
+// exercise regulation each records
+func catalogReceptors() []Receptor {
+	regulation := make(map[string]string)
+	for _, r := range heart.GetExerciseResponsiveReceptors() {
+		regulation["Heart/"+r.Name] = r.ExerciseResponse.Expression
+	}
+	for _, r := range append(liver.GetExerciseResponsiveReceptors(), liver.GlucagonReceptor) {
+		regulation["Liver/"+r.Name] = r.ExerciseEffect.Regulation
+	}
+	for _, r := range pancreas.GetExerciseResponsiveReceptors() {
+		regulation["Pancreas/"+r.Name] = r.ExerciseRegulation
+	}
+	for _, r := range adipose.GetReceptorsByAdiposeType("") {
+		regulation["Adipose/"+r.Name] = r.ExerciseRegulation
+	}
+
+	var receptors []Receptor
+	for _, r := range network.CatalogReceptors() {
+		receptors = append(receptors, Receptor{
+			Name:       r.Name,
+			Tissue:     r.Tissue,
+			Ligands:    r.Ligands,
+			Pathways:   r.Pathways,
+			Regulation: normalizeRegulation(regulation[r.Tissue+"/"+r.Name]),
+		})
+	}
+	return receptors
+}
+
+// withNetworkReceptors adds the receptors network edges engage in their target
+// tissues. Edges to a receptor already catalogued in that tissue add their ligand
+// and pathway to it; vesicle cargo edges name no receptor and are skipped.
+func withNetworkReceptors(receptors []Receptor, interactions []molecular_types.Interaction) []Receptor {
+	index := make(map[string]int)
+	for i, r := range receptors {
+		index[r.Tissue+"/"+network.CanonicalReceptor(r.Name)] = i
+	}
+	for _, in := range interactions {
+		tissue := network.NormalizeTissue(in.TargetTissue)
+		if tissue == "" {
+			tissue = network.NormalizeTissue(in.Tissue)
+		}
+		if in.Type == molecular_types.VesicleCargo || in.Receptor == "" || tissue == "" {
+			continue
+		}
+		key := tissue + "/" + network.CanonicalReceptor(in.Receptor)
+		i, ok := index[key]
+		if !ok {
+			i = len(receptors)
+			index[key] = i
+			receptors = append(receptors, Receptor{Name: in.Receptor, Tissue: tissue})
+		}
+		r := &receptors[i]
+		listed := false
+		for _, name := range r.Ligands {
+			listed = listed || network.CanonicalLigand(name) == network.CanonicalLigand(in.Ligand)
+		}
+		// Copy before appending so component catalog slices are never modified
+		if !listed && in.Ligand != "" {
+			r.Ligands = append(append([]string{}, r.Ligands...), in.Ligand)
+		}
+		if in.Pathway != "" && !containsFold(r.Pathways, in.Pathway) {
+			r.Pathways = append(append([]string{}, r.Pathways...), in.Pathway)
+		}
+	}
+	return receptors
+}
+
+// conditionReceptors returns the receptors a tissue's component associates with
+// a condition; ok is false for tissues without condition data
+func conditionReceptors(tissue, condition string) (names []string, ok bool) {
+	switch tissue {
+	case "Heart":
+		for _, r := range heart.GetReceptorsByCardiacCondition(condition) {
+			names = append(names, r.Name)
+		}
+	case "Liver":
+		for _, r := range liver.GetReceptorsByLiverCondition(condition) {
+			names = append(names, r.Name)
+		}
+	default:
+		return nil, false
+	}
+	return names, true
+}
+
+// tissuePrescriptions returns the component prescriptions for a tissue, filtered
+// by condition when one is given; ok is false for tissues without prescriptions
+func tissuePrescriptions(tissue, condition string) (prescriptions []Prescription, ok bool) {
+	switch tissue {
+	case "Bone":
+		list := bone.GetStandardPrescriptions()
+		if condition != "" {
+			list = bone.GetPrescriptionByCondition(condition)
+		}
+		for _, p := range list {
+			prescriptions = append(prescriptions, Prescription{
+				Name: p.Name, Tissue: tissue, Description: p.Description, ExerciseType: p.PrimaryType,
+				IntensityPercent: p.IntensityPercent, DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek,
+				Targets: p.TargetOsteokines, ExpectedBenefits: p.ExpectedBenefits,
+				Indications: p.IndicationsFor, Contraindications: p.Contraindications,
+			})
+		}
+	case "Liver":
+		list := liver.GetStandardPrescriptions()
+		if condition != "" {
+			list = liver.GetPrescriptionByCondition(condition)
+		}
+		for _, p := range list {
+			prescriptions = append(prescriptions, Prescription{
+				Name: p.Name, Tissue: tissue, Description: p.Description, ExerciseType: p.PrimaryType,
+				IntensityPercent: p.IntensityPercent, DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek,
+				Targets: append(append([]string{}, p.TargetHepatokines...), p.TargetReceptors...), ExpectedBenefits: p.ExpectedBenefits,
+				Indications: p.IndicationsFor, Contraindications: p.Contraindications,
+			})
+		}
+	case "Adipose":
+		list := adipose.GetStandardPrescriptions()
+		if condition != "" {
+			list = adipose.GetPrescriptionByTarget(condition)
+		}
+		for _, p := range list {
+			prescriptions = append(prescriptions, Prescription{
+				Name: p.Name, Tissue: tissue, Description: p.Description, ExerciseType: p.PrimaryType,
+				IntensityPercent: p.IntensityPercent, DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek,
+				Targets: append(append([]string{}, p.TargetAdipokines...), p.TargetReceptors...), ExpectedBenefits: p.ExpectedBenefits,
+			})
+		}
+	case "Pancreas":
+		list := pancreas.GetStandardPrescriptions()
+		if condition != "" {
+			list = pancreas.GetPrescriptionByCondition(condition)
+		}
+		for _, p := range list {
+			prescriptions = append(prescriptions, Prescription{
+				Name: p.Name, Tissue: tissue, ExerciseType: p.PrimaryType,
+				IntensityPercent: p.IntensityPercent, DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek,
+				Targets: p.TargetExerkines, ExpectedBenefits: p.ExpectedBenefits,
+				Indications: p.IndicationsFor, Contraindications: p.ContraindicationsFor,
+			})
+		}
+	case "Bloodstream":
+		list := bloodstream.GetStandardPrescriptions()
+		if condition != "" {
+			list = bloodstream.GetPrescriptionByCondition(condition)
+		}
+		for _, p := range list {
+			prescriptions = append(prescriptions, Prescription{
+				Name: p.Name, Tissue: tissue, Description: p.Description, ExerciseType: p.PrimaryType,
+				IntensityPercent: p.IntensityPercent, DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek,
+				Targets: p.TargetCircFactors, ExpectedBenefits: p.ExpectedBenefits,
+				Indications: p.IndicationsFor, Contraindications: p.Contraindications,
+			})
+		}
+	default:
+		return nil, false
+	}
+	return prescriptions, true
+}
+
+// prescriptionTissues are the tissues with component prescriptions
+var prescriptionTissues = []string{"Adipose", "Bloodstream", "Bone", "Liver", "Pancreas"}
+
+// graphQLResolver holds the data the GraphQL schema resolves against
+type graphQLResolver struct {
+	server    *Server
+	receptors []Receptor
+	responses map[string]map[string]float64 // Exercise type -> ligand key -> log2 fold change
+}
+
+func newGraphQLResolver(s *Server) *graphQLResolver {
+	g := &graphQLResolver{server: s, receptors: withNetworkReceptors(catalogReceptors(), s.network.Interactions),
+		responses: make(map[string]map[string]float64)}
+	for _, t := range exerciseTypes {
+		g.responses[t.Value.(string)] = network.ExerciseResponse(t.Value.(string))
+	}
+	return g
+}
+
+// tissues returns every tissue with edges, receptors or prescriptions, sorted
+func (g *graphQLResolver) tissues() []string {
+	seen := make(map[string]bool)
+	var tissues []string
+	add := func(t string) {
+		if t != "" && !seen[t] {
+			seen[t] = true
+			tissues = append(tissues, t)
+		}
+	}
+	for _, t := range g.server.tissues() {
+		add(t)
+	}
+	for _, r := range g.receptors {
+		add(r.Tissue)
+	}
+	for _, t := range prescriptionTissues {
+		add(t)
+	}
+	sort.Strings(tissues)
+	return tissues
+}
+
+func (g *graphQLResolver) exerkine(id string) (Exerkine, bool) {
+	key := network.CanonicalLigand(id)
+	for _, e := range g.server.catalog {
+		if e.ID == id || e.ID == key {
+			return e, true
+		}
+	}
+	return Exerkine{}, false
+}
+
+// exerkines filters the catalog. With an exercise type, regulation is the
+// direction of the predicted change rather than the catalog annotation.
+func (g *graphQLResolver) exerkines(tissue, category, regulation, exerciseType string) []Exerkine {
+	tissue = network.NormalizeTissue(tissue)
+	matches := []Exerkine{}
+	for _, e := range g.server.catalog {
+		if tissue != "" && !containsTissue(e.SourceTissues, tissue) && !containsTissue(e.TargetTissues, tissue) {
+			continue
+		}
+		if category != "" && !strings.EqualFold(e.Category, category) {
+			continue
+		}
+		if !g.regulated(e, regulation, exerciseType) {
+			continue
+		}
+		matches = append(matches, e)
+	}
+	return matches
+}
+
+// regulated reports whether an exerkine passes a regulation filter. With an exercise
+// type it must have a non-zero predicted change, and regulation is the direction of
+// that change rather than the catalog annotation.
+func (g *graphQLResolver) regulated(e Exerkine, regulation, exerciseType string) bool {
+	if exerciseType == "" {
+		return regulation == "" || normalizeRegulation(e.Regulation) == regulation
+	}
+	change, ok := g.responses[exerciseType][e.ID]
+	if !ok || change == 0 {
+		return false
+	}
+	switch regulation {
+	case "":
+		return true
+	case "Up":
+		return change > 0
+	case "Down":
+		return change < 0
+	}
+	return false
+}
+
+// receptorsFor filters receptors by tissue, condition and exercise type. Conditions
+// use the heart and liver condition catalogs; exercise types use the pancreatic
+// exercise catalog, and elsewhere keep receptors binding a responsive exerkine.
+func (g *graphQLResolver) receptorsFor(tissue, condition, exerciseType string) ([]Receptor, error) {
+	tissue = network.NormalizeTissue(tissue)
+	matches := []Receptor{}
+
+	var byCondition map[string]bool
+	if condition != "" {
+		tissues := []string{"Heart", "Liver"}
+		if tissue != "" {
+			tissues = []string{tissue}
+		}
+		byCondition = make(map[string]bool)
+		for _, t := range tissues {
+			names, ok := conditionReceptors(t, condition)
+			if !ok {
+				return nil, fmt.Errorf("no condition data for tissue %q; conditions apply to Heart and Liver", t)
+			}
+			for _, name := range names {
+				byCondition[t+"/"+name] = true
+			}
+		}
+	}
+
+	var pancreatic map[string]bool
+	if exerciseType != "" {
+		pancreatic = make(map[string]bool)
+		for _, r := range pancreas.GetReceptorsByExerciseType(exerciseType) {
+			pancreatic[r.Name] = true
+		}
+	}
+
+	for _, r := range g.receptors {
+		if tissue != "" && !strings.EqualFold(r.Tissue, tissue) {
+			continue
+		}
+		if byCondition != nil && !byCondition[r.Tissue+"/"+r.Name] {
+			continue
+		}
+		if exerciseType != "" {
+			if r.Tissue == "Pancreas" {
+				if !pancreatic[r.Name] {
+					continue
+				}
+			} else if !g.bindsResponsive(r, exerciseType) {
+				continue
+			}
+		}
+		matches = append(matches, r)
+	}
+	return matches, nil
+}
+
+// bindsResponsive reports whether any ligand of a receptor changes with an exercise type
+func (g *graphQLResolver) bindsResponsive(r Receptor, exerciseType string) bool {
+	return len(g.ligandsOf(r, "", exerciseType)) > 0
+}
+
+// receptorsOf matches an exerkine's receptors against the tissue receptors from the
+// component catalogs and network edges. Receptors not expressed in any known tissue
+// are returned without a tissue.
+func (g *graphQLResolver) receptorsOf(e Exerkine) []Receptor {
+	matches := []Receptor{}
+	seen := make(map[string]bool)
+	for _, r := range g.receptors {
+		if g.binds(e, r) {
+			matches = append(matches, r)
+			seen[network.CanonicalReceptor(r.Name)] = true
+		}
+	}
+	for _, name := range e.Receptors {
+		if key := network.CanonicalReceptor(name); !seen[key] {
+			seen[key] = true
+			matches = append(matches, Receptor{Name: name})
+		}
+	}
+	return matches
+}
+
+// ligandsOf returns the catalog exerkines binding a receptor, filtered as exerkines filters them
+func (g *graphQLResolver) ligandsOf(r Receptor, regulation, exerciseType string) []Exerkine {
+	matches := []Exerkine{}
+	for _, e := range g.server.catalog {
+		if g.binds(e, r) && g.regulated(e, regulation, exerciseType) {
+			matches = append(matches, e)
+		}
+	}
+	return matches
+}
+
+// binds reports whether the exerkine or receptor entry names the other, or a
+// network edge connects them
+func (g *graphQLResolver) binds(e Exerkine, r Receptor) bool {
+	receptorKey := network.CanonicalReceptor(r.Name)
+	for _, name := range e.Receptors {
+		if network.CanonicalReceptor(name) == receptorKey {
+			return true
+		}
+	}
+	for _, name := range r.Ligands {
+		if network.CanonicalLigand(name) == e.ID {
+			return true
+		}
+	}
+	for _, in := range g.server.network.Interactions {
+		if network.CanonicalLigand(in.Ligand) == e.ID && network.CanonicalReceptor(in.Receptor) == receptorKey &&
+			(r.Tissue == "" || in.TargetTissue == "" || strings.EqualFold(in.TargetTissue, r.Tissue)) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathways returns every pathway named by an exerkine or receptor, sorted
+func (g *graphQLResolver) pathways() []string {
+	seen := make(map[string]bool)
+	var pathways []string
+	add := func(names []string) {
+		for _, p := range names {
+			if p != "" && !seen[p] {
+				seen[p] = true
+				pathways = append(pathways, p)
+			}
+		}
+	}
+	for _, e := range g.server.catalog {
+		add(e.Pathways)
+	}
+	for _, r := range g.receptors {
+		add(r.Pathways)
+	}
+	sort.Strings(pathways)
+	return pathways
+}
+
+func containsFold(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringArg(p graphql.ResolveParams, name string) string {
+	s, _ := p.Args[name].(string)
+	return s
+}
+
+// newGraphQLSchema builds the Tissue/Exerkine/Receptor/Pathway/Prescription schema
+func newGraphQLSchema(s *Server) (*graphql.Schema, error) {
+	g := newGraphQLResolver(s)
+
+	regulation := &graphql.Enum{Name: "Regulation", Description: "Direction of an exercise-induced change",
+		Values: []graphql.EnumValue{
+			{Name: "UP", Value: "Up"},
+			{Name: "DOWN", Value: "Down"},
+			{Name: "BIPHASIC", Value: "Biphasic"},
+			{Name: "NO_CHANGE", Value: "No change"},
+		}}
+	exerciseType := &graphql.Enum{Name: "ExerciseType", Description: "Exercise modality driving the session predictors",
+		Values: exerciseTypes}
+
+	tissue := graphql.NewObject("Tissue", "An organ or tissue that secretes or responds to exerkines")
+	exerkine := graphql.NewObject("Exerkine", "A secreted factor merged across component catalogs")
+	receptor := graphql.NewObject("Receptor", "A receptor expressed in a tissue")
+	pathway := graphql.NewObject("Pathway", "A signaling pathway")
+	prescription := graphql.NewObject("Prescription", "A tissue-targeted exercise prescription")
+
+	list := func(t graphql.Type) graphql.Type { return graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(t))) }
+	regulationValue := func(value string) any {
+		if r := normalizeRegulation(value); r != "" {
+			return r
+		}
+		return nil
+	}
+
+	tissue.AddField(&graphql.Field{Name: "name", Type: graphql.NonNullOf(graphql.String),
+		Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source, nil }})
+	tissue.AddField(&graphql.Field{Name: "secretes", Type: list(exerkine), Description: "Exerkines with this source tissue",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			matches := []Exerkine{}
+			for _, e := range s.catalog {
+				if containsTissue(e.SourceTissues, p.Source.(string)) {
+					matches = append(matches, e)
+				}
+			}
+			return matches, nil
+		}})
+	tissue.AddField(&graphql.Field{Name: "targetedBy", Type: list(exerkine), Description: "Exerkines acting on this tissue",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			matches := []Exerkine{}
+			for _, e := range s.catalog {
+				if containsTissue(e.TargetTissues, p.Source.(string)) {
+					matches = append(matches, e)
+				}
+			}
+			return matches, nil
+		}})
+	tissue.AddField(&graphql.Field{Name: "receptors", Type: list(receptor),
+		Args: []graphql.Argument{
+			{Name: "condition", Type: graphql.String, Description: "Heart or liver condition, e.g. \"Heart Failure\" or \"NAFLD\""},
+			{Name: "exerciseType", Type: exerciseType},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return g.receptorsFor(p.Source.(string), stringArg(p, "condition"), stringArg(p, "exerciseType"))
+		}})
+	tissue.AddField(&graphql.Field{Name: "prescriptions", Type: list(prescription),
+		Args: []graphql.Argument{{Name: "condition", Type: graphql.String, Description: "Condition or training target"}},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			prescriptions, _ := tissuePrescriptions(p.Source.(string), stringArg(p, "condition"))
+			return append([]Prescription{}, prescriptions...), nil
+		}})
+
+	exerkine.AddField(&graphql.Field{Name: "id", Type: graphql.NonNullOf(graphql.ID), Description: "Canonical key, e.g. \"il6\""})
+	exerkine.AddField(&graphql.Field{Name: "name", Type: graphql.NonNullOf(graphql.String)})
+	exerkine.AddField(&graphql.Field{Name: "category", Type: graphql.String})
+	exerkine.AddField(&graphql.Field{Name: "regulation", Type: regulation, Description: "Catalog exercise regulation",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return regulationValue(p.Source.(Exerkine).Regulation), nil
+		}})
+	exerkine.AddField(&graphql.Field{Name: "sourceTissues", Type: list(tissue),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return normalizedTissues(p.Source.(Exerkine).SourceTissues), nil
+		}})
+	exerkine.AddField(&graphql.Field{Name: "targetTissues", Type: list(tissue),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return normalizedTissues(p.Source.(Exerkine).TargetTissues), nil
+		}})
+	exerkine.AddField(&graphql.Field{Name: "receptors", Type: list(receptor),
+		Resolve: func(p graphql.ResolveParams) (any, error) { return g.receptorsOf(p.Source.(Exerkine)), nil }})
+	exerkine.AddField(&graphql.Field{Name: "pathways", Type: list(pathway),
+		Resolve: func(p graphql.ResolveParams) (any, error) { return nonNilStrings(p.Source.(Exerkine).Pathways), nil }})
+	exerkine.AddField(&graphql.Field{Name: "response", Type: graphql.Float,
+		Description: "Predicted log2 fold change after one session; null without a prediction",
+		Args:        []graphql.Argument{{Name: "exerciseType", Type: graphql.NonNullOf(exerciseType)}},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if change, ok := g.responses[stringArg(p, "exerciseType")][p.Source.(Exerkine).ID]; ok {
+				return change, nil
+			}
+			return nil, nil
+		}})
+
+	receptor.AddField(&graphql.Field{Name: "name", Type: graphql.NonNullOf(graphql.String)})
+	receptor.AddField(&graphql.Field{Name: "tissue", Type: tissue,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if t := p.Source.(Receptor).Tissue; t != "" {
+				return t, nil
+			}
+			return nil, nil
+		}})
+	receptor.AddField(&graphql.Field{Name: "regulation", Type: regulation, Description: "Exercise effect on expression",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return regulationValue(p.Source.(Receptor).Regulation), nil
+		}})
+	receptor.AddField(&graphql.Field{Name: "ligands", Type: list(exerkine),
+		Description: "Exerkines binding the receptor. With exerciseType, regulation is the direction of the predicted change.",
+		Args: []graphql.Argument{
+			{Name: "exerciseType", Type: exerciseType},
+			{Name: "regulation", Type: regulation},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return g.ligandsOf(p.Source.(Receptor), stringArg(p, "regulation"), stringArg(p, "exerciseType")), nil
+		}})
+	receptor.AddField(&graphql.Field{Name: "pathways", Type: list(pathway),
+		Resolve: func(p graphql.ResolveParams) (any, error) { return nonNilStrings(p.Source.(Receptor).Pathways), nil }})
+
+	pathway.AddField(&graphql.Field{Name: "name", Type: graphql.NonNullOf(graphql.String),
+		Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source, nil }})
+	pathway.AddField(&graphql.Field{Name: "exerkines", Type: list(exerkine),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			matches := []Exerkine{}
+			for _, e := range s.catalog {
+				if containsFold(e.Pathways, p.Source.(string)) {
+					matches = append(matches, e)
+				}
+			}
+			return matches, nil
+		}})
+	pathway.AddField(&graphql.Field{Name: "receptors", Type: list(receptor),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			matches := []Receptor{}
+			for _, r := range g.receptors {
+				if containsFold(r.Pathways, p.Source.(string)) {
+					matches = append(matches, r)
+				}
+			}
+			return matches, nil
+		}})
+
+	prescription.AddField(&graphql.Field{Name: "name", Type: graphql.NonNullOf(graphql.String)})
+	prescription.AddField(&graphql.Field{Name: "tissue", Type: graphql.NonNullOf(tissue)})
+	prescription.AddField(&graphql.Field{Name: "description", Type: graphql.String})
+	prescription.AddField(&graphql.Field{Name: "exerciseType", Type: graphql.String, Description: "Primary exercise type, e.g. \"Aerobic\" or \"Impact\""})
+	prescription.AddField(&graphql.Field{Name: "intensityPercent", Type: graphql.Int, Description: "% of max HR, VO2max or 1RM"})
+	prescription.AddField(&graphql.Field{Name: "durationMinutes", Type: graphql.Int})
+	prescription.AddField(&graphql.Field{Name: "frequencyPerWeek", Type: graphql.Int})
+	prescription.AddField(&graphql.Field{Name: "targets", Type: list(graphql.String), Description: "Targeted exerkines or receptors",
+		Resolve: func(p graphql.ResolveParams) (any, error) { return nonNilStrings(p.Source.(Prescription).Targets), nil }})
+	prescription.AddField(&graphql.Field{Name: "expectedBenefits", Type: list(graphql.String),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return nonNilStrings(p.Source.(Prescription).ExpectedBenefits), nil
+		}})
+	prescription.AddField(&graphql.Field{Name: "indications", Type: list(graphql.String),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return nonNilStrings(p.Source.(Prescription).Indications), nil
+		}})
+	prescription.AddField(&graphql.Field{Name: "contraindications", Type: list(graphql.String),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return nonNilStrings(p.Source.(Prescription).Contraindications), nil
+		}})
+
+	query := graphql.NewObject("Query", "",
+		&graphql.Field{Name: "tissues", Type: list(tissue),
+			Resolve: func(p graphql.ResolveParams) (any, error) { return g.tissues(), nil }},
+		&graphql.Field{Name: "tissue", Type: tissue,
+			Args: []graphql.Argument{{Name: "name", Type: graphql.NonNullOf(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				name := network.NormalizeTissue(stringArg(p, "name"))
+				for _, t := range g.tissues() {
+					if strings.EqualFold(t, name) {
+						return t, nil
+					}
+				}
+				return nil, nil
+			}},
+		&graphql.Field{Name: "exerkines", Type: list(exerkine),
+			Description: "Filter exerkines. With exerciseType, regulation is the direction of the predicted change.",
+			Args: []graphql.Argument{
+				{Name: "tissue", Type: graphql.String, Description: "Source or target tissue"},
+				{Name: "category", Type: graphql.String},
+				{Name: "regulation", Type: regulation},
+				{Name: "exerciseType", Type: exerciseType},
+			},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return g.exerkines(stringArg(p, "tissue"), stringArg(p, "category"),
+					stringArg(p, "regulation"), stringArg(p, "exerciseType")), nil
+			}},
+		&graphql.Field{Name: "exerkine", Type: exerkine,
+			Args: []graphql.Argument{{Name: "id", Type: graphql.NonNullOf(graphql.ID), Description: "Canonical ID or any alias"}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				if e, ok := g.exerkine(stringArg(p, "id")); ok {
+					return e, nil
+				}
+				return nil, nil
+			}},
+		&graphql.Field{Name: "receptors", Type: list(receptor),
+			Args: []graphql.Argument{
+				{Name: "tissue", Type: graphql.String},
+				{Name: "condition", Type: graphql.String, Description: "Heart or liver condition"},
+				{Name: "exerciseType", Type: exerciseType},
+			},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return g.receptorsFor(stringArg(p, "tissue"), stringArg(p, "condition"), stringArg(p, "exerciseType"))
+			}},
+		&graphql.Field{Name: "pathways", Type: list(pathway),
+			Resolve: func(p graphql.ResolveParams) (any, error) { return g.pathways(), nil }},
+		&graphql.Field{Name: "pathway", Type: pathway,
+			Args: []graphql.Argument{{Name: "name", Type: graphql.NonNullOf(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				for _, name := range g.pathways() {
+					if strings.EqualFold(name, stringArg(p, "name")) {
+						return name, nil
+					}
+				}
+				return nil, nil
+			}},
+		&graphql.Field{Name: "prescriptions", Type: list(prescription),
+			Args: []graphql.Argument{
+				{Name: "tissue", Type: graphql.String, Description: "One of " + strings.Join(prescriptionTissues, ", ")},
+				{Name: "condition", Type: graphql.String, Description: "Condition or training target"},
+			},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				tissues := prescriptionTissues
+				if t := stringArg(p, "tissue"); t != "" {
+					tissues = []string{network.NormalizeTissue(t)}
+					for _, known := range prescriptionTissues {
+						if strings.EqualFold(known, tissues[0]) {
+							tissues[0] = known
+						}
+					}
+				}
+				all := []Prescription{}
+				for _, t := range tissues {
+					prescriptions, ok := tissuePrescriptions(t, stringArg(p, "condition"))
+					if !ok {
+						return nil, fmt.Errorf("no prescriptions for tissue %q; expected one of %s",
+							t, strings.Join(prescriptionTissues, ", "))
+					}
+					all = append(all, prescriptions...)
+				}
+				return all, nil
+			}},
+	)
+
+	return graphql.NewSchema(query)
+}
+
+// normalizedTissues maps tissue spellings onto network tissue names, deduplicated
+func normalizedTissues(tissues []string) []string {
+	out := []string{}
+	for _, t := range tissues {
+		if n := network.NormalizeTissue(t); n != "" && !containsFold(out, n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func nonNilStrings(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// OpenAPI returns the OpenAPI 3.0 document for the server's routes. Request and
+// response schemas are generated from the Go types by reflection, with JSON
+// property names matching encoding/json's default (exported field names).
+func (s *Server) OpenAPI() map[string]any {
+	schemas := make(map[string]any)
+	paths := make(map[string]any)
+
+	for _, r := range s.routes {
+		op := map[string]any{
+			"summary":     r.Summary,
+			"operationId": operationID(r),
+		}
+
+		var params []any
+		for _, p := range r.Params {
+			params = append(params, map[string]any{
+				"name":        p.Name,
+				"in":          p.In,
+				"required":    p.Required || p.In == "path",
+				"description": p.Description,
+				"schema":      map[string]any{"type": p.Type},
+			})
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		if r.Body != nil {
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(r.Body), schemas)},
+				},
+			}
+		}
+
+		okResponse := map[string]any{"description": "OK"}
+		if r.Response != nil {
+			okResponse["content"] = map[string]any{
+				"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(r.Response), schemas)},
+			}
+		} else {
+			okResponse["content"] = map[string]any{"application/json": map[string]any{"schema": map[string]any{"type": "object"}}}
+		}
+		errorResponse := map[string]any{
+			"description": "Error",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(Error{}), schemas)},
+			},
+		}
+		op["responses"] = map[string]any{"200": okResponse, "default": errorResponse}
+
+		item, ok := paths[r.Path].(map[string]any)
+		if !ok {
+			item = make(map[string]any)
+			paths[r.Path] = item
+		}
+		item[strings.ToLower(r.Method)] = op
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Exersomes API",
+			"version":     Version,
+			"description": "Exerkine catalog, ligand-receptor network and exercise response predictions",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func (s *Server) serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.OpenAPI())
+}
+
+// operationID derives an identifier such as "getExerkinesId" from a route
+func operationID(r Route) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(r.Method))
+	for _, part := range strings.Split(strings.TrimPrefix(r.Path, "/"+Version), "/") {
+		part = strings.Trim(part, "{}")
+		part = strings.TrimSuffix(part, ".json")
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// schemaName names a component schema; types outside this package are qualified
+// by package, e.g. "bone.ExercisePrescription"
+func schemaName(t reflect.Type) string {
+	if t.PkgPath() == reflect.TypeOf(Server{}).PkgPath() {
+		return t.Name()
+	}
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+// schemaFor returns the JSON schema of a Go type, adding named structs to schemas
+// and referring to them by $ref
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := schemaFor(t.Elem(), schemas)
+		return map[string]any{"allOf": []any{s}, "nullable": true}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Interface:
+		return map[string]any{}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, schemas)
+		}
+		name := schemaName(t)
+		if _, ok := schemas[name]; !ok {
+			schemas[name] = nil // Reserve the name for recursive types
+			schemas[name] = structSchema(t, schemas)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]any{}
+}
+
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := make(map[string]any)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if n := strings.Split(tag, ",")[0]; n != "" {
+				name = n
+			}
+		}
+		properties[name] = schemaFor(f.Type, schemas)
+	}
+	return map[string]any{"type": "object", "properties": properties}
+}
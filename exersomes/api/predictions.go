@@ -0,0 +1,256 @@
+package api
+
+import (
+	"exersomes/components/bone"
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/metabolic/adipose"
+	"exersomes/components/metabolic/liver"
+	"exersomes/profile"
+	"net/http"
+)
+
+// Request limits shared by the predictors
+const (
+	maxWeeks           = 104
+	maxDurationMinutes = 300
+	maxSessionsPerWeek = 14
+)
+
+var (
+	boneTypes      = []string{"Resistance", "Impact", "Combined", "Plyometric"}
+	boneLoads      = []string{"Low", "Moderate", "High", "Variable"}
+	metabolicTypes = []string{"Aerobic", "Resistance", "HIIT", "Combined"}
+)
+
+// Prediction requests name either a standard Protocol (the Name of one of the
+// component's predefined prescriptions) or a full Prescription, not both. The
+// component predictors select their coefficients by prescription Name, so custom
+// prescriptions should keep the Name of the protocol they adapt. A Profile
+// describes the individual and replaces the request's own risk factors.
+
+// BonePredictionRequest is the input to bone.PredictBoneResponse
+type BonePredictionRequest struct {
+	Protocol           string
+	Prescription       *bone.ExercisePrescription
+	Weeks              int
+	LowBaselineDensity bool
+	HighResorption     bool
+	Profile            *profile.Profile
+}
+
+// BonePrediction is the result of a bone prediction
+type BonePrediction struct {
+	Prescription bone.ExercisePrescription
+	Weeks        int
+	Response     bone.BoneResponse
+}
+
+// LiverPredictionRequest is the input to liver.PredictLiverResponse
+type LiverPredictionRequest struct {
+	Protocol             string
+	Prescription         *liver.ExercisePrescription
+	Weeks                int
+	HasNAFLD             bool
+	HasInsulinResistance bool
+	Profile              *profile.Profile
+}
+
+// LiverPrediction is the result of a liver prediction
+type LiverPrediction struct {
+	Prescription liver.ExercisePrescription
+	Weeks        int
+	Response     map[string]interface{}
+}
+
+// AdiposePredictionRequest is the input to adipose.PredictAdiposeResponse
+type AdiposePredictionRequest struct {
+	Protocol       string
+	Prescription   *adipose.ExercisePrescription
+	Weeks          int
+	BodyFatPercent float64
+	Profile        *profile.Profile
+}
+
+// AdiposePrediction is the result of an adipose prediction
+type AdiposePrediction struct {
+	Prescription adipose.ExercisePrescription
+	Weeks        int
+	Response     map[string]float64
+}
+
+// CirculatoryPredictionRequest is the input to bloodstream.PredictCirculatoryResponse
+type CirculatoryPredictionRequest struct {
+	Protocol               string
+	Prescription           *bloodstream.ExercisePrescription
+	Weeks                  int
+	BaselineInflammation   bool
+	EndothelialDysfunction bool
+	Profile                *profile.Profile
+}
+
+// CirculatoryPrediction is the result of a bloodstream prediction
+type CirculatoryPrediction struct {
+	Prescription bloodstream.ExercisePrescription
+	Weeks        int
+	Response     bloodstream.CirculatoryResponse
+}
+
+// resolvePrescription returns the standard protocol or the custom prescription
+func resolvePrescription[P any](v *validator, protocol string, custom *P, standard []P, name func(P) string) P {
+	var zero P
+	switch {
+	case protocol != "" && custom != nil:
+		v.check(false, "Protocol", "set either Protocol or Prescription, not both")
+	case protocol != "":
+		var names []string
+		for _, p := range standard {
+			if name(p) == protocol {
+				return p
+			}
+			names = append(names, name(p))
+		}
+		v.checkOneOf(protocol, names, "Protocol")
+	case custom != nil:
+		return *custom
+	default:
+		v.check(false, "Prescription", "Protocol or Prescription is required")
+	}
+	return zero
+}
+
+// checkProfile validates a request's profile, which may not be combined with
+// the request's own risk factors
+func checkProfile(v *validator, p *profile.Profile, riskFactors bool, fields string) {
+	if p == nil {
+		return
+	}
+	v.check(!riskFactors, "Profile", "set either Profile or %s, not both", fields)
+	if err := p.Validate(); err != nil {
+		v.check(false, "Profile", "%v", err)
+	}
+}
+
+// checkDose validates the parameters every prescription shares
+func checkDose(v *validator, intensityPercent, durationMinutes, frequencyPerWeek int) {
+	v.checkRange(intensityPercent, 1, 100, "Prescription.IntensityPercent")
+	v.checkRange(durationMinutes, 1, maxDurationMinutes, "Prescription.DurationMinutes")
+	v.checkRange(frequencyPerWeek, 1, maxSessionsPerWeek, "Prescription.FrequencyPerWeek")
+}
+
+func (s *Server) predictBone(w http.ResponseWriter, r *http.Request) {
+	var req BonePredictionRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	var v validator
+	p := resolvePrescription(&v, req.Protocol, req.Prescription, bone.GetStandardPrescriptions(),
+		func(p bone.ExercisePrescription) string { return p.Name })
+	if req.Prescription != nil {
+		v.checkOneOf(p.PrimaryType, boneTypes, "Prescription.PrimaryType")
+		v.checkOneOf(p.LoadMagnitude, boneLoads, "Prescription.LoadMagnitude")
+		checkDose(&v, p.IntensityPercent, p.DurationMinutes, p.FrequencyPerWeek)
+	}
+	v.checkRange(req.Weeks, 1, maxWeeks, "Weeks")
+	checkProfile(&v, req.Profile, req.LowBaselineDensity || req.HighResorption, "LowBaselineDensity and HighResorption")
+	if v.failed(w) {
+		return
+	}
+
+	var response bone.BoneResponse
+	if req.Profile != nil {
+		response = bone.PredictBoneResponseForProfile(p, req.Weeks, *req.Profile)
+	} else {
+		response = bone.PredictBoneResponse(p, req.Weeks, req.LowBaselineDensity, req.HighResorption)
+	}
+	writeJSON(w, http.StatusOK, BonePrediction{Prescription: p, Weeks: req.Weeks, Response: response})
+}
+
+func (s *Server) predictLiver(w http.ResponseWriter, r *http.Request) {
+	var req LiverPredictionRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	var v validator
+	p := resolvePrescription(&v, req.Protocol, req.Prescription, liver.GetStandardPrescriptions(),
+		func(p liver.ExercisePrescription) string { return p.Name })
+	if req.Prescription != nil {
+		v.checkOneOf(p.PrimaryType, metabolicTypes, "Prescription.PrimaryType")
+		checkDose(&v, p.IntensityPercent, p.DurationMinutes, p.FrequencyPerWeek)
+	}
+	v.checkRange(req.Weeks, 1, maxWeeks, "Weeks")
+	checkProfile(&v, req.Profile, req.HasNAFLD || req.HasInsulinResistance, "HasNAFLD and HasInsulinResistance")
+	if v.failed(w) {
+		return
+	}
+
+	var response map[string]interface{}
+	if req.Profile != nil {
+		response = liver.PredictLiverResponseForProfile(p, req.Weeks, *req.Profile)
+	} else {
+		response = liver.PredictLiverResponse(p, req.Weeks, req.HasNAFLD, req.HasInsulinResistance)
+	}
+	writeJSON(w, http.StatusOK, LiverPrediction{Prescription: p, Weeks: req.Weeks, Response: response})
+}
+
+func (s *Server) predictAdipose(w http.ResponseWriter, r *http.Request) {
+	var req AdiposePredictionRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	var v validator
+	p := resolvePrescription(&v, req.Protocol, req.Prescription, adipose.GetStandardPrescriptions(),
+		func(p adipose.ExercisePrescription) string { return p.Name })
+	if req.Prescription != nil {
+		v.checkOneOf(p.PrimaryType, metabolicTypes, "Prescription.PrimaryType")
+		checkDose(&v, p.IntensityPercent, p.DurationMinutes, p.FrequencyPerWeek)
+	}
+	v.checkRange(req.Weeks, 1, maxWeeks, "Weeks")
+	if req.Profile == nil {
+		v.check(req.BodyFatPercent >= 3 && req.BodyFatPercent <= 70, "BodyFatPercent",
+			"must be between 3 and 70, got %g", req.BodyFatPercent)
+	}
+	checkProfile(&v, req.Profile, req.BodyFatPercent != 0, "BodyFatPercent")
+	if v.failed(w) {
+		return
+	}
+
+	var response map[string]float64
+	if req.Profile != nil {
+		response = adipose.PredictAdiposeResponseForProfile(p, req.Weeks, *req.Profile)
+	} else {
+		response = adipose.PredictAdiposeResponse(p, req.Weeks, req.BodyFatPercent)
+	}
+	writeJSON(w, http.StatusOK, AdiposePrediction{Prescription: p, Weeks: req.Weeks, Response: response})
+}
+
+func (s *Server) predictCirculatory(w http.ResponseWriter, r *http.Request) {
+	var req CirculatoryPredictionRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	var v validator
+	p := resolvePrescription(&v, req.Protocol, req.Prescription, bloodstream.GetStandardPrescriptions(),
+		func(p bloodstream.ExercisePrescription) string { return p.Name })
+	if req.Prescription != nil {
+		v.checkOneOf(p.PrimaryType, metabolicTypes, "Prescription.PrimaryType")
+		checkDose(&v, p.IntensityPercent, p.DurationMinutes, p.FrequencyPerWeek)
+	}
+	v.checkRange(req.Weeks, 1, maxWeeks, "Weeks")
+	checkProfile(&v, req.Profile, req.BaselineInflammation || req.EndothelialDysfunction,
+		"BaselineInflammation and EndothelialDysfunction")
+	if v.failed(w) {
+		return
+	}
+
+	var response bloodstream.CirculatoryResponse
+	if req.Profile != nil {
+		response = bloodstream.PredictCirculatoryResponseForProfile(p, req.Weeks, *req.Profile)
+	} else {
+		response = bloodstream.PredictCirculatoryResponse(p, req.Weeks, req.BaselineInflammation, req.EndothelialDysfunction)
+	}
+	writeJSON(w, http.StatusOK, CirculatoryPrediction{Prescription: p, Weeks: req.Weeks, Response: response})
+}
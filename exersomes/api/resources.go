@@ -0,0 +1,253 @@
+package api
+
+import (
+	"exersomes/molecular_types"
+	"exersomes/network"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pagination defaults
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var pageParams = []Param{
+	{Name: "page", In: "query", Type: "integer", Description: "1-based page number (default 1)"},
+	{Name: "page_size", In: "query", Type: "integer", Description: "Items per page, 1-100 (default 20)"},
+}
+
+// Exerkine is a catalog ligand merged across component packages
+type Exerkine struct {
+	ID            string // Canonical key, e.g. "il6"
+	Name          string
+	Category      string
+	SourceTissues []string
+	TargetTissues []string
+	Receptors     []string
+	Pathways      []string
+	Regulation    string
+}
+
+// Pagination describes one page of a list
+type Pagination struct {
+	Page       int
+	PageSize   int
+	Total      int
+	TotalPages int
+}
+
+// ExerkineList is a page of exerkines
+type ExerkineList struct {
+	Items      []Exerkine
+	Pagination Pagination
+}
+
+// InteractionList is a page of ligand-receptor edges
+type InteractionList struct {
+	Items      []molecular_types.Interaction
+	Pagination Pagination
+}
+
+func exerkineFromEntry(e network.LigandEntry) Exerkine {
+	return Exerkine{
+		ID:            network.CanonicalLigand(e.Name),
+		Name:          e.Name,
+		Category:      e.Category,
+		SourceTissues: e.SourceTissues,
+		TargetTissues: e.TargetTissues,
+		Receptors:     e.Receptors,
+		Pathways:      e.Pathways,
+		Regulation:    e.Regulation,
+	}
+}
+
+// pageBounds validates page and page_size and returns the slice bounds for total items
+func pageBounds(v *validator, query url.Values, total int) (Pagination, int, int) {
+	p := Pagination{Page: 1, PageSize: defaultPageSize, Total: total}
+	if s := query.Get("page"); s != "" {
+		n, err := strconv.Atoi(s)
+		v.check(err == nil && n >= 1, "page", "must be a positive integer")
+		p.Page = n
+	}
+	if s := query.Get("page_size"); s != "" {
+		n, err := strconv.Atoi(s)
+		v.check(err == nil && n >= 1 && n <= maxPageSize, "page_size", "must be an integer between 1 and %d", maxPageSize)
+		p.PageSize = n
+	}
+	if len(v.errors) > 0 {
+		return p, 0, 0
+	}
+
+	p.TotalPages = (total + p.PageSize - 1) / p.PageSize
+	// Pages past the last are empty; checking first keeps the offset from overflowing
+	if p.Page > p.TotalPages {
+		return p, total, total
+	}
+	start := (p.Page - 1) * p.PageSize
+	end := start + p.PageSize
+	if end > total {
+		end = total
+	}
+	return p, start, end
+}
+
+func containsTissue(tissues []string, tissue string) bool {
+	for _, t := range tissues {
+		if strings.EqualFold(network.NormalizeTissue(t), tissue) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listExerkines(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	tissue := network.NormalizeTissue(query.Get("tissue"))
+	category := query.Get("category")
+	regulation := query.Get("regulation")
+	receptor := query.Get("receptor")
+	search := strings.ToLower(query.Get("q"))
+
+	var matches []Exerkine
+	for _, e := range s.catalog {
+		if tissue != "" && !containsTissue(e.SourceTissues, tissue) && !containsTissue(e.TargetTissues, tissue) {
+			continue
+		}
+		if category != "" && !strings.EqualFold(e.Category, category) {
+			continue
+		}
+		if regulation != "" && !strings.EqualFold(e.Regulation, regulation) {
+			continue
+		}
+		if receptor != "" {
+			found := false
+			for _, name := range e.Receptors {
+				if network.CanonicalReceptor(name) == network.CanonicalReceptor(receptor) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if search != "" && !strings.Contains(strings.ToLower(e.Name), search) && !strings.Contains(e.ID, search) {
+			continue
+		}
+		matches = append(matches, e)
+	}
+
+	var v validator
+	page, start, end := pageBounds(&v, query, len(matches))
+	if v.failed(w) {
+		return
+	}
+	writeJSON(w, http.StatusOK, ExerkineList{Items: append([]Exerkine{}, matches[start:end]...), Pagination: page})
+}
+
+func (s *Server) getExerkine(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	key := network.CanonicalLigand(id)
+	for _, e := range s.catalog {
+		if e.ID == id || e.ID == key {
+			writeJSON(w, http.StatusOK, e)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "unknown exerkine "+strconv.Quote(id), nil)
+}
+
+func (s *Server) listInteractions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	tissue := network.NormalizeTissue(query.Get("tissue"))
+	role := query.Get("role")
+	ligand := query.Get("ligand")
+	receptor := query.Get("receptor")
+
+	var v validator
+	if role == "" {
+		role = "any"
+	}
+	v.checkOneOf(role, []string{"source", "target", "any"}, "role")
+	if v.failed(w) {
+		return
+	}
+
+	var matches []molecular_types.Interaction
+	for _, in := range s.network.Interactions {
+		if tissue != "" {
+			isSource := strings.EqualFold(in.SourceTissue, tissue)
+			isTarget := strings.EqualFold(in.TargetTissue, tissue)
+			if (role == "source" && !isSource) || (role == "target" && !isTarget) || (role == "any" && !isSource && !isTarget) {
+				continue
+			}
+		}
+		if ligand != "" && network.CanonicalLigand(in.Ligand) != network.CanonicalLigand(ligand) {
+			continue
+		}
+		if receptor != "" && network.CanonicalReceptor(in.Receptor) != network.CanonicalReceptor(receptor) {
+			continue
+		}
+		matches = append(matches, in)
+	}
+
+	page, start, end := pageBounds(&v, query, len(matches))
+	if v.failed(w) {
+		return
+	}
+	writeJSON(w, http.StatusOK, InteractionList{
+		Items:      append([]molecular_types.Interaction{}, matches[start:end]...),
+		Pagination: page,
+	})
+}
+
+// tissues returns every source and target tissue in the network, sorted
+func (s *Server) tissues() []string {
+	seen := make(map[string]bool)
+	var tissues []string
+	for _, in := range s.network.Interactions {
+		for _, t := range []string{in.SourceTissue, in.TargetTissue} {
+			if t != "" && !seen[t] {
+				seen[t] = true
+				tissues = append(tissues, t)
+			}
+		}
+	}
+	sort.Strings(tissues)
+	return tissues
+}
+
+func (s *Server) listTissues(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.tissues())
+}
+
+func (s *Server) getNetwork(w http.ResponseWriter, r *http.Request) {
+	tissue := network.NormalizeTissue(r.PathValue("tissue"))
+
+	sub := molecular_types.ExerkineNetwork{
+		Nodes:        []molecular_types.Exerkine{},
+		Interactions: []molecular_types.Interaction{},
+	}
+	ligands := make(map[string]bool)
+	for _, in := range s.network.Interactions {
+		if strings.EqualFold(in.SourceTissue, tissue) || strings.EqualFold(in.TargetTissue, tissue) {
+			sub.Interactions = append(sub.Interactions, in)
+			ligands[in.Ligand] = true
+		}
+	}
+	if len(sub.Interactions) == 0 {
+		writeError(w, http.StatusNotFound, "no interactions for tissue "+strconv.Quote(tissue), nil)
+		return
+	}
+	for _, node := range s.network.Nodes {
+		if ligands[node.Name] {
+			sub.Nodes = append(sub.Nodes, node)
+		}
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"exersomes/graphql"
+	"exersomes/molecular_types"
+	"exersomes/network"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Version is the API version prefix of every route
+const Version = "v1"
+
+// maxBodyBytes limits request bodies
+const maxBodyBytes = 1 << 20
+
+// Param documents a path or query parameter
+type Param struct {
+	Name        string
+	In          string // "path" or "query"
+	Type        string // "string" or "integer"
+	Description string
+	Required    bool
+}
+
+// Route is one endpoint; Body and Response are zero values of the Go types the
+// OpenAPI document describes
+type Route struct {
+	Method   string
+	Path     string
+	Summary  string
+	Params   []Param
+	Body     any
+	Response any
+	handler  http.HandlerFunc
+}
+
+// Error is the body of every non-2xx response
+type Error struct {
+	Status  int
+	Message string
+	Fields  []FieldError // Validation failures, if any
+}
+
+// FieldError is one invalid request field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Server serves the exerkine catalog, network and predictors over HTTP
+type Server struct {
+	catalog []Exerkine
+	network molecular_types.ExerkineNetwork
+	routes  []Route
+	mux     *http.ServeMux
+	graphql *graphql.Schema
+}
+
+// NewServer returns a server over merged catalog ligands and an exerkine network
+func NewServer(catalog []network.LigandEntry, net molecular_types.ExerkineNetwork) *Server {
+	s := &Server{network: net, mux: http.NewServeMux()}
+	for _, e := range catalog {
+		s.catalog = append(s.catalog, exerkineFromEntry(e))
+	}
+	schema, err := newGraphQLSchema(s)
+	if err != nil {
+		panic(err) // The schema is static; an error is a programming mistake
+	}
+	s.graphql = schema
+	query := graphql.Handler(schema).ServeHTTP
+
+	prefix := "/" + Version
+	s.routes = []Route{
+		{Method: http.MethodGet, Path: prefix + "/exerkines", Summary: "List and filter exerkines",
+			Params: append([]Param{
+				{Name: "tissue", In: "query", Type: "string", Description: "Source or target tissue"},
+				{Name: "category", In: "query", Type: "string", Description: "Myokine, Hepatokine, Adipokine, ..."},
+				{Name: "regulation", In: "query", Type: "string", Description: "Exercise regulation, e.g. Up or Down"},
+				{Name: "receptor", In: "query", Type: "string", Description: "Receptor name or alias"},
+				{Name: "q", In: "query", Type: "string", Description: "Case-insensitive name search"},
+			}, pageParams...),
+			Response: ExerkineList{}, handler: s.listExerkines},
+		{Method: http.MethodGet, Path: prefix + "/exerkines/{id}", Summary: "Get an exerkine by ID or alias",
+			Params:   []Param{{Name: "id", In: "path", Type: "string", Required: true, Description: "Canonical ID such as il6, or any alias"}},
+			Response: Exerkine{}, handler: s.getExerkine},
+		{Method: http.MethodGet, Path: prefix + "/interactions", Summary: "Query ligand-receptor edges",
+			Params: append([]Param{
+				{Name: "tissue", In: "query", Type: "string", Description: "Tissue to filter by"},
+				{Name: "role", In: "query", Type: "string", Description: "source, target or any (default)"},
+				{Name: "ligand", In: "query", Type: "string", Description: "Ligand name or alias"},
+				{Name: "receptor", In: "query", Type: "string", Description: "Receptor name or alias"},
+			}, pageParams...),
+			Response: InteractionList{}, handler: s.listInteractions},
+		{Method: http.MethodGet, Path: prefix + "/tissues", Summary: "List tissues in the network",
+			Response: []string{}, handler: s.listTissues},
+		{Method: http.MethodGet, Path: prefix + "/networks/{tissue}", Summary: "Get the exerkine network around a tissue",
+			Params:   []Param{{Name: "tissue", In: "path", Type: "string", Required: true}},
+			Response: molecular_types.ExerkineNetwork{}, handler: s.getNetwork},
+		{Method: http.MethodPost, Path: prefix + "/predictions/bone", Summary: "Predict bone adaptation to a prescription",
+			Body: BonePredictionRequest{}, Response: BonePrediction{}, handler: s.predictBone},
+		{Method: http.MethodPost, Path: prefix + "/predictions/liver", Summary: "Predict liver adaptation to a prescription",
+			Body: LiverPredictionRequest{}, Response: LiverPrediction{}, handler: s.predictLiver},
+		{Method: http.MethodPost, Path: prefix + "/predictions/adipose", Summary: "Predict adipose adaptation to a prescription",
+			Body: AdiposePredictionRequest{}, Response: AdiposePrediction{}, handler: s.predictAdipose},
+		{Method: http.MethodPost, Path: prefix + "/predictions/circulatory", Summary: "Predict bloodstream adaptation to a prescription",
+			Body: CirculatoryPredictionRequest{}, Response: CirculatoryPrediction{}, handler: s.predictCirculatory},
+		{Method: http.MethodGet, Path: prefix + "/graphql", Summary: "Run a GraphQL query",
+			Params: []Param{
+				{Name: "query", In: "query", Type: "string", Required: true, Description: "GraphQL query document"},
+				{Name: "variables", In: "query", Type: "string", Description: "JSON object of variable values"},
+				{Name: "operationName", In: "query", Type: "string", Description: "Operation to run if the document has several"},
+			},
+			handler: query},
+		{Method: http.MethodPost, Path: prefix + "/graphql", Summary: "Run a GraphQL query",
+			Body: GraphQLRequest{}, handler: query},
+		{Method: http.MethodGet, Path: prefix + "/openapi.json", Summary: "OpenAPI document",
+			handler: s.serveOpenAPI},
+	}
+
+	for _, r := range s.routes {
+		s.mux.HandleFunc(r.Method+" "+r.Path, r.handler)
+	}
+	// Schema text and playground are for people, not clients, so they stay out of OpenAPI
+	s.mux.Handle("GET "+prefix+"/graphql/schema", graphql.SDLHandler(schema))
+	s.mux.Handle("GET "+prefix+"/graphql/playground", graphql.PlaygroundHandler(schema, prefix+"/graphql", playgroundExample))
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "no route for "+r.Method+" "+r.URL.Path, nil)
+	})
+	return s
+}
+
+// Routes returns the endpoints the server registers
+func (s *Server) Routes() []Route {
+	return s.routes
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string, fields []FieldError) {
+	writeJSON(w, status, Error{Status: status, Message: message, Fields: fields})
+}
+
+// decodeBody decodes a JSON request body, rejecting unknown fields and trailing data
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if ct := r.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "application/json") {
+		writeError(w, http.StatusUnsupportedMediaType, "expected application/json body", nil)
+		return false
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "request body too large", nil)
+		} else {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %v", err), nil)
+		}
+		return false
+	}
+	if dec.More() {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: unexpected data after object", nil)
+		return false
+	}
+	return true
+}
+
+// validator collects field errors
+type validator struct {
+	errors []FieldError
+}
+
+func (v *validator) check(ok bool, field string, format string, args ...any) {
+	if !ok {
+		v.errors = append(v.errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+func (v *validator) checkRange(value, min, max int, field string) {
+	v.check(value >= min && value <= max, field, "must be between %d and %d, got %d", min, max, value)
+}
+
+func (v *validator) checkOneOf(value string, allowed []string, field string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+// failed writes a 400 response if any check failed
+func (v *validator) failed(w http.ResponseWriter) bool {
+	if len(v.errors) == 0 {
+		return false
+	}
+	writeError(w, http.StatusBadRequest, "validation failed", v.errors)
+	return true
+}
@@ -0,0 +1,203 @@
+package canonical
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ligandAliases maps normalized names and gene symbols to one canonical key
+var ligandAliases = map[string]string{
+	"interleukin6":                     "il6",
+	"il6":                              "il6",
+	"interleukin15":                    "il15",
+	"il15":                             "il15",
+	"interleukin10":                    "il10",
+	"il10":                             "il10",
+	"il1ra":                            "il1rn",
+	"il1receptorantagonist":            "il1rn",
+	"tnf":                              "tnfa",
+	"tnfa":                             "tnfa",
+	"tumornecrosisfactora":             "tnfa",
+	"tumornecrosisfactoralpha":         "tnfa",
+	"tgfb":                             "tgfb1",
+	"tgfb1":                            "tgfb1",
+	"transforminggrowthfactorb":        "tgfb1",
+	"brainderivedneurotrophicfactor":   "bdnf",
+	"bdnf":                             "bdnf",
+	"insulinlikegrowthfactor1":         "igf1",
+	"igf1":                             "igf1",
+	"vegf":                             "vegfa",
+	"vegfa":                            "vegfa",
+	"vascularendothelialgrowthfactor":  "vegfa",
+	"vascularendothelialgrowthfactora": "vegfa",
+	"myostatin":                        "mstn",
+	"myostatingdf8":                    "mstn",
+	"gdf8":                             "mstn",
+	"mstn":                             "mstn",
+	"fibroblastgrowthfactor21":         "fgf21",
+	"fgf21":                            "fgf21",
+	"fibroblastgrowthfactor23":         "fgf23",
+	"fgf23":                            "fgf23",
+	"growthdifferentiationfactor15":    "gdf15",
+	"gdf15":                            "gdf15",
+	"irisin":                           "fndc5",
+	"fndc5":                            "fndc5",
+	"adiponectin":                      "adipoq",
+	"adipoq":                           "adipoq",
+	"leptin":                           "lep",
+	"lep":                              "lep",
+	"osteocalcin":                      "bglap",
+	"bglap":                            "bglap",
+	"osteocrin":                        "ostn",
+	"ostn":                             "ostn",
+	"sclerostin":                       "sost",
+	"sost":                             "sost",
+	"glucagonlikepeptide1":             "glp1",
+	"glp1":                             "glp1",
+	"epinephrine":                      "epinephrine",
+	"adrenaline":                       "epinephrine",
+	"norepinephrine":                   "norepinephrine",
+	"noradrenaline":                    "norepinephrine",
+	"anp":                              "nppa",
+	"bnp":                              "nppb",
+	"natriureticpeptides":              "nppa",
+	"natriureticpeptidesanpbnp":        "nppa",
+	"angiotensinii":                    "angii",
+	"insulin":                          "ins",
+	"ins":                              "ins",
+	"glucagon":                         "gcg",
+	"gcg":                              "gcg",
+}
+
+// receptorAliases maps normalized receptor names to one canonical key
+var receptorAliases = map[string]string{
+	"il6r":                  "il6r",
+	"il6receptor":           "il6r",
+	"gp130":                 "il6st",
+	"il6st":                 "il6st",
+	"il15receptor":          "il15ra",
+	"il15ra":                "il15ra",
+	"igf1receptor":          "igf1r",
+	"igf1r":                 "igf1r",
+	"insulinreceptor":       "insr",
+	"insr":                  "insr",
+	"glp1receptor":          "glp1r",
+	"glp1r":                 "glp1r",
+	"tnfreceptor":           "tnfrsf1a",
+	"tnfreceptor1":          "tnfrsf1a",
+	"tnfr1":                 "tnfrsf1a",
+	"activinreceptortype2b": "acvr2b",
+	"acvr2b":                "acvr2b",
+	"adiponectinreceptor1":  "adipor1",
+	"adipor1":               "adipor1",
+	"leptinreceptor":        "lepr",
+	"lepr":                  "lepr",
+	"vegfr":                 "kdr",
+	"vegfr2":                "kdr",
+	"kdr":                   "kdr",
+	"fgfr1":                 "fgfr1",
+	"fgfr1c":                "fgfr1",
+	"klb":                   "klb",
+	"bklotho":               "klb",
+	"fgfr1cbklothocomplex":  "fgfr1+klb",
+	"fgfr1bklotho":          "fgfr1+klb",
+	"fgfr1+klb":             "fgfr1+klb",
+	"lrp56":                 "lrp5",
+	"lrp5":                  "lrp5",
+	"vascularendothelialgrowthfactorreceptor": "kdr",
+}
+
+// tissueAliases collapses the tissue spellings used across component packages
+var tissueAliases = map[string]string{
+	"skeletalmuscle":     "Muscle",
+	"muscle":             "Muscle",
+	"muscleitself":       "Muscle",
+	"musclecells":        "Muscle",
+	"myocytes":           "Muscle",
+	"liver":              "Liver",
+	"hepatocyte":         "Liver",
+	"hepatocytes":        "Liver",
+	"adipose":            "Adipose",
+	"adipocytes":         "Adipose",
+	"adiposetissue":      "Adipose",
+	"fattissue":          "Adipose",
+	"whiteadiposetissue": "Adipose",
+	"heart":              "Heart",
+	"cardiomyocytes":     "Heart",
+	"bone":               "Bone",
+	"osteoblasts":        "Bone",
+	"osteocytes":         "Bone",
+	"pancreas":           "Pancreas",
+	"pancreaticbcells":   "Pancreas",
+	"brain":              "Brain",
+	"braincells":         "Brain",
+	"immunecells":        "Immune",
+	"leukocytes":         "Immune",
+	"macrophages":        "Immune",
+	"placenta":           "Placenta",
+	"endothelialcells":   "Endothelium",
+	"endothelium":        "Endothelium",
+	"adrenalmedulla":     "Adrenal",
+	"adrenalcortex":      "Adrenal",
+}
+
+// Key lowercases, transliterates Greek letters and drops punctuation
+func Key(name string) string {
+	replacer := strings.NewReplacer(
+		"α", "a", "β", "b", "γ", "g", "δ", "d", "κ", "k",
+		"Α", "a", "Β", "b", "Γ", "g", "Δ", "d", "Κ", "k",
+	)
+	name = strings.ToLower(replacer.Replace(name))
+
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// nameVariants returns the full name plus the outer and parenthetical parts,
+// so "Irisin (FNDC5)" can match either "Irisin" or "FNDC5"
+func nameVariants(name string) []string {
+	variants := []string{name}
+	if open := strings.Index(name, "("); open >= 0 {
+		outer := strings.TrimSpace(name[:open])
+		inner := name[open+1:]
+		if end := strings.Index(inner, ")"); end >= 0 {
+			inner = inner[:end]
+		}
+		variants = append(variants, outer, strings.TrimSpace(inner))
+	}
+	return variants
+}
+
+func canonical(name string, aliases map[string]string) string {
+	for _, variant := range nameVariants(name) {
+		key := Key(variant)
+		if c, ok := aliases[key]; ok {
+			return c
+		}
+	}
+	return Key(name)
+}
+
+// Ligand returns the alias-normalized key for a ligand name
+func Ligand(name string) string {
+	return canonical(name, ligandAliases)
+}
+
+// Receptor returns the alias-normalized key for a receptor name
+func Receptor(name string) string {
+	return canonical(name, receptorAliases)
+}
+
+// Tissue maps a tissue or cell-type spelling to the network's tissue names.
+// Unknown tissues are returned trimmed but otherwise unchanged.
+func Tissue(tissue string) string {
+	if t, ok := tissueAliases[Key(tissue)]; ok {
+		return t
+	}
+	return strings.TrimSpace(tissue)
+}
@@ -0,0 +1,1146 @@
+package main
+
+import (
+	"encoding/json"
+	"exersomes/api"
+	_ "exersomes/components/bone"
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/immune"
+	_ "exersomes/components/metabolic/adipose"
+	"exersomes/components/metabolic/liver"
+	_ "exersomes/components/neural"
+	"exersomes/export"
+	"exersomes/fit"
+	"exersomes/graph"
+	"exersomes/ingest"
+	"exersomes/models"
+	"exersomes/molecular_types"
+	"exersomes/motif"
+	"exersomes/network"
+	"exersomes/optimize"
+	"exersomes/params"
+	"exersomes/plan"
+	"exersomes/profile"
+	"exersomes/safety"
+	"exersomes/sensitivity"
+	"exersomes/session"
+	"exersomes/store"
+	"exersomes/study"
+	"exersomes/uncertainty"
+	"exersomes/vesicles"
+	"exersomes/visualize"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// subcommands maps a command name to its handler; each handler parses its own flags
+var subcommands = map[string]func(args []string){
+	"compare":     runCompare,
+	"export":      runExport,
+	"fit":         runFit,
+	"generate":    runGenerate,
+	"import":      runImport,
+	"montecarlo":  runMonteCarlo,
+	"optimize":    runOptimize,
+	"plan":        runPlan,
+	"regulators":  runRegulators,
+	"screen":      runScreen,
+	"sensitivity": runSensitivity,
+	"serve":       runServe,
+	"targets":     runTargets,
+	"vesicles":    runVesicles,
+	"visualize":   runVisualize,
+}
+
+// runSubcommand runs a registered subcommand and reports whether one was found
+func runSubcommand(name string, args []string) bool {
+	run, ok := subcommands[name]
+	if !ok {
+		return false
+	}
+	run(args)
+	return true
+}
+
+// runRegulators ranks key regulators in the cross-tissue exerkine network
+func runRegulators(args []string) {
+	fs := flag.NewFlagSet("regulators", flag.ExitOnError)
+	exercise := fs.String("exercise", "", "Weight ligands by response to an exercise type (Aerobic, HIIT, Sprint, Resistance)")
+	method := fs.String("method", graph.MethodComposite, "Ranking method: "+strings.Join(graph.Methods, ", "))
+	top := fs.Int("top", 10, "Number of regulators to print")
+	path := fs.String("path", "", "Print the signalling path between two tissues, e.g. Muscle:Liver")
+	structure := fs.Bool("structure", false, "Print communities and articulation points")
+	sources := networkFlags(fs)
+	fs.Parse(args)
+
+	net, err := sources.build()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var relevance map[string]float64
+	if *exercise != "" {
+		relevance = network.NodeRelevance(net, *exercise)
+	}
+
+	rankings, err := graph.KeyRegulators(net, *method, relevance)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Key regulators (%s", *method)
+	if *exercise != "" {
+		fmt.Printf(", %s", *exercise)
+	}
+	fmt.Println(")")
+	for i, r := range rankings {
+		if i >= *top {
+			break
+		}
+		fmt.Printf("%3d. %-30s %.4f\n", i+1, r.Node.Name, r.Score)
+	}
+
+	g := graph.FromNetwork(net)
+
+	if *path != "" {
+		parts := strings.SplitN(*path, ":", 2)
+		if len(parts) != 2 {
+			log.Fatalf("invalid path %q, expected Source:Target", *path)
+		}
+		source := network.NormalizeTissue(parts[0])
+		target := network.NormalizeTissue(parts[1])
+		nodes := g.TissuePath(source, target)
+		if nodes == nil {
+			fmt.Printf("\nNo signalling path from %s to %s\n", source, target)
+		} else {
+			names := make([]string, len(nodes))
+			for i, n := range nodes {
+				names[i] = n.Name
+			}
+			fmt.Printf("\nPath: %s\n", strings.Join(names, " -> "))
+		}
+	}
+
+	if *structure {
+		communities := g.Communities()
+		members := make(map[int][]string)
+		count := 0
+		for i, c := range communities {
+			if g.Nodes[i].Kind == graph.KindReceptor {
+				continue
+			}
+			members[c] = append(members[c], g.Nodes[i].Name)
+			if c+1 > count {
+				count = c + 1
+			}
+		}
+		fmt.Printf("\nCommunities (modularity %.3f)\n", g.Modularity(communities))
+		for c := 0; c < count; c++ {
+			if len(members[c]) > 0 {
+				fmt.Printf("  %d: %s\n", c+1, strings.Join(members[c], ", "))
+			}
+		}
+
+		fmt.Println("\nArticulation points")
+		for _, n := range g.ArticulationPoints() {
+			fmt.Printf("  %s (%s)\n", n.Name, n.Kind)
+		}
+	}
+}
+
+// runServe starts the HTTP/JSON API over the component catalog and network
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "Address to listen on")
+	openapi := fs.Bool("openapi", false, "Print the OpenAPI document and exit")
+	sources := networkFlags(fs)
+	fs.Parse(args)
+
+	net, err := sources.build()
+	if err != nil {
+		log.Fatal(err)
+	}
+	ligands := append(network.CatalogLigands(), network.ExerkineLigands(sources.stored)...)
+	server := api.NewServer(network.MergeLigandEntries(ligands), net)
+
+	if *openapi {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(server.OpenAPI()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("Serving exersomes API %s on %s", api.Version, *addr)
+	log.Printf("GraphQL playground at http://%s/%s/graphql/playground", displayAddr(*addr), api.Version)
+	log.Fatal(httpServer.ListenAndServe())
+}
+
+// displayAddr turns a listen address such as ":8080" into a browsable host:port
+func displayAddr(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
+}
+
+// networkSources are the flags shared by commands that build the exerkine network
+type networkSources struct {
+	db     string
+	files  network.DatabaseFiles
+	stored []molecular_types.Exerkine // Loaded from db by build
+}
+
+func networkFlags(fs *flag.FlagSet) *networkSources {
+	var n networkSources
+	fs.StringVar(&n.db, "db", "", "Store whose curated exerkines extend the catalog (written by exersomes import)")
+	fs.StringVar(&n.files.CellPhoneDB, "cellphonedb", "", "CellPhoneDB database directory to merge")
+	fs.StringVar(&n.files.CellChatDB, "cellchat", "", "CellChatDB export directory to merge")
+	fs.StringVar(&n.files.OmniPath, "omnipath", "", "OmniPath interactions TSV to merge")
+	return &n
+}
+
+// build matches catalog and stored exerkines to receptors and merges any ligand-receptor databases
+func (n *networkSources) build() (molecular_types.ExerkineNetwork, error) {
+	if n.db != "" {
+		db, err := store.OpenReadOnly(n.db)
+		if err != nil {
+			return molecular_types.ExerkineNetwork{}, err
+		}
+		n.stored, err = db.Exerkines()
+		db.Close()
+		if err != nil {
+			return molecular_types.ExerkineNetwork{}, err
+		}
+	}
+
+	net := network.Build(n.stored)
+	records, err := network.LoadDatabases(n.files)
+	if err != nil {
+		return molecular_types.ExerkineNetwork{}, err
+	}
+	if len(records) > 0 {
+		net = network.MergeDatabases(net, records)
+	}
+	return net, nil
+}
+
+// runImport loads retrieval outputs and the curated catalog into the store
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	dbPath := fs.String("db", store.DefaultPath, "Store to create or update")
+	dir := fs.String("dir", ".", "Directory holding gene_references.tsv, protein_info.tsv, etc.")
+	catalog := fs.Bool("catalog", true, "Also store the curated exerkine catalog")
+	sheet := fs.String("exerkines", "", "TSV or CSV sheet of curated exerkines; columns are matched by header")
+	fs.Parse(args)
+
+	db, err := store.Open(*dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	counts, err := db.ImportDir(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, file := range []string{store.GeneReferencesFile, store.ProteinInfoFile, store.ProteinSequencesFile,
+		store.PathwayMapsFile, store.FunctionalInsightsFile} {
+		if n, ok := counts[file]; ok {
+			fmt.Printf("%-26s %d rows\n", file, n)
+		}
+	}
+	if *catalog {
+		exerkines := network.Build(nil).Nodes
+		if err := db.UpsertExerkines(exerkines); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%-26s %d exerkines\n", "catalog", len(exerkines))
+	}
+	if *sheet != "" {
+		exerkines, err := ingest.LoadFile(*sheet, ingest.Options{})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := db.UpsertExerkines(exerkines); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%-26s %d exerkines\n", filepath.Base(*sheet), len(exerkines))
+	}
+
+	totals, err := db.Counts()
+	if err != nil {
+		log.Fatal(err)
+	}
+	version, _ := db.Version()
+	fmt.Printf("\n%s (schema v%d): %d genes, %d proteins, %d sequences, %d pathways, %d publications, %d exerkines\n",
+		*dbPath, version, totals["genes"], totals["proteins"], totals["sequences"], totals["pathways"],
+		totals["publications"], totals["exerkines"])
+}
+
+// runCompare checks the predictors against measured study data
+func runCompare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	path := fs.String("study", "", "Study TSV or CSV: subject, session, exercise_type, time_min, analyte, value, unit, ...")
+	residuals := fs.String("residuals", "", "Write per-observation residuals to this TSV for plotting")
+	paramsFile := fs.String("params", "", "Parameter file to load before predicting (written by exersomes fit)")
+	fs.Parse(args)
+	if *path == "" {
+		log.Fatal("compare: -study is required")
+	}
+	if *paramsFile != "" {
+		if _, err := params.LoadFile(*paramsFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	st, err := study.LoadFile(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmp := study.Compare(st, nil)
+	if err := cmp.WriteMetrics(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+	for _, u := range cmp.Unmatched {
+		fmt.Fprintf(os.Stderr, "line %d: skipped %s: %s\n", u.Observation.Line, u.Observation.Analyte, u.Reason)
+	}
+
+	if *residuals != "" {
+		f, err := os.Create(*residuals)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := cmp.WriteResiduals(f); err != nil {
+			f.Close()
+			log.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// runFit fits predictor parameter sets to measured study data
+func runFit(args []string) {
+	fs := flag.NewFlagSet("fit", flag.ExitOnError)
+	path := fs.String("study", "", "Study TSV or CSV to fit to")
+	setNames := fs.String("sets", "immune.acute_response", "Comma-separated parameter sets to fit")
+	start := fs.String("params", "", "Parameter file to start from")
+	out := fs.String("out", "fitted_params.json", "Where to write the fitted parameter file")
+	holdout := fs.Float64("holdout", 0.2, "Fraction of subjects held out for validation")
+	seed := fs.Int64("seed", 1, "Seed for the holdout split")
+	iterations := fs.Int("iterations", 0, "Maximum Nelder-Mead iterations (0 scales with the number of parameters)")
+	list := fs.Bool("list", false, "List the registered parameter sets and exit")
+	fs.Parse(args)
+
+	if *list {
+		for _, s := range params.Registered() {
+			fmt.Println(s.Name)
+			for _, p := range s.Parameters() {
+				fixed := ""
+				if p.Fixed {
+					fixed = " (fixed)"
+				}
+				fmt.Printf("  %-32s %10.4g  [%g, %g]%s\n", p.Name, p.Value, p.Min, p.Max, fixed)
+			}
+		}
+		return
+	}
+	if *path == "" {
+		log.Fatal("fit: -study is required")
+	}
+	if *start != "" {
+		if _, err := params.LoadFile(*start); err != nil {
+			log.Fatal(err)
+		}
+	}
+	var sets []*params.Set
+	for _, name := range strings.Split(*setNames, ",") {
+		s, ok := params.Lookup(strings.TrimSpace(name))
+		if !ok {
+			log.Fatalf("fit: unknown parameter set %q (see -list)", name)
+		}
+		sets = append(sets, s)
+	}
+
+	st, err := study.LoadFile(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	report, err := fit.Study(st, sets, fit.StudyOptions{
+		Options: fit.Options{MaxIterations: *iterations},
+		Holdout: *holdout,
+		Seed:    *seed,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, p := range report.Parameters {
+		fmt.Printf("%-24s %-32s %10.4g -> %-10.4g\n", p.Set, p.Name, p.Initial, p.Fitted)
+	}
+	fmt.Printf("\nTraining (%d subjects):   %.4g -> %.4g\n", report.TrainSubjects, report.InitialTrainScore, report.TrainScore)
+	fmt.Printf("Validation (%d subjects): %.4g -> %.4g\n", report.ValidationSubjects, report.InitialValidationScore, report.ValidationScore)
+	if !report.Converged {
+		fmt.Printf("Stopped after %d iterations without converging\n", report.Iterations)
+	}
+
+	if err := params.WriteFile(*out, report.File(*path, sets)); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("\nWrote %s\n", *out)
+}
+
+// runMonteCarlo propagates catalog ranges and individual factors through a
+// predictor and prints credible intervals per output key
+func runMonteCarlo(args []string) {
+	fs := flag.NewFlagSet("montecarlo", flag.ExitOnError)
+	model := fs.String("model", "liver", "Predictor to sample: liver, circulating or cytokine")
+	protocol := fs.String("protocol", liver.NAFLDReduction.Name, "Liver prescription name")
+	weeks := fs.Int("weeks", 12, "Liver training weeks")
+	nafld := fs.Float64("nafld", 0.3, "Probability a liver subject has NAFLD")
+	insulinResistance := fs.Float64("insulin-resistance", 0.3, "Probability a liver subject is insulin resistant")
+	analyte := fs.String("analyte", "IL-6", "Circulating factor or cytokine")
+	exercise := fs.String("exercise", "Aerobic", "Session exercise type (Aerobic, HIIT, Sprint, Resistance)")
+	intensity := fs.Int("intensity", 70, "Cytokine session intensity, % of max")
+	duration := fs.Int("duration", 60, "Cytokine session duration in minutes")
+	training := fs.String("training", "Untrained", "Cytokine training status")
+	sd := fs.Float64("sd", 0.15, "Relative SD of sampled cytokine coefficients")
+	n := fs.Int("n", 1000, "Number of draws")
+	seed := fs.Int64("seed", 1, "Random seed")
+	workers := fs.Int("workers", 0, "Parallel runs (0 uses all CPUs)")
+	fs.Parse(args)
+
+	var (
+		inputs []uncertainty.Input
+		run    uncertainty.Model
+	)
+	switch *model {
+	case "liver":
+		var p *liver.ExercisePrescription
+		for _, candidate := range liver.GetStandardPrescriptions() {
+			if strings.EqualFold(candidate.Name, *protocol) {
+				p = &candidate
+			}
+		}
+		if p == nil {
+			log.Fatalf("montecarlo: unknown liver prescription %q", *protocol)
+		}
+		inputs, run = uncertainty.LiverModel(*p, *weeks, *nafld, *insulinResistance)
+	case "circulating":
+		var cf *bloodstream.CirculatingFactor
+		for _, candidate := range bloodstream.GetExerciseResponsiveFactors() {
+			if network.CanonicalLigand(candidate.Name) == network.CanonicalLigand(*analyte) {
+				cf = &candidate
+			}
+		}
+		if cf == nil {
+			log.Fatalf("montecarlo: unknown circulating factor %q", *analyte)
+		}
+		s := session.ForExerciseType(*exercise)
+		var timePoints []time.Duration
+		for t := time.Duration(0); t <= s.TotalDuration()+time.Hour; t += 15 * time.Minute {
+			timePoints = append(timePoints, t)
+		}
+		inputs, run = uncertainty.CirculatingModel(*cf, s, timePoints)
+	case "cytokine":
+		var c *immune.Cytokine
+		for _, candidate := range immune.GetAcutelyUpregulatedCytokines() {
+			if network.CanonicalLigand(candidate.Name) == network.CanonicalLigand(*analyte) {
+				c = &candidate
+			}
+		}
+		if c == nil {
+			log.Fatalf("montecarlo: unknown cytokine %q", *analyte)
+		}
+		inputs, run = uncertainty.CytokineModel(*c, *intensity, *duration, *training, *sd)
+	default:
+		log.Fatalf("montecarlo: unknown model %q", *model)
+	}
+
+	result, err := uncertainty.Run(inputs, run, uncertainty.Options{N: *n, Seed: *seed, Workers: *workers})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%d draws, seed %d\n\n", result.Draws, result.Seed)
+	fmt.Printf("%-36s %12s %12s %12s %25s\n", "Key", "Mean", "SD", "Median", "95% interval")
+	for _, s := range result.Summaries {
+		iv := s.Intervals[len(s.Intervals)-1]
+		fmt.Printf("%-36s %12.4g %12.4g %12.4g   [%10.4g, %10.4g]\n", s.Key, s.Mean, s.SD, s.Median, iv.Lower, iv.Upper)
+	}
+}
+
+// runSensitivity ranks the influence of prescription parameters on each
+// response key of a registered predictor
+func runSensitivity(args []string) {
+	fs := flag.NewFlagSet("sensitivity", flag.ExitOnError)
+	name := fs.String("predictor", "bone", "Registered predictor to analyse")
+	method := fs.String("method", "sobol", "Method: morris or sobol")
+	n := fs.Int("n", 1024, "Sobol base samples")
+	trajectories := fs.Int("trajectories", 20, "Morris trajectories")
+	levels := fs.Int("levels", 4, "Morris grid levels")
+	seed := fs.Int64("seed", 1, "Random seed")
+	out := fs.String("out", "", "Write the index table to this TSV file instead of stdout")
+	list := fs.Bool("list", false, "List the registered predictors and exit")
+	fs.Parse(args)
+
+	if *list {
+		for _, p := range sensitivity.Registered() {
+			fmt.Printf("%-10s %s\n", p.Name, p.Description)
+			for _, f := range p.Factors {
+				fmt.Printf("  %-20s [%g, %g]\n", f.Name, f.Min, f.Max)
+			}
+		}
+		return
+	}
+	p, ok := sensitivity.Lookup(*name)
+	if !ok {
+		log.Fatalf("sensitivity: unknown predictor %q (see -list)", *name)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	switch *method {
+	case "morris":
+		indices, err := sensitivity.Morris(p, sensitivity.MorrisOptions{Trajectories: *trajectories, Levels: *levels, Seed: *seed})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := sensitivity.WriteMorris(w, p.Name, indices); err != nil {
+			log.Fatal(err)
+		}
+	case "sobol":
+		indices, err := sensitivity.Sobol(p, sensitivity.SobolOptions{N: *n, Seed: *seed})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := sensitivity.WriteSobol(w, p.Name, indices); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("sensitivity: unknown method %q", *method)
+	}
+}
+
+// runVisualize renders the exerkine × tissue heatmap, the circulating time
+// course and the network layout as SVG and/or PNG
+func runVisualize(args []string) {
+	fs := flag.NewFlagSet("visualize", flag.ExitOnError)
+	out := fs.String("out", "figures", "Directory to write figures to")
+	formats := fs.String("format", "svg,png", "Comma-separated image formats: svg, png")
+	exercise := fs.String("exercise", "Aerobic", "Exercise type for the heatmap and time course (Aerobic, HIIT, Sprint, Resistance)")
+	intensity := fs.Float64("intensity", 70, "Time-course intensity, % of max")
+	duration := fs.Float64("duration", 60, "Time-course duration in minutes")
+	points := fs.Int("points", 25, "Time-course samples")
+	seed := fs.Int64("seed", 1, "Seed for the network layout")
+	sources := networkFlags(fs)
+	fs.Parse(args)
+
+	net, err := sources.build()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	g := graph.FromNetwork(net)
+	figures := map[string]*visualize.Figure{
+		"heatmap":    visualize.ExerkineTissueHeatmap(net, *exercise).Figure(),
+		"timecourse": visualize.CirculatingTimeCourse(*exercise, *intensity, *duration, *points).Figure(),
+		"network": visualize.NetworkFigure(g, visualize.ForceLayout(g, visualize.LayoutOptions{Seed: *seed}),
+			"Exerkine network: source tissue, ligand, receptor, target tissue"),
+	}
+	for name, f := range figures {
+		for _, format := range strings.Split(*formats, ",") {
+			path := filepath.Join(*out, name+"."+strings.TrimSpace(format))
+			if err := f.WriteFile(path); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println("Wrote", path)
+		}
+	}
+}
+
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	name := fs.String("name", "", "Network name (default names the exercise type)")
+	exercise := fs.String("exercise", "Aerobic", "Exercise type for ligand regulation (Aerobic, HIIT, Sprint, Resistance)")
+	seed := fs.Int64("seed", 1, "Seed for the layout hints")
+	sources := networkFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: exersomes export [flags] FILE...")
+		fmt.Fprintln(fs.Output(), "Formats follow the extension: .cyjs (Cytoscape.js JSON), .cx2, .graphml, .gexf, .sif")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	net, err := sources.build()
+	if err != nil {
+		log.Fatal(err)
+	}
+	g := export.FromNetwork(net, export.Options{
+		Name: *name, ExerciseType: *exercise, Layout: visualize.LayoutOptions{Seed: *seed},
+	})
+	for _, path := range fs.Args() {
+		if err := export.WriteFile(path, g); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Wrote %s (%d nodes, %d edges)\n", path, len(g.Nodes), len(g.Edges))
+	}
+}
+
+func runGenerate(args []string) {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	modelPath := fs.String("model", "", "Transformer weights (.safetensors) with config and tokenizer metadata")
+	family := fs.String("family", "", "Family prompt, e.g. FGF or myokine (empty for unconditional)")
+	score := fs.Bool("score", false, "Score the FASTA sequences instead of generating: log-likelihood and perplexity per sequence")
+	strategy := fs.String("strategy", models.TopP, "Decoding: greedy, top-k, top-p or beam")
+	n := fs.Int("n", 1, "Sequences per prompt; beam search returns the n best beams")
+	maxLength := fs.Int("max-length", 256, "Residues to generate at most")
+	minLength := fs.Int("min-length", 1, "Residues before the sequence may end")
+	topK := fs.Int("top-k", 20, "Tokens kept by top-k sampling")
+	topP := fs.Float64("top-p", 0.9, "Probability mass kept by top-p sampling")
+	temperature := fs.Float64("temperature", 1, "Sampling temperature")
+	prefixLength := fs.Int("prefix-length", 8, "Residues of each FASTA sequence to continue from")
+	seed := fs.Int64("seed", 1, "Random seed")
+	out := fs.String("out", "", "Write to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: exersomes generate -model FILE [flags] [FASTA...]")
+		fmt.Fprintln(fs.Output(), "Generates FASTA, continuing each input sequence's prefix when FASTA files are given;")
+		fmt.Fprintf(fs.Output(), "with -score, scores the FASTA sequences (default %s) as TSV\n", store.ProteinSequencesFile)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *modelPath == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	m, err := models.Load(*modelPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	source, err := m.Prompt(*family)
+	if err != nil {
+		log.Fatal(err)
+	}
+	files := fs.Args()
+	if *score && len(files) == 0 {
+		files = []string{store.ProteinSequencesFile}
+	}
+	var sequences []store.Sequence
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = store.ReadFASTA(f, func(seq store.Sequence) error {
+			sequences = append(sequences, seq)
+			return nil
+		})
+		f.Close()
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *score {
+		fmt.Fprintln(w, "ID\tLength\tLogLikelihood\tPerplexity")
+		for _, seq := range sequences {
+			s := m.Score(source, m.Tokenizer.Encode(seq.Residues))
+			fmt.Fprintf(w, "%s\t%d\t%.4f\t%.4f\n", seq.ID, s.Tokens-1, s.LogLikelihood, s.Perplexity())
+		}
+		return
+	}
+
+	opts := models.GenerateOptions{
+		Strategy: *strategy, MaxLength: *maxLength, MinLength: *minLength,
+		TopK: *topK, TopP: *topP, Temperature: *temperature, Beams: *n,
+	}
+	settings := "strategy=" + *strategy
+	switch *strategy {
+	case models.TopK:
+		settings += fmt.Sprintf(" top_k=%d temperature=%g seed=%d", *topK, *temperature, *seed)
+	case models.TopP:
+		settings += fmt.Sprintf(" top_p=%g temperature=%g seed=%d", *topP, *temperature, *seed)
+	case models.Beam:
+		settings += fmt.Sprintf(" beams=%d", *n)
+	}
+	if *family != "" {
+		settings = "family=" + *family + " " + settings
+	}
+
+	prompts := []store.Sequence{{}}
+	if len(sequences) > 0 {
+		prompts = sequences
+	}
+	rng := rand.New(rand.NewSource(*seed))
+	count := 0
+	for _, prompt := range prompts {
+		prefix := m.Tokenizer.Encode(prompt.Residues)
+		prefix = prefix[:min(len(prefix), *prefixLength)]
+		runs := *n
+		if *strategy == models.Greedy || *strategy == models.Beam {
+			runs = 1 // Deterministic; beam search returns n candidates itself
+		}
+		for r := 0; r < runs; r++ {
+			gens, err := m.Generate(source, prefix, opts, rng)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, g := range gens {
+				count++
+				header := fmt.Sprintf("gen%d %s", count, settings)
+				if prompt.ID != "" {
+					header += fmt.Sprintf(" prompt=%s prefix=%d", prompt.ID, len(prefix))
+				}
+				header += fmt.Sprintf(" length=%d loglik=%.4f perplexity=%.4f finished=%t",
+					len(g.IDs), g.LogLikelihood, g.Perplexity(), g.Finished)
+				if err := store.WriteFASTA(w, header, g.Sequence); err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+func runTargets(args []string) {
+	fs := flag.NewFlagSet("targets", flag.ExitOnError)
+	utrPath := fs.String("utr", "", "3'UTR FASTA to scan (headers name the gene: >GENE, >TRANSCRIPT GENE or >ENSG|ENST|GENE)")
+	names := fs.String("mirna", "", "Comma-separated panel miRNAs to analyse (default the whole panel: miR-1, miR-133a, miR-206, miR-486-5p)")
+	minSite := fs.String("min-site", string(motif.Site7merA1), "Weakest site that makes a target: 8mer, 7mer-m8, 7mer-A1 or 6mer")
+	out := fs.String("out", "", "Write the target table to this TSV file instead of stdout")
+	fs.Parse(args)
+	if *utrPath == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if !slices.Contains(motif.SiteTypes, motif.SiteType(*minSite)) {
+		log.Fatalf("targets: unknown site type %q", *minSite)
+	}
+
+	panel := molecular_types.CirculatingMiRNAPanel
+	if *names != "" {
+		panel = nil
+		for _, name := range strings.Split(*names, ",") {
+			i := slices.IndexFunc(molecular_types.CirculatingMiRNAPanel, func(m molecular_types.ExerciseMiRNA) bool {
+				return strings.EqualFold(m.Name, strings.TrimSpace(name))
+			})
+			if i < 0 {
+				log.Fatalf("targets: %q is not in the circulating miRNA panel", name)
+			}
+			panel = append(panel, molecular_types.CirculatingMiRNAPanel[i])
+		}
+	}
+
+	f, err := os.Open(*utrPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	utrs, err := motif.ReadUTRs(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("%s: %v", *utrPath, err)
+	}
+
+	var checks []motif.CrossCheck
+	for _, m := range panel {
+		c, err := motif.Check(m, utrs, motif.Options{MinSite: motif.SiteType(*minSite)})
+		if err != nil {
+			log.Fatal(err)
+		}
+		checks = append(checks, c)
+		fmt.Fprintf(os.Stderr, "%s (seed %s): %d targets; curated %d/%d confirmed", c.MiRNA, c.Seed,
+			len(c.Predicted), len(c.Confirmed), len(m.TargetGenes))
+		if len(c.Missed) > 0 {
+			fmt.Fprintf(os.Stderr, ", no site in %s", strings.Join(c.Missed, ", "))
+		}
+		if len(c.NoUTR) > 0 {
+			fmt.Fprintf(os.Stderr, ", no UTR for %s", strings.Join(c.NoUTR, ", "))
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+	if families, err := motif.SeedFamilies(panel); err == nil {
+		for seed, members := range families {
+			if len(members) > 1 {
+				fmt.Fprintf(os.Stderr, "%s share seed %s and its targets\n", strings.Join(members, " and "), seed)
+			}
+		}
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := motif.WriteTargets(w, checks); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runVesicles(args []string) {
+	fs := flag.NewFlagSet("vesicles", flag.ExitOnError)
+	exercise := fs.String("exercise", "HIIT", "Session exercise type (Aerobic, HIIT, Sprint, Resistance)")
+	hours := fs.Float64("hours", 24, "Recovery hours after the session to include in delivered cargo")
+	exoCarta := fs.String("exocarta", "", "ExoCarta download directory to merge into the vesicle catalog")
+	vesiclepedia := fs.String("vesiclepedia", "", "Vesiclepedia download directory to merge into the vesicle catalog")
+	requireMarkers := fs.Bool("require-markers", false, "Merge only experiments passing the MISEV marker check")
+	fs.Parse(args)
+
+	catalog := molecular_types.VesicleCatalog
+	var datasets []vesicles.Dataset
+	for _, src := range []struct {
+		dir  string
+		read func(string) (vesicles.Dataset, error)
+	}{{*exoCarta, vesicles.ReadExoCarta}, {*vesiclepedia, vesicles.ReadVesiclepedia}} {
+		if src.dir == "" {
+			continue
+		}
+		d, err := src.read(src.dir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		filtered := d.Filter()
+		fmt.Printf("%s: %d of %d experiments from human exercise-relevant samples\n",
+			d.Database, len(filtered.Experiments), len(d.Experiments))
+		datasets = append(datasets, filtered)
+	}
+	if len(datasets) > 0 {
+		var summaries []vesicles.Summary
+		catalog, summaries = vesicles.Merge(catalog, datasets, vesicles.Options{RequireMarkers: *requireMarkers})
+		fmt.Printf("\n%-22s %-18s %6s %6s %6s %10s  %s\n", "Vesicle", "Tissue", "Exps", "Skip", "Cargo", "Unresolved", "MISEV")
+		for _, s := range summaries {
+			status := "pass"
+			if !s.Markers.Pass() {
+				status = "fail"
+			}
+			if findings := s.Markers.Findings(); len(findings) > 0 {
+				status += ": " + strings.Join(findings, "; ")
+			}
+			fmt.Printf("%-22s %-18s %6d %6d %6d %10d  %s\n", s.Type, s.Tissue, s.Experiments, s.Skipped, s.Cargo, s.Unresolved, status)
+		}
+		fmt.Println()
+	}
+
+	s := session.ForExerciseType(*exercise)
+	window := s.TotalDuration() + time.Duration(*hours*float64(time.Hour))
+	fmt.Printf("Session: %s (%s), delivery over %s\n", s.Name, s.TotalDuration(), window)
+	for _, v := range catalog {
+		// Imported records have no release kinetics to simulate
+		if v.Kinetics.BaselinePerML <= 0 {
+			continue
+		}
+		d, err := bloodstream.SimulateVesicleDelivery(v, s, window)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("\n%s from %s: %.3g particles released, %.3g taken up\n", v.Type, v.PrimarySource, d.Released, d.Cleared)
+		fmt.Printf("%-20s %-18s %-8s %12s %12s %8s\n", "Tissue", "Cargo", "Kind", "Copies", "Excess", "Studies")
+		for _, c := range d.Cargo {
+			fmt.Printf("%-20s %-18s %-8s %12.4g %12.4g %8d\n", c.Tissue, c.Name, c.Cargo.Kind, c.Copies, c.Excess, len(c.Cargo.Studies))
+		}
+	}
+}
+
+// runOptimize searches weekly prescriptions for the Pareto front of the tissue
+// objectives under a time budget and contraindications
+func runOptimize(args []string) {
+	fs := flag.NewFlagSet("optimize", flag.ExitOnError)
+	budget := fs.Int("budget", 150, "Weekly training minutes available")
+	weeks := fs.Int("weeks", 12, "Training horizon in weeks")
+	weightList := fs.String("weights", "", "Objective weights as name=weight, comma-separated, e.g. bone=2,liver=1 (default weights each objective 1)")
+	conditions := fs.String("conditions", "", "Comma-separated conditions besides the profile's; modalities whose protocols list one as a contraindication are ruled out")
+	modalities := fs.String("modalities", "", "Comma-separated modalities to mix (default Aerobic,HIIT,Resistance,Impact)")
+	maxIntensity := fs.Int("max-intensity", 95, "Highest intensity, % of max")
+	maxSession := fs.Int("max-session", 90, "Longest session in minutes")
+	top := fs.Int("top", 10, "Front solutions to print, best weighted score first (0 for all)")
+	profilePath := fs.String("profile", "", "JSON profile of the individual (age, sex, body mass and fat, VO2max, training status, conditions, medications)")
+	fs.Parse(args)
+
+	subject := profile.Reference
+	if *profilePath != "" {
+		p, err := profile.LoadFile(*profilePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		subject = p
+	}
+
+	c := optimize.Constraints{WeeklyMinutes: *budget, MaxIntensity: *maxIntensity, MaxSessionMinutes: *maxSession}
+	if *modalities != "" {
+		for _, m := range strings.Split(*modalities, ",") {
+			c.Modalities = append(c.Modalities, strings.TrimSpace(m))
+		}
+	}
+	c.Conditions = subject.Conditions
+	if *conditions != "" {
+		c.Conditions = append(c.Conditions, strings.Split(*conditions, ",")...)
+	}
+	opts := optimize.Options{Weeks: *weeks}
+	if *weightList != "" {
+		opts.Weights = make(map[string]float64)
+		for _, pair := range strings.Split(*weightList, ",") {
+			name, value, ok := strings.Cut(pair, "=")
+			w, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if !ok || err != nil {
+				log.Fatalf("optimize: invalid weight %q, want name=weight", pair)
+			}
+			opts.Weights[strings.TrimSpace(name)] = w
+		}
+	}
+
+	result, err := optimize.Optimize(optimize.ObjectivesFor(subject), c, opts)
+	for _, m := range optimize.Modalities {
+		if reasons := result.Excluded[m]; len(reasons) > 0 {
+			fmt.Printf("%s ruled out: %s\n", m, strings.Join(reasons, "; "))
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%d feasible prescriptions, %d on the Pareto front\n\n", result.Evaluated, len(result.Front))
+
+	fmt.Printf("%6s  %-64s", "Score", "Prescription")
+	for _, o := range result.Objectives {
+		fmt.Printf(" %16s", o.Name+" ("+o.Unit+")")
+	}
+	fmt.Println()
+	front := result.Front
+	if *top > 0 && len(front) > *top {
+		front = front[:*top]
+	}
+	for _, s := range front {
+		fmt.Printf("%6.3f  %-64s", s.Score, s.Candidate)
+		for _, o := range result.Objectives {
+			fmt.Printf(" %16.3f", s.Outcomes[o.Name])
+		}
+		fmt.Println()
+	}
+}
+
+// runScreen screens prescriptions against an individual's conditions,
+// medications and recent vitals with the safety rules
+func runScreen(args []string) {
+	fs := flag.NewFlagSet("screen", flag.ExitOnError)
+	names := fs.String("prescription", "", "Comma-separated standard prescriptions to screen (default all)")
+	profilePath := fs.String("profile", "", "JSON profile of the individual (age, sex, body mass and fat, VO2max, training status, conditions, medications)")
+	conditions := fs.String("conditions", "", "Comma-separated conditions besides the profile's")
+	medications := fs.String("medications", "", "Comma-separated medications besides the profile's")
+	vitalList := fs.String("vitals", "", "Recent vitals as name=value, comma-separated, e.g. SystolicBP=150,RestingHeartRate=72,Glucose=110 (SpO2 in %, Glucose in mg/dL, TemperatureC in °C)")
+	rulesPath := fs.String("rules", "", "JSON rules file added to the built-in rules; a rule with a built-in rule's ID replaces it")
+	asJSON := fs.Bool("json", false, "Print the screenings as JSON")
+	fs.Parse(args)
+
+	subject := profile.Reference
+	if *profilePath != "" {
+		p, err := profile.LoadFile(*profilePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		subject = p
+	}
+	if *conditions != "" {
+		subject.Conditions = append(subject.Conditions, strings.Split(*conditions, ",")...)
+	}
+	if *medications != "" {
+		subject.Medications = append(subject.Medications, strings.Split(*medications, ",")...)
+	}
+	vitals := make(safety.Vitals)
+	if *vitalList != "" {
+		for _, pair := range strings.Split(*vitalList, ",") {
+			name, value, ok := strings.Cut(pair, "=")
+			v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if !ok || err != nil {
+				log.Fatalf("screen: invalid vital %q, want name=value", pair)
+			}
+			vitals[strings.TrimSpace(name)] = v
+		}
+	}
+	if err := vitals.Validate(); err != nil {
+		log.Fatalf("screen: %v", err)
+	}
+	rules := safety.Default()
+	if *rulesPath != "" {
+		extra, err := safety.LoadFile(*rulesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rules = safety.Merge(rules, extra)
+	}
+
+	prescriptions := safety.Standard()
+	if *names != "" {
+		prescriptions = nil
+		for _, name := range strings.Split(*names, ",") {
+			p, ok := safety.Lookup(name)
+			if !ok {
+				log.Fatalf("screen: unknown prescription %q", name)
+			}
+			prescriptions = append(prescriptions, p)
+		}
+	}
+	var screenings []safety.Screening
+	for _, p := range prescriptions {
+		screenings = append(screenings, safety.Screen(p, subject, vitals, rules))
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(screenings); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	for _, s := range screenings {
+		fmt.Println(s.Prescription)
+		if len(s.Findings) == 0 {
+			fmt.Println("  OK")
+		}
+		for _, f := range s.Findings {
+			fmt.Printf("  %-5s %s: %s\n", strings.ToUpper(f.Severity), f.Rule, f.Message)
+		}
+		if s.Suggested != nil {
+			fmt.Printf("  Suggested: %s\n", s.Suggested)
+		}
+		for _, alt := range s.Alternatives {
+			fmt.Printf("  Alternative: %s\n", alt)
+		}
+	}
+}
+
+// runPlan builds a periodized training plan, projects the tissue outcomes week
+// by week and writes it as JSON or iCalendar
+func runPlan(args []string) {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	name := fs.String("name", "Periodized plan", "Plan name, used in calendar events")
+	startDate := fs.String("start", "", "Date of the first session as YYYY-MM-DD (default the coming Monday, today if it is one)")
+	startTime := fs.String("time", "07:00", "Session start time as HH:MM")
+	weeks := fs.Int("weeks", 12, "Plan length in weeks")
+	mixList := fs.String("mix", "Aerobic=1", "Share of weekly minutes by modality as name=share, comma-separated, e.g. Aerobic=0.5,Resistance=0.5")
+	startIntensity := fs.Int("start-intensity", 60, "Intensity of the first week, % of max")
+	peakIntensity := fs.Int("peak-intensity", 85, "Intensity of the last loading week, % of max")
+	duration := fs.Int("duration", 40, "Session length in the first week of each mesocycle, minutes")
+	frequency := fs.Int("frequency", 3, "Sessions per week")
+	days := fs.String("days", "", "Comma-separated training days, e.g. Mon,Wed,Fri (default spread evenly from the start day)")
+	mesocycle := fs.Int("mesocycle", 4, "Mesocycle length in weeks, including its closing deload week")
+	taper := fs.Int("taper", 1, "Final weeks at peak intensity and reduced volume")
+	profilePath := fs.String("profile", "", "JSON profile of the individual (age, sex, body mass and fat, VO2max, training status, conditions, medications)")
+	out := fs.String("out", "", "Comma-separated files to write the plan to: .json or .ics")
+	fs.Parse(args)
+
+	subject := profile.Reference
+	if *profilePath != "" {
+		p, err := profile.LoadFile(*profilePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		subject = p
+	}
+
+	c := plan.Config{
+		Name: *name, Weeks: *weeks, StartIntensity: *startIntensity, PeakIntensity: *peakIntensity,
+		DurationMinutes: *duration, FrequencyPerWeek: *frequency, MesocycleWeeks: *mesocycle, TaperWeeks: *taper,
+		Mix: make(map[string]float64),
+	}
+	day := time.Now()
+	day = time.Date(day.Year(), day.Month(), day.Day()+(8-int(day.Weekday()))%7, 0, 0, 0, 0, time.Local)
+	if *startDate != "" {
+		d, err := time.ParseInLocation("2006-01-02", *startDate, time.Local)
+		if err != nil {
+			log.Fatalf("plan: invalid start date %q, want YYYY-MM-DD", *startDate)
+		}
+		day = d
+	}
+	clock, err := time.Parse("15:04", *startTime)
+	if err != nil {
+		log.Fatalf("plan: invalid time %q, want HH:MM", *startTime)
+	}
+	c.Start = day.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+	for _, pair := range strings.Split(*mixList, ",") {
+		modality, value, ok := strings.Cut(pair, "=")
+		share, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil {
+			log.Fatalf("plan: invalid share %q, want modality=share", pair)
+		}
+		c.Mix[strings.TrimSpace(modality)] = share
+	}
+	if *days != "" {
+		for _, d := range strings.Split(*days, ",") {
+			i := slices.IndexFunc([]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}, func(prefix string) bool {
+				return strings.HasPrefix(strings.ToLower(strings.TrimSpace(d)), prefix)
+			})
+			if i < 0 {
+				log.Fatalf("plan: unknown day %q", d)
+			}
+			c.Days = append(c.Days, time.Weekday(i))
+		}
+	}
+
+	p, err := plan.Build(c, optimize.ObjectivesFor(subject))
+	if err != nil {
+		log.Fatalf("plan: %v", err)
+	}
+	fmt.Printf("%4s %4s  %-7s %-10s %-52s", "Week", "Meso", "Phase", "Starts", "Prescription")
+	for _, o := range p.Outcomes {
+		fmt.Printf(" %16s", o.Name+" ("+o.Unit+")")
+	}
+	fmt.Println()
+	for _, w := range p.Weeks {
+		fmt.Printf("%4d %4d  %-7s %-10s %-52s", w.Number, w.Mesocycle, w.Phase, w.Sessions[0].Format("2006-01-02"), w.Candidate)
+		for _, o := range p.Outcomes {
+			fmt.Printf(" %16.3f", w.Projected[o.Name])
+		}
+		fmt.Println()
+	}
+
+	if *out != "" {
+		for _, path := range strings.Split(*out, ",") {
+			if err := plan.WriteFile(strings.TrimSpace(path), p); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println("Wrote", strings.TrimSpace(path))
+		}
+	}
+}
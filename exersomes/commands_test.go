@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCommand runs a subcommand and returns what it printed
+func runCommand(t *testing.T, name string, args ...string) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan []byte)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.Bytes()
+	}()
+
+	found := runSubcommand(name, args)
+	os.Stdout = stdout
+	w.Close()
+	out := string(<-done)
+	if !found {
+		t.Fatalf("Expected %s to be a subcommand", name)
+	}
+	return out
+}
+
+// Test regulators ranks the catalog network and finds a cross-tissue path
+func TestRegulatorsCommand(t *testing.T) {
+	out := runCommand(t, "regulators", "-exercise", "HIIT", "-top", "3", "-path", "Muscle:Liver")
+	if !strings.Contains(out, "Key regulators (composite, HIIT)") {
+		t.Errorf("Expected a ranking header, got:\n%s", out)
+	}
+	if !strings.Contains(out, "  3. ") || strings.Contains(out, "  4. ") {
+		t.Errorf("Expected exactly 3 regulators, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Path: ") {
+		t.Errorf("Expected a signalling path from muscle to liver, got:\n%s", out)
+	}
+	if runSubcommand("no-such-command", nil) {
+		t.Error("Expected an unknown name not to run")
+	}
+}
+
+// Test plan prints one row per week and writes the requested files
+func TestPlanCommand(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "plan.json")
+	icsPath := filepath.Join(dir, "plan.ics")
+	out := runCommand(t, "plan", "-weeks", "4", "-start", "2026-01-05", "-out", jsonPath+","+icsPath)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 1+4+2 || !strings.HasPrefix(lines[0], "Week") {
+		t.Fatalf("Expected a header, 4 weeks and 2 written files, got:\n%s", out)
+	}
+	if !strings.Contains(lines[1], "2026-01-05") {
+		t.Errorf("Expected week 1 to start on the given date, got %q", lines[1])
+	}
+	for _, path := range []string{jsonPath, icsPath} {
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("Expected %s to be written: %v", path, err)
+		}
+	}
+}
+
+// Test fit -list reports the parameter set of every calibrated predictor
+func TestFitListCommand(t *testing.T) {
+	out := runCommand(t, "fit", "-list")
+	for _, name := range []string{"immune.acute_response", "bone.response", "liver.response",
+		"neural.response", "adipose.response", "bloodstream.response"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("Expected %s among the parameter sets, got:\n%s", name, out)
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package ligands
+package bone
 
 // Ligand represents a signaling molecule that binds to a receptor
 type Ligand struct {
@@ -53,3 +53,8 @@ var (
 
 	// Add more receptors
 )
+
+// GetBoneLigands returns exerkine ligands acting on or released from bone
+func GetBoneLigands() []Ligand {
+	return []Ligand{VEGF, SPARC, SOST, BMP2, BMP4, SPP1}
+}
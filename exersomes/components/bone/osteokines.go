@@ -29,3 +29,8 @@ var (
 
 	// Add more bone osteokines
 )
+
+// GetOsteokines returns bone-derived signaling molecules
+func GetOsteokines() []BoneOsteokine {
+	return []BoneOsteokine{OSTN, BGLAP}
+}
@@ -1,5 +1,7 @@
 package bone
 
+import "exersomes/params"
+
 // ExercisePrescription defines parameters for bone-targeted exercise
 type ExercisePrescription struct {
 	Name              string
@@ -198,6 +200,11 @@ var (
 	}
 )
 
+// GetStandardPrescriptions returns every predefined bone prescription
+func GetStandardPrescriptions() []ExercisePrescription {
+	return []ExercisePrescription{BoneMineralDensity, OsteocyteActivation, BoneRemodeling, BoneAnabolism}
+}
+
 // GetPrescriptionByCondition returns appropriate exercise prescriptions for a bone condition
 func GetPrescriptionByCondition(condition string) []ExercisePrescription {
 	var prescriptions []ExercisePrescription
@@ -220,6 +227,47 @@ func GetPrescriptionByCondition(condition string) []ExercisePrescription {
 	return prescriptions
 }
 
+// protocolParamKeys maps prescription names to their effect weights in ResponseParams
+var protocolParamKeys = map[string]string{
+	"Bone Mineral Density Protocol": "bmd",
+	"Osteocyte Network Stimulation": "osteocyte",
+	"Bone Remodeling Optimization":  "remodeling",
+	"Osteogenic Loading Protocol":   "osteogenic",
+}
+
+// effectWeights declares one protocol's formation, resorption, mineralization and
+// architecture weights, each bounded to keep its sign
+func effectWeights(key string, formation, resorption, mineralization, architecture float64) []params.Parameter {
+	return []params.Parameter{
+		{Name: key + ".formation", Value: formation, Min: 0, Max: 1},
+		{Name: key + ".resorption", Value: resorption, Min: -1, Max: 0},
+		{Name: key + ".mineralization", Value: mineralization, Min: 0, Max: 1},
+		{Name: key + ".architecture", Value: architecture, Min: 0, Max: 1},
+	}
+}
+
+// ResponseParams are the coefficients of PredictBoneResponse
+var ResponseParams = params.Register(params.NewSet("bone.response", responseParameters()...))
+
+func responseParameters() []params.Parameter {
+	parameters := []params.Parameter{
+		{Name: "load.low", Value: 0.6, Min: 0, Max: 1},
+		{Name: "load.moderate", Value: 0.8, Min: 0, Max: 1},
+		{Name: "load.high", Value: 1.0, Min: 0, Max: 1.5},
+		{Name: "load.variable", Value: 0.85, Min: 0, Max: 1},
+		{Name: "duration.plateau_weeks", Value: 16, Min: 4, Max: 52, Fixed: true},
+		{Name: "duration.late_gain", Value: 0.1, Min: 0, Max: 1,
+			Description: "Extra effect per plateau length trained beyond the plateau"},
+		{Name: "low_density.formation", Value: 1.3, Min: 1, Max: 2},
+		{Name: "low_density.mineralization", Value: 1.2, Min: 1, Max: 2},
+		{Name: "high_resorption.resorption", Value: 1.5, Min: 1, Max: 3},
+	}
+	parameters = append(parameters, effectWeights("bmd", 0.4, -0.2, 0.4, 0.3)...)
+	parameters = append(parameters, effectWeights("osteocyte", 0.3, -0.1, 0.2, 0.4)...)
+	parameters = append(parameters, effectWeights("remodeling", 0.3, -0.3, 0.3, 0.3)...)
+	return append(parameters, effectWeights("osteogenic", 0.5, -0.1, 0.3, 0.4)...)
+}
+
 // PredictBoneResponse estimates the effects of an exercise prescription
 // on bone parameters based on the protocol, duration of adherence, and patient factors
 func PredictBoneResponse(prescription ExercisePrescription, weeks int,
@@ -237,65 +285,50 @@ func PredictBoneResponse(prescription ExercisePrescription, weeks int,
 	// Calculate intensity factor
 	intensityFactor := float64(prescription.IntensityPercent) / 100.0
 
+	p := ResponseParams
+
 	// Load magnitude factor
 	loadFactor := 0.0
 	switch prescription.LoadMagnitude {
 	case "Low":
-		loadFactor = 0.6
+		loadFactor = p.Get("load.low")
 	case "Moderate":
-		loadFactor = 0.8
+		loadFactor = p.Get("load.moderate")
 	case "High":
-		loadFactor = 1.0
+		loadFactor = p.Get("load.high")
 	case "Variable":
-		loadFactor = 0.85
+		loadFactor = p.Get("load.variable")
 	}
 
-	// Calculate duration factor (diminishing returns after 16 weeks for bone)
+	// Calculate duration factor (diminishing returns after the plateau, 16 weeks by default)
+	plateau := p.Get("duration.plateau_weeks")
 	durationFactor := 0.0
-	if weeks <= 16 {
-		durationFactor = float64(weeks) / 16.0
+	if float64(weeks) <= plateau {
+		durationFactor = float64(weeks) / plateau
 	} else {
-		durationFactor = 1.0 + (0.1 * float64(weeks-16) / 16.0)
+		durationFactor = 1.0 + (p.Get("duration.late_gain") * (float64(weeks) - plateau) / plateau)
 	}
 
 	// Base effect factors
 	var formationEffect, resorptionEffect, mineralizationEffect, architectureEffect float64
 
-	// Calculate base effects based on prescription type
-	switch prescription.Name {
-	case "Bone Mineral Density Protocol":
-		formationEffect = 0.4 * intensityFactor * loadFactor * durationFactor
-		resorptionEffect = -0.2 * intensityFactor * loadFactor * durationFactor
-		mineralizationEffect = 0.4 * intensityFactor * loadFactor * durationFactor
-		architectureEffect = 0.3 * intensityFactor * loadFactor * durationFactor
-
-	case "Osteocyte Network Stimulation":
-		formationEffect = 0.3 * intensityFactor * loadFactor * durationFactor
-		resorptionEffect = -0.1 * intensityFactor * loadFactor * durationFactor
-		mineralizationEffect = 0.2 * intensityFactor * loadFactor * durationFactor
-		architectureEffect = 0.4 * intensityFactor * loadFactor * durationFactor
-
-	case "Bone Remodeling Optimization":
-		formationEffect = 0.3 * intensityFactor * loadFactor * durationFactor
-		resorptionEffect = -0.3 * intensityFactor * loadFactor * durationFactor
-		mineralizationEffect = 0.3 * intensityFactor * loadFactor * durationFactor
-		architectureEffect = 0.3 * intensityFactor * loadFactor * durationFactor
-
-	case "Osteogenic Loading Protocol":
-		formationEffect = 0.5 * intensityFactor * loadFactor * durationFactor
-		resorptionEffect = -0.1 * intensityFactor * loadFactor * durationFactor
-		mineralizationEffect = 0.3 * intensityFactor * loadFactor * durationFactor
-		architectureEffect = 0.4 * intensityFactor * loadFactor * durationFactor
+	// Calculate base effects from the prescription's effect weights
+	if key, ok := protocolParamKeys[prescription.Name]; ok {
+		scale := intensityFactor * loadFactor * durationFactor
+		formationEffect = p.Get(key+".formation") * scale
+		resorptionEffect = p.Get(key+".resorption") * scale
+		mineralizationEffect = p.Get(key+".mineralization") * scale
+		architectureEffect = p.Get(key+".architecture") * scale
 	}
 
 	// Adjust for baseline conditions
 	if lowBaselineDensity {
-		formationEffect *= 1.3
-		mineralizationEffect *= 1.2
+		formationEffect *= p.Get("low_density.formation")
+		mineralizationEffect *= p.Get("low_density.mineralization")
 	}
 
 	if highResorption {
-		resorptionEffect *= 1.5
+		resorptionEffect *= p.Get("high_resorption.resorption")
 	}
 
 	// Calculate specific factor changes
@@ -0,0 +1,16 @@
+package bone
+
+import "exersomes/profile"
+
+// PredictBoneResponseForProfile runs PredictBoneResponse for an individual.
+// Osteopenia, osteoporosis and glucocorticoids mark low baseline density;
+// osteoporosis, glucocorticoids and menopause raise resorption unless
+// bisphosphonates suppress it. Adaptation accrues over the profile's
+// effective weeks.
+func PredictBoneResponseForProfile(prescription ExercisePrescription, weeks int, p profile.Profile) BoneResponse {
+	p = p.WithDefaults()
+	lowDensity := p.HasAny(profile.Osteopenia, profile.Osteoporosis) || p.Takes(profile.Glucocorticoids)
+	highResorption := (p.Has(profile.Osteoporosis) || p.Takes(profile.Glucocorticoids) || p.Postmenopausal()) &&
+		!p.Takes(profile.Bisphosphonates)
+	return PredictBoneResponse(prescription, p.EffectiveWeeks(weeks), lowDensity, highResorption)
+}
@@ -0,0 +1,42 @@
+package bone
+
+import (
+	"exersomes/profile"
+	"reflect"
+	"testing"
+)
+
+// Test the reference profile reproduces the default prediction, and age, sex,
+// conditions and medications shift it
+func TestPredictBoneResponseForProfile(t *testing.T) {
+	legacy := PredictBoneResponse(BoneMineralDensity, 12, false, false)
+	if got := PredictBoneResponseForProfile(BoneMineralDensity, 12, profile.Reference); !reflect.DeepEqual(got, legacy) {
+		t.Error("Expected the reference profile to match PredictBoneResponse without risk factors")
+	}
+	bmd := func(p profile.Profile) float64 {
+		return PredictBoneResponseForProfile(BoneMineralDensity, 12, p).StructuralMetrics["Bone mineral density"]
+	}
+	ctx := func(p profile.Profile) float64 {
+		return PredictBoneResponseForProfile(BoneMineralDensity, 12, p).ResorptionMetrics["CTX"]
+	}
+
+	if older := (profile.Profile{AgeYears: 70}); bmd(older) >= bmd(profile.Reference) {
+		t.Error("Expected a smaller density gain at 70")
+	}
+	if low := (profile.Profile{Conditions: []string{profile.Osteopenia}}); bmd(low) <= bmd(profile.Reference) {
+		t.Error("Expected a larger density gain from a low baseline")
+	}
+
+	man := profile.Profile{AgeYears: 60, Sex: profile.Male}
+	woman := profile.Profile{AgeYears: 60, Sex: profile.Female}
+	if ctx(woman) == ctx(man) {
+		t.Error("Expected menopause to change the resorption response")
+	}
+	woman.Medications = []string{profile.Bisphosphonates}
+	if ctx(woman) != ctx(man) {
+		t.Error("Expected bisphosphonates to suppress the postmenopausal resorption adjustment")
+	}
+	if steroids := (profile.Profile{AgeYears: 60, Medications: []string{profile.Glucocorticoids}}); bmd(steroids) <= bmd(man) || ctx(steroids) == ctx(man) {
+		t.Error("Expected glucocorticoids to mark low density and high resorption")
+	}
+}
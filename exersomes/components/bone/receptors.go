@@ -1,4 +1,4 @@
-package receptors
+package bone
 
 // Receptor represents a protein that binds to a ligand
 type Receptor struct {
@@ -33,3 +33,8 @@ var (
 
 	// Add more receptors
 )
+
+// GetBoneReceptors returns receptors expressed in bone tissue
+func GetBoneReceptors() []Receptor {
+	return []Receptor{VEGFR, TNFRSF11B, BMP2R}
+}
@@ -0,0 +1,24 @@
+package bone
+
+import "exersomes/sensitivity"
+
+// SensitivityPredictor exposes PredictBoneResponse to sensitivity analysis:
+// the Bone Mineral Density Protocol over 12 weeks, varying its intensity,
+// session length and weekly frequency
+var SensitivityPredictor = sensitivity.Register(sensitivity.Predictor{
+	Name:        "bone",
+	Description: "PredictBoneResponse, " + BoneMineralDensity.Name + ", 12 weeks",
+	Factors:     sensitivity.PrescriptionFactors(),
+	Evaluate: func(x map[string]float64) map[string]float64 {
+		p := BoneMineralDensity
+		sensitivity.ApplyPrescription(x, &p.IntensityPercent, &p.DurationMinutes, &p.FrequencyPerWeek)
+		r := PredictBoneResponse(p, 12, false, false)
+		return sensitivity.Flatten(map[string]map[string]float64{
+			"osteokine":  r.OsteokineChanges,
+			"cell":       r.CellActivityChanges,
+			"structure":  r.StructuralMetrics,
+			"formation":  r.FormationMetrics,
+			"resorption": r.ResorptionMetrics,
+		})
+	},
+})
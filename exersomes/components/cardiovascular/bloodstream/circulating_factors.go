@@ -1,6 +1,9 @@
 package bloodstream
 
-import "time"
+import (
+	"exersomes/session"
+	"time"
+)
 
 // CirculatingFactor represents an exercise-responsive molecule in circulation
 type CirculatingFactor struct {
@@ -12,7 +15,12 @@ type CirculatingFactor struct {
 	ExerciseResponse string  // "Up", "Down", "Biphasic"
 	TimeToMaxChange  float64 // Minutes from exercise onset to peak change
 	RecoveryTime     float64 // Minutes from exercise cessation to baseline
-	BaselineRange    struct {
+	// Minutes release lags the effort driving it, so the factor keeps rising
+	// after exercise (e.g. cortisol via the HPA axis)
+	ResponseLagMinutes float64
+	// Optional first-order uptake into tissues, per minute (adds to half-life clearance)
+	TissueUptakePerMinute float64
+	BaselineRange         struct {
 		Min  float64
 		Max  float64
 		Unit string
@@ -102,6 +110,8 @@ var (
 		ExerciseResponse: "Up",
 		TimeToMaxChange:  30.0,
 		RecoveryTime:     120.0,
+		// ACTH-driven release peaks after exercise ends
+		ResponseLagMinutes: 15.0,
 		PhysiologicalRoles: []string{
 			"Gluconeogenesis", "Anti-inflammatory",
 			"Protein catabolism", "Lipid mobilization",
@@ -259,72 +269,20 @@ var (
 	}
 )
 
-// CalculateExerciseResponse predicts the concentration during/after exercise
+// CalculateExerciseResponse predicts the concentration during/after a continuous bout
+// using the factor's pharmacokinetic model, including release that outlasts the
+// effort such as cortisol's post-exercise peak.
+//
+// Deprecated: isPostExercise is ignored because it is implied by timePoint and
+// duration. Use CalculateSessionResponse with session.ContinuousSession instead.
 func (cf *CirculatingFactor) CalculateExerciseResponse(
 	intensity float64, // % of max (VO2max, HR max)
 	duration time.Duration, // Exercise duration
 	timePoint time.Duration, // Time from start of exercise
-	isPostExercise bool, // Whether timePoint is after exercise ended
+	isPostExercise bool, // Ignored; see the deprecation note
 ) float64 {
-	// Convert intensity to 0-1 scale
-	intensityFactor := intensity / 100.0
-
-	// Calculate baseline average
-	baseline := (cf.BaselineRange.Min + cf.BaselineRange.Max) / 2.0
-
-	// Maximum possible response (middle of exercise-induced range)
-	maxResponse := (cf.ExerciseInducedRange.Min + cf.ExerciseInducedRange.Max) / 2.0
-
-	// For factors that decrease with exercise
-	if cf.ExerciseResponse == "Down" {
-		maxResponse = cf.BaselineRange.Min * 0.7 // Approximately 30% reduction
-	}
-
-	// Scale maximum response by intensity
-	maxPossibleIncrease := maxResponse - baseline
-	intensityAdjustedMax := baseline + (maxPossibleIncrease * intensityFactor)
-
-	// Calculate time-dependent response curve
-	// Time is in minutes from exercise start
-	timeMin := timePoint.Minutes()
-
-	if !isPostExercise {
-		// During exercise: rising curve until TimeToMaxChange
-		if timeMin < cf.TimeToMaxChange {
-			// Rising phase - simplified model
-			riseRatio := timeMin / cf.TimeToMaxChange
-			return baseline + (intensityAdjustedMax-baseline)*riseRatio
-		} else {
-			// Plateau or slight decline depending on factor
-			return intensityAdjustedMax
-		}
-	} else {
-		// After exercise: exponential decay
-		exerciseDuration := duration.Minutes()
-		timeAfterExercise := timeMin - exerciseDuration
-
-		// Some factors may continue to rise briefly after exercise
-		if cf.Name == "Cortisol" && timeAfterExercise < 15 {
-			// Cortisol often peaks just after exercise
-			return intensityAdjustedMax * 1.1
-		}
-
-		// Standard exponential decay
-		decayConstant := 0.693 / cf.RecoveryTime // ln(2)/half-life
-		decayFactor := timeAfterExercise * decayConstant
-
-		// Cap the decay factor to avoid extreme values
-		if decayFactor > 5.0 {
-			decayFactor = 5.0
-		}
-
-		// Calculate decay and ensure we don't go below baseline
-		decayedValue := intensityAdjustedMax * float64(time.Now().Nanosecond())
-		if decayedValue < baseline {
-			return baseline
-		}
-		return decayedValue
-	}
+	bout := session.ContinuousSession(cf.Name, intensity, duration.Minutes())
+	return cf.CalculateSessionResponse(bout, timePoint)
 }
 
 // GetExerciseResponsiveFactors returns all factors that change with exercise
@@ -335,48 +293,52 @@ func GetExerciseResponsiveFactors() []CirculatingFactor {
 	}
 }
 
-// PredictCirculatingProfileDuringExercise generates a time series of changes
+// PredictCirculatingProfileDuringExercise generates a time series of changes over a
+// bout of the given exercise type. The type's representative session (see
+// session.ForExerciseType) is scaled to peak at intensityPercent and last
+// durationMinutes, so HIIT intervals and rests shape the profile.
 func PredictCirculatingProfileDuringExercise(
 	exerciseType string,
 	intensityPercent float64,
 	durationMinutes float64,
 	timePointsCount int,
 ) map[string][]float64 {
-	// Create exercise duration
-	duration := time.Duration(durationMinutes) * time.Minute
-
-	// Adjust intensity based on exercise type
-	adjustedIntensity := intensityPercent
-	if exerciseType == "HIIT" {
-		// HIIT has higher peak responses
-		adjustedIntensity *= 1.2
-		if adjustedIntensity > 100 {
-			adjustedIntensity = 100
-		}
-	}
-
-	// Generate time series for all factors
-	factors := GetExerciseResponsiveFactors()
-	results := make(map[string][]float64)
+	bout := session.ForExerciseType(exerciseType).Scaled(intensityPercent, durationMinutes)
+	return PredictCirculatingProfileForSession(bout, 0, timePointsCount)
+}
 
-	// Calculate time intervals
-	interval := durationMinutes / float64(timePointsCount-1)
+// sessionStep is the integration step used when driving responses from a session
+const sessionStep = 15 * time.Second
 
-	for _, factor := range factors {
-		var values []float64
+// CalculateSessionResponse predicts the concentration at timePoint (from session start)
+// by solving the factor's release/clearance model over each work, rest, warm-up and
+// cool-down segment. Time points after the session ends follow first-order clearance.
+func (cf *CirculatingFactor) CalculateSessionResponse(s session.Session, timePoint time.Duration) float64 {
+	return cf.Model().Simulate(s, []time.Duration{timePoint})[0]
+}
 
-		// Generate values at each time point
-		for i := 0; i < timePointsCount; i++ {
-			timePoint := time.Duration(float64(i)*interval) * time.Minute
-			isPost := timePoint > duration
+// PredictCirculatingProfileForSession generates a time series of changes for a composed
+// session, sampled evenly from session start to the end of the recovery window
+func PredictCirculatingProfileForSession(
+	s session.Session,
+	recovery time.Duration,
+	timePointsCount int,
+) map[string][]float64 {
+	results := make(map[string][]float64)
+	if timePointsCount < 2 {
+		return results
+	}
 
-			concentration := factor.CalculateExerciseResponse(
-				adjustedIntensity, duration, timePoint, isPost)
+	total := s.TotalDuration() + recovery
+	interval := total / time.Duration(timePointsCount-1)
 
-			values = append(values, concentration)
-		}
+	timePoints := make([]time.Duration, timePointsCount)
+	for i := range timePoints {
+		timePoints[i] = time.Duration(i) * interval
+	}
 
-		results[factor.Name] = values
+	for _, factor := range GetExerciseResponsiveFactors() {
+		results[factor.Name] = factor.Model().Simulate(s, timePoints)
 	}
 
 	return results
@@ -1,5 +1,11 @@
 package bloodstream
 
+import (
+	"exersomes/session"
+	"math"
+	"time"
+)
+
 // ImmuneCell represents a circulating immune cell affected by exercise
 type ImmuneCell struct {
 	Name          string
@@ -251,61 +257,15 @@ var (
 	}
 )
 
-// CalculateImmuneResponse predicts immune cell counts during/after exercise
+// CalculateImmuneResponse predicts immune cell counts during/after a continuous bout
 func CalculateImmuneResponse(
 	cell ImmuneCell,
 	exerciseIntensity float64, // 0-100%
 	exerciseDuration float64, // Minutes
 	timeFromStart float64, // Minutes from exercise start
 ) float64 {
-	// Calculate baseline
-	baseline := cell.BaselineCount.Mean
-
-	// Intensity factor (0-1)
-	intensityFactor := exerciseIntensity / 100.0
-
-	// Scale the response by exercise intensity and duration
-	// More intense exercise = stronger immune response
-	responseFactor := intensityFactor * (1.0 + (exerciseDuration / 60.0)) // Normalized to 1 hour
-
-	// Cap response factor for reasonable physiological response
-	if responseFactor > 2.0 {
-		responseFactor = 2.0
-	}
-
-	// Adjust magnitude based on response factor
-	adjustedMagnitude := 1.0 + ((cell.ExerciseResponse.AcuteMagnitude - 1.0) * responseFactor)
-
-	// Different phases of immune response
-	if timeFromStart <= exerciseDuration {
-		// During exercise phase - progressive increase
-		progressRatio := timeFromStart / exerciseDuration
-		return baseline * (1.0 + ((adjustedMagnitude - 1.0) * progressRatio))
-
-	} else if timeFromStart <= (exerciseDuration + 30.0) {
-		// Early recovery phase (first 30 minutes) - often peak for some cell types
-		if cell.Name == "Neutrophils" {
-			// Neutrophils often continue to increase post-exercise
-			return baseline * (adjustedMagnitude + 0.5)
-		} else {
-			return baseline * adjustedMagnitude
-		}
-
-	} else {
-		// Recovery phase - exponential decline
-		recoveryTimeHours := cell.ExerciseResponse.RecoveryTime
-		recoveryTimeMinutes := recoveryTimeHours * 60.0
-
-		timeIntoRecovery := timeFromStart - exerciseDuration - 30.0
-		recoveryRatio := timeIntoRecovery / recoveryTimeMinutes
-
-		if recoveryRatio >= 1.0 {
-			return baseline // Fully recovered
-		}
-
-		// Exponential decay back to baseline
-		return baseline + ((baseline*adjustedMagnitude)-baseline)*(1.0-recoveryRatio)
-	}
+	bout := session.ContinuousSession("Continuous", exerciseIntensity, exerciseDuration)
+	return CalculateImmuneSessionResponse(cell, bout, timeFromStart)
 }
 
 // GetImmuneCellsAffectedByExercise returns all immune cells modulated by exercise
@@ -327,32 +287,8 @@ func PredictImmuneCellTimeseriesForExercise(
 	recoveryPeriod float64, // Minutes to monitor post-exercise
 	timepoints int, // Number of timepoints to simulate
 ) map[string][]float64 {
-	// Calculate total timeline duration
-	totalDuration := exerciseDuration + recoveryPeriod
-
-	// Calculate time interval between points
-	interval := totalDuration / float64(timepoints-1)
-
-	// Get immune cells
-	cells := GetImmuneCellsAffectedByExercise()
-
-	// Initialize results
-	results := make(map[string][]float64)
-
-	// Generate time series for each cell type
-	for _, cell := range cells {
-		cellValues := make([]float64, timepoints)
-
-		for i := 0; i < timepoints; i++ {
-			timeFromStart := float64(i) * interval
-			cellValues[i] = CalculateImmuneResponse(cell, exerciseIntensity,
-				exerciseDuration, timeFromStart)
-		}
-
-		results[cell.Name] = cellValues
-	}
-
-	return results
+	bout := session.ContinuousSession("Continuous", exerciseIntensity, exerciseDuration)
+	return PredictImmuneCellTimeseriesForSession(bout, recoveryPeriod, timepoints)
 }
 
 // PredictImmuneIndexes calculates integrated measures of immune function
@@ -439,3 +375,81 @@ func PredictImmuneIndexes(
 
 	return indexes
 }
+
+// CalculateImmuneSessionResponse predicts immune cell counts at timeFromStart (minutes)
+// for a composed session. Mobilization follows the intensity of each segment and grows
+// with accumulated work, up to twice the acute change; rest segments and recovery
+// let counts fall back over the cell's recovery time. Neutrophils add a delayed rise.
+func CalculateImmuneSessionResponse(
+	cell ImmuneCell,
+	s session.Session,
+	timeFromStart float64, // Minutes from session start
+) float64 {
+	baseline := cell.BaselineCount.Mean
+
+	const mobilizationRate = 0.2 // Per minute; lymphocytes mobilize within minutes
+	recoveryRate := 3.0 / math.Max(cell.ExerciseResponse.RecoveryTime*60.0, 1.0)
+	delayedRate := 1.0 / 30.0 // Delayed neutrophilia lags mobilization by ~30 min
+
+	activation := 0.0
+	delayed := 0.0
+	workMinutes := 0.0
+	end := time.Duration(timeFromStart * float64(time.Minute))
+
+	for t := time.Duration(0); t < end; t += sessionStep {
+		step := sessionStep
+		if t+step > end {
+			step = end - t
+		}
+		dt := step.Minutes()
+
+		seg, active := s.SegmentAt(t)
+		target := 0.0
+		if active {
+			if seg.Phase == session.PhaseWork {
+				workMinutes += dt
+			}
+			target = math.Min((seg.IntensityPercent/100.0)*(1.0+workMinutes/60.0), 2.0)
+		}
+
+		rate := recoveryRate
+		if target > activation {
+			rate = mobilizationRate
+		}
+		activation += (target - activation) * (1.0 - math.Exp(-rate*dt))
+		delayed += (activation - delayed) * (1.0 - math.Exp(-delayedRate*dt))
+	}
+
+	count := baseline * (1.0 + (cell.ExerciseResponse.AcuteMagnitude-1.0)*activation)
+	if cell.Name == "Neutrophils" {
+		count += baseline * 0.5 * delayed
+	}
+
+	return count
+}
+
+// PredictImmuneCellTimeseriesForSession generates immune cell time series for a composed
+// session followed by a recovery period (minutes)
+func PredictImmuneCellTimeseriesForSession(
+	s session.Session,
+	recoveryPeriod float64, // Minutes to monitor post-exercise
+	timepoints int,
+) map[string][]float64 {
+	results := make(map[string][]float64)
+	if timepoints < 2 {
+		return results
+	}
+
+	totalDuration := s.TotalDuration().Minutes() + recoveryPeriod
+	interval := totalDuration / float64(timepoints-1)
+
+	for _, cell := range GetImmuneCellsAffectedByExercise() {
+		cellValues := make([]float64, timepoints)
+		for i := 0; i < timepoints; i++ {
+			cellValues[i] = CalculateImmuneSessionResponse(cell, s, float64(i)*interval)
+		}
+		results[cell.Name] = cellValues
+	}
+
+	return results
+}
@@ -0,0 +1,113 @@
+package bloodstream
+
+import (
+	"exersomes/session"
+	"math"
+	"testing"
+	"time"
+)
+
+// Test that post-exercise clearance reproduces the analytic half-life decay
+func TestModelReproducesHalfLifeDecay(t *testing.T) {
+	for _, factor := range GetExerciseResponsiveFactors() {
+		m := factor.Model()
+		excess := m.Baseline * 2.0
+		start := State{Concentration: m.Baseline + excess}
+
+		for _, halfLives := range []float64{0.5, 1, 2, 4} {
+			elapsed := time.Duration(halfLives * factor.HalfLifeMinutes * float64(time.Minute))
+			got := m.Advance(start, 0, elapsed).Concentration
+
+			expected := m.Baseline + excess*math.Pow(0.5, halfLives)
+			if math.Abs(got-expected) > 1e-4*math.Max(expected, 1) {
+				t.Errorf("%s after %.1f half-lives: got %.6f; expected %.6f",
+					factor.Name, halfLives, got, expected)
+			}
+		}
+	}
+}
+
+// Test that tissue uptake shortens the effective half-life
+func TestTissueUptakeShortensHalfLife(t *testing.T) {
+	factor := IL6Circ
+	withoutUptake := factor.Model().HalfLife()
+
+	factor.TissueUptakePerMinute = factor.Model().EliminationRate
+	withUptake := factor.Model().HalfLife()
+
+	if math.Abs(withUptake.Minutes()-withoutUptake.Minutes()/2) > 1e-6 {
+		t.Errorf("Expected uptake equal to elimination to halve half-life: %v -> %v",
+			withoutUptake, withUptake)
+	}
+}
+
+// Test that sustained maximal effort settles at the exercise-induced midpoint
+func TestModelSteadyState(t *testing.T) {
+	m := Epinephrine.Model()
+	state := m.Advance(m.RestingState(), 100, 10*time.Hour)
+
+	expected := (Epinephrine.ExerciseInducedRange.Min + Epinephrine.ExerciseInducedRange.Max) / 2
+	if math.Abs(state.Concentration-expected) > 1e-3*expected {
+		t.Errorf("Steady state = %.4f; expected %.4f", state.Concentration, expected)
+	}
+}
+
+// Test that the response is deterministic and returns to baseline after recovery
+func TestExerciseResponseIsDeterministic(t *testing.T) {
+	duration := 45 * time.Minute
+	timePoint := 90 * time.Minute
+
+	first := Cortisol.CalculateExerciseResponse(70, duration, timePoint, true)
+	second := Cortisol.CalculateExerciseResponse(70, duration, timePoint, true)
+	if first != second {
+		t.Errorf("Expected identical results, got %.10f and %.10f", first, second)
+	}
+
+	baseline := Cortisol.Model().Baseline
+	late := Lactate.CalculateExerciseResponse(70, duration, 24*time.Hour, true)
+	lactateBaseline := Lactate.Model().Baseline
+	if math.Abs(late-lactateBaseline) > 1e-3 {
+		t.Errorf("Expected lactate back at baseline %.3f after 24h, got %.6f", lactateBaseline, late)
+	}
+	if first <= baseline {
+		t.Errorf("Expected cortisol above baseline 45 min post-exercise, got %.3f", first)
+	}
+}
+
+// Test that Simulate returns values in the caller's order
+func TestSimulateUnsortedTimePoints(t *testing.T) {
+	s := session.FourByFourHIIT()
+	m := Lactate.Model()
+
+	points := []time.Duration{30 * time.Minute, 5 * time.Minute, 20 * time.Minute}
+	got := m.Simulate(s, points)
+	for i, tp := range points {
+		single := m.Simulate(s, []time.Duration{tp})[0]
+		if math.Abs(got[i]-single) > 1e-6*single {
+			t.Errorf("Point %v: batch %.6f vs single %.6f", tp, got[i], single)
+		}
+	}
+}
+
+// Test that catecholamines fall within minutes of stopping while lagged cortisol
+// release keeps rising after exercise and peaks in recovery
+func TestPostExerciseKinetics(t *testing.T) {
+	duration := 40 * time.Minute
+
+	end := Epinephrine.CalculateExerciseResponse(80, duration, duration, false)
+	after := Epinephrine.CalculateExerciseResponse(80, duration, duration+5*time.Minute, true)
+	if after >= end/2 {
+		t.Errorf("Expected epinephrine to halve within 5 min of stopping, got %.1f -> %.1f", end, after)
+	}
+
+	atEnd := Cortisol.CalculateExerciseResponse(80, duration, duration, false)
+	peak, peakAt := atEnd, duration
+	for m := duration; m <= duration+3*time.Hour; m += 5 * time.Minute {
+		if c := Cortisol.CalculateExerciseResponse(80, duration, m, m > duration); c > peak {
+			peak, peakAt = c, m
+		}
+	}
+	if peakAt <= duration+10*time.Minute || peakAt >= duration+time.Hour {
+		t.Errorf("Expected cortisol to peak 10-60 min into recovery, peaked %v after exercise", peakAt-duration)
+	}
+}
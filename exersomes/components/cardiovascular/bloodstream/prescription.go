@@ -1,5 +1,7 @@
 package bloodstream
 
+import "exersomes/params"
+
 // ExercisePrescription defines parameters for bloodstream-targeted exercise
 type ExercisePrescription struct {
 	Name               string
@@ -191,6 +193,11 @@ var (
 	}
 )
 
+// GetStandardPrescriptions returns every predefined bloodstream prescription
+func GetStandardPrescriptions() []ExercisePrescription {
+	return []ExercisePrescription{EndothelialHealth, InflammationReduction, AnticoagulationProtocol, CirculatingProgenitorStimulation}
+}
+
 // GetPrescriptionByCondition returns appropriate exercise prescriptions for a condition
 func GetPrescriptionByCondition(condition string) []ExercisePrescription {
 	var prescriptions []ExercisePrescription
@@ -213,6 +220,42 @@ func GetPrescriptionByCondition(condition string) []ExercisePrescription {
 	return prescriptions
 }
 
+// protocolParamKeys maps prescription names to their effect weights in ResponseParams
+var protocolParamKeys = map[string]string{
+	"Endothelial Health Protocol":     "endothelial_health",
+	"Vascular Inflammation Reduction": "inflammation_reduction",
+	"Coagulation Profile Improvement": "coagulation",
+	"Progenitor Cell Mobilization":    "progenitor",
+}
+
+// effectWeights declares one protocol's endothelial, inflammation, coagulation
+// and progenitor weights
+func effectWeights(key string, endothelial, inflammation, coagulation, progenitor float64) []params.Parameter {
+	return []params.Parameter{
+		{Name: key + ".endothelial", Value: endothelial, Min: 0, Max: 1},
+		{Name: key + ".inflammation", Value: inflammation, Min: 0, Max: 1},
+		{Name: key + ".coagulation", Value: coagulation, Min: 0, Max: 1},
+		{Name: key + ".progenitor", Value: progenitor, Min: 0, Max: 1},
+	}
+}
+
+// ResponseParams are the coefficients of PredictCirculatoryResponse
+var ResponseParams = params.Register(params.NewSet("bloodstream.response", responseParameters()...))
+
+func responseParameters() []params.Parameter {
+	parameters := []params.Parameter{
+		{Name: "duration.plateau_weeks", Value: 8, Min: 4, Max: 52, Fixed: true},
+		{Name: "duration.late_gain", Value: 0.1, Min: 0, Max: 1,
+			Description: "Extra effect per plateau length trained beyond the plateau"},
+		{Name: "endothelial_dysfunction.endothelial", Value: 1.5, Min: 1, Max: 3},
+		{Name: "baseline_inflammation.inflammation", Value: 1.5, Min: 1, Max: 3},
+	}
+	parameters = append(parameters, effectWeights("endothelial_health", 0.4, 0.2, 0.1, 0.1)...)
+	parameters = append(parameters, effectWeights("inflammation_reduction", 0.2, 0.5, 0.2, 0.1)...)
+	parameters = append(parameters, effectWeights("coagulation", 0.2, 0.2, 0.5, 0.1)...)
+	return append(parameters, effectWeights("progenitor", 0.3, 0.1, 0.1, 0.6)...)
+}
+
 // PredictCirculatoryResponse estimates the effects of an exercise prescription
 // on bloodstream parameters based on the protocol, duration of adherence, and patient factors
 func PredictCirculatoryResponse(prescription ExercisePrescription, weeks int,
@@ -230,51 +273,35 @@ func PredictCirculatoryResponse(prescription ExercisePrescription, weeks int,
 	// Calculate intensity factor
 	intensityFactor := float64(prescription.IntensityPercent) / 100.0
 
-	// Calculate duration factor (diminishing returns after 8 weeks)
+	p := ResponseParams
+
+	// Calculate duration factor (diminishing returns after the plateau, 8 weeks by default)
+	plateau := p.Get("duration.plateau_weeks")
 	durationFactor := 0.0
-	if weeks <= 8 {
-		durationFactor = float64(weeks) / 8.0
+	if float64(weeks) <= plateau {
+		durationFactor = float64(weeks) / plateau
 	} else {
-		durationFactor = 1.0 + (0.1 * float64(weeks-8) / 8.0)
+		durationFactor = 1.0 + (p.Get("duration.late_gain") * (float64(weeks) - plateau) / plateau)
 	}
 
 	// Base effect factors
 	var endothelialEffect, inflammationEffect, coagulationEffect, progenitorEffect float64
 
-	// Calculate base effects based on prescription type
-	switch prescription.Name {
-	case "Endothelial Health Protocol":
-		endothelialEffect = 0.4 * intensityFactor * durationFactor
-		inflammationEffect = 0.2 * intensityFactor * durationFactor
-		coagulationEffect = 0.1 * intensityFactor * durationFactor
-		progenitorEffect = 0.1 * intensityFactor * durationFactor
-
-	case "Vascular Inflammation Reduction":
-		endothelialEffect = 0.2 * intensityFactor * durationFactor
-		inflammationEffect = 0.5 * intensityFactor * durationFactor
-		coagulationEffect = 0.2 * intensityFactor * durationFactor
-		progenitorEffect = 0.1 * intensityFactor * durationFactor
-
-	case "Coagulation Profile Improvement":
-		endothelialEffect = 0.2 * intensityFactor * durationFactor
-		inflammationEffect = 0.2 * intensityFactor * durationFactor
-		coagulationEffect = 0.5 * intensityFactor * durationFactor
-		progenitorEffect = 0.1 * intensityFactor * durationFactor
-
-	case "Progenitor Cell Mobilization":
-		endothelialEffect = 0.3 * intensityFactor * durationFactor
-		inflammationEffect = 0.1 * intensityFactor * durationFactor
-		coagulationEffect = 0.1 * intensityFactor * durationFactor
-		progenitorEffect = 0.6 * intensityFactor * durationFactor
+	// Calculate base effects from the prescription's effect weights
+	if key, ok := protocolParamKeys[prescription.Name]; ok {
+		endothelialEffect = p.Get(key+".endothelial") * intensityFactor * durationFactor
+		inflammationEffect = p.Get(key+".inflammation") * intensityFactor * durationFactor
+		coagulationEffect = p.Get(key+".coagulation") * intensityFactor * durationFactor
+		progenitorEffect = p.Get(key+".progenitor") * intensityFactor * durationFactor
 	}
 
 	// Adjust for baseline conditions (higher effect if pathological at baseline)
 	if endothelialDysfunction {
-		endothelialEffect *= 1.5
+		endothelialEffect *= p.Get("endothelial_dysfunction.endothelial")
 	}
 
 	if baselineInflammation {
-		inflammationEffect *= 1.5
+		inflammationEffect *= p.Get("baseline_inflammation.inflammation")
 	}
 
 	// Calculate specific factor changes
@@ -0,0 +1,56 @@
+package bloodstream
+
+import "exersomes/profile"
+
+// PredictCirculatoryResponseForProfile runs PredictCirculatoryResponse for an
+// individual. Chronic inflammation, type 2 diabetes and obesity mark baseline
+// inflammation; endothelial dysfunction, hypertension, type 2 diabetes and
+// heart disease mark endothelial dysfunction. Adaptation accrues over the
+// profile's effective weeks.
+func PredictCirculatoryResponseForProfile(prescription ExercisePrescription, weeks int, p profile.Profile) CirculatoryResponse {
+	p = p.WithDefaults()
+	inflammation := p.HasAny(profile.ChronicInflammation, profile.Type2Diabetes) || p.Obese()
+	dysfunction := p.HasAny(profile.EndothelialDysfunction, profile.Hypertension, profile.Type2Diabetes, profile.HeartDisease)
+	return PredictCirculatoryResponse(prescription, p.EffectiveWeeks(weeks), inflammation, dysfunction)
+}
+
+// immuneTrainingStatus maps a profile's training status to PredictImmuneIndexes'
+var immuneTrainingStatus = map[string]string{
+	profile.Untrained: "Untrained",
+	profile.Trained:   "Moderately trained",
+	profile.Athlete:   "Highly trained",
+}
+
+// PredictImmuneIndexesForProfile runs PredictImmuneIndexes at the profile's
+// training status
+func PredictImmuneIndexesForProfile(exerciseIntensity, exerciseDuration float64, p profile.Profile) map[string]float64 {
+	return PredictImmuneIndexes(exerciseIntensity, exerciseDuration, immuneTrainingStatus[p.WithDefaults().TrainingStatus])
+}
+
+// PredictCirculatingProfileDuringExerciseForProfile runs
+// PredictCirculatingProfileDuringExercise for an individual, scaling each
+// factor's change from rest by the profile's AcuteResponseScale
+func PredictCirculatingProfileDuringExerciseForProfile(exerciseType string, intensityPercent float64,
+	durationMinutes float64, timePointsCount int, p profile.Profile) map[string][]float64 {
+	series := PredictCirculatingProfileDuringExercise(exerciseType, intensityPercent, durationMinutes, timePointsCount)
+	return scaleFromRest(series, p.AcuteResponseScale())
+}
+
+// PredictImmuneCellTimeseriesForExerciseForProfile runs
+// PredictImmuneCellTimeseriesForExercise for an individual, scaling each cell
+// count's change from rest by the profile's AcuteResponseScale
+func PredictImmuneCellTimeseriesForExerciseForProfile(exerciseIntensity float64, exerciseDuration float64,
+	recoveryPeriod float64, timepoints int, p profile.Profile) map[string][]float64 {
+	series := PredictImmuneCellTimeseriesForExercise(exerciseIntensity, exerciseDuration, recoveryPeriod, timepoints)
+	return scaleFromRest(series, p.AcuteResponseScale())
+}
+
+// scaleFromRest scales every series' change from its first, resting value in place
+func scaleFromRest(series map[string][]float64, scale float64) map[string][]float64 {
+	for _, values := range series {
+		for i := 1; i < len(values); i++ {
+			values[i] = values[0] + (values[i]-values[0])*scale
+		}
+	}
+	return series
+}
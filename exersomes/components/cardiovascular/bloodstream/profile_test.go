@@ -0,0 +1,65 @@
+package bloodstream
+
+import (
+	"exersomes/profile"
+	"reflect"
+	"testing"
+)
+
+// Test fitness, training status, body fat and sex shift the circulatory and
+// immune predictions
+func TestPredictCirculatoryResponseForProfile(t *testing.T) {
+	legacy := PredictCirculatoryResponse(EndothelialHealth, 12, false, false)
+	if got := PredictCirculatoryResponseForProfile(EndothelialHealth, 12, profile.Reference); !reflect.DeepEqual(got, legacy) {
+		t.Error("Expected the reference profile to match PredictCirculatoryResponse without conditions")
+	}
+	fmd := func(p profile.Profile) float64 {
+		return PredictCirculatoryResponseForProfile(EndothelialHealth, 12, p).FlowMetrics["Flow-mediated dilation"]
+	}
+	crp := func(p profile.Profile) float64 {
+		return PredictCirculatoryResponseForProfile(EndothelialHealth, 12, p).FactorChanges["CRP"]
+	}
+
+	if fmd(profile.Profile{VO2max: 50}) >= fmd(profile.Reference) || fmd(profile.Profile{TrainingStatus: profile.Athlete}) >= fmd(profile.Reference) {
+		t.Error("Expected fitter and trained subjects to adapt less")
+	}
+	if fmd(profile.Profile{Conditions: []string{profile.Hypertension}}) <= fmd(profile.Reference) {
+		t.Error("Expected hypertension to count as endothelial dysfunction")
+	}
+	man := profile.Profile{Sex: profile.Male, BodyFatPercent: 30}
+	woman := profile.Profile{Sex: profile.Female, BodyFatPercent: 30}
+	if crp(man) >= crp(woman) || crp(woman) != crp(profile.Reference) {
+		t.Errorf("Expected 30%% body fat to mark inflammation in men only, got CRP %g and %g", crp(man), crp(woman))
+	}
+
+	untrained := PredictImmuneIndexesForProfile(70, 60, profile.Reference)
+	athlete := PredictImmuneIndexesForProfile(70, 60, profile.Profile{TrainingStatus: profile.Athlete})
+	if !reflect.DeepEqual(athlete, PredictImmuneIndexes(70, 60, "Highly trained")) || reflect.DeepEqual(athlete, untrained) {
+		t.Error("Expected athletes to map to the highly trained immune indexes")
+	}
+}
+
+// Test training status scales acute changes from rest in the circulating and
+// immune cell time series, and other fields leave them
+func TestTimeseriesForProfile(t *testing.T) {
+	legacy := PredictCirculatingProfileDuringExercise("HIIT", 85, 40, 21)
+	if got := PredictCirculatingProfileDuringExerciseForProfile("HIIT", 85, 40, 21, profile.Reference); !reflect.DeepEqual(got, legacy) {
+		t.Error("Expected the reference profile to match PredictCirculatingProfileDuringExercise")
+	}
+	athlete := PredictCirculatingProfileDuringExerciseForProfile("HIIT", 85, 40, 21, profile.Profile{TrainingStatus: profile.Athlete})
+	lactate, reference := athlete[Lactate.Name], legacy[Lactate.Name]
+	if lactate[0] != reference[0] || lactate[20]-lactate[0] >= reference[20]-reference[0] {
+		t.Errorf("Expected athletes to start at rest and raise lactate less, got %g -> %g for %g -> %g",
+			lactate[0], lactate[20], reference[0], reference[20])
+	}
+
+	cells := PredictImmuneCellTimeseriesForExercise(75, 60, 120, 19)
+	if got := PredictImmuneCellTimeseriesForExerciseForProfile(75, 60, 120, 19, profile.Profile{AgeYears: 70, BodyFatPercent: 40}); !reflect.DeepEqual(got, cells) {
+		t.Error("Expected age and body fat to leave the immune cell time series unchanged")
+	}
+	trained := PredictImmuneCellTimeseriesForExerciseForProfile(75, 60, 120, 19, profile.Profile{TrainingStatus: profile.Trained})
+	nk, reference := trained[NaturalKillerCells.Name], cells[NaturalKillerCells.Name]
+	if peak := 6; nk[peak]-nk[0] >= reference[peak]-reference[0] {
+		t.Errorf("Expected trained subjects to mobilise fewer NK cells, got %g for %g", nk[peak], reference[peak])
+	}
+}
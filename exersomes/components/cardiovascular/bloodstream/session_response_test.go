@@ -0,0 +1,109 @@
+package bloodstream
+
+import (
+	"exersomes/session"
+	"math"
+	"testing"
+	"time"
+)
+
+// Test that interval, sprint and strength sessions give distinct trajectories
+func TestSessionTrajectoriesAreDistinct(t *testing.T) {
+	sessions := []session.Session{
+		session.FourByFourHIIT(),
+		session.TenByOneSprint(),
+		session.FiveByFiveSquat(),
+	}
+
+	var profiles [][]float64
+	for _, s := range sessions {
+		profile := PredictCirculatingProfileForSession(s, 60*time.Minute, 40)
+		lactate := profile[Lactate.Name]
+		if len(lactate) != 40 {
+			t.Fatalf("%s: expected 40 time points, got %d", s.Name, len(lactate))
+		}
+		profiles = append(profiles, lactate)
+	}
+
+	for i := 0; i < len(profiles); i++ {
+		for j := i + 1; j < len(profiles); j++ {
+			diff := 0.0
+			for k := range profiles[i] {
+				diff += math.Abs(profiles[i][k] - profiles[j][k])
+			}
+			if diff < 1e-6 {
+				t.Errorf("%s and %s produced identical lactate trajectories",
+					sessions[i].Name, sessions[j].Name)
+			}
+		}
+	}
+}
+
+// Test that rest segments let a fast factor fall back between intervals
+func TestSessionResponseFallsDuringRest(t *testing.T) {
+	s := session.FourByFourHIIT()
+
+	// End of first work interval (10 min warm-up + 4 min) vs end of first rest
+	endWork := 14 * time.Minute
+	endRest := 17 * time.Minute
+
+	atWork := Epinephrine.CalculateSessionResponse(s, endWork)
+	atRest := Epinephrine.CalculateSessionResponse(s, endRest)
+	if atRest >= atWork {
+		t.Errorf("Expected epinephrine to fall during active recovery, got %.2f -> %.2f", atWork, atRest)
+	}
+}
+
+// Test that immune counts return toward baseline after the session
+func TestImmuneSessionResponseRecovers(t *testing.T) {
+	s := session.FourByFourHIIT()
+	end := s.TotalDuration().Minutes()
+
+	peak := CalculateImmuneSessionResponse(NaturalKillerCells, s, end)
+	later := CalculateImmuneSessionResponse(NaturalKillerCells, s, end+6*60)
+	if peak <= NaturalKillerCells.BaselineCount.Mean {
+		t.Errorf("Expected NK cells above baseline at end of session, got %.1f", peak)
+	}
+	if later >= peak {
+		t.Errorf("Expected NK cells to decline in recovery, got %.1f -> %.1f", peak, later)
+	}
+}
+
+// Test the continuous immune predictor follows the session model, and counts
+// fall back during passive rest between intervals
+func TestImmuneResponseIntegratesSegments(t *testing.T) {
+	bout := session.ContinuousSession("Aerobic", 80, 30)
+	for _, minutes := range []float64{10, 30, 90} {
+		got := CalculateImmuneResponse(NaturalKillerCells, 80, 30, minutes)
+		if want := CalculateImmuneSessionResponse(NaturalKillerCells, bout, minutes); got != want {
+			t.Errorf("At %g min: expected %.1f, got %.1f", minutes, want, got)
+		}
+	}
+
+	s := session.NewBuilder("3x5 min", "HIIT").
+		Intervals(3, 5*time.Minute, 95, 10*time.Minute, 0).
+		Build()
+	atWork := CalculateImmuneSessionResponse(NaturalKillerCells, s, 5)
+	atRest := CalculateImmuneSessionResponse(NaturalKillerCells, s, 15)
+	again := CalculateImmuneSessionResponse(NaturalKillerCells, s, 20)
+	if atRest >= atWork || again <= atRest {
+		t.Errorf("Expected NK cells to fall during rest and rise with the next interval, got %.1f -> %.1f -> %.1f", atWork, atRest, again)
+	}
+}
+
+// Test that the exercise-type profile follows the type's session rather than a flat bout
+func TestProfileDuringExerciseUsesSession(t *testing.T) {
+	aerobic := PredictCirculatingProfileDuringExercise("Aerobic", 80, 40, 41)
+	continuous := PredictCirculatingProfileForSession(session.ContinuousSession("Aerobic", 80, 40), 0, 41)
+	for i, v := range continuous[Lactate.Name] {
+		if aerobic[Lactate.Name][i] != v {
+			t.Fatalf("Expected aerobic work to match a continuous bout at point %d", i)
+		}
+	}
+
+	// 40 min of 4x4 at a 80% peak: the first rest ends at 17 min
+	hiit := PredictCirculatingProfileDuringExercise("HIIT", 80, 40, 41)[Epinephrine.Name]
+	if hiit[17] >= hiit[14] {
+		t.Errorf("Expected epinephrine to fall during the first HIIT rest, got %.2f -> %.2f", hiit[14], hiit[17])
+	}
+}
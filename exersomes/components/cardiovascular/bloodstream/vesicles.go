@@ -0,0 +1,128 @@
+package bloodstream
+
+import (
+	"exersomes/molecular_types"
+	"exersomes/ode"
+	"exersomes/session"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PlasmaVolumeML converts plasma concentrations to whole-body amounts
+const PlasmaVolumeML = 3000.0
+
+// VesicleModel derives the release/clearance model for a vesicle population.
+// Vesicles leave plasma only by tissue uptake, so all clearance is uptake.
+func VesicleModel(v molecular_types.ExerciseVesicle) PharmacokineticModel {
+	k := v.Kinetics
+	return PharmacokineticModel{
+		Baseline:         k.BaselinePerML,
+		TissueUptakeRate: k.ClearanceRate(),
+		ProductionGain:   k.PeakFoldChange - 1.0,
+		ActivationRate:   k.ActivationRate(),
+		DeactivationRate: k.DeactivationRate(),
+	}
+}
+
+// CargoDelivery is the amount of one cargo molecule a target tissue takes up
+type CargoDelivery struct {
+	Tissue string
+	Cargo  molecular_types.Cargo
+	Name   string
+	Copies float64 // Copies taken up over the window
+	Excess float64 // Copies above resting uptake over the same window
+}
+
+// VesicleDelivery is the fate of a vesicle population over a session and the
+// recovery that follows it
+type VesicleDelivery struct {
+	Vesicle  string
+	Window   time.Duration      // From session start
+	Released float64            // Particles released into plasma over the window
+	Cleared  float64            // Particles taken up by all tissues over the window
+	Uptake   map[string]float64 // Particles taken up by each target tissue
+	Cargo    []CargoDelivery    // By tissue, then in KeyCargo order
+}
+
+// SimulateVesicleDelivery propagates session-driven vesicle release into the
+// cargo each target tissue takes up between session start and window. Uptake
+// into a tissue is its UptakeShare of the clearance flux, integrated over time
+// and scaled to plasma volume; cargo copies follow from the loading stoichiometry.
+func SimulateVesicleDelivery(v molecular_types.ExerciseVesicle, s session.Session, window time.Duration) (VesicleDelivery, error) {
+	names := make([]string, len(v.KeyCargo))
+	for i, c := range v.KeyCargo {
+		name, ok := molecular_types.CargoName(c)
+		if !ok {
+			return VesicleDelivery{}, fmt.Errorf("%s: unknown %s cargo %q", v.Type, c.Kind, c.ID)
+		}
+		names[i] = name
+	}
+
+	m := VesicleModel(v)
+	end, area := m.exposure(s, window)
+	clearance := m.ClearanceRate() * PlasmaVolumeML
+	rest := m.Baseline * window.Minutes()
+
+	d := VesicleDelivery{
+		Vesicle:  v.Type,
+		Window:   window,
+		Cleared:  clearance * area,
+		Released: clearance*area + (end.Concentration-m.Baseline)*PlasmaVolumeML,
+		Uptake:   make(map[string]float64),
+	}
+
+	tissues := make([]string, 0, len(v.UptakeShare))
+	for t := range v.UptakeShare {
+		tissues = append(tissues, t)
+	}
+	sort.Strings(tissues)
+	for _, t := range tissues {
+		share := v.UptakeShare[t]
+		d.Uptake[t] = share * d.Cleared
+		for i, c := range v.KeyCargo {
+			d.Cargo = append(d.Cargo, CargoDelivery{
+				Tissue: t,
+				Cargo:  c,
+				Name:   names[i],
+				Copies: share * d.Cleared * c.CopiesPerVesicle,
+				Excess: share * clearance * (area - rest) * c.CopiesPerVesicle,
+			})
+		}
+	}
+	return d, nil
+}
+
+// exposure integrates the model over a session from rest until end, returning
+// the final state and the area under the concentration curve, in concentration
+// × minutes
+func (m PharmacokineticModel) exposure(s session.Session, end time.Duration) (State, float64) {
+	state := m.RestingState()
+	area := 0.0
+	for now := time.Duration(0); now < end; {
+		next := end
+		intensity := 0.0
+		if seg, active := s.SegmentAt(now); active {
+			intensity = seg.IntensityPercent
+			if e := segmentEnd(s, now); e < next {
+				next = e
+			}
+		}
+
+		f := m.derivatives(intensity)
+		withArea := func(t float64, y []float64, dydt []float64) {
+			f(t, y[:2], dydt[:2])
+			dydt[2] = y[0]
+		}
+		y := []float64{state.Concentration, state.Activation, area}
+		out, err := ode.RK45(withArea, y, 0, (next - now).Minutes(), ode.DefaultOptions)
+		if err != nil {
+			out = ode.RK4(withArea, y, 0, (next - now).Minutes(), 0.05)
+		}
+
+		state = State{Concentration: out[0], Activation: out[1]}
+		area = out[2]
+		now = next
+	}
+	return state, area
+}
@@ -0,0 +1,79 @@
+package bloodstream
+
+import (
+	"exersomes/molecular_types"
+	"exersomes/session"
+	"math"
+	"testing"
+	"time"
+)
+
+// Test that the closed-form vesicle response matches the integrated model
+func TestPredictVesicleResponseMatchesModel(t *testing.T) {
+	v := molecular_types.MuscleDerivedExosomes
+	m := VesicleModel(v)
+	for _, bout := range []struct {
+		intensity float64
+		minutes   int
+	}{{70, 45}, {90, 10}, {40, 120}} {
+		s := session.ContinuousSession("Aerobic", bout.intensity, float64(bout.minutes))
+		end := time.Duration(bout.minutes) * time.Minute
+		simulated := m.Simulate(s, []time.Duration{end})[0] / m.Baseline
+
+		predicted := molecular_types.PredictVesicleResponse(v, "Aerobic", bout.intensity, bout.minutes)
+		if math.Abs(predicted-simulated) > 1e-4*simulated {
+			t.Errorf("%.0f%% for %d min: closed form %.5f, simulated %.5f", bout.intensity, bout.minutes, predicted, simulated)
+		}
+	}
+	if got := molecular_types.PredictVesicleResponse(v, "Aerobic", 70, 0); got != 1 {
+		t.Errorf("Expected no change without exercise, got %.3f", got)
+	}
+}
+
+// Test that delivered cargo follows uptake shares and loading stoichiometry,
+// with no excess at rest
+func TestSimulateVesicleDelivery(t *testing.T) {
+	v := molecular_types.MuscleDerivedExosomes
+	window := 24 * time.Hour
+
+	rest, err := SimulateVesicleDelivery(v, session.Session{}, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(rest.Released-rest.Cleared) > 1e-6*rest.Cleared {
+		t.Errorf("Expected release to balance clearance at rest, got %.4g and %.4g", rest.Released, rest.Cleared)
+	}
+	for _, c := range rest.Cargo {
+		if math.Abs(c.Excess) > 1e-6*c.Copies {
+			t.Errorf("%s to %s: expected no excess at rest, got %.4g", c.Name, c.Tissue, c.Excess)
+		}
+	}
+
+	hiit, err := SimulateVesicleDelivery(v, session.FourByFourHIIT(), window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hiit.Cleared <= rest.Cleared {
+		t.Errorf("Expected HIIT to increase vesicle uptake, got %.4g vs %.4g at rest", hiit.Cleared, rest.Cleared)
+	}
+	if len(hiit.Cargo) != len(v.KeyCargo)*len(v.UptakeShare) {
+		t.Fatalf("Expected one delivery per cargo and tissue, got %d", len(hiit.Cargo))
+	}
+	for _, c := range hiit.Cargo {
+		want := hiit.Uptake[c.Tissue] * c.Cargo.CopiesPerVesicle
+		if math.Abs(c.Copies-want) > 1e-9*want {
+			t.Errorf("%s to %s: got %.4g copies, expected %.4g", c.Name, c.Tissue, c.Copies, want)
+		}
+		if c.Excess <= 0 || c.Excess >= c.Copies {
+			t.Errorf("%s to %s: expected excess between 0 and %.4g, got %.4g", c.Name, c.Tissue, c.Copies, c.Excess)
+		}
+	}
+	if hiit.Uptake["Liver"] <= hiit.Uptake["Adipose tissue"] {
+		t.Errorf("Expected the liver to take up the most vesicles, got %v", hiit.Uptake)
+	}
+
+	v.KeyCargo = []molecular_types.Cargo{{Kind: molecular_types.CargoProtein, ID: "P00000"}}
+	if _, err := SimulateVesicleDelivery(v, session.FourByFourHIIT(), window); err == nil {
+		t.Error("Expected an error for cargo missing from the catalog")
+	}
+}
@@ -5,7 +5,7 @@ type Cardiokine struct {
 	Name               string
 	MolecularWeight    float64  // In kDa
 	SourceCells        []string // Cell types producing this factor
-	TargetOrgans       []strings
+	TargetOrgans       []string
 	ExerciseRegulation string // "Up", "Down", "Biphasic"
 	TemporalPattern    string // "Acute", "Chronic", "Both"
 	PrimaryEffects     []string
@@ -161,9 +161,6 @@ func CalculateExerciseResponse(cardiokine Cardiokine, exerciseType string,
 	acuteIntensityEffect := float64(intensityPercent) / 100.0
 	acuteDurationEffect := float64(durationMinutes) / 60.0
 
-	// Chronic adaptation factors
-	chronicEffect := float64(trainingWeeks) / 12.0 // Normalized to 12 weeks
-
 	// Different cardiokines have distinct response patterns to exercise
 	switch cardiokine.Name {
 	case "Natriuretic Peptides (ANP/BNP)":
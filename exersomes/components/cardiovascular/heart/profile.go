@@ -0,0 +1,13 @@
+package heart
+
+import "exersomes/profile"
+
+// PredictCardiokineResponseForProfile runs PredictCardiokineResponse for an
+// individual, with heart disease taken from the profile's conditions and
+// training accruing over its effective weeks
+func PredictCardiokineResponseForProfile(exerciseType string, intensityPercent int,
+	durationMinutes int, trainingWeeks int, p profile.Profile) map[string]float64 {
+	p = p.WithDefaults()
+	return PredictCardiokineResponse(exerciseType, intensityPercent, durationMinutes,
+		p.EffectiveWeeks(trainingWeeks), p.Has(profile.HeartDisease))
+}
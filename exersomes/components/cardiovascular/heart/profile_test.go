@@ -0,0 +1,26 @@
+package heart
+
+import (
+	"exersomes/profile"
+	"reflect"
+	"testing"
+)
+
+// Test heart disease and the fields behind effective weeks shift the cardiokine response
+func TestPredictCardiokineResponseForProfile(t *testing.T) {
+	legacy := PredictCardiokineResponse("Aerobic", 70, 45, 12, false)
+	predict := func(p profile.Profile) map[string]float64 {
+		return PredictCardiokineResponseForProfile("Aerobic", 70, 45, 12, p)
+	}
+	if !reflect.DeepEqual(predict(profile.Reference), legacy) {
+		t.Error("Expected the reference profile to match PredictCardiokineResponse without heart disease")
+	}
+	if !reflect.DeepEqual(predict(profile.Profile{Conditions: []string{profile.HeartDisease}}), PredictCardiokineResponse("Aerobic", 70, 45, 12, true)) {
+		t.Error("Expected heart disease to come from the profile's conditions")
+	}
+	for _, p := range []profile.Profile{{TrainingStatus: profile.Athlete}, {AgeYears: 70}, {VO2max: 50}} {
+		if weeks := p.EffectiveWeeks(12); !reflect.DeepEqual(predict(p), PredictCardiokineResponse("Aerobic", 70, 45, weeks, false)) {
+			t.Errorf("Expected %+v to train over %d effective weeks", p, weeks)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package immune
 
+import "exersomes/params"
+
 // Cytokine represents an immune signaling molecule affected by exercise
 type Cytokine struct {
 	Name               string
@@ -373,9 +375,35 @@ func GetAntiInflammatoryCytokines() []Cytokine {
 	return []Cytokine{IL10, IL1RA, TGFbeta}
 }
 
+// AcuteResponseParams are the coefficients of CalculateAcuteResponse. Gains
+// scale the fold change per unit intensity x duration (hours).
+var AcuteResponseParams = params.Register(params.NewSet("immune.acute_response",
+	params.Parameter{Name: "trained_factor", Value: 0.7, Min: 0.2, Max: 1.0,
+		Description: "Blunting of the inflammatory response in trained individuals"},
+	params.Parameter{Name: "il6.high_intensity_threshold", Value: 0.7, Min: 0.5, Max: 0.9, Fixed: true},
+	params.Parameter{Name: "il6.high_intensity_gain", Value: 5.0, Min: 0, Max: 20},
+	params.Parameter{Name: "il6.gain", Value: 3.0, Min: 0, Max: 20},
+	params.Parameter{Name: "tnf.high_intensity_threshold", Value: 0.8, Min: 0.5, Max: 0.95, Fixed: true},
+	params.Parameter{Name: "tnf.long_duration_threshold", Value: 1.5, Min: 0.5, Max: 4, Fixed: true,
+		Description: "Hours"},
+	params.Parameter{Name: "tnf.high_intensity_gain", Value: 1.5, Min: 0, Max: 10},
+	params.Parameter{Name: "tnf.gain", Value: 0.5, Min: 0, Max: 10},
+	params.Parameter{Name: "il10.gain", Value: 2.0, Min: 0, Max: 20},
+	params.Parameter{Name: "il1ra.gain", Value: 3.0, Min: 0, Max: 20},
+	params.Parameter{Name: "tgfb.gain", Value: 0.5, Min: 0, Max: 5},
+))
+
 // CalculateAcuteResponse estimates cytokine changes immediately after exercise
 func CalculateAcuteResponse(cytokine Cytokine, exerciseIntensityPercent int,
 	durationMinutes int, trainingStatus string) float64 {
+	return CalculateAcuteResponseWithParams(AcuteResponseParams, cytokine, exerciseIntensityPercent,
+		durationMinutes, trainingStatus)
+}
+
+// CalculateAcuteResponseWithParams is CalculateAcuteResponse with coefficients
+// read from p, a copy of AcuteResponseParams
+func CalculateAcuteResponseWithParams(p *params.Set, cytokine Cytokine, exerciseIntensityPercent int,
+	durationMinutes int, trainingStatus string) float64 {
 
 	// Base response factor (1.0 = no change)
 	responseFactor := 1.0
@@ -387,38 +415,38 @@ func CalculateAcuteResponse(cytokine Cytokine, exerciseIntensityPercent int,
 	// Training status factor (trained individuals often have blunted acute inflammatory responses)
 	trainingFactor := 1.0
 	if trainingStatus == "Trained" || trainingStatus == "Athlete" {
-		trainingFactor = 0.7 // 30% reduction in inflammatory response for trained individuals
+		trainingFactor = p.Get("trained_factor")
 	}
 
 	// Calculate response based on specific cytokine
 	switch cytokine.Name {
 	case "Interleukin-6":
 		// IL-6 increases dramatically with exercise, especially with longer durations
-		if intensityFactor > 0.7 {
-			responseFactor = 1.0 + (5.0 * intensityFactor * durationFactor * trainingFactor)
+		if intensityFactor > p.Get("il6.high_intensity_threshold") {
+			responseFactor = 1.0 + (p.Get("il6.high_intensity_gain") * intensityFactor * durationFactor * trainingFactor)
 		} else {
-			responseFactor = 1.0 + (3.0 * intensityFactor * durationFactor * trainingFactor)
+			responseFactor = 1.0 + (p.Get("il6.gain") * intensityFactor * durationFactor * trainingFactor)
 		}
 
 	case "Tumor Necrosis Factor-α":
 		// TNF-α increases modestly with high-intensity exercise
-		if intensityFactor > 0.8 || durationFactor > 1.5 {
-			responseFactor = 1.0 + (1.5 * intensityFactor * trainingFactor)
+		if intensityFactor > p.Get("tnf.high_intensity_threshold") || durationFactor > p.Get("tnf.long_duration_threshold") {
+			responseFactor = 1.0 + (p.Get("tnf.high_intensity_gain") * intensityFactor * trainingFactor)
 		} else {
-			responseFactor = 1.0 + (0.5 * intensityFactor * trainingFactor)
+			responseFactor = 1.0 + (p.Get("tnf.gain") * intensityFactor * trainingFactor)
 		}
 
 	case "Interleukin-10":
 		// IL-10 increases following the inflammatory response
-		responseFactor = 1.0 + (2.0 * intensityFactor * durationFactor * trainingFactor)
+		responseFactor = 1.0 + (p.Get("il10.gain") * intensityFactor * durationFactor * trainingFactor)
 
 	case "Interleukin-1 Receptor Antagonist":
 		// IL-1RA increases significantly with exercise
-		responseFactor = 1.0 + (3.0 * intensityFactor * durationFactor * trainingFactor)
+		responseFactor = 1.0 + (p.Get("il1ra.gain") * intensityFactor * durationFactor * trainingFactor)
 
 	case "Transforming Growth Factor-β":
 		// TGF-β shows modest increases with exercise
-		responseFactor = 1.0 + (0.5 * intensityFactor * durationFactor)
+		responseFactor = 1.0 + (p.Get("tgfb.gain") * intensityFactor * durationFactor)
 	}
 
 	return responseFactor
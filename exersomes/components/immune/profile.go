@@ -0,0 +1,10 @@
+package immune
+
+import "exersomes/profile"
+
+// CalculateAcuteResponseForProfile runs CalculateAcuteResponse at the profile's
+// training status
+func CalculateAcuteResponseForProfile(cytokine Cytokine, exerciseIntensityPercent int,
+	durationMinutes int, p profile.Profile) float64 {
+	return CalculateAcuteResponse(cytokine, exerciseIntensityPercent, durationMinutes, p.WithDefaults().TrainingStatus)
+}
@@ -0,0 +1,21 @@
+package immune
+
+import (
+	"exersomes/profile"
+	"testing"
+)
+
+// Test the training status of a profile selects the acute cytokine response
+func TestCalculateAcuteResponseForProfile(t *testing.T) {
+	untrained := CalculateAcuteResponse(IL6, 75, 60, profile.Untrained)
+	if got := CalculateAcuteResponseForProfile(IL6, 75, 60, profile.Reference); got != untrained {
+		t.Errorf("Expected the reference profile to respond as untrained, got %g for %g", got, untrained)
+	}
+	athlete := CalculateAcuteResponseForProfile(IL6, 75, 60, profile.Profile{TrainingStatus: profile.Athlete})
+	if athlete != CalculateAcuteResponse(IL6, 75, 60, profile.Athlete) || athlete >= untrained {
+		t.Errorf("Expected athletes to mount a blunted IL-6 response, got %g for %g", athlete, untrained)
+	}
+	if got := CalculateAcuteResponseForProfile(IL6, 75, 60, profile.Profile{AgeYears: 70, VO2max: 20}); got != untrained {
+		t.Errorf("Expected age and fitness to leave the acute response unchanged, got %g", got)
+	}
+}
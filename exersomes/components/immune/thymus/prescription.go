@@ -1,4 +1,4 @@
-package immune
+package thymus
 
 // ExercisePrescription defines parameters for immune-targeting exercise
 type ExercisePrescription struct {
@@ -0,0 +1,20 @@
+package thymus
+
+import "exersomes/profile"
+
+// PredictImmuneResponseForProfile runs PredictImmuneResponse for an individual.
+// Chronic inflammation and obesity mark inflammation; being immunocompromised
+// or taking immunosuppressants or glucocorticoids marks immunocompromise.
+// Chronic adaptation accrues over the profile's effective weeks.
+func PredictImmuneResponseForProfile(prescription ExercisePrescription, timePoint string,
+	chronicWeeks int, p profile.Profile) ImmuneResponse {
+	p = p.WithDefaults()
+	inflammation := p.Has(profile.ChronicInflammation) || p.Obese()
+	compromised := p.Has(profile.Immunocompromised) || p.Takes(profile.Immunosuppressants) || p.Takes(profile.Glucocorticoids)
+	return PredictImmuneResponse(prescription, timePoint, p.EffectiveWeeks(chronicWeeks), inflammation, compromised)
+}
+
+// PredictThymicSizeForProfile runs PredictThymicSize at the profile's age
+func PredictThymicSizeForProfile(chronicTrainingWeeks int, intensityPercent int, consistencyPercent int, p profile.Profile) float64 {
+	return PredictThymicSize(p.WithDefaults().AgeYears, chronicTrainingWeeks, intensityPercent, consistencyPercent)
+}
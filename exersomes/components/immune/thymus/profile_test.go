@@ -0,0 +1,44 @@
+package thymus
+
+import (
+	"exersomes/profile"
+	"reflect"
+	"testing"
+)
+
+// Test body fat, conditions, medications and training status shift the immune
+// response, and age the thymic size
+func TestPredictImmuneResponseForProfile(t *testing.T) {
+	legacy := PredictImmuneResponse(AntiInflammatoryProtocol, "Chronic", 12, false, false)
+	predict := func(p profile.Profile) ImmuneResponse {
+		return PredictImmuneResponseForProfile(AntiInflammatoryProtocol, "Chronic", 12, p)
+	}
+	if !reflect.DeepEqual(predict(profile.Reference), legacy) {
+		t.Error("Expected the reference profile to match PredictImmuneResponse without risk factors")
+	}
+
+	inflamed := PredictImmuneResponse(AntiInflammatoryProtocol, "Chronic", 12, true, false)
+	if !reflect.DeepEqual(predict(profile.Profile{BodyFatPercent: 30}), inflamed) {
+		t.Error("Expected obesity to mark inflammation")
+	}
+	if !reflect.DeepEqual(predict(profile.Profile{Conditions: []string{profile.ChronicInflammation}}), inflamed) {
+		t.Error("Expected chronic inflammation to mark inflammation")
+	}
+	compromised := PredictImmuneResponse(AntiInflammatoryProtocol, "Chronic", 12, false, true)
+	for _, medication := range []string{profile.Glucocorticoids, profile.Immunosuppressants} {
+		if !reflect.DeepEqual(predict(profile.Profile{Medications: []string{medication}}), compromised) {
+			t.Errorf("Expected %s to mark immunocompromise", medication)
+		}
+	}
+	if athlete := predict(profile.Profile{TrainingStatus: profile.Athlete}); !reflect.DeepEqual(athlete, PredictImmuneResponse(AntiInflammatoryProtocol, "Chronic", 6, false, false)) {
+		t.Error("Expected athletes to adapt over half the weeks")
+	}
+
+	young := PredictThymicSizeForProfile(12, 70, 80, profile.Profile{AgeYears: 25})
+	if old := PredictThymicSizeForProfile(12, 70, 80, profile.Profile{AgeYears: 65}); old >= young {
+		t.Errorf("Expected a smaller thymus at 65, got %g and %g", old, young)
+	}
+	if got := PredictThymicSizeForProfile(12, 70, 80, profile.Reference); got != PredictThymicSize(40, 12, 70, 80) {
+		t.Error("Expected the reference profile to predict at 40")
+	}
+}
@@ -165,31 +165,25 @@ func PredictAdipokineLevels(exerciseType string, intensityPercent int,
 	levels["FABP4"] = 20.0 + (bodyFatPercent * 0.6)       // Direct relationship with body fat
 
 	// Modify based on exercise
+	p := ResponseParams
+	adjust := func(key string, factor float64) {
+		levels["Adiponectin"] *= (1.0 + p.Get(key+".adiponectin")*factor)
+		levels["Irisin"] *= (1.0 + p.Get(key+".irisin")*factor)
+		levels["Leptin"] *= (1.0 + p.Get(key+".leptin")*factor)
+		levels["TNF-α"] *= (1.0 + p.Get(key+".tnfa")*factor)
+		levels["FABP4"] *= (1.0 + p.Get(key+".fabp4")*factor)
+	}
+
 	if exerciseType == "Aerobic" || exerciseType == "HIIT" {
 		// Effect increases with intensity, duration
 		intensityFactor := float64(intensityPercent) / 100.0
-		durationFactor := float64(durationWeeks) / 12.0 // Normalized to 12 weeks
-		combinedFactor := intensityFactor * durationFactor
-
-		// Upregulated adipokines
-		levels["Adiponectin"] *= (1.0 + 0.3*combinedFactor)
-		levels["Irisin"] *= (1.0 + 0.5*combinedFactor)
-
-		// Downregulated adipokines
-		levels["Leptin"] *= (1.0 - 0.2*combinedFactor)
-		levels["TNF-α"] *= (1.0 - 0.3*combinedFactor)
-		levels["FABP4"] *= (1.0 - 0.2*combinedFactor)
+		durationFactor := float64(durationWeeks) / p.Get("adipokines.weeks") // Normalized to 12 weeks
+		adjust("endurance", intensityFactor*durationFactor)
 	}
 
 	if exerciseType == "Resistance" {
-		// Resistance has different effects
-		durationFactor := float64(durationWeeks) / 12.0
-
-		levels["Adiponectin"] *= (1.0 + 0.2*durationFactor)
-		levels["Irisin"] *= (1.0 + 0.3*durationFactor)
-		levels["Leptin"] *= (1.0 - 0.15*durationFactor)
-		levels["TNF-α"] *= (1.0 - 0.25*durationFactor)
-		levels["FABP4"] *= (1.0 - 0.15*durationFactor)
+		// Resistance has different effects, independent of intensity
+		adjust("resistance", float64(durationWeeks)/p.Get("adipokines.weeks"))
 	}
 
 	return levels
@@ -1,5 +1,7 @@
 package adipose
 
+import "exersomes/params"
+
 // ExercisePrescription defines exercise parameters targeting adipose tissue
 type ExercisePrescription struct {
 	Name                  string
@@ -21,25 +23,58 @@ type ExercisePrescription struct {
 	}
 }
 
+// fatRateParamKeys maps prescription names to their weekly fat loss rates in
+// ResponseParams; other prescriptions use "fat_rate.other"
+var fatRateParamKeys = map[string]string{
+	"Fat Loss HIIT":              "fat_rate.hiit",
+	"Metabolic Health":           "fat_rate.metabolic_health",
+	"Brown Adipose Activation":   "fat_rate.brown_activation",
+	"Combined Resistance-Cardio": "fat_rate.combined",
+}
+
+// adipokineGains declares the relative change in each adipokine per unit of
+// training for one exercise type, each bounded to keep its sign
+func adipokineGains(key string, adiponectin, irisin, leptin, tnfa, fabp4 float64) []params.Parameter {
+	return []params.Parameter{
+		{Name: key + ".adiponectin", Value: adiponectin, Min: 0, Max: 1},
+		{Name: key + ".irisin", Value: irisin, Min: 0, Max: 1},
+		{Name: key + ".leptin", Value: leptin, Min: -1, Max: 0},
+		{Name: key + ".tnfa", Value: tnfa, Min: -1, Max: 0},
+		{Name: key + ".fabp4", Value: fabp4, Min: -1, Max: 0},
+	}
+}
+
+// ResponseParams are the coefficients of PredictFatLoss and PredictAdipokineLevels
+var ResponseParams = params.Register(params.NewSet("adipose.response", responseParameters()...))
+
+func responseParameters() []params.Parameter {
+	parameters := []params.Parameter{
+		{Name: "fat_rate.hiit", Value: 0.004, Min: 0, Max: 0.01,
+			Description: "Fraction of body weight lost as fat per week at 3 sessions a week"},
+		{Name: "fat_rate.metabolic_health", Value: 0.002, Min: 0, Max: 0.01},
+		{Name: "fat_rate.brown_activation", Value: 0.003, Min: 0, Max: 0.01},
+		{Name: "fat_rate.combined", Value: 0.005, Min: 0, Max: 0.01},
+		{Name: "fat_rate.other", Value: 0.002, Min: 0, Max: 0.01},
+		{Name: "fat_loss.max_fraction", Value: 0.3, Min: 0.1, Max: 0.5, Fixed: true,
+			Description: "Largest fraction of initial fat mass a prediction may lose"},
+		{Name: "adipokines.weeks", Value: 12, Min: 4, Max: 52, Fixed: true,
+			Description: "Weeks of training the adipokine gains are normalized to"},
+	}
+	parameters = append(parameters, adipokineGains("endurance", 0.3, 0.5, -0.2, -0.3, -0.2)...)
+	return append(parameters, adipokineGains("resistance", 0.2, 0.3, -0.15, -0.25, -0.15)...)
+}
+
 // Calculate expected fat mass change in kg over time
 func (p *ExercisePrescription) PredictFatLoss(weeks int, initialWeight float64, initialBodyFatPercent float64) float64 {
 	// Simple model for demonstration, would be more sophisticated in practice
+	set := ResponseParams
 
-	// Base weekly fat loss rate factors by prescription type
-	var weeklyRateFactor float64
-
-	switch p.Name {
-	case "Fat Loss HIIT":
-		weeklyRateFactor = 0.004 // 0.4% of body weight per week
-	case "Metabolic Health":
-		weeklyRateFactor = 0.002 // 0.2% of body weight per week
-	case "Brown Adipose Activation":
-		weeklyRateFactor = 0.003 // 0.3% of body weight per week
-	case "Combined Resistance-Cardio":
-		weeklyRateFactor = 0.005 // 0.5% of body weight per week
-	default:
-		weeklyRateFactor = 0.002
+	// Base weekly fat loss rate factors by prescription type, as a fraction of body weight
+	key, ok := fatRateParamKeys[p.Name]
+	if !ok {
+		key = "fat_rate.other"
 	}
+	weeklyRateFactor := set.Get(key)
 
 	// Adjust for frequency
 	frequencyFactor := float64(p.FrequencyPerWeek) / 3.0 // Normalized to 3x/week
@@ -50,7 +85,7 @@ func (p *ExercisePrescription) PredictFatLoss(weeks int, initialWeight float64,
 	fatLoss := initialWeight * weeklyRateFactor * float64(weeks)
 
 	// Cap fat loss to avoid unrealistic predictions
-	maxSafeFatLoss := initialFatMass * 0.3 // Cap at 30% of initial fat mass
+	maxSafeFatLoss := initialFatMass * set.Get("fat_loss.max_fraction") // Cap at 30% of initial fat mass by default
 	if fatLoss > maxSafeFatLoss {
 		fatLoss = maxSafeFatLoss
 	}
@@ -153,6 +188,11 @@ var (
 	}
 )
 
+// GetStandardPrescriptions returns every predefined adipose prescription
+func GetStandardPrescriptions() []ExercisePrescription {
+	return []ExercisePrescription{FatLossHIIT, MetabolicHealth, BrownAdiposeActivation, CombinedResistanceCardio}
+}
+
 // GetPrescriptionByTarget returns appropriate exercise prescriptions for specific targets
 func GetPrescriptionByTarget(target string) []ExercisePrescription {
 	var prescriptions []ExercisePrescription
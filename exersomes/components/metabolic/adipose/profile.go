@@ -0,0 +1,20 @@
+package adipose
+
+import "exersomes/profile"
+
+// PredictAdiposeResponseForProfile runs PredictAdiposeResponse at the profile's
+// body fat, with fat loss for its body mass in place of the assumed 80 kg.
+// Adaptation accrues over the profile's effective weeks.
+func PredictAdiposeResponseForProfile(prescription ExercisePrescription, weeks int, p profile.Profile) map[string]float64 {
+	p = p.WithDefaults()
+	weeks = p.EffectiveWeeks(weeks)
+	response := PredictAdiposeResponse(prescription, weeks, p.BodyFatPercent)
+	response["Fat mass change (kg)"] = -prescription.PredictFatLoss(weeks, p.BodyMassKg, p.BodyFatPercent)
+	return response
+}
+
+// PredictAdipokineLevelsForProfile runs PredictAdipokineLevels at the profile's body fat
+func PredictAdipokineLevelsForProfile(exerciseType string, intensityPercent int, durationWeeks int, p profile.Profile) map[string]float64 {
+	p = p.WithDefaults()
+	return PredictAdipokineLevels(exerciseType, intensityPercent, p.EffectiveWeeks(durationWeeks), p.BodyFatPercent)
+}
@@ -0,0 +1,30 @@
+package adipose
+
+import (
+	"exersomes/profile"
+	"reflect"
+	"testing"
+)
+
+// Test fat loss follows the profile's body mass and body fat, where
+// PredictAdiposeResponse assumes 80 kg
+func TestPredictAdiposeResponseForProfile(t *testing.T) {
+	legacy := PredictAdiposeResponse(MetabolicHealth, 12, 25)
+	if got := PredictAdiposeResponseForProfile(MetabolicHealth, 12, profile.Reference); !reflect.DeepEqual(got, legacy) {
+		t.Error("Expected the reference profile to match PredictAdiposeResponse at 80 kg and 25% body fat")
+	}
+
+	const key = "Fat mass change (kg)"
+	heavy := PredictAdiposeResponseForProfile(MetabolicHealth, 12, profile.Profile{BodyMassKg: 100})
+	if heavy[key] >= legacy[key] {
+		t.Errorf("Expected more fat loss at 100 kg, got %g vs %g", heavy[key], legacy[key])
+	}
+	// At 8% body fat the loss is capped at 30% of the fat mass
+	lean := PredictAdiposeResponseForProfile(MetabolicHealth, 12, profile.Profile{BodyFatPercent: 8})
+	if want := -0.3 * 80 * 0.08; lean[key] != want {
+		t.Errorf("Expected the fat loss cap of %g kg, got %g", want, lean[key])
+	}
+	if reflect.DeepEqual(lean, legacy) {
+		t.Error("Expected body fat to change the adipokine response")
+	}
+}
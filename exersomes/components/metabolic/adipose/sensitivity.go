@@ -0,0 +1,20 @@
+package adipose
+
+import "exersomes/sensitivity"
+
+// BodyFatPercent is the sensitivity factor for the subject's body fat
+const BodyFatPercent = "BodyFatPercent"
+
+// SensitivityPredictor exposes PredictAdiposeResponse to sensitivity analysis:
+// the Metabolic Health Protocol over 12 weeks, varying its intensity, session
+// length and weekly frequency and the subject's body fat
+var SensitivityPredictor = sensitivity.Register(sensitivity.Predictor{
+	Name:        "adipose",
+	Description: "PredictAdiposeResponse, " + MetabolicHealth.Name + ", 12 weeks",
+	Factors:     append(sensitivity.PrescriptionFactors(), sensitivity.Factor{Name: BodyFatPercent, Min: 15, Max: 45}),
+	Evaluate: func(x map[string]float64) map[string]float64 {
+		p := MetabolicHealth
+		sensitivity.ApplyPrescription(x, &p.IntensityPercent, &p.DurationMinutes, &p.FrequencyPerWeek)
+		return PredictAdiposeResponse(p, 12, x[BodyFatPercent])
+	},
+})
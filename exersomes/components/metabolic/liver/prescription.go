@@ -1,5 +1,7 @@
 package liver
 
+import "exersomes/params"
+
 // ExercisePrescription defines exercise parameters targeting liver health
 type ExercisePrescription struct {
 	Name              string
@@ -136,6 +138,11 @@ var (
 	}
 )
 
+// GetStandardPrescriptions returns every predefined liver prescription
+func GetStandardPrescriptions() []ExercisePrescription {
+	return []ExercisePrescription{NAFLDReduction, HepatitisCProtocol, LiverGlucoseMetabolism, LiverFibrosis}
+}
+
 // GetPrescriptionByCondition returns appropriate exercise prescriptions for liver conditions
 func GetPrescriptionByCondition(condition string) []ExercisePrescription {
 	var prescriptions []ExercisePrescription
@@ -156,6 +163,42 @@ func GetPrescriptionByCondition(condition string) []ExercisePrescription {
 	return prescriptions
 }
 
+// fatParamKeys maps prescription names to their liver fat reduction rates in
+// ResponseParams; other prescriptions use "fat.other"
+var fatParamKeys = map[string]string{
+	"NAFLD Reduction Protocol":                       "fat.nafld_protocol",
+	"Hepatic Glucose Metabolism Optimizing Protocol": "fat.glucose_protocol",
+}
+
+// reduction declares an outcome's reduction at week 0 and per week trained, in percent
+func reduction(key string, base, perWeek float64) []params.Parameter {
+	return []params.Parameter{
+		{Name: key + ".base", Value: base, Min: 0, Max: 20},
+		{Name: key + ".per_week", Value: perWeek, Min: 0, Max: 3},
+	}
+}
+
+// ResponseParams are the coefficients of PredictLiverResponse's clinical outcomes
+var ResponseParams = params.Register(params.NewSet("liver.response", responseParameters()...))
+
+func responseParameters() []params.Parameter {
+	parameters := []params.Parameter{
+		{Name: "fat.max_reduction", Value: 30, Min: 10, Max: 60, Fixed: true},
+		{Name: "nafld.fat_gain", Value: 1.5, Min: 1, Max: 3,
+			Description: "Greater liver fat reduction when starting with NAFLD"},
+		{Name: "insulin.base", Value: 10, Min: 0, Max: 30},
+		{Name: "insulin.per_week", Value: 1.2, Min: 0, Max: 5},
+		{Name: "insulin_resistance.gain", Value: 1.3, Min: 1, Max: 3,
+			Description: "Greater insulin sensitivity gain when insulin resistant at baseline"},
+	}
+	parameters = append(parameters, reduction("fat.nafld_protocol", 5.0, 0.6)...)
+	parameters = append(parameters, reduction("fat.glucose_protocol", 3.0, 0.4)...)
+	parameters = append(parameters, reduction("fat.other", 2.0, 0.3)...)
+	parameters = append(parameters, reduction("inflammation", 5.0, 0.8)...)
+	parameters = append(parameters, reduction("alt", 5.0, 0.7)...)
+	return append(parameters, reduction("ast", 4.0, 0.6)...)
+}
+
 // PredictLiverResponse estimates the effects of an exercise prescription on liver health
 func PredictLiverResponse(prescription ExercisePrescription, weeks int, hasNAFLD bool,
 	hasInsulinResistance bool) map[string]interface{} {
@@ -181,47 +224,42 @@ func PredictLiverResponse(prescription ExercisePrescription, weeks int, hasNAFLD
 	}
 
 	// Predict clinical outcomes
-	liverFatChange := 0.0
-	insulinSensitivityChange := 0.0
-	inflammationChange := 0.0
-
-	// Liver fat reduction calculation
-	switch prescription.Name {
-	case "NAFLD Reduction Protocol":
-		liverFatChange = -5.0 - (float64(weeks) * 0.6) // About 5% baseline plus 0.6% per week
-	case "Hepatic Glucose Metabolism Optimizing Protocol":
-		liverFatChange = -3.0 - (float64(weeks) * 0.4) // About 3% baseline plus 0.4% per week
-	default:
-		liverFatChange = -2.0 - (float64(weeks) * 0.3) // About 2% baseline plus 0.3% per week
+	p := ResponseParams
+	decline := func(key string) float64 {
+		return -p.Get(key+".base") - (float64(weeks) * p.Get(key+".per_week"))
+	}
+
+	// Liver fat reduction: a protocol-specific baseline plus a weekly rate
+	fatKey, ok := fatParamKeys[prescription.Name]
+	if !ok {
+		fatKey = "fat.other"
 	}
+	liverFatChange := decline(fatKey)
 
 	// Cap the maximum change
-	if liverFatChange < -30.0 {
-		liverFatChange = -30.0
+	if maxReduction := p.Get("fat.max_reduction"); liverFatChange < -maxReduction {
+		liverFatChange = -maxReduction
 	}
 
 	// Adjust for starting conditions
 	if hasNAFLD {
-		liverFatChange *= 1.5 // Greater reduction potential if starting with NAFLD
+		liverFatChange *= p.Get("nafld.fat_gain") // Greater reduction potential if starting with NAFLD
 	}
 
 	// Insulin sensitivity improvement
-	insulinSensitivityChange = 10.0 + (float64(weeks) * 1.2)
+	insulinSensitivityChange := p.Get("insulin.base") + (float64(weeks) * p.Get("insulin.per_week"))
 	if hasInsulinResistance {
-		insulinSensitivityChange *= 1.3 // Greater improvement if insulin resistant at baseline
+		insulinSensitivityChange *= p.Get("insulin_resistance.gain") // Greater improvement if insulin resistant at baseline
 	}
 
-	// Inflammation reduction
-	inflammationChange = -5.0 - (float64(weeks) * 0.8)
-
 	// Store clinical outcomes
 	response["liver_fat_percent_change"] = liverFatChange
 	response["insulin_sensitivity_percent_improvement"] = insulinSensitivityChange
-	response["inflammation_percent_reduction"] = inflammationChange
+	response["inflammation_percent_reduction"] = decline("inflammation")
 
 	// Predict liver enzyme changes
-	response["ALT_percent_change"] = -5.0 - (float64(weeks) * 0.7)
-	response["AST_percent_change"] = -4.0 - (float64(weeks) * 0.6)
+	response["ALT_percent_change"] = decline("alt")
+	response["AST_percent_change"] = decline("ast")
 
 	return response
 }
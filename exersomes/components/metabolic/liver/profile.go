@@ -0,0 +1,29 @@
+package liver
+
+import "exersomes/profile"
+
+// metforminBlunting is the share of the insulin sensitivity gain left with
+// metformin, which attenuates it by about half (Malin et al., 2012)
+const metforminBlunting = 0.5
+
+// PredictLiverResponseForProfile runs PredictLiverResponse for an individual.
+// NAFLD comes from the profile's conditions, insulin resistance from insulin
+// resistance or type 2 diabetes, and metformin attenuates the insulin
+// sensitivity gain. Adaptation accrues over the profile's effective weeks.
+func PredictLiverResponseForProfile(prescription ExercisePrescription, weeks int, p profile.Profile) map[string]interface{} {
+	p = p.WithDefaults()
+	response := PredictLiverResponse(prescription, p.EffectiveWeeks(weeks), p.Has(profile.NAFLD),
+		p.HasAny(profile.InsulinResistance, profile.Type2Diabetes))
+	if v, ok := response["insulin_sensitivity_percent_improvement"].(float64); ok && p.Takes(profile.Metformin) {
+		response["insulin_sensitivity_percent_improvement"] = v * metforminBlunting
+	}
+	return response
+}
+
+// PredictHepatokineLevelsForProfile runs PredictHepatokineLevels for an
+// individual, with NAFLD from the profile's conditions
+func PredictHepatokineLevelsForProfile(exerciseType string, intensityPercent int,
+	durationMinutes int, weeks int, p profile.Profile) map[string]float64 {
+	p = p.WithDefaults()
+	return PredictHepatokineLevels(exerciseType, intensityPercent, durationMinutes, p.EffectiveWeeks(weeks), p.Has(profile.NAFLD))
+}
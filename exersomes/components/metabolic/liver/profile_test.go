@@ -0,0 +1,30 @@
+package liver
+
+import (
+	"exersomes/profile"
+	"reflect"
+	"testing"
+)
+
+// Test conditions and metformin shift the liver response
+func TestPredictLiverResponseForProfile(t *testing.T) {
+	legacy := PredictLiverResponse(NAFLDReduction, 12, false, false)
+	if got := PredictLiverResponseForProfile(NAFLDReduction, 12, profile.Reference); !reflect.DeepEqual(got, legacy) {
+		t.Error("Expected the reference profile to match PredictLiverResponse without conditions")
+	}
+
+	const fat, insulin = "liver_fat_percent_change", "insulin_sensitivity_percent_improvement"
+	nafld := PredictLiverResponseForProfile(NAFLDReduction, 12, profile.Profile{Conditions: []string{profile.NAFLD}})
+	if nafld[fat].(float64) >= legacy[fat].(float64) {
+		t.Error("Expected a larger liver fat reduction with NAFLD")
+	}
+	diabetic := profile.Profile{Conditions: []string{profile.Type2Diabetes}}
+	without := PredictLiverResponseForProfile(NAFLDReduction, 12, diabetic)[insulin].(float64)
+	if without <= legacy[insulin].(float64) {
+		t.Error("Expected type 2 diabetes to count as insulin resistance")
+	}
+	diabetic.Medications = []string{profile.Metformin}
+	if with := PredictLiverResponseForProfile(NAFLDReduction, 12, diabetic)[insulin].(float64); with != without*metforminBlunting {
+		t.Errorf("Expected metformin to halve the insulin sensitivity gain, got %g of %g", with, without)
+	}
+}
@@ -100,6 +100,11 @@ var (
 	}
 )
 
+// GetStandardPrescriptions returns every predefined pancreatic prescription
+func GetStandardPrescriptions() []ExercisePrescription {
+	return []ExercisePrescription{AcuteGlucoseControl, BetaCellSupport, InsulinSensitivity}
+}
+
 // GetPrescriptionByCondition returns appropriate exercise prescriptions for a condition
 func GetPrescriptionByCondition(condition string) []ExercisePrescription {
 	var prescriptions []ExercisePrescription
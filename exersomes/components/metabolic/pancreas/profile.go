@@ -0,0 +1,12 @@
+package pancreas
+
+import "exersomes/profile"
+
+// CalculateExerkineResponseRatioForProfile runs CalculateExerkineResponseRatio for
+// an individual. Training blunts the insulin fall and glucagon rise at a given
+// relative intensity, so the change from baseline is scaled by AcuteResponseScale.
+func CalculateExerkineResponseRatioForProfile(exerkine PancreaticExerkine,
+	intensityPercent float64, durationMinutes float64, p profile.Profile) float64 {
+	ratio := CalculateExerkineResponseRatio(exerkine, intensityPercent, durationMinutes)
+	return 1 + (ratio-1)*p.AcuteResponseScale()
+}
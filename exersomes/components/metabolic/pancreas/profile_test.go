@@ -0,0 +1,28 @@
+package pancreas
+
+import (
+	"exersomes/profile"
+	"testing"
+)
+
+// Test training status blunts the pancreatic hormone response and other fields leave it
+func TestCalculateExerkineResponseRatioForProfile(t *testing.T) {
+	for _, e := range GetExerciseResponsiveExerkines() {
+		legacy := CalculateExerkineResponseRatio(e, 80, 45)
+		if got := CalculateExerkineResponseRatioForProfile(e, 80, 45, profile.Reference); got != legacy {
+			t.Errorf("%s: expected the reference profile to match, got %g for %g", e.Name, got, legacy)
+		}
+		if got := CalculateExerkineResponseRatioForProfile(e, 80, 45, profile.Profile{AgeYears: 65, Conditions: []string{profile.Type2Diabetes}}); got != legacy {
+			t.Errorf("%s: expected age and conditions to leave the ratio unchanged, got %g", e.Name, got)
+		}
+	}
+
+	trained := CalculateExerkineResponseRatioForProfile(Glucagon, 80, 45, profile.Profile{TrainingStatus: profile.Trained})
+	athlete := CalculateExerkineResponseRatioForProfile(Glucagon, 80, 45, profile.Profile{TrainingStatus: profile.Athlete})
+	if untrained := CalculateExerkineResponseRatio(Glucagon, 80, 45); !(untrained > trained && trained > athlete && athlete > 1) {
+		t.Errorf("Expected training to shrink the glucagon rise, got %g, %g and %g", untrained, trained, athlete)
+	}
+	if insulin := CalculateExerkineResponseRatioForProfile(Insulin, 80, 45, profile.Profile{TrainingStatus: profile.Athlete}); insulin <= CalculateExerkineResponseRatio(Insulin, 80, 45) || insulin >= 1 {
+		t.Errorf("Expected athletes to show a smaller insulin fall, got %g", insulin)
+	}
+}
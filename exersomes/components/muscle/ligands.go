@@ -1,6 +1,9 @@
 package musculoskeletal
 
-import "time"
+import (
+	"exersomes/session"
+	"time"
+)
 
 // Ligand represents a signaling molecule that binds to receptors
 type Ligand struct {
@@ -25,28 +28,10 @@ type Range struct {
 	Unit string // e.g., "pg/mL", "ng/mL"
 }
 
-// CalculateExerciseResponse predicts the concentration after exercise
+// CalculateExerciseResponse predicts the concentration after a continuous bout
+// at intensity (% of max) for duration
 func (l *Ligand) CalculateExerciseResponse(intensity float64, duration time.Duration) float64 {
-	if !l.ExerciseResponsive || intensity < l.ResponseThreshold {
-		return l.BaselineConc.Min
-	}
-
-	// Simple model: response is proportional to exercise intensity and duration
-	// More sophisticated models would consider more variables
-	durationFactor := float64(duration.Minutes()) / 60.0
-	intensityFactor := intensity / 100.0
-
-	baseline := (l.BaselineConc.Min + l.BaselineConc.Max) / 2
-	maxIncrease := l.ExerciseInducedConc.Max - baseline
-
-	response := baseline + (maxIncrease * intensityFactor * durationFactor)
-
-	// Cap at maximum value
-	if response > l.ExerciseInducedConc.Max {
-		response = l.ExerciseInducedConc.Max
-	}
-
-	return response
+	return l.CalculateSessionResponse(session.ContinuousSession("Continuous", intensity, duration.Minutes()))
 }
 
 // Common musculoskeletal ligands involved in exercise response
@@ -117,15 +102,59 @@ func GetExerciseResponsiveLigands() []Ligand {
 	return []Ligand{IL6, IGF1, Myostatin, IL15}
 }
 
+// CalculateSessionResponse predicts the concentration after a composed session.
+// The threshold is checked against the hardest segment, and the intensity-time
+// load integrated over the segments stands in for intensity x duration, so rest
+// adds nothing. Upregulated ligands rise toward ExerciseInducedConc.Max and
+// downregulated ones fall toward ExerciseInducedConc.Min.
+func (l *Ligand) CalculateSessionResponse(s session.Session) float64 {
+	if !l.ExerciseResponsive || s.PeakIntensity() < l.ResponseThreshold {
+		return l.BaselineConc.Min
+	}
+
+	// Load is in %·minutes; 100% for 60 minutes gives a factor of 1.0
+	loadFactor := s.Load() / (100.0 * 60.0)
+
+	baseline := (l.BaselineConc.Min + l.BaselineConc.Max) / 2
+
+	if l.ExerciseRegulation == "Down" {
+		maxDecrease := baseline - l.ExerciseInducedConc.Min
+		return max(baseline-maxDecrease*loadFactor, l.ExerciseInducedConc.Min)
+	}
+
+	maxIncrease := l.ExerciseInducedConc.Max - baseline
+
+	response := baseline + (maxIncrease * loadFactor)
+
+	// Cap at maximum value
+	if response > l.ExerciseInducedConc.Max {
+		response = l.ExerciseInducedConc.Max
+	}
+
+	return response
+}
+
+// SessionFromPrescription composes the sets, reps and rest periods of a prescription
+func SessionFromPrescription(prescription ExercisePrescription) session.Session {
+	return session.NewBuilder(prescription.ExerciseType, prescription.ExerciseType).
+		Sets(prescription.SetsCount, prescription.RepsPerSet,
+			float64(prescription.IntensityPercent1RM),
+			time.Duration(prescription.RestBetweenSetsSec)*time.Second, 0).
+		Build()
+}
+
 // PredictLigandResponse calculates expected ligand levels for a given exercise protocol
 func PredictLigandResponse(prescription ExercisePrescription) map[string]float64 {
+	return PredictLigandSessionResponse(SessionFromPrescription(prescription))
+}
+
+// PredictLigandSessionResponse calculates expected ligand levels for a composed session
+func PredictLigandSessionResponse(s session.Session) map[string]float64 {
 	response := make(map[string]float64)
-	intensity := float64(prescription.IntensityPercent1RM)
-	duration := time.Duration(prescription.SetsCount*prescription.RepsPerSet*4) * time.Second
 
 	ligands := GetExerciseResponsiveLigands()
 	for _, ligand := range ligands {
-		response[ligand.Name] = ligand.CalculateExerciseResponse(intensity, duration)
+		response[ligand.Name] = ligand.CalculateSessionResponse(s)
 	}
 
 	return response
@@ -0,0 +1,51 @@
+package musculoskeletal
+
+import (
+	"exersomes/session"
+	"testing"
+	"time"
+)
+
+// Test a continuous bout predicts the same as the equivalent one-segment session
+func TestCalculateExerciseResponse(t *testing.T) {
+	for _, l := range GetExerciseResponsiveLigands() {
+		bout := session.ContinuousSession("Aerobic", 80, 45)
+		if got, want := l.CalculateExerciseResponse(80, 45*time.Minute), l.CalculateSessionResponse(bout); got != want {
+			t.Errorf("%s: expected %g, got %g", l.Name, want, got)
+		}
+	}
+}
+
+// Test downregulated ligands fall with exercise and stop at the induced minimum
+func TestSessionResponseDownregulation(t *testing.T) {
+	baseline := (Myostatin.BaselineConc.Min + Myostatin.BaselineConc.Max) / 2
+	got := Myostatin.CalculateSessionResponse(session.FiveByFiveSquat())
+	if got >= baseline {
+		t.Errorf("Expected myostatin to fall below %g, got %g", baseline, got)
+	}
+	long := session.ContinuousSession("Aerobic", 100, 600)
+	if got := Myostatin.CalculateSessionResponse(long); got != Myostatin.ExerciseInducedConc.Min {
+		t.Errorf("Expected myostatin to stop at %g, got %g", Myostatin.ExerciseInducedConc.Min, got)
+	}
+}
+
+// Test the response integrates over segments: passive rest between sets adds
+// nothing, while lighter segments around the work add their share of load
+func TestSessionResponseSegments(t *testing.T) {
+	sets := session.NewBuilder("Sets", "Resistance").Sets(4, 10, 80, 3*time.Minute, 0).Build()
+	backToBack := session.NewBuilder("Sets", "Resistance").Sets(4, 10, 80, 0, 0).Build()
+	if a, b := IL15.CalculateSessionResponse(sets), IL15.CalculateSessionResponse(backToBack); a != b {
+		t.Errorf("Expected passive rest to leave IL-15 unchanged, got %g and %g", a, b)
+	}
+
+	withWarmUp := session.NewBuilder("Sets", "Resistance").WarmUp(10*time.Minute, 50).Sets(4, 10, 80, 3*time.Minute, 0).Build()
+	if a, b := IL15.CalculateSessionResponse(withWarmUp), IL15.CalculateSessionResponse(sets); a <= b {
+		t.Errorf("Expected the warm-up to add to IL-15, got %g and %g", a, b)
+	}
+
+	// The threshold applies to the hardest segment, not the session mean
+	easy := session.NewBuilder("Easy", "Aerobic").WarmUp(30*time.Minute, 50).Continuous(5*time.Minute, 80).Build()
+	if got := IL15.CalculateSessionResponse(easy); got <= IL15.BaselineConc.Min {
+		t.Errorf("Expected the hard segment to cross the threshold, got %g", got)
+	}
+}
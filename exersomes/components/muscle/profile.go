@@ -0,0 +1,18 @@
+package musculoskeletal
+
+import "exersomes/profile"
+
+// myokineTrainingStatus maps a profile's training status to CalculateMyokineResponse's
+var myokineTrainingStatus = map[string]string{
+	profile.Untrained: "Untrained",
+	profile.Trained:   "Moderately trained",
+	profile.Athlete:   "Highly trained",
+}
+
+// CalculateMyokineResponseForProfile runs CalculateMyokineResponse at the
+// profile's training status
+func CalculateMyokineResponseForProfile(myokine Myokine, exerciseType string,
+	intensityPercent int, durationMinutes int, p profile.Profile) float64 {
+	return CalculateMyokineResponse(myokine, exerciseType, intensityPercent, durationMinutes,
+		myokineTrainingStatus[p.WithDefaults().TrainingStatus])
+}
@@ -0,0 +1,22 @@
+package musculoskeletal
+
+import (
+	"exersomes/profile"
+	"testing"
+)
+
+// Test the training status of a profile selects the myokine response
+func TestCalculateMyokineResponseForProfile(t *testing.T) {
+	untrained := CalculateMyokineResponse(IL6Myokine, "Endurance", 70, 60, "Untrained")
+	if got := CalculateMyokineResponseForProfile(IL6Myokine, "Endurance", 70, 60, profile.Reference); got != untrained {
+		t.Errorf("Expected the reference profile to respond as untrained, got %g for %g", got, untrained)
+	}
+	trained := CalculateMyokineResponseForProfile(IL6Myokine, "Endurance", 70, 60, profile.Profile{TrainingStatus: profile.Trained})
+	athlete := CalculateMyokineResponseForProfile(IL6Myokine, "Endurance", 70, 60, profile.Profile{TrainingStatus: profile.Athlete})
+	if !(untrained > trained && trained > athlete) {
+		t.Errorf("Expected training to shrink the IL-6 response, got %g, %g and %g", untrained, trained, athlete)
+	}
+	if got := CalculateMyokineResponseForProfile(IL6Myokine, "Endurance", 70, 60, profile.Profile{AgeYears: 70, Sex: profile.Female}); got != untrained {
+		t.Errorf("Expected age and sex to leave the myokine response unchanged, got %g", got)
+	}
+}
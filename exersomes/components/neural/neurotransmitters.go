@@ -350,15 +350,16 @@ func CalculateNeuromodulatorResponse(nt Neurotransmitter,
 		}
 
 	case "Endocannabinoids (primarily Anandamide)":
+		var intensityBoost float64
 		if intensityPercent >= 70 && intensityPercent <= 85 {
 			// Greatest increase at moderate-to-high intensity
-			intensityBoost := 1.0
+			intensityBoost = 1.0
 		} else if intensityPercent > 85 {
 			// Less pronounced at very high intensities
-			intensityBoost := 0.8
+			intensityBoost = 0.8
 		} else {
 			// Lower response at lower intensities
-			intensityBoost := 0.6
+			intensityBoost = 0.6
 		}
 
 		if timePoint == "During" {
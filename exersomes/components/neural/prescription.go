@@ -1,5 +1,7 @@
 package neural
 
+import "exersomes/params"
+
 // ExercisePrescription defines exercise parameters targeting neurological function
 type ExercisePrescription struct {
 	Name                 string
@@ -248,6 +250,12 @@ var (
 	}
 )
 
+// GetStandardPrescriptions returns all standard neural-targeting prescriptions
+func GetStandardPrescriptions() []ExercisePrescription {
+	return []ExercisePrescription{ExecutiveFunctionEnhancement, HippocampalMemoryProtocol,
+		PeripheralNerveRegeneration, MotorLearningEnhancement, MoodRegulationProtocol}
+}
+
 // GetPrescriptionByTarget returns appropriate exercise prescriptions for specific neural targets
 func GetPrescriptionByTarget(target string) []ExercisePrescription {
 	var prescriptions []ExercisePrescription
@@ -273,6 +281,22 @@ func GetPrescriptionByTarget(target string) []ExercisePrescription {
 	return prescriptions
 }
 
+// ResponseParams are the shared modifiers of PredictNeuralResponse's effect
+// factor; the per-protocol outcome coefficients stay with each protocol below
+var ResponseParams = params.Register(params.NewSet("neural.response",
+	params.Parameter{Name: "frequency.reference_per_week", Value: 3, Min: 1, Max: 7, Fixed: true},
+	params.Parameter{Name: "duration.plateau_weeks", Value: 12, Min: 4, Max: 52, Fixed: true},
+	params.Parameter{Name: "duration.late_gain", Value: 0.1, Min: 0, Max: 1,
+		Description: "Extra effect per plateau length trained beyond the plateau"},
+	params.Parameter{Name: "age.onset_years", Value: 30, Min: 20, Max: 60, Fixed: true},
+	params.Parameter{Name: "age.decline_per_year", Value: 0.01, Min: 0, Max: 0.03,
+		Description: "Loss of plasticity per year of age past the onset"},
+	params.Parameter{Name: "age.floor", Value: 0.5, Min: 0.2, Max: 1,
+		Description: "Smallest age factor, relative to a young adult's response"},
+	params.Parameter{Name: "condition.gain", Value: 1.3, Min: 1, Max: 2,
+		Description: "Greater improvement potential when baseline function is impaired"},
+))
+
 // PredictNeuralResponse estimates the effects of an exercise prescription
 // on neural parameters based on the protocol and individual factors
 func PredictNeuralResponse(prescription ExercisePrescription, weeks int,
@@ -287,31 +311,34 @@ func PredictNeuralResponse(prescription ExercisePrescription, weeks int,
 		CognitiveDomainChanges: make(map[string]float64),
 	}
 
+	p := ResponseParams
+
 	// Calculate baseline factors
 	intensityFactor := float64(prescription.IntensityPercent) / 100.0
-	frequencyFactor := float64(prescription.FrequencyPerWeek) / 3.0 // Normalized to 3x/week
+	frequencyFactor := float64(prescription.FrequencyPerWeek) / p.Get("frequency.reference_per_week") // Normalized to 3x/week
 
-	// Calculate duration factor (diminishing returns after 12 weeks)
+	// Calculate duration factor (diminishing returns after the plateau, 12 weeks by default)
+	plateau := p.Get("duration.plateau_weeks")
 	durationFactor := 0.0
-	if weeks <= 12 {
-		durationFactor = float64(weeks) / 12.0
+	if float64(weeks) <= plateau {
+		durationFactor = float64(weeks) / plateau
 	} else {
-		durationFactor = 1.0 + (0.1 * float64(weeks-12) / 12.0)
+		durationFactor = 1.0 + (p.Get("duration.late_gain") * (float64(weeks) - plateau) / plateau)
 	}
 
 	// Age adjustment factor (neural plasticity decreases with age)
 	ageFactor := 1.0
-	if age > 30 {
-		ageFactor = 1.0 - (float64(age-30) / 100.0)
-		if ageFactor < 0.5 { // Floor at 50% of young adult response
-			ageFactor = 0.5
+	if onset := p.Get("age.onset_years"); float64(age) > onset {
+		ageFactor = 1.0 - ((float64(age) - onset) * p.Get("age.decline_per_year"))
+		if floor := p.Get("age.floor"); ageFactor < floor { // Floor at 50% of young adult response by default
+			ageFactor = floor
 		}
 	}
 
 	// Condition adjustment (greater potential improvement if baseline is impaired)
 	conditionFactor := 1.0
 	if hasNeurologicalCondition {
-		conditionFactor = 1.3 // 30% more improvement potential
+		conditionFactor = p.Get("condition.gain") // 30% more improvement potential by default
 	}
 
 	// Calculate combined effect factor
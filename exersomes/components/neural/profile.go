@@ -0,0 +1,16 @@
+package neural
+
+import (
+	"exersomes/profile"
+	"math"
+)
+
+// PredictNeuralResponseForProfile runs PredictNeuralResponse at the profile's
+// age, treating any of profile.NeurologicalConditions as a neurological
+// condition. PredictNeuralResponse already adjusts for age, so adaptation
+// accrues over weeks scaled by Trainability alone.
+func PredictNeuralResponseForProfile(prescription ExercisePrescription, weeks int, p profile.Profile) NeuralResponse {
+	p = p.WithDefaults()
+	effectiveWeeks := int(math.Round(float64(weeks) * p.Trainability()))
+	return PredictNeuralResponse(prescription, effectiveWeeks, p.AgeYears, p.HasAny(profile.NeurologicalConditions...))
+}
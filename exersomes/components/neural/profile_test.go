@@ -0,0 +1,33 @@
+package neural
+
+import (
+	"exersomes/profile"
+	"reflect"
+	"testing"
+)
+
+// Test the reference profile reproduces the default prediction, and age,
+// neurological conditions and training status shift it
+func TestPredictNeuralResponseForProfile(t *testing.T) {
+	legacy := PredictNeuralResponse(ExecutiveFunctionEnhancement, 12, 40, false)
+	if got := PredictNeuralResponseForProfile(ExecutiveFunctionEnhancement, 12, profile.Reference); !reflect.DeepEqual(got, legacy) {
+		t.Error("Expected the reference profile to match PredictNeuralResponse at 40 without a condition")
+	}
+	bdnf := func(p profile.Profile) float64 {
+		return PredictNeuralResponseForProfile(ExecutiveFunctionEnhancement, 12, p).NeurokineChanges["BDNF"]
+	}
+
+	if bdnf(profile.Profile{AgeYears: 70}) >= bdnf(profile.Reference) {
+		t.Error("Expected a smaller BDNF gain at 70")
+	}
+	depressed := profile.Profile{Conditions: []string{"depression"}}
+	if got := PredictNeuralResponseForProfile(ExecutiveFunctionEnhancement, 12, depressed); !reflect.DeepEqual(got, PredictNeuralResponse(ExecutiveFunctionEnhancement, 12, 40, true)) {
+		t.Error("Expected depression to count as a neurological condition")
+	}
+	if bdnf(profile.Profile{TrainingStatus: profile.Athlete}) >= bdnf(profile.Reference) {
+		t.Error("Expected athletes to adapt less over the same weeks")
+	}
+	if bdnf(profile.Profile{AgeYears: 40, Sex: profile.Female, BodyFatPercent: 35}) != bdnf(profile.Reference) {
+		t.Error("Expected sex and body fat to leave the neural response unchanged")
+	}
+}
@@ -0,0 +1,27 @@
+package neural
+
+import "exersomes/sensitivity"
+
+// Age is the sensitivity factor for the subject's age in years
+const Age = "Age"
+
+// SensitivityPredictor exposes PredictNeuralResponse to sensitivity analysis:
+// the Hippocampal Memory Protocol over 12 weeks, varying its intensity,
+// session length and weekly frequency and the subject's age
+var SensitivityPredictor = sensitivity.Register(sensitivity.Predictor{
+	Name:        "neural",
+	Description: "PredictNeuralResponse, " + HippocampalMemoryProtocol.Name + ", 12 weeks",
+	Factors:     append(sensitivity.PrescriptionFactors(), sensitivity.Factor{Name: Age, Min: 20, Max: 80, Integer: true}),
+	Evaluate: func(x map[string]float64) map[string]float64 {
+		p := HippocampalMemoryProtocol
+		sensitivity.ApplyPrescription(x, &p.IntensityPercent, &p.DurationMinutes, &p.FrequencyPerWeek)
+		r := PredictNeuralResponse(p, 12, int(x[Age]), false)
+		return sensitivity.Flatten(map[string]map[string]float64{
+			"neurokine":  r.NeurokineChanges,
+			"receptor":   r.ReceptorChanges,
+			"structural": r.StructuralChanges,
+			"functional": r.FunctionalChanges,
+			"cognitive":  r.CognitiveDomainChanges,
+		})
+	},
+})
@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"encoding/xml"
 	"exersomes/molecular_types"
+	"exersomes/network"
+	"exersomes/store"
 	"fmt"
 	"log"
 	"os"
@@ -43,14 +45,15 @@ type Item struct {
 
 // Main function
 func main() {
+	if len(os.Args) > 1 && runSubcommand(os.Args[1], os.Args[2:]) {
+		return
+	}
+
 	var molecules []molecular_types.MolecularType
 
 	// Add different molecular types to the list
 	molecules = append(molecules, molecular_types.Metabolite{ID: "1", Name: "Metabolite1"})
 	molecules = append(molecules, molecular_types.MiRNA{ID: "2", Name: "MiRNA1"})
-	molecules = append(molecules, molecular_types.Protein{ID: "3", Name: "Protein1"})
-	molecules = append(molecules, molecular_types.RNA{ID: "4", Name: "RNA1"})
-	molecules = append(molecules, molecular_types.Vesicles{ID: "5", Name: "Vesicles1"})
 
 	// Process the molecules
 	for _, molecule := range molecules {
@@ -72,21 +75,35 @@ func main() {
 	// Load the list of genes/proteins of interest
 	geneList := loadInputList("exerkines_list.txt")
 
+	// Retrieved records are upserted into the store alongside the TSV/FASTA files
+	db, err := store.Open(store.DefaultPath)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+	defer db.Close()
+
+	fmt.Println("Storing curated exerkine catalog...")
+	if err := db.UpsertExerkines(network.Build(nil).Nodes); err != nil {
+		log.Fatalf("Failed to store exerkines: %v", err)
+	}
+
 	// Process each database type
 	fmt.Println("Retrieving Gene References...")
-	fetchGeneReferences(geneList)
+	fetchGeneReferences(geneList, db)
 
 	fmt.Println("\nRetrieving Protein IDs and Sequences...")
-	fetchProteinData(geneList)
+	fetchProteinData(geneList, db)
 
 	fmt.Println("\nRetrieving Pathway Maps...")
-	fetchPathwayMaps(geneList)
+	fetchPathwayMaps(geneList, db)
 
 	fmt.Println("\nGathering Functional Insights...")
-	fetchFunctionalInsights(geneList)
+	fetchFunctionalInsights(geneList, db)
+
+	fmt.Printf("\nRecords stored in %s\n", store.DefaultPath)
 }
 
-func fetchGeneReferences(geneList []string) {
+func fetchGeneReferences(geneList []string, db *store.Store) {
 	outputFile, err := os.Create("gene_references.tsv")
 	if err != nil {
 		log.Fatalf("Failed to create output file: %v", err)
@@ -168,6 +185,11 @@ func fetchGeneReferences(geneList []string) {
 				outputFile.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\n",
 					gene, geneID, symbol, description, chromosome, mapLocation))
 				fileMutex.Unlock()
+
+				if err := db.UpsertGene(store.Gene{Symbol: symbol, GeneID: geneID, Query: gene,
+					Description: description, Chromosome: chromosome, MapLocation: mapLocation}); err != nil {
+					fmt.Printf("Error storing gene %s: %v\n", gene, err)
+				}
 			}
 
 			progress.Increment()
@@ -343,7 +365,7 @@ func loadInputList(filename string) []string {
 }
 
 // Replace your existing fetchProteinData function with this:
-func fetchProteinData(geneList []string) {
+func fetchProteinData(geneList []string, db *store.Store) {
 	// Create protein info file
 	infoFile, err := os.Create("protein_info.tsv")
 	if err != nil {
@@ -404,6 +426,11 @@ func fetchProteinData(geneList []string) {
 					gene, prot.ProtID, prot.ProtAccession, prot.ProtName, prot.ProtLength, prot.ProtMolWeight))
 				infoMutex.Unlock()
 
+				if err := db.UpsertProtein(store.Protein{Accession: prot.ProtAccession, ProteinID: prot.ProtID, Query: gene,
+					Name: prot.ProtName, Length: prot.ProtLength, MolecularWeight: prot.ProtMolWeight}); err != nil {
+					fmt.Printf("Error storing protein %s: %v\n", prot.ProtID, err)
+				}
+
 				// Use mutex when writing to FASTA file
 				fastaMutex.Lock()
 				fastaFile.WriteString(fmt.Sprintf(">%s|%s|%s|%s\n", gene, prot.ProtID, prot.ProtAccession, prot.ProtName))
@@ -419,13 +446,22 @@ func fetchProteinData(geneList []string) {
 				}
 
 				// Skip the header line and write the sequence
+				var residues strings.Builder
 				seqLines := strings.Split(string(seqOutput), "\n")
 				for i := 1; i < len(seqLines); i++ {
 					if len(seqLines[i]) > 0 {
 						fastaFile.WriteString(seqLines[i] + "\n")
+						residues.WriteString(strings.TrimSpace(seqLines[i]))
 					}
 				}
 				fastaMutex.Unlock()
+
+				id, query, description := store.ParseFASTAHeader(
+					fmt.Sprintf("%s|%s|%s|%s", gene, prot.ProtID, prot.ProtAccession, prot.ProtName))
+				if err := db.UpsertSequence(store.Sequence{ID: id, Query: query, Description: description,
+					Residues: residues.String()}); err != nil {
+					fmt.Printf("Error storing sequence %s: %v\n", prot.ProtID, err)
+				}
 			}
 
 			progress.Increment()
@@ -438,7 +474,7 @@ func fetchProteinData(geneList []string) {
 }
 
 // Fetch pathway maps
-func fetchPathwayMaps(geneList []string) {
+func fetchPathwayMaps(geneList []string, db *store.Store) {
 	outputFile, err := os.Create("pathway_maps.tsv")
 	if err != nil {
 		log.Fatalf("Failed to create pathway file: %v", err)
@@ -488,6 +524,8 @@ func fetchPathwayMaps(geneList []string) {
 
 			outputFile.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%s\n",
 				gene, bs.BSID, bs.BSName, bs.BSSource.SourceName, role))
+			storePathway(db, store.Pathway{ID: bs.BSID, Name: bs.BSName, Source: bs.BSSource.SourceName,
+				Members: []store.PathwayMember{{Gene: gene, Role: role}}})
 		}
 
 		// Alternative search in KEGG
@@ -520,13 +558,21 @@ func fetchPathwayMaps(geneList []string) {
 
 			outputFile.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%s\n",
 				gene, pathwayID, pathwayName, pathwaySource, "Member"))
+			storePathway(db, store.Pathway{ID: pathwayID, Name: pathwayName, Source: pathwaySource,
+				Members: []store.PathwayMember{{Gene: gene, Role: "Member"}}})
 		}
 	}
 	fmt.Printf("Pathway information saved to pathway_maps.tsv\n")
 }
 
+func storePathway(db *store.Store, p store.Pathway) {
+	if err := db.UpsertPathway(p); err != nil {
+		fmt.Printf("Error storing pathway %s: %v\n", p.ID, err)
+	}
+}
+
 // Fetch functional insights
-func fetchFunctionalInsights(geneList []string) {
+func fetchFunctionalInsights(geneList []string, db *store.Store) {
 	outputFile, err := os.Create("functional_insights.tsv")
 	if err != nil {
 		log.Fatalf("Failed to create functional insights file: %v", err)
@@ -616,6 +662,8 @@ func fetchFunctionalInsights(geneList []string) {
 
 			outputFile.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%s\n",
 				gene, functionType, description, evidence, article.PMID))
+			storePublication(db, store.Publication{Gene: gene, FunctionType: functionType,
+				Description: description, Evidence: evidence, Reference: article.PMID})
 		}
 
 		// Get Gene Ontology annotations
@@ -654,7 +702,15 @@ func fetchFunctionalInsights(geneList []string) {
 
 			outputFile.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%s\n",
 				gene, functionType, term.TermName, term.Evidence, term.Source))
+			storePublication(db, store.Publication{Gene: gene, FunctionType: functionType,
+				Description: term.TermName, Evidence: term.Evidence, Reference: term.Source})
 		}
 	}
 	fmt.Printf("Functional insights saved to functional_insights.tsv\n")
 }
+
+func storePublication(db *store.Store, p store.Publication) {
+	if err := db.UpsertPublication(p); err != nil {
+		fmt.Printf("Error storing annotation for %s: %v\n", p.Gene, err)
+	}
+}
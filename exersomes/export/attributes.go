@@ -0,0 +1,98 @@
+package export
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// Attribute types, named as in CX2
+const (
+	typeString     = "string"
+	typeDouble     = "double"
+	typeInteger    = "integer"
+	typeStringList = "list_of_string"
+)
+
+// attribute declares an exported node or edge column
+type attribute struct {
+	name string
+	kind string
+}
+
+var nodeAttributes = []attribute{
+	{"name", typeString},
+	{"kind", typeString},
+	{"category", typeString},
+	{"tissue_sources", typeStringList},
+	{"tissue", typeString},
+	{"regulation", typeString},
+	{"log2_fold_change", typeDouble},
+}
+
+var edgeAttributes = []attribute{
+	{"interaction", typeString},
+	{"tissue", typeString},
+	{"source_tissues", typeStringList},
+	{"target_tissue", typeString},
+	{"pathway", typeString},
+	{"strength", typeDouble},
+	{"evidence", typeInteger},
+	{"databases", typeStringList},
+}
+
+// values returns a node's attributes by name, omitting empty ones
+func (n Node) values() map[string]any {
+	v := map[string]any{"name": n.Label, "kind": string(n.Kind)}
+	setString(v, "category", n.Category)
+	setList(v, "tissue_sources", n.TissueSources)
+	setString(v, "tissue", n.Tissue)
+	setString(v, "regulation", n.Regulation)
+	if !math.IsNaN(n.Log2FoldChange) {
+		v["log2_fold_change"] = n.Log2FoldChange
+	}
+	return v
+}
+
+// values returns an edge's attributes by name, omitting empty ones
+func (e Edge) values() map[string]any {
+	v := map[string]any{"interaction": e.Interaction, "strength": e.Strength}
+	setString(v, "tissue", e.Tissue)
+	setList(v, "source_tissues", e.SourceTissues)
+	setString(v, "target_tissue", e.TargetTissue)
+	setString(v, "pathway", e.Pathway)
+	if e.Evidence > 0 {
+		v["evidence"] = e.Evidence
+	}
+	setList(v, "databases", e.Databases)
+	return v
+}
+
+func setString(v map[string]any, name, value string) {
+	if value != "" {
+		v[name] = value
+	}
+}
+
+func setList(v map[string]any, name string, values []string) {
+	if len(values) > 0 {
+		v[name] = values
+	}
+}
+
+// formatValue renders an attribute for formats without list types, joining
+// lists with "; "
+func formatValue(value any) string {
+	switch x := value.(type) {
+	case []string:
+		return strings.Join(x, "; ")
+	case float64:
+		return fmt.Sprintf("%g", x)
+	}
+	return fmt.Sprint(value)
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type cyPosition struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type cyElement struct {
+	Data     map[string]any `json:"data"`
+	Position *cyPosition    `json:"position,omitempty"`
+}
+
+// WriteCytoscapeJSON writes Cytoscape.js JSON (.cyjs), which Cytoscape desktop
+// imports with node positions. Node data also carries color and size hints for
+// style mappings such as data(color).
+func WriteCytoscapeJSON(w io.Writer, g Graph) error {
+	nodes := make([]cyElement, len(g.Nodes))
+	for i, n := range g.Nodes {
+		data := n.values()
+		data["id"] = n.ID
+		data["color"] = hexColor(n.Color)
+		data["size"] = n.Size
+		nodes[i] = cyElement{Data: data, Position: &cyPosition{X: n.X, Y: n.Y}}
+	}
+	edges := make([]cyElement, len(g.Edges))
+	for i, e := range g.Edges {
+		data := e.values()
+		data["id"], data["source"], data["target"] = e.ID, e.Source, e.Target
+		edges[i] = cyElement{Data: data}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]any{
+		"format_version":             "1.0",
+		"generated_by":               "exersomes",
+		"target_cytoscapejs_version": "~2.1",
+		"data":                       map[string]any{"name": g.Name},
+		"elements":                   map[string]any{"nodes": nodes, "edges": edges},
+	})
+}
+
+type cx2Node struct {
+	ID int            `json:"id"`
+	X  float64        `json:"x"`
+	Y  float64        `json:"y"`
+	V  map[string]any `json:"v"`
+}
+
+type cx2Edge struct {
+	ID     int            `json:"id"`
+	Source int            `json:"s"`
+	Target int            `json:"t"`
+	V      map[string]any `json:"v"`
+}
+
+// WriteCX2 writes the Cytoscape Exchange format version 2, as used by NDEx and
+// Cytoscape 3.10+, with node positions as layout hints
+func WriteCX2(w io.Writer, g Graph) error {
+	declare := func(attrs []attribute) map[string]any {
+		d := make(map[string]any, len(attrs))
+		for _, a := range attrs {
+			d[a.name] = map[string]string{"d": a.kind}
+		}
+		return d
+	}
+	index := make(map[string]int, len(g.Nodes))
+	nodes := make([]cx2Node, len(g.Nodes))
+	for i, n := range g.Nodes {
+		index[n.ID] = i
+		nodes[i] = cx2Node{ID: i, X: n.X, Y: n.Y, V: n.values()}
+	}
+	edges := make([]cx2Edge, len(g.Edges))
+	for i, e := range g.Edges {
+		edges[i] = cx2Edge{ID: i, Source: index[e.Source], Target: index[e.Target], V: e.values()}
+	}
+
+	aspects := []any{
+		map[string]any{"CXVersion": "2.0", "hasFragments": false},
+		map[string]any{"metaData": []map[string]any{
+			{"name": "attributeDeclarations", "elementCount": 1},
+			{"name": "networkAttributes", "elementCount": 1},
+			{"name": "nodes", "elementCount": len(nodes)},
+			{"name": "edges", "elementCount": len(edges)},
+		}},
+		map[string]any{"attributeDeclarations": []map[string]any{{
+			"networkAttributes": map[string]any{"name": map[string]string{"d": typeString}},
+			"nodes":             declare(nodeAttributes),
+			"edges":             declare(edgeAttributes),
+		}}},
+		map[string]any{"networkAttributes": []map[string]any{{"name": g.Name}}},
+		map[string]any{"nodes": nodes},
+		map[string]any{"edges": edges},
+		map[string]any{"status": []map[string]any{{"error": "", "success": true}}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(aspects)
+}
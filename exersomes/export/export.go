@@ -0,0 +1,245 @@
+package export
+
+import (
+	"exersomes/graph"
+	"exersomes/molecular_types"
+	"exersomes/network"
+	"exersomes/visualize"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Interaction types, used as edge interaction and SIF relationship names
+const (
+	Secretes      = "secretes"
+	Binds         = "binds"
+	VesicleUptake = "ev_uptake" // Vesicle cargo to the uptake route of a target tissue
+	ExpressedIn   = "expressed_in"
+)
+
+// Regulation of a ligand after a session, from its predicted log2 fold change
+const (
+	RegulationUp        = "Up"
+	RegulationDown      = "Down"
+	RegulationUnchanged = "Unchanged"
+)
+
+// unchangedLog2 is the largest |log2 fold change| reported as Unchanged
+const unchangedLog2 = 0.1
+
+// Node is a tissue, ligand or receptor with its exported attributes
+type Node struct {
+	ID             string
+	Label          string
+	Kind           graph.NodeKind
+	Category       string   // Ligands: Protein, Metabolite, miRNA, ...
+	TissueSources  []string // Ligands: secreting tissues
+	Tissue         string   // Receptors: expressing tissue
+	Regulation     string   // Ligands with a prediction: Up, Down or Unchanged
+	Log2FoldChange float64  // NaN without a prediction
+	X, Y           float64  // Layout hint
+	Color          color.RGBA
+	Size           float64
+}
+
+// Edge joins two nodes. Ligand-receptor edges merge every interaction of the
+// pair, keeping the largest Strength and Evidence.
+type Edge struct {
+	ID            string
+	Source        string
+	Target        string
+	Interaction   string // Secretes, Binds, VesicleUptake or ExpressedIn
+	Tissue        string
+	SourceTissues []string
+	TargetTissue  string
+	Pathway       string
+	Strength      float64
+	Evidence      int
+	Databases     []string
+}
+
+// Graph is an exerkine network prepared for export
+type Graph struct {
+	Name  string
+	Nodes []Node
+	Edges []Edge
+}
+
+// Options control how a network is exported
+type Options struct {
+	Name         string                  // Network name; empty uses a name from ExerciseType
+	ExerciseType string                  // Predicts ligand regulation; empty uses Aerobic
+	Layout       visualize.LayoutOptions // Force-directed layout hints
+}
+
+// FromNetwork builds the source tissue → ligand → receptor → target tissue
+// graph, using the node IDs of the graph package, with regulation predicted for
+// the exercise type and positions from a force-directed layout
+func FromNetwork(net molecular_types.ExerkineNetwork, opts Options) Graph {
+	if opts.ExerciseType == "" {
+		opts.ExerciseType = "Aerobic"
+	}
+	if opts.Name == "" {
+		opts.Name = "Exerkine network (" + opts.ExerciseType + ")"
+	}
+	response := network.ExerciseResponse(opts.ExerciseType)
+	layout := graph.FromNetwork(net)
+	positions := visualize.ForceLayout(layout, opts.Layout)
+
+	g := Graph{Name: opts.Name}
+	index := make(map[string]int)
+	addNode := func(n Node) int {
+		if i, ok := index[n.ID]; ok {
+			return i
+		}
+		n.Log2FoldChange = math.NaN()
+		if i, ok := layout.Lookup(n.ID); ok {
+			n.X, n.Y = positions[i].X, positions[i].Y
+		}
+		n.Color, n.Size = style(n.Kind)
+		index[n.ID] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, n)
+		return len(g.Nodes) - 1
+	}
+	ligand := func(name string) int {
+		return addNode(Node{ID: graph.LigandID(name), Label: name, Kind: graph.KindLigand})
+	}
+
+	for _, e := range net.Nodes {
+		i := ligand(e.Name)
+		g.Nodes[i].Category = e.Category
+		g.Nodes[i].TissueSources = appendUnique(g.Nodes[i].TissueSources, e.TissueSources...)
+	}
+
+	edges := make(map[string]int)
+	addEdge := func(e Edge) *Edge {
+		key := e.Source + "\x00" + e.Interaction + "\x00" + e.Target
+		if i, ok := edges[key]; ok {
+			return &g.Edges[i]
+		}
+		e.ID = fmt.Sprintf("e%d", len(g.Edges))
+		edges[key] = len(g.Edges)
+		g.Edges = append(g.Edges, e)
+		return &g.Edges[len(g.Edges)-1]
+	}
+
+	for _, in := range net.Interactions {
+		target := in.TargetTissue
+		if target == "" {
+			target = in.Tissue
+		}
+		l := ligand(in.Ligand)
+		r := addNode(Node{ID: graph.ReceptorID(in.Receptor, target), Label: in.Receptor, Kind: graph.KindReceptor, Tissue: target})
+
+		strength := in.Strength
+		if strength <= 0 {
+			strength = 1
+		}
+		interaction := Binds
+		if in.Type == molecular_types.VesicleCargo {
+			interaction = VesicleUptake
+		}
+		e := addEdge(Edge{Source: g.Nodes[l].ID, Target: g.Nodes[r].ID, Interaction: interaction, Tissue: target, TargetTissue: target})
+		e.Strength = math.Max(e.Strength, strength)
+		e.Evidence = max(e.Evidence, in.Evidence)
+		e.Databases = appendUnique(e.Databases, in.Sources...)
+		if e.Pathway == "" {
+			e.Pathway = in.Pathway
+		}
+		e.SourceTissues = appendUnique(e.SourceTissues, in.SourceTissue)
+
+		if in.SourceTissue != "" {
+			g.Nodes[l].TissueSources = appendUnique(g.Nodes[l].TissueSources, in.SourceTissue)
+			s := addNode(Node{ID: graph.TissueID(in.SourceTissue), Label: in.SourceTissue, Kind: graph.KindTissue})
+			addEdge(Edge{Source: g.Nodes[s].ID, Target: g.Nodes[l].ID, Interaction: Secretes,
+				Tissue: in.SourceTissue, SourceTissues: []string{in.SourceTissue}, Strength: 1})
+		}
+		if target != "" {
+			t := addNode(Node{ID: graph.TissueID(target), Label: target, Kind: graph.KindTissue})
+			addEdge(Edge{Source: g.Nodes[r].ID, Target: g.Nodes[t].ID, Interaction: ExpressedIn,
+				Tissue: target, TargetTissue: target, Strength: 1})
+		}
+	}
+
+	for i, n := range g.Nodes {
+		if n.Kind != graph.KindLigand {
+			continue
+		}
+		sort.Strings(g.Nodes[i].TissueSources)
+		if change, ok := response[network.CanonicalLigand(n.Label)]; ok {
+			g.Nodes[i].Log2FoldChange = change
+			g.Nodes[i].Regulation = regulation(change)
+		}
+	}
+	return g
+}
+
+func regulation(log2FoldChange float64) string {
+	switch {
+	case log2FoldChange > unchangedLog2:
+		return RegulationUp
+	case log2FoldChange < -unchangedLog2:
+		return RegulationDown
+	}
+	return RegulationUnchanged
+}
+
+// style returns the color and size hints of a node kind, matching visualize.NetworkFigure
+func style(kind graph.NodeKind) (color.RGBA, float64) {
+	switch kind {
+	case graph.KindTissue:
+		return visualize.Category(0), 22
+	case graph.KindLigand:
+		return visualize.Category(1), 12
+	}
+	return visualize.Category(4), 8
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		found := v == ""
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// Formats maps file extensions to writers
+var Formats = map[string]func(w io.Writer, g Graph) error{
+	".cyjs":    WriteCytoscapeJSON,
+	".cx2":     WriteCX2,
+	".graphml": WriteGraphML,
+	".gexf":    WriteGEXF,
+	".sif":     WriteSIF,
+}
+
+// WriteFile writes the graph in the format named by the path's extension:
+// .cyjs (Cytoscape.js JSON), .cx2, .graphml, .gexf or .sif
+func WriteFile(path string, g Graph) error {
+	write, ok := Formats[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return fmt.Errorf("%s: unsupported network format, use .cyjs, .cx2, .graphml, .gexf or .sif", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, g); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,210 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"exersomes/molecular_types"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testNetwork is a small crosstalk network between muscle, liver and adipose
+func testNetwork() molecular_types.ExerkineNetwork {
+	edge := func(ligand, receptor, source, target string, sources ...string) molecular_types.Interaction {
+		return molecular_types.Interaction{
+			Ligand: ligand, Receptor: receptor, Tissue: target, SourceTissue: source, TargetTissue: target,
+			Pathway: "JAK-STAT", Strength: 0.5, Sources: sources, Evidence: len(sources),
+		}
+	}
+	return molecular_types.ExerkineNetwork{
+		Nodes: []molecular_types.Exerkine{
+			{Name: "IL-6", Category: "Protein", TissueSources: []string{"Muscle"}},
+			{Name: "BDNF", TissueSources: []string{"Brain", "Muscle"}},
+			{Name: "Unpredicted"},
+		},
+		Interactions: []molecular_types.Interaction{
+			edge("IL-6", "IL6R", "Muscle", "Liver", "CellChatDB"),
+			edge("IL-6", "IL6R", "Muscle", "Liver", "CellPhoneDB", "CellChatDB"),
+			edge("IL-6", "IL6R", "Muscle", "Adipose"),
+			edge("BDNF", "TrkB", "Brain", "Brain"),
+		},
+	}
+}
+
+func edgeByIDs(g Graph, source, interaction, target string) (Edge, bool) {
+	for _, e := range g.Edges {
+		if e.Source == source && e.Interaction == interaction && e.Target == target {
+			return e, true
+		}
+	}
+	return Edge{}, false
+}
+
+// Test the export graph merges duplicate pairs and links tissues to ligands and receptors
+func TestFromNetwork(t *testing.T) {
+	g := FromNetwork(testNetwork(), Options{})
+	if g.Name != "Exerkine network (Aerobic)" {
+		t.Errorf("Unexpected default name %q", g.Name)
+	}
+	if len(g.Nodes) != 10 {
+		t.Errorf("Expected 4 tissues, 3 ligands and 3 receptors, got %d nodes", len(g.Nodes))
+	}
+	// 3 binds, 2 secretes and 3 expressed_in edges
+	if len(g.Edges) != 8 {
+		t.Errorf("Expected 8 edges, got %d", len(g.Edges))
+	}
+
+	e, ok := edgeByIDs(g, "ligand:IL-6", Binds, "receptor:IL6R@Liver")
+	if !ok {
+		t.Fatal("Missing IL-6 → IL6R (Liver) edge")
+	}
+	if e.Evidence != 2 || !reflect.DeepEqual(e.Databases, []string{"CellChatDB", "CellPhoneDB"}) {
+		t.Errorf("Expected merged evidence and databases, got %d %v", e.Evidence, e.Databases)
+	}
+	if e.Pathway != "JAK-STAT" || e.Strength != 0.5 || !reflect.DeepEqual(e.SourceTissues, []string{"Muscle"}) {
+		t.Errorf("Unexpected edge attributes %+v", e)
+	}
+	if _, ok := edgeByIDs(g, "tissue:Muscle", Secretes, "ligand:IL-6"); !ok {
+		t.Error("Missing Muscle secretes IL-6 edge")
+	}
+	if _, ok := edgeByIDs(g, "receptor:IL6R@Adipose", ExpressedIn, "tissue:Adipose"); !ok {
+		t.Error("Missing IL6R expressed_in Adipose edge")
+	}
+
+	net := testNetwork()
+	net.Interactions = append(net.Interactions, molecular_types.Interaction{
+		Type: molecular_types.VesicleCargo, Ligand: "miR-1", Receptor: "Exosome uptake",
+		SourceTissue: "Muscle", TargetTissue: "Liver", Strength: 0.55,
+	})
+	if _, ok := edgeByIDs(FromNetwork(net, Options{}), "ligand:miR-1", VesicleUptake, "receptor:Exosome uptake@Liver"); !ok {
+		t.Error("Missing miR-1 ev_uptake edge")
+	}
+
+	for _, n := range g.Nodes {
+		switch n.Label {
+		case "IL-6":
+			if n.Regulation != RegulationUp || n.Category != "Protein" {
+				t.Errorf("Expected IL-6 up-regulated after aerobic exercise, got %+v", n)
+			}
+		case "Unpredicted":
+			if n.Regulation != "" || !math.IsNaN(n.Log2FoldChange) {
+				t.Errorf("Expected no regulation without a prediction, got %+v", n)
+			}
+		}
+		if n.X == 0 && n.Y == 0 {
+			t.Errorf("Node %s has no layout position", n.ID)
+		}
+	}
+}
+
+// Test every format parses and carries the graph's nodes, edges and attributes
+func TestFormats(t *testing.T) {
+	g := FromNetwork(testNetwork(), Options{Name: "Test & network"})
+	write := func(format string) []byte {
+		var b bytes.Buffer
+		if err := Formats[format](&b, g); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		return b.Bytes()
+	}
+
+	var cyjs struct {
+		Data     struct{ Name string }
+		Elements struct {
+			Nodes []struct {
+				Data     map[string]any
+				Position struct{ X, Y float64 }
+			}
+			Edges []struct{ Data map[string]any }
+		}
+	}
+	if err := json.Unmarshal(write(".cyjs"), &cyjs); err != nil {
+		t.Fatalf("Cytoscape.js JSON: %v", err)
+	}
+	if cyjs.Data.Name != g.Name || len(cyjs.Elements.Nodes) != len(g.Nodes) || len(cyjs.Elements.Edges) != len(g.Edges) {
+		t.Errorf("Cytoscape.js JSON lost elements")
+	}
+	for _, n := range cyjs.Elements.Nodes {
+		if n.Data["name"] == "IL-6" && (n.Data["regulation"] != RegulationUp || n.Data["color"] == nil) {
+			t.Errorf("Cytoscape.js JSON lost IL-6 attributes: %v", n.Data)
+		}
+		if _, ok := n.Data["log2_fold_change"]; n.Data["name"] == "Unpredicted" && ok {
+			t.Error("Cytoscape.js JSON exported a missing fold change")
+		}
+	}
+
+	var cx2 []map[string]json.RawMessage
+	if err := json.Unmarshal(write(".cx2"), &cx2); err != nil {
+		t.Fatalf("CX2: %v", err)
+	}
+	var cx2Nodes []cx2Node
+	var cx2Edges []cx2Edge
+	for _, aspect := range cx2 {
+		if raw, ok := aspect["nodes"]; ok {
+			json.Unmarshal(raw, &cx2Nodes)
+		}
+		if raw, ok := aspect["edges"]; ok {
+			json.Unmarshal(raw, &cx2Edges)
+		}
+	}
+	if len(cx2Nodes) != len(g.Nodes) || len(cx2Edges) != len(g.Edges) {
+		t.Errorf("CX2 lost elements: %d nodes, %d edges", len(cx2Nodes), len(cx2Edges))
+	}
+	for _, e := range cx2Edges {
+		if e.Source < 0 || e.Source >= len(cx2Nodes) || e.Target < 0 || e.Target >= len(cx2Nodes) {
+			t.Errorf("CX2 edge %d points outside the node list", e.ID)
+		}
+	}
+
+	for _, format := range []string{".graphml", ".gexf"} {
+		elements := make(map[string]int)
+		d := xml.NewDecoder(bytes.NewReader(write(format)))
+		for {
+			tok, err := d.Token()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+			if start, ok := tok.(xml.StartElement); ok {
+				elements[start.Name.Local]++
+			}
+		}
+		if elements["node"] != len(g.Nodes) || elements["edge"] != len(g.Edges) {
+			t.Errorf("%s lost elements: %v", format, elements)
+		}
+	}
+
+	var want []string
+	for _, e := range g.Edges {
+		want = append(want, e.Source+"\t"+e.Interaction+"\t"+e.Target)
+	}
+	want = append(want, "ligand:Unpredicted") // Isolated
+	if sif := strings.Split(strings.TrimSpace(string(write(".sif"))), "\n"); !reflect.DeepEqual(sif, want) {
+		t.Errorf("Unexpected SIF lines %q", sif)
+	}
+}
+
+// Test WriteFile picks the format from the extension
+func TestWriteFile(t *testing.T) {
+	g := FromNetwork(testNetwork(), Options{})
+	dir := t.TempDir()
+	for format := range Formats {
+		path := filepath.Join(dir, "network"+strings.ToUpper(format))
+		if err := WriteFile(path, g); err != nil {
+			t.Fatal(err)
+		}
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("%s was not written", path)
+		}
+	}
+	if err := WriteFile(filepath.Join(dir, "network.dot"), g); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,26 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteSIF writes the Simple Interaction Format: one tab-separated
+// "source interaction target" line per edge, and a line of its own for each
+// isolated node. Node names are the graph's node IDs, which keep receptors in
+// different tissues apart; SIF carries no attributes.
+func WriteSIF(w io.Writer, g Graph) error {
+	bw := bufio.NewWriter(w)
+	connected := make(map[string]bool)
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "%s\t%s\t%s\n", e.Source, e.Interaction, e.Target)
+		connected[e.Source], connected[e.Target] = true, true
+	}
+	for _, n := range g.Nodes {
+		if !connected[n.ID] {
+			fmt.Fprintln(bw, n.ID)
+		}
+	}
+	return bw.Flush()
+}
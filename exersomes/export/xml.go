@@ -0,0 +1,122 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// xmlTypes maps attribute types to their GraphML and GEXF names. Lists are
+// written as strings joined with "; ", since neither format has a list type
+// that Cytoscape and Gephi both read.
+var xmlTypes = map[string]struct{ graphml, gexf string }{
+	typeString:     {"string", "string"},
+	typeDouble:     {"double", "double"},
+	typeInteger:    {"int", "integer"},
+	typeStringList: {"string", "string"},
+}
+
+func escapeXML(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// WriteGraphML writes GraphML, with every attribute declared as a key and node
+// positions and colors as x, y and color keys
+func WriteGraphML(w io.Writer, g Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(bw, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"`+
+		` xsi:schemaLocation="http://graphml.graphdrawing.org/xmlns http://graphml.graphdrawing.org/xmlns/1.0/graphml.xsd">`)
+	for _, a := range nodeAttributes {
+		fmt.Fprintf(bw, "  <key id=\"n_%s\" for=\"node\" attr.name=\"%s\" attr.type=\"%s\"/>\n", a.name, a.name, xmlTypes[a.kind].graphml)
+	}
+	for _, hint := range []attribute{{"x", typeDouble}, {"y", typeDouble}, {"size", typeDouble}, {"color", typeString}} {
+		fmt.Fprintf(bw, "  <key id=\"n_%s\" for=\"node\" attr.name=\"%s\" attr.type=\"%s\"/>\n", hint.name, hint.name, xmlTypes[hint.kind].graphml)
+	}
+	for _, a := range edgeAttributes {
+		fmt.Fprintf(bw, "  <key id=\"e_%s\" for=\"edge\" attr.name=\"%s\" attr.type=\"%s\"/>\n", a.name, a.name, xmlTypes[a.kind].graphml)
+	}
+	fmt.Fprintf(bw, "  <graph id=\"%s\" edgedefault=\"directed\">\n", escapeXML(g.Name))
+	for _, n := range g.Nodes {
+		fmt.Fprintf(bw, "    <node id=\"%s\">\n", escapeXML(n.ID))
+		values := n.values()
+		for _, a := range nodeAttributes {
+			if v, ok := values[a.name]; ok {
+				fmt.Fprintf(bw, "      <data key=\"n_%s\">%s</data>\n", a.name, escapeXML(formatValue(v)))
+			}
+		}
+		fmt.Fprintf(bw, "      <data key=\"n_x\">%g</data>\n      <data key=\"n_y\">%g</data>\n", n.X, n.Y)
+		fmt.Fprintf(bw, "      <data key=\"n_size\">%g</data>\n      <data key=\"n_color\">%s</data>\n", n.Size, hexColor(n.Color))
+		fmt.Fprintln(bw, "    </node>")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "    <edge id=\"%s\" source=\"%s\" target=\"%s\">\n", e.ID, escapeXML(e.Source), escapeXML(e.Target))
+		values := e.values()
+		for _, a := range edgeAttributes {
+			if v, ok := values[a.name]; ok {
+				fmt.Fprintf(bw, "      <data key=\"e_%s\">%s</data>\n", a.name, escapeXML(formatValue(v)))
+			}
+		}
+		fmt.Fprintln(bw, "    </edge>")
+	}
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</graphml>")
+	return bw.Flush()
+}
+
+// WriteGEXF writes GEXF 1.3 for Gephi, with positions, colors and sizes in the
+// viz namespace and Strength as the edge weight
+func WriteGEXF(w io.Writer, g Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(bw, `<gexf xmlns="http://gexf.net/1.3" xmlns:viz="http://gexf.net/1.3/viz" version="1.3">`)
+	fmt.Fprintf(bw, "  <meta>\n    <creator>exersomes</creator>\n    <description>%s</description>\n  </meta>\n", escapeXML(g.Name))
+	fmt.Fprintln(bw, `  <graph defaultedgetype="directed" mode="static">`)
+	declare := func(class string, attrs []attribute) {
+		fmt.Fprintf(bw, "    <attributes class=\"%s\">\n", class)
+		for _, a := range attrs {
+			if a.name == "name" {
+				continue // The node label
+			}
+			fmt.Fprintf(bw, "      <attribute id=\"%s\" title=\"%s\" type=\"%s\"/>\n", a.name, a.name, xmlTypes[a.kind].gexf)
+		}
+		fmt.Fprintln(bw, "    </attributes>")
+	}
+	declare("node", nodeAttributes)
+	declare("edge", edgeAttributes)
+	attvalues := func(attrs []attribute, values map[string]any) {
+		fmt.Fprintln(bw, "        <attvalues>")
+		for _, a := range attrs {
+			if v, ok := values[a.name]; ok && a.name != "name" {
+				fmt.Fprintf(bw, "          <attvalue for=\"%s\" value=\"%s\"/>\n", a.name, escapeXML(formatValue(v)))
+			}
+		}
+		fmt.Fprintln(bw, "        </attvalues>")
+	}
+
+	fmt.Fprintln(bw, "    <nodes>")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(bw, "      <node id=\"%s\" label=\"%s\">\n", escapeXML(n.ID), escapeXML(n.Label))
+		attvalues(nodeAttributes, n.values())
+		fmt.Fprintf(bw, "        <viz:color r=\"%d\" g=\"%d\" b=\"%d\"/>\n", n.Color.R, n.Color.G, n.Color.B)
+		fmt.Fprintf(bw, "        <viz:position x=\"%g\" y=\"%g\" z=\"0\"/>\n", n.X, -n.Y) // Gephi's y axis points up
+		fmt.Fprintf(bw, "        <viz:size value=\"%g\"/>\n", n.Size)
+		fmt.Fprintln(bw, "      </node>")
+	}
+	fmt.Fprintln(bw, "    </nodes>")
+	fmt.Fprintln(bw, "    <edges>")
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "      <edge id=\"%s\" source=\"%s\" target=\"%s\" label=\"%s\" weight=\"%g\">\n",
+			e.ID, escapeXML(e.Source), escapeXML(e.Target), e.Interaction, e.Strength)
+		attvalues(edgeAttributes, e.values())
+		fmt.Fprintln(bw, "      </edge>")
+	}
+	fmt.Fprintln(bw, "    </edges>")
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</gexf>")
+	return bw.Flush()
+}
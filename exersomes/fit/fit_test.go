@@ -0,0 +1,81 @@
+package fit
+
+import (
+	"exersomes/components/immune"
+	"exersomes/params"
+	"exersomes/study"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// Test Nelder-Mead finds unconstrained and bound-constrained minima
+func TestNelderMead(t *testing.T) {
+	rosenbrock := func(x []float64) float64 {
+		return 100*math.Pow(x[1]-x[0]*x[0], 2) + math.Pow(1-x[0], 2)
+	}
+	r := NelderMead(rosenbrock, []float64{-1.2, 1}, []float64{-5, -5}, []float64{5, 5}, Options{MaxIterations: 5000, Tolerance: 1e-14})
+	if math.Abs(r.X[0]-1) > 1e-3 || math.Abs(r.X[1]-1) > 1e-3 {
+		t.Errorf("Expected minimum at (1, 1), got %v after %d iterations", r.X, r.Iterations)
+	}
+
+	// Unconstrained minimum at (3, -2) lies outside the box
+	bowl := func(x []float64) float64 { return math.Pow(x[0]-3, 2) + math.Pow(x[1]+2, 2) }
+	r = NelderMead(bowl, []float64{0.5, 0.5}, []float64{0, 0}, []float64{1, 1}, Options{})
+	if math.Abs(r.X[0]-1) > 1e-4 || math.Abs(r.X[1]) > 1e-4 {
+		t.Errorf("Expected the bound minimum (1, 0), got %v", r.X)
+	}
+}
+
+// Test fitting recovers a coefficient used to generate the observations and
+// validates on held-out subjects
+func TestStudy(t *testing.T) {
+	p := immune.AcuteResponseParams
+	defaults := p.Parameters()
+	t.Cleanup(func() {
+		for _, d := range defaults {
+			p.Set(d.Name, d.Value)
+		}
+	})
+
+	il10, ok := study.FindPredictor(study.Predictors(), "IL-10", study.DefaultTissue)
+	if !ok {
+		t.Fatal("Expected an IL-10 predictor")
+	}
+	p.Set("il10.gain", 4)
+	var st study.Study
+	for i := 0; i < 8; i++ {
+		subject := study.Subject{ID: "P" + strconv.Itoa(i)}
+		sess := study.Session{ID: "S1", Subject: subject.ID, ExerciseType: "Aerobic",
+			IntensityPercent: 50 + 5*float64(i), DurationMinutes: 30 + 10*float64(i)}
+		st.Subjects = append(st.Subjects, subject)
+		st.Sessions = append(st.Sessions, sess)
+		times := []float64{0, sess.DurationMinutes, sess.DurationMinutes + 60}
+		for j, v := range il10.Predict(sess.Protocol(), subject, times) {
+			st.Observations = append(st.Observations, study.Observation{Subject: subject.ID, Session: "S1",
+				TimeMinutes: times[j], Analyte: "IL-10", Tissue: study.DefaultTissue, Value: v, Unit: il10.Unit})
+		}
+	}
+	p.Set("il10.gain", 2)
+
+	train, validation := Split(st, 0.25, 1)
+	if len(train.Subjects) != 6 || len(validation.Subjects) != 2 || len(train.Observations) != 18 {
+		t.Fatalf("Unexpected split: %d/%d subjects", len(train.Subjects), len(validation.Subjects))
+	}
+
+	report, err := Study(st, []*params.Set{p}, StudyOptions{Holdout: 0.25, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Get("il10.gain"); math.Abs(got-4) > 1e-3 {
+		t.Errorf("Expected il10.gain near 4, got %g", got)
+	}
+	if report.ValidationScore > 1e-6 || report.ValidationScore >= report.InitialValidationScore {
+		t.Errorf("Expected held-out score to improve to ~0, got %g from %g", report.ValidationScore, report.InitialValidationScore)
+	}
+	for _, fp := range report.Parameters {
+		if fp.Name == "il6.high_intensity_threshold" {
+			t.Error("Expected fixed parameters to be left out of the fit")
+		}
+	}
+}
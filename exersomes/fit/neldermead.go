@@ -0,0 +1,129 @@
+package fit
+
+import (
+	"math"
+	"sort"
+)
+
+// Options control the optimizer
+type Options struct {
+	MaxIterations int     // 0 uses 200 per free parameter
+	Tolerance     float64 // Stop when the simplex's objective values agree within this; 0 uses 1e-8
+	InitialStep   float64 // Initial simplex size as a fraction of each parameter's range; 0 uses 0.1
+}
+
+// Result is the outcome of a minimization
+type Result struct {
+	X          []float64
+	Value      float64
+	Iterations int
+	Converged  bool
+}
+
+// NelderMead minimizes f within the box [lower, upper] starting from x0. Points
+// the simplex moves outside the box are projected back onto it.
+func NelderMead(f func([]float64) float64, x0, lower, upper []float64, opts Options) Result {
+	n := len(x0)
+	if opts.MaxIterations == 0 {
+		opts.MaxIterations = 200 * max(n, 1)
+	}
+	if opts.Tolerance == 0 {
+		opts.Tolerance = 1e-8
+	}
+	if opts.InitialStep == 0 {
+		opts.InitialStep = 0.1
+	}
+	clamp := func(x []float64) []float64 {
+		for i := range x {
+			x[i] = math.Min(math.Max(x[i], lower[i]), upper[i])
+		}
+		return x
+	}
+	if n == 0 {
+		return Result{X: []float64{}, Value: f(nil), Converged: true}
+	}
+
+	type vertex struct {
+		x []float64
+		v float64
+	}
+	eval := func(x []float64) vertex { return vertex{x, f(x)} }
+
+	// Initial simplex: x0 plus a step along each axis, stepping down when up would leave the box
+	simplex := make([]vertex, n+1)
+	simplex[0] = eval(clamp(append([]float64(nil), x0...)))
+	for i := 0; i < n; i++ {
+		x := append([]float64(nil), simplex[0].x...)
+		step := opts.InitialStep * (upper[i] - lower[i])
+		if step == 0 {
+			step = opts.InitialStep * math.Max(math.Abs(x[i]), 1)
+		}
+		if x[i]+step > upper[i] {
+			step = -step
+		}
+		x[i] += step
+		simplex[i+1] = eval(clamp(x))
+	}
+
+	const (
+		reflection  = 1.0
+		expansion   = 2.0
+		contraction = 0.5
+		shrink      = 0.5
+	)
+	along := func(centroid, x []float64, t float64) []float64 {
+		y := make([]float64, n)
+		for i := range y {
+			y[i] = centroid[i] + t*(x[i]-centroid[i])
+		}
+		return clamp(y)
+	}
+
+	result := Result{}
+	for result.Iterations = 0; result.Iterations < opts.MaxIterations; result.Iterations++ {
+		sort.SliceStable(simplex, func(i, j int) bool { return simplex[i].v < simplex[j].v })
+		best, worst := simplex[0], simplex[n]
+		if math.Abs(worst.v-best.v) <= opts.Tolerance*(math.Abs(best.v)+opts.Tolerance) {
+			result.Converged = true
+			break
+		}
+
+		centroid := make([]float64, n)
+		for _, vx := range simplex[:n] {
+			for i := range centroid {
+				centroid[i] += vx.x[i] / float64(n)
+			}
+		}
+
+		r := eval(along(centroid, worst.x, -reflection))
+		switch {
+		case r.v < best.v:
+			if e := eval(along(centroid, worst.x, -expansion)); e.v < r.v {
+				simplex[n] = e
+			} else {
+				simplex[n] = r
+			}
+		case r.v < simplex[n-1].v:
+			simplex[n] = r
+		default:
+			// Contract toward the better of the worst point and its reflection,
+			// shrinking toward the best point if that fails
+			if r.v < worst.v {
+				if c := eval(along(centroid, worst.x, -contraction)); c.v <= r.v {
+					simplex[n] = c
+					continue
+				}
+			} else if c := eval(along(centroid, worst.x, contraction)); c.v < worst.v {
+				simplex[n] = c
+				continue
+			}
+			for i := 1; i <= n; i++ {
+				simplex[i] = eval(along(best.x, simplex[i].x, shrink))
+			}
+		}
+	}
+
+	sort.SliceStable(simplex, func(i, j int) bool { return simplex[i].v < simplex[j].v })
+	result.X, result.Value = simplex[0].x, simplex[0].v
+	return result
+}
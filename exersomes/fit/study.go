@@ -0,0 +1,206 @@
+package fit
+
+import (
+	"errors"
+	"exersomes/params"
+	"exersomes/study"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// Split divides a study by subject into a training part and a held-out
+// validation part holding about the given fraction of subjects
+func Split(st study.Study, holdout float64, seed int64) (train, validation study.Study) {
+	ids := make([]string, len(st.Subjects))
+	for i, s := range st.Subjects {
+		ids[i] = s.ID
+	}
+	rand.New(rand.NewSource(seed)).Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+
+	held := int(math.Round(holdout * float64(len(ids))))
+	if holdout > 0 && held == 0 && len(ids) > 1 {
+		held = 1
+	}
+	if held >= len(ids) {
+		held = len(ids) - 1
+	}
+	validationIDs := make(map[string]bool, held)
+	for _, id := range ids[:max(held, 0)] {
+		validationIDs[id] = true
+	}
+
+	for _, s := range st.Subjects {
+		if validationIDs[s.ID] {
+			validation.Subjects = append(validation.Subjects, s)
+		} else {
+			train.Subjects = append(train.Subjects, s)
+		}
+	}
+	for _, s := range st.Sessions {
+		if validationIDs[s.Subject] {
+			validation.Sessions = append(validation.Sessions, s)
+		} else {
+			train.Sessions = append(train.Sessions, s)
+		}
+	}
+	for _, o := range st.Observations {
+		if validationIDs[o.Subject] {
+			validation.Observations = append(validation.Observations, o)
+		} else {
+			train.Observations = append(train.Observations, o)
+		}
+	}
+	return train, validation
+}
+
+// Score is the mean over analytes of the squared RMSE relative to the mean
+// observed value, so analytes in pg/mL and cells/μL weigh alike. It is NaN when
+// no observation matches a predictor.
+func Score(cmp study.Comparison) float64 {
+	type sums struct{ sq, abs, n float64 }
+	byAnalyte := make(map[string]*sums)
+	for _, r := range cmp.Residuals {
+		key := r.Analyte + "\x00" + r.Tissue
+		s, ok := byAnalyte[key]
+		if !ok {
+			s = &sums{}
+			byAnalyte[key] = s
+		}
+		s.sq += r.Residual * r.Residual
+		s.abs += math.Abs(r.Observed)
+		s.n++
+	}
+	if len(byAnalyte) == 0 {
+		return math.NaN()
+	}
+	total := 0.0
+	for _, s := range byAnalyte {
+		relative := math.Sqrt(s.sq / s.n)
+		if s.abs > 0 {
+			relative /= s.abs / s.n
+		}
+		total += relative * relative
+	}
+	return total / float64(len(byAnalyte))
+}
+
+// StudyOptions configure fitting parameter sets to study data
+type StudyOptions struct {
+	Options
+	Holdout float64 // Fraction of subjects held out for validation
+	Seed    int64   // Seeds the holdout split
+}
+
+// FittedParameter is a free parameter's value before and after fitting
+type FittedParameter struct {
+	Set     string
+	Name    string
+	Initial float64
+	Fitted  float64
+	Min     float64
+	Max     float64
+}
+
+// Report describes a fit
+type Report struct {
+	Parameters             []FittedParameter
+	InitialTrainScore      float64
+	TrainScore             float64
+	InitialValidationScore float64 // NaN without held-out subjects
+	ValidationScore        float64
+	TrainSubjects          int
+	ValidationSubjects     int
+	Train                  study.Comparison // At the fitted values
+	Validation             study.Comparison
+	Iterations             int
+	Converged              bool
+}
+
+// Study fits the free parameters of sets to a study by minimizing Score on the
+// training subjects, then scores the held-out subjects. The sets are left at
+// the fitted values.
+func Study(st study.Study, sets []*params.Set, opts StudyOptions) (Report, error) {
+	train, validation := Split(st, opts.Holdout, opts.Seed)
+	predictors := study.Predictors()
+
+	var (
+		free         []FittedParameter
+		owners       []*params.Set
+		x0           []float64
+		lower, upper []float64
+	)
+	for _, s := range sets {
+		for _, p := range s.Parameters() {
+			if p.Fixed {
+				continue
+			}
+			free = append(free, FittedParameter{Set: s.Name, Name: p.Name, Initial: p.Value, Min: p.Min, Max: p.Max})
+			owners = append(owners, s)
+			x0 = append(x0, p.Value)
+			lower = append(lower, p.Min)
+			upper = append(upper, p.Max)
+		}
+	}
+	if len(free) == 0 {
+		return Report{}, errors.New("no free parameters to fit")
+	}
+	apply := func(x []float64) {
+		for i, p := range free {
+			owners[i].Set(p.Name, x[i])
+		}
+	}
+
+	report := Report{
+		Parameters:         free,
+		TrainSubjects:      len(train.Subjects),
+		ValidationSubjects: len(validation.Subjects),
+	}
+	report.InitialTrainScore = Score(study.Compare(train, predictors))
+	if math.IsNaN(report.InitialTrainScore) {
+		return report, errors.New("no training observations match a predictor")
+	}
+	report.InitialValidationScore = Score(study.Compare(validation, predictors))
+
+	result := NelderMead(func(x []float64) float64 {
+		apply(x)
+		return Score(study.Compare(train, predictors))
+	}, x0, lower, upper, opts.Options)
+	apply(result.X)
+
+	for i := range report.Parameters {
+		report.Parameters[i].Fitted = result.X[i]
+	}
+	report.Train = study.Compare(train, predictors)
+	report.Validation = study.Compare(validation, predictors)
+	report.TrainScore = Score(report.Train)
+	report.ValidationScore = Score(report.Validation)
+	report.Iterations, report.Converged = result.Iterations, result.Converged
+	return report, nil
+}
+
+// File returns a parameter file holding the fitted sets and how they were fitted
+func (r Report) File(source string, sets []*params.Set) params.File {
+	f := params.Snapshot(sets...)
+	f.Fitted = map[string]string{
+		"study":                    source,
+		"objective":                "mean squared relative RMSE per analyte",
+		"train_subjects":           strconv.Itoa(r.TrainSubjects),
+		"validation_subjects":      strconv.Itoa(r.ValidationSubjects),
+		"initial_train_score":      formatScore(r.InitialTrainScore),
+		"train_score":              formatScore(r.TrainScore),
+		"initial_validation_score": formatScore(r.InitialValidationScore),
+		"validation_score":         formatScore(r.ValidationScore),
+		"iterations":               strconv.Itoa(r.Iterations),
+		"converged":                strconv.FormatBool(r.Converged),
+	}
+	return f
+}
+
+func formatScore(v float64) string {
+	if math.IsNaN(v) {
+		return "NA"
+	}
+	return fmt.Sprintf("%.6g", v)
+}
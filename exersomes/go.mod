@@ -1,3 +1,7 @@
 module exersomes
 
 go 1.23.2
+
+require go.etcd.io/bbolt v1.3.11
+
+require golang.org/x/sys v0.4.0 // indirect
@@ -0,0 +1,215 @@
+package graph
+
+// Communities detects communities with the Louvain method on the weighted undirected
+// graph, followed by a Leiden-style refinement that splits any community whose
+// members are not connected inside it. Nodes are visited in index order so the
+// result is deterministic. Returned labels are 0..k-1 in order of first appearance.
+func (g *Graph) Communities() []int {
+	n := g.Len()
+	if n == 0 {
+		return nil
+	}
+	adj := g.undirected()
+
+	// membership[v] is the community of original node v
+	membership := make([]int, n)
+	for i := range membership {
+		membership[i] = i
+	}
+
+	level := adj
+	nodeOf := make([][]int, n) // original nodes inside each level node
+	for i := range nodeOf {
+		nodeOf[i] = []int{i}
+	}
+
+	for {
+		assign, moved := louvainLocalMoving(level)
+		if !moved {
+			break
+		}
+
+		// Relabel communities densely and record original memberships
+		labels := relabel(assign)
+		count := 0
+		for _, l := range labels {
+			if l+1 > count {
+				count = l + 1
+			}
+		}
+		nextNodeOf := make([][]int, count)
+		for v, l := range labels {
+			nextNodeOf[l] = append(nextNodeOf[l], nodeOf[v]...)
+			for _, orig := range nodeOf[v] {
+				membership[orig] = l
+			}
+		}
+
+		// Aggregate: one node per community, edge weights summed
+		agg := make([]map[int]float64, count)
+		for i := range agg {
+			agg[i] = make(map[int]float64)
+		}
+		for v, edges := range level {
+			for _, e := range edges {
+				agg[labels[v]][labels[e.to]] += e.weight
+			}
+		}
+		nextLevel := make([][]edge, count)
+		for c := 0; c < count; c++ {
+			for d := 0; d < count; d++ {
+				if w, ok := agg[c][d]; ok {
+					nextLevel[c] = append(nextLevel[c], edge{to: d, weight: w})
+				}
+			}
+		}
+
+		if count == len(level) {
+			break
+		}
+		level = nextLevel
+		nodeOf = nextNodeOf
+	}
+
+	return relabel(splitDisconnected(adj, membership))
+}
+
+// louvainLocalMoving moves each node to the neighboring community with the best
+// modularity gain until no move improves modularity
+func louvainLocalMoving(adj [][]edge) ([]int, bool) {
+	n := len(adj)
+	community := make([]int, n)
+	degree := make([]float64, n)
+	communityDegree := make([]float64, n)
+	totalWeight := 0.0
+
+	for v, edges := range adj {
+		community[v] = v
+		for _, e := range edges {
+			degree[v] += e.weight
+		}
+		communityDegree[v] = degree[v]
+		totalWeight += degree[v]
+	}
+	if totalWeight == 0 {
+		return community, false
+	}
+
+	movedAny := false
+	for improved := true; improved; {
+		improved = false
+		for v := 0; v < n; v++ {
+			current := community[v]
+
+			// Weight from v to each neighboring community, excluding self-loops
+			links := make(map[int]float64)
+			var order []int
+			for _, e := range adj[v] {
+				if e.to == v {
+					continue
+				}
+				c := community[e.to]
+				if _, ok := links[c]; !ok {
+					order = append(order, c)
+				}
+				links[c] += e.weight
+			}
+
+			communityDegree[current] -= degree[v]
+			best := current
+			bestGain := links[current] - communityDegree[current]*degree[v]/totalWeight
+			for _, c := range order {
+				gain := links[c] - communityDegree[c]*degree[v]/totalWeight
+				if gain > bestGain+1e-12 {
+					best = c
+					bestGain = gain
+				}
+			}
+			communityDegree[best] += degree[v]
+
+			if best != current {
+				community[v] = best
+				improved = true
+				movedAny = true
+			}
+		}
+	}
+
+	return community, movedAny
+}
+
+// splitDisconnected gives each connected piece of a community its own label
+func splitDisconnected(adj [][]edge, membership []int) []int {
+	n := len(adj)
+	result := make([]int, n)
+	for i := range result {
+		result[i] = -1
+	}
+
+	next := 0
+	for start := 0; start < n; start++ {
+		if result[start] >= 0 {
+			continue
+		}
+		result[start] = next
+		stack := []int{start}
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, e := range adj[v] {
+				if result[e.to] < 0 && membership[e.to] == membership[start] {
+					result[e.to] = next
+					stack = append(stack, e.to)
+				}
+			}
+		}
+		next++
+	}
+	return result
+}
+
+// relabel maps labels to 0..k-1 in order of first appearance
+func relabel(labels []int) []int {
+	mapping := make(map[int]int)
+	out := make([]int, len(labels))
+	for i, l := range labels {
+		if _, ok := mapping[l]; !ok {
+			mapping[l] = len(mapping)
+		}
+		out[i] = mapping[l]
+	}
+	return out
+}
+
+// Modularity returns Newman's weighted modularity of a community assignment
+func (g *Graph) Modularity(communities []int) float64 {
+	adj := g.undirected()
+	degree := make([]float64, len(adj))
+	total := 0.0
+	for v, edges := range adj {
+		for _, e := range edges {
+			degree[v] += e.weight
+		}
+		total += degree[v]
+	}
+	if total == 0 {
+		return 0
+	}
+
+	inside := make(map[int]float64)
+	degreeSum := make(map[int]float64)
+	for v, edges := range adj {
+		degreeSum[communities[v]] += degree[v]
+		for _, e := range edges {
+			if communities[v] == communities[e.to] {
+				inside[communities[v]] += e.weight
+			}
+		}
+	}
+
+	q := 0.0
+	for c, d := range degreeSum {
+		q += inside[c]/total - (d/total)*(d/total)
+	}
+	return q
+}
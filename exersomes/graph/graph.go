@@ -0,0 +1,229 @@
+package graph
+
+import (
+	"exersomes/molecular_types"
+	"sort"
+)
+
+// NodeKind distinguishes the three layers of the exerkine communication graph
+type NodeKind string
+
+const (
+	KindTissue   NodeKind = "Tissue"
+	KindLigand   NodeKind = "Ligand"
+	KindReceptor NodeKind = "Receptor"
+)
+
+// Node is a tissue, a ligand, or a receptor in a specific tissue
+type Node struct {
+	ID     string
+	Name   string
+	Kind   NodeKind
+	Tissue string // Expressing tissue for receptors, empty otherwise
+}
+
+type edge struct {
+	to     int
+	weight float64
+}
+
+// Graph is a directed, weighted view of an ExerkineNetwork:
+// source tissue -> ligand -> receptor -> target tissue
+type Graph struct {
+	Nodes []Node
+	index map[string]int
+	out   [][]edge
+	in    [][]edge
+}
+
+// New returns an empty graph
+func New() *Graph {
+	return &Graph{index: make(map[string]int)}
+}
+
+// FromNetwork builds the tissue/ligand/receptor graph from network interactions.
+// Ligand-to-receptor edges carry the interaction Strength (1.0 when unset); the
+// secretion and expression edges carry weight 1.0.
+func FromNetwork(network molecular_types.ExerkineNetwork) *Graph {
+	g := New()
+
+	for _, node := range network.Nodes {
+		g.AddNode(Node{ID: LigandID(node.Name), Name: node.Name, Kind: KindLigand})
+	}
+
+	for _, in := range network.Interactions {
+		ligand := g.AddNode(Node{ID: LigandID(in.Ligand), Name: in.Ligand, Kind: KindLigand})
+
+		target := in.TargetTissue
+		if target == "" {
+			target = in.Tissue
+		}
+		receptor := g.AddNode(Node{
+			ID:     ReceptorID(in.Receptor, target),
+			Name:   in.Receptor,
+			Kind:   KindReceptor,
+			Tissue: target,
+		})
+
+		weight := in.Strength
+		if weight <= 0 {
+			weight = 1.0
+		}
+		g.AddEdge(ligand, receptor, weight)
+
+		if in.SourceTissue != "" {
+			source := g.AddNode(Node{ID: TissueID(in.SourceTissue), Name: in.SourceTissue, Kind: KindTissue})
+			g.AddEdge(source, ligand, 1.0)
+		}
+		if target != "" {
+			t := g.AddNode(Node{ID: TissueID(target), Name: target, Kind: KindTissue})
+			g.AddEdge(receptor, t, 1.0)
+		}
+	}
+
+	return g
+}
+
+// TissueID returns the node ID for a tissue
+func TissueID(name string) string {
+	return "tissue:" + name
+}
+
+// LigandID returns the node ID for a ligand
+func LigandID(name string) string {
+	return "ligand:" + name
+}
+
+// ReceptorID returns the node ID for a receptor expressed in a tissue
+func ReceptorID(name string, tissue string) string {
+	return "receptor:" + name + "@" + tissue
+}
+
+// AddNode adds a node if its ID is new and returns its index
+func (g *Graph) AddNode(n Node) int {
+	if i, ok := g.index[n.ID]; ok {
+		return i
+	}
+	g.index[n.ID] = len(g.Nodes)
+	g.Nodes = append(g.Nodes, n)
+	g.out = append(g.out, nil)
+	g.in = append(g.in, nil)
+	return len(g.Nodes) - 1
+}
+
+// AddEdge adds a directed edge, keeping the larger weight if it already exists
+func (g *Graph) AddEdge(from, to int, weight float64) {
+	for i, e := range g.out[from] {
+		if e.to == to {
+			if weight > e.weight {
+				g.out[from][i].weight = weight
+				for j, r := range g.in[to] {
+					if r.to == from {
+						g.in[to][j].weight = weight
+					}
+				}
+			}
+			return
+		}
+	}
+	g.out[from] = append(g.out[from], edge{to: to, weight: weight})
+	g.in[to] = append(g.in[to], edge{to: from, weight: weight})
+}
+
+// Reverse returns a copy of the graph with every edge direction flipped, so that
+// directed measures such as PageRank score influence rather than reachability
+func (g *Graph) Reverse() *Graph {
+	r := New()
+	for _, n := range g.Nodes {
+		r.AddNode(n)
+	}
+	for from, edges := range g.out {
+		for _, e := range edges {
+			r.AddEdge(e.to, from, e.weight)
+		}
+	}
+	return r
+}
+
+// Len returns the number of nodes
+func (g *Graph) Len() int {
+	return len(g.Nodes)
+}
+
+// Edge is a directed edge between node indices
+type Edge struct {
+	From   int
+	To     int
+	Weight float64
+}
+
+// Edges returns every directed edge, ordered by source node
+func (g *Graph) Edges() []Edge {
+	var edges []Edge
+	for from, out := range g.out {
+		for _, e := range out {
+			edges = append(edges, Edge{From: from, To: e.to, Weight: e.weight})
+		}
+	}
+	return edges
+}
+
+// Lookup returns the index of a node ID
+func (g *Graph) Lookup(id string) (int, bool) {
+	i, ok := g.index[id]
+	return i, ok
+}
+
+// undirected returns symmetric adjacency with summed weights for reciprocal edges
+func (g *Graph) undirected() [][]edge {
+	adj := make([][]edge, len(g.Nodes))
+	weights := make([]map[int]float64, len(g.Nodes))
+	for i := range weights {
+		weights[i] = make(map[int]float64)
+	}
+	for from, edges := range g.out {
+		for _, e := range edges {
+			if from == e.to {
+				continue
+			}
+			weights[from][e.to] += e.weight
+			weights[e.to][from] += e.weight
+		}
+	}
+	for i, neighbors := range weights {
+		keys := make([]int, 0, len(neighbors))
+		for j := range neighbors {
+			keys = append(keys, j)
+		}
+		sort.Ints(keys)
+		for _, j := range keys {
+			adj[i] = append(adj[i], edge{to: j, weight: neighbors[j]})
+		}
+	}
+	return adj
+}
+
+// Ranking is a node with a score, ordered highest first by Rank
+type Ranking struct {
+	Node  Node
+	Score float64
+}
+
+// Rank orders scores for nodes of the given kind, highest first.
+// An empty kind ranks every node.
+func (g *Graph) Rank(scores []float64, kind NodeKind) []Ranking {
+	var rankings []Ranking
+	for i, n := range g.Nodes {
+		if kind != "" && n.Kind != kind {
+			continue
+		}
+		rankings = append(rankings, Ranking{Node: n, Score: scores[i]})
+	}
+	sort.SliceStable(rankings, func(a, b int) bool {
+		if rankings[a].Score != rankings[b].Score {
+			return rankings[a].Score > rankings[b].Score
+		}
+		return rankings[a].Node.Name < rankings[b].Node.Name
+	})
+	return rankings
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"exersomes/molecular_types"
+	"math"
+	"testing"
+)
+
+// testNetwork is a small muscle/liver/adipose crosstalk network where IL-6 is the hub
+func testNetwork() molecular_types.ExerkineNetwork {
+	edge := func(ligand, receptor, source, target string) molecular_types.Interaction {
+		return molecular_types.Interaction{
+			Ligand: ligand, Receptor: receptor, Tissue: target,
+			SourceTissue: source, TargetTissue: target, Strength: 1.0,
+		}
+	}
+	return molecular_types.ExerkineNetwork{
+		Nodes: []molecular_types.Exerkine{{Name: "IL-6"}, {Name: "FGF21"}, {Name: "Irisin"}},
+		Interactions: []molecular_types.Interaction{
+			edge("IL-6", "IL6R", "Muscle", "Liver"),
+			edge("IL-6", "IL6R", "Muscle", "Adipose"),
+			edge("IL-6", "IL6R", "Muscle", "Pancreas"),
+			edge("FGF21", "FGFR1/KLB", "Liver", "Adipose"),
+			edge("Irisin", "Integrin αV", "Muscle", "Bone"),
+		},
+	}
+}
+
+// Test that the hub ligand ranks first under every method
+func TestKeyRegulatorsFindsHub(t *testing.T) {
+	for _, method := range Methods {
+		rankings, err := KeyRegulators(testNetwork(), method, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if len(rankings) != 3 {
+			t.Fatalf("%s: expected 3 ranked ligands, got %d", method, len(rankings))
+		}
+		if rankings[0].Node.Name != "IL-6" {
+			t.Errorf("%s: expected IL-6 first, got %s", method, rankings[0].Node.Name)
+		}
+	}
+
+	if _, err := KeyRegulators(testNetwork(), "unknown", nil); err == nil {
+		t.Errorf("Expected error for unknown method")
+	}
+}
+
+// Test that relevance weighting can reorder ligands
+func TestKeyRegulatorsRelevance(t *testing.T) {
+	relevance := map[string]float64{"FGF21": 50.0}
+	rankings, _ := KeyRegulators(testNetwork(), MethodPageRank, relevance)
+	if rankings[0].Node.Name != "FGF21" {
+		t.Errorf("Expected FGF21 first when heavily weighted, got %s", rankings[0].Node.Name)
+	}
+}
+
+// Test tissue-to-tissue signalling paths
+func TestTissuePath(t *testing.T) {
+	g := FromNetwork(testNetwork())
+
+	path := g.TissuePath("Muscle", "Adipose")
+	if len(path) != 4 {
+		t.Fatalf("Expected Muscle -> ligand -> receptor -> Adipose, got %d nodes", len(path))
+	}
+	if path[1].Name != "IL-6" {
+		t.Errorf("Expected direct IL-6 path, got %s", path[1].Name)
+	}
+
+	if g.TissuePath("Bone", "Muscle") != nil {
+		t.Errorf("Expected no path from Bone to Muscle")
+	}
+}
+
+// Test articulation points and communities
+func TestStructure(t *testing.T) {
+	g := FromNetwork(testNetwork())
+
+	cut := make(map[string]bool)
+	for _, n := range g.ArticulationPoints() {
+		cut[n.ID] = true
+	}
+	if !cut[TissueID("Muscle")] || !cut[LigandID("IL-6")] {
+		t.Errorf("Expected Muscle and IL-6 to be articulation points, got %v", cut)
+	}
+	if cut[TissueID("Bone")] {
+		t.Errorf("Leaf tissue Bone should not be an articulation point")
+	}
+
+	communities := g.Communities()
+	if len(communities) != g.Len() {
+		t.Fatalf("Expected one label per node")
+	}
+	if q := g.Modularity(communities); q <= 0 {
+		t.Errorf("Expected positive modularity, got %.3f", q)
+	}
+}
+
+// Test PageRank sums to one
+func TestPageRankNormalized(t *testing.T) {
+	g := FromNetwork(testNetwork())
+	total := 0.0
+	for _, v := range g.PageRank(0.85, nil) {
+		total += v
+	}
+	if math.Abs(total-1.0) > 1e-6 {
+		t.Errorf("Expected PageRank to sum to 1, got %.8f", total)
+	}
+}
@@ -0,0 +1,125 @@
+package graph
+
+import "sort"
+
+// ShortestPath returns the node sequence of the fewest-hop directed signalling path
+// from one node ID to another, or nil if the target is unreachable. Ties are broken
+// by edge weight (stronger first) and then node order, so results are deterministic.
+func (g *Graph) ShortestPath(fromID, toID string) []Node {
+	from, ok := g.index[fromID]
+	if !ok {
+		return nil
+	}
+	to, ok := g.index[toID]
+	if !ok {
+		return nil
+	}
+
+	prev := make([]int, g.Len())
+	for i := range prev {
+		prev[i] = -1
+	}
+	visited := make([]bool, g.Len())
+	visited[from] = true
+
+	queue := []int{from}
+	for len(queue) > 0 && !visited[to] {
+		v := queue[0]
+		queue = queue[1:]
+
+		edges := append([]edge(nil), g.out[v]...)
+		sort.SliceStable(edges, func(a, b int) bool {
+			if edges[a].weight != edges[b].weight {
+				return edges[a].weight > edges[b].weight
+			}
+			return edges[a].to < edges[b].to
+		})
+
+		for _, e := range edges {
+			if !visited[e.to] {
+				visited[e.to] = true
+				prev[e.to] = v
+				queue = append(queue, e.to)
+			}
+		}
+	}
+
+	if !visited[to] {
+		return nil
+	}
+
+	var path []Node
+	for v := to; v >= 0; v = prev[v] {
+		path = append([]Node{g.Nodes[v]}, path...)
+		if v == from {
+			break
+		}
+	}
+	return path
+}
+
+// TissuePath returns the shortest signalling path from one tissue to another,
+// e.g. Muscle -> IL-6 -> IL-6 Receptor@Liver -> Liver
+func (g *Graph) TissuePath(sourceTissue, targetTissue string) []Node {
+	return g.ShortestPath(TissueID(sourceTissue), TissueID(targetTissue))
+}
+
+// ArticulationPoints returns nodes whose removal disconnects the undirected graph,
+// in node order (Tarjan's low-link algorithm)
+func (g *Graph) ArticulationPoints() []Node {
+	n := g.Len()
+	adj := g.undirected()
+
+	disc := make([]int, n)
+	low := make([]int, n)
+	parent := make([]int, n)
+	isCut := make([]bool, n)
+	for i := range disc {
+		disc[i] = -1
+		parent[i] = -1
+	}
+
+	timer := 0
+	var visit func(v int)
+	visit = func(v int) {
+		disc[v] = timer
+		low[v] = timer
+		timer++
+		children := 0
+
+		for _, e := range adj[v] {
+			w := e.to
+			if disc[w] < 0 {
+				children++
+				parent[w] = v
+				visit(w)
+				if low[w] < low[v] {
+					low[v] = low[w]
+				}
+				if parent[v] >= 0 && low[w] >= disc[v] {
+					isCut[v] = true
+				}
+			} else if w != parent[v] && disc[w] < low[v] {
+				low[v] = disc[w]
+			}
+		}
+
+		if parent[v] < 0 && children > 1 {
+			isCut[v] = true
+		}
+	}
+
+	for v := 0; v < n; v++ {
+		if disc[v] < 0 {
+			visit(v)
+		}
+	}
+
+	var points []Node
+	for v, cut := range isCut {
+		if cut {
+			points = append(points, g.Nodes[v])
+		}
+	}
+	return points
+}
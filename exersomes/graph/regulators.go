@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"exersomes/molecular_types"
+	"fmt"
+)
+
+// Ranking methods accepted by KeyRegulators
+const (
+	MethodDegree      = "degree"
+	MethodBetweenness = "betweenness"
+	MethodEigenvector = "eigenvector"
+	MethodPageRank    = "pagerank"
+	MethodComposite   = "composite" // Mean of the four normalized centralities
+)
+
+// Methods lists the supported ranking methods
+var Methods = []string{MethodComposite, MethodDegree, MethodBetweenness, MethodEigenvector, MethodPageRank}
+
+// KeyRegulators ranks the ligands of a network by importance. PageRank runs on the
+// reversed graph so a ligand scores by the tissues it signals to. Relevance optionally
+// weights ligands by name (e.g. by predicted response to an exercise type): it
+// personalizes PageRank and scales every other method. Missing names weigh 1.0.
+func KeyRegulators(network molecular_types.ExerkineNetwork, method string,
+	relevance map[string]float64) ([]Ranking, error) {
+
+	g := FromNetwork(network)
+	influence := g.Reverse()
+
+	weight := func(n Node) float64 {
+		if relevance == nil {
+			return 1.0
+		}
+		if w, ok := relevance[n.Name]; ok {
+			return w
+		}
+		return 1.0
+	}
+
+	var personalization map[string]float64
+	if relevance != nil {
+		personalization = make(map[string]float64)
+		for _, n := range g.Nodes {
+			if n.Kind == KindLigand {
+				personalization[n.ID] = weight(n)
+			}
+		}
+	}
+
+	var scores []float64
+	switch method {
+	case MethodDegree:
+		scores = g.DegreeCentrality()
+	case MethodBetweenness:
+		scores = g.BetweennessCentrality()
+	case MethodEigenvector:
+		scores = g.EigenvectorCentrality(500, 1e-9)
+	case MethodPageRank:
+		scores = influence.PageRank(0.85, personalization)
+	case MethodComposite, "":
+		parts := [][]float64{
+			g.DegreeCentrality(),
+			g.BetweennessCentrality(),
+			g.EigenvectorCentrality(500, 1e-9),
+			influence.PageRank(0.85, personalization),
+		}
+		scores = make([]float64, g.Len())
+		for _, part := range parts {
+			for i, v := range normalizeScores(part) {
+				scores[i] += v / float64(len(parts))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown ranking method %q", method)
+	}
+
+	if method != MethodPageRank {
+		for i, n := range g.Nodes {
+			scores[i] *= weight(n)
+		}
+	}
+
+	return g.Rank(scores, KindLigand), nil
+}
+
+// normalizeScores rescales scores to 0-1 by their maximum
+func normalizeScores(scores []float64) []float64 {
+	max := 0.0
+	for _, s := range scores {
+		if s > max {
+			max = s
+		}
+	}
+	out := make([]float64, len(scores))
+	if max == 0 {
+		return out
+	}
+	for i, s := range scores {
+		out[i] = s / max
+	}
+	return out
+}
@@ -0,0 +1,730 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Error is a GraphQL error as returned in a response
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Params is one request to execute
+type Params struct {
+	Context       context.Context
+	Query         string
+	OperationName string
+	Variables     map[string]any
+}
+
+// Result is a GraphQL response. Data is nil when the request failed before execution.
+type Result struct {
+	Data     *OrderedMap
+	Errors   []*Error
+	executed bool
+}
+
+// MarshalJSON writes {"data": ..., "errors": [...]}, omitting data for requests that
+// never executed and errors when there are none
+func (r *Result) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	if r.executed {
+		b.WriteString(`"data":`)
+		data, err := json.Marshal(r.Data)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(data)
+	}
+	if len(r.Errors) > 0 {
+		if r.executed {
+			b.WriteString(",")
+		}
+		b.WriteString(`"errors":`)
+		errs, err := json.Marshal(r.Errors)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(errs)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// OrderedMap is a response object that keeps fields in selection order
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrderedMap() *OrderedMap {
+	return &OrderedMap{values: make(map[string]any)}
+}
+
+// Set adds or replaces a key
+func (m *OrderedMap) Set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get returns the value of a key
+func (m *OrderedMap) Get(key string) (any, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Keys returns the keys in insertion order
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+// MarshalJSON writes the object with keys in insertion order
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, k := range m.keys {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteString(":")
+		v, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// Execute parses, validates and executes a query
+func (s *Schema) Execute(p Params) *Result {
+	doc, err := parse(p.Query)
+	if err != nil {
+		return &Result{Errors: []*Error{toError(err)}}
+	}
+
+	op, err := selectOperation(doc, p.OperationName)
+	if err != nil {
+		return &Result{Errors: []*Error{toError(err)}}
+	}
+	if op.kind != "query" {
+		return &Result{Errors: []*Error{{Message: fmt.Sprintf("Schema is not configured for %ss.", op.kind),
+			Locations: []Location{op.loc}}}}
+	}
+
+	if errs := s.validate(doc, op); len(errs) > 0 {
+		return &Result{Errors: errs}
+	}
+
+	vars, errs := s.coerceVariables(op, p.Variables)
+	if len(errs) > 0 {
+		return &Result{Errors: errs}
+	}
+
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	e := &executor{schema: s, doc: doc, vars: vars, ctx: ctx}
+	data, ok := e.executeSelectionSet(s.Query, nil, op.selection, nil)
+	if !ok {
+		data = nil
+	}
+	return &Result{Data: data, Errors: e.errors, executed: true}
+}
+
+func toError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Message: err.Error()}
+}
+
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations."}
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q.", name)}
+}
+
+// fieldDef looks up a field, including the introspection meta-fields
+func (s *Schema) fieldDef(obj *Object, name string) (*Field, bool) {
+	switch name {
+	case "__typename":
+		return typenameField, true
+	case "__schema", "__type":
+		if obj == s.Query {
+			if name == "__schema" {
+				return schemaMetaField, true
+			}
+			return typeMetaField, true
+		}
+	}
+	return obj.Field(name)
+}
+
+// namedOf unwraps lists and non-nulls
+func namedOf(t Type) Type {
+	for {
+		switch w := t.(type) {
+		case *List:
+			t = w.OfType
+		case *NonNull:
+			t = w.OfType
+		default:
+			return t
+		}
+	}
+}
+
+// validate checks fields, arguments, fragments and variable usage before execution
+func (s *Schema) validate(doc *document, op *operation) []*Error {
+	var errs []*Error
+	addErr := func(loc Location, format string, args ...any) {
+		errs = append(errs, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}})
+	}
+
+	defined := make(map[string]*typeRef)
+	for _, v := range op.variables {
+		if _, dup := defined[v.name]; dup {
+			addErr(op.loc, "There can be only one variable named \"$%s\".", v.name)
+		}
+		defined[v.name] = v.typ
+		if t, ok := s.inputType(v.typ); !ok {
+			addErr(op.loc, "Unknown type %q.", v.typ.String())
+		} else if _, isObj := namedOf(t).(*Object); isObj {
+			addErr(op.loc, "Variable \"$%s\" cannot be non-input type %q.", v.name, v.typ.String())
+		}
+	}
+
+	used := make(map[string]bool)
+	// checkVars reports undefined variables and whether value references any
+	checkVars := func(value any, loc Location) bool {
+		hasVars := false
+		var walk func(v any)
+		walk = func(v any) {
+			switch v := v.(type) {
+			case variableRef:
+				hasVars = true
+				used[string(v)] = true
+				if _, ok := defined[string(v)]; !ok {
+					addErr(loc, "Variable \"$%s\" is not defined.", string(v))
+				}
+			case []any:
+				for _, item := range v {
+					walk(item)
+				}
+			case map[string]any:
+				for _, item := range v {
+					walk(item)
+				}
+			}
+		}
+		walk(value)
+		return hasVars
+	}
+
+	var visit func(obj *Object, set []selection, fragmentPath map[string]bool)
+	visit = func(obj *Object, set []selection, fragmentPath map[string]bool) {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *field:
+				for _, d := range sel.directives {
+					if d.name != "skip" && d.name != "include" {
+						addErr(sel.loc, "Unknown directive \"@%s\".", d.name)
+					}
+					for _, a := range d.arguments {
+						checkVars(a.value, sel.loc)
+					}
+				}
+				def, ok := s.fieldDef(obj, sel.name)
+				if !ok {
+					addErr(sel.loc, "Cannot query field %q on type %q.", sel.name, obj.Name)
+					continue
+				}
+				for _, a := range sel.arguments {
+					var argDef *Argument
+					for i := range def.Args {
+						if def.Args[i].Name == a.name {
+							argDef = &def.Args[i]
+							break
+						}
+					}
+					if argDef == nil {
+						addErr(sel.loc, "Unknown argument %q on field \"%s.%s\".", a.name, obj.Name, sel.name)
+						checkVars(a.value, sel.loc)
+						continue
+					}
+					// Literal values can be checked now; variables are checked once coerced
+					if !checkVars(a.value, sel.loc) {
+						if _, err := coerceInput(argDef.Type, a.value, nil); err != nil {
+							addErr(sel.loc, "Argument %q has invalid value: %v", a.name, err)
+						}
+					}
+				}
+				for _, da := range def.Args {
+					if _, required := da.Type.(*NonNull); !required || da.DefaultValue != nil {
+						continue
+					}
+					provided := false
+					for _, a := range sel.arguments {
+						if a.name == da.Name {
+							provided = true
+						}
+					}
+					if !provided {
+						addErr(sel.loc, "Field %q argument %q of type %q is required, but it was not provided.",
+							sel.name, da.Name, da.Type.String())
+					}
+				}
+				switch inner := namedOf(def.Type).(type) {
+				case *Object:
+					if len(sel.selection) == 0 {
+						addErr(sel.loc, "Field %q of type %q must have a selection of subfields.", sel.name, def.Type.String())
+					} else {
+						visit(inner, sel.selection, fragmentPath)
+					}
+				default:
+					if len(sel.selection) > 0 {
+						addErr(sel.loc, "Field %q must not have a selection since type %q has no subfields.",
+							sel.name, def.Type.String())
+					}
+				}
+			case *inlineFragment:
+				target := obj
+				if sel.typeCondition != "" {
+					t, ok := s.types[sel.typeCondition]
+					o, isObj := t.(*Object)
+					if !ok || !isObj {
+						addErr(Location{}, "Unknown type %q.", sel.typeCondition)
+						continue
+					}
+					target = o
+				}
+				visit(target, sel.selection, fragmentPath)
+			case *fragmentSpread:
+				f, ok := doc.fragments[sel.name]
+				if !ok {
+					addErr(sel.loc, "Unknown fragment %q.", sel.name)
+					continue
+				}
+				if fragmentPath[sel.name] {
+					addErr(sel.loc, "Cannot spread fragment %q within itself.", sel.name)
+					continue
+				}
+				t, ok := s.types[f.typeCondition]
+				target, isObj := t.(*Object)
+				if !ok || !isObj {
+					addErr(f.loc, "Unknown type %q.", f.typeCondition)
+					continue
+				}
+				next := make(map[string]bool, len(fragmentPath)+1)
+				for k := range fragmentPath {
+					next[k] = true
+				}
+				next[sel.name] = true
+				visit(target, f.selection, next)
+			}
+		}
+	}
+	visit(s.Query, op.selection, map[string]bool{})
+	for _, v := range op.variables {
+		if !used[v.name] {
+			addErr(op.loc, "Variable \"$%s\" is never used.", v.name)
+		}
+	}
+	return errs
+}
+
+// inputType resolves a variable type reference against the schema
+func (s *Schema) inputType(ref *typeRef) (Type, bool) {
+	var t Type
+	if ref.list != nil {
+		inner, ok := s.inputType(ref.list)
+		if !ok {
+			return nil, false
+		}
+		t = &List{OfType: inner}
+	} else {
+		named, ok := s.types[ref.name]
+		if !ok {
+			return nil, false
+		}
+		t = named
+	}
+	if ref.nonNull {
+		t = &NonNull{OfType: t}
+	}
+	return t, true
+}
+
+func (s *Schema) coerceVariables(op *operation, raw map[string]any) (map[string]any, []*Error) {
+	vars := make(map[string]any)
+	var errs []*Error
+	for _, v := range op.variables {
+		t, _ := s.inputType(v.typ)
+		value, provided := raw[v.name]
+		if !provided {
+			if v.hasDefault {
+				coerced, err := coerceInput(t, v.defaultVal, nil)
+				if err != nil {
+					errs = append(errs, &Error{Message: fmt.Sprintf("Variable \"$%s\" has invalid default value: %v", v.name, err)})
+				}
+				vars[v.name] = coerced
+			} else if _, required := t.(*NonNull); required {
+				errs = append(errs, &Error{Message: fmt.Sprintf("Variable \"$%s\" of required type %q was not provided.",
+					v.name, v.typ.String())})
+			}
+			continue
+		}
+		coerced, err := coerceInput(t, value, nil)
+		if err != nil {
+			errs = append(errs, &Error{Message: fmt.Sprintf("Variable \"$%s\" got invalid value: %v", v.name, err)})
+			continue
+		}
+		vars[v.name] = coerced
+	}
+	return vars, errs
+}
+
+// coerceInput converts a literal or JSON variable value to the Go value of an input
+// type. Variable references are replaced from vars when vars is non-nil.
+func coerceInput(t Type, v any, vars map[string]any) (any, error) {
+	if ref, ok := v.(variableRef); ok {
+		value, provided := vars[string(ref)]
+		if !provided || value == nil {
+			if _, required := t.(*NonNull); required {
+				return nil, fmt.Errorf("variable \"$%s\" of non-null type %s must not be null", string(ref), t)
+			}
+			return nil, nil
+		}
+		return value, nil // Already coerced to the variable's declared type
+	}
+
+	switch t := t.(type) {
+	case *NonNull:
+		if v == nil {
+			return nil, fmt.Errorf("expected non-null value of type %s", t)
+		}
+		return coerceInput(t.OfType, v, vars)
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		items, ok := v.([]any)
+		if !ok {
+			item, err := coerceInput(t.OfType, v, vars)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		out := make([]any, len(items))
+		for i, item := range items {
+			c, err := coerceInput(t.OfType, item, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = c
+		}
+		return out, nil
+	case *Enum:
+		var name string
+		switch n := v.(type) {
+		case enumLiteral:
+			name = string(n)
+		case string:
+			name = n // From JSON variables
+		default:
+			return nil, fmt.Errorf("enum %s cannot represent non-enum value: %v", t.Name, v)
+		}
+		ev, ok := t.byName(name)
+		if !ok {
+			return nil, fmt.Errorf("value %q does not exist in %q enum", name, t.Name)
+		}
+		return ev.Value, nil
+	case *Scalar:
+		if _, isEnum := v.(enumLiteral); isEnum {
+			return nil, fmt.Errorf("%s cannot represent an enum value: %v", t.Name, v)
+		}
+		return t.Coerce(v)
+	}
+	return nil, fmt.Errorf("type %s is not an input type", t)
+}
+
+type executor struct {
+	schema *Schema
+	doc    *document
+	vars   map[string]any
+	ctx    context.Context
+	errors []*Error
+}
+
+func (e *executor) fieldError(f *field, path []any, err error) {
+	e.errors = append(e.errors, &Error{
+		Message:   err.Error(),
+		Locations: []Location{f.loc},
+		Path:      append([]any(nil), path...),
+	})
+}
+
+// fieldGroup is every selection sharing one response key
+type fieldGroup struct {
+	key    string
+	fields []*field
+}
+
+// included evaluates @skip and @include
+func (e *executor) included(directives []directive) bool {
+	for _, d := range directives {
+		for _, a := range d.arguments {
+			if a.name != "if" {
+				continue
+			}
+			v, _ := coerceInput(NonNullOf(Boolean), a.value, e.vars)
+			b, _ := v.(bool)
+			if d.name == "skip" && b {
+				return false
+			}
+			if d.name == "include" && !b {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e *executor) collectFields(obj *Object, set []selection, groups []fieldGroup, visited map[string]bool) []fieldGroup {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *field:
+			if !e.included(sel.directives) {
+				continue
+			}
+			key := sel.responseKey()
+			found := false
+			for i := range groups {
+				if groups[i].key == key {
+					groups[i].fields = append(groups[i].fields, sel)
+					found = true
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, fieldGroup{key: key, fields: []*field{sel}})
+			}
+		case *inlineFragment:
+			if !e.included(sel.directives) || (sel.typeCondition != "" && sel.typeCondition != obj.Name) {
+				continue
+			}
+			groups = e.collectFields(obj, sel.selection, groups, visited)
+		case *fragmentSpread:
+			if !e.included(sel.directives) || visited[sel.name] {
+				continue
+			}
+			visited[sel.name] = true
+			f := e.doc.fragments[sel.name]
+			if f.typeCondition != obj.Name {
+				continue
+			}
+			groups = e.collectFields(obj, f.selection, groups, visited)
+		}
+	}
+	return groups
+}
+
+// executeSelectionSet resolves fields in order; ok is false when a non-null field
+// failed, so the null propagates to the parent
+func (e *executor) executeSelectionSet(obj *Object, source any, set []selection, path []any) (*OrderedMap, bool) {
+	result := newOrderedMap()
+	for _, group := range e.collectFields(obj, set, nil, make(map[string]bool)) {
+		value, ok := e.executeField(obj, source, group, append(path, group.key))
+		if !ok {
+			return nil, false
+		}
+		result.Set(group.key, value)
+	}
+	return result, true
+}
+
+func (e *executor) executeField(obj *Object, source any, group fieldGroup, path []any) (any, bool) {
+	f := group.fields[0]
+	def, _ := e.schema.fieldDef(obj, f.name)
+
+	args := make(map[string]any)
+	for _, da := range def.Args {
+		var raw any
+		provided := false
+		for _, a := range f.arguments {
+			if a.name == da.Name {
+				raw, provided = a.value, true
+			}
+		}
+		if ref, isVar := raw.(variableRef); isVar {
+			if _, set := e.vars[string(ref)]; !set {
+				provided = false
+			}
+		}
+		if !provided {
+			if da.DefaultValue != nil {
+				args[da.Name] = da.DefaultValue
+			}
+			continue
+		}
+		value, err := coerceInput(da.Type, raw, e.vars)
+		if err != nil {
+			e.fieldError(f, path, fmt.Errorf("Argument %q has invalid value: %v", da.Name, err))
+			return nil, nullable(def.Type)
+		}
+		args[da.Name] = value
+	}
+
+	var value any
+	var err error
+	switch {
+	case def == typenameField:
+		value = obj.Name
+	case def.Resolve != nil:
+		value, err = def.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args, schema: e.schema})
+	default:
+		value, err = defaultResolve(source, f.name)
+	}
+	if err != nil {
+		e.fieldError(f, path, err)
+		return nil, nullable(def.Type)
+	}
+
+	var subselection []selection
+	for _, gf := range group.fields {
+		subselection = append(subselection, gf.selection...)
+	}
+	return e.completeValue(def.Type, f, subselection, value, path)
+}
+
+func nullable(t Type) bool {
+	_, required := t.(*NonNull)
+	return !required
+}
+
+func (e *executor) completeValue(t Type, f *field, subselection []selection, value any, path []any) (any, bool) {
+	if nn, ok := t.(*NonNull); ok {
+		completed, ok := e.completeValue(nn.OfType, f, subselection, value, path)
+		if !ok {
+			return nil, false
+		}
+		if completed == nil {
+			e.fieldError(f, path, fmt.Errorf("Cannot return null for non-nullable field %s.", f.name))
+			return nil, false
+		}
+		return completed, true
+	}
+
+	if isNil(value) {
+		return nil, true
+	}
+
+	switch t := t.(type) {
+	case *List:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.fieldError(f, path, fmt.Errorf("Expected a list for field %s, got %T.", f.name, value))
+			return nil, true
+		}
+		items := make([]any, rv.Len())
+		for i := range items {
+			item, ok := e.completeValue(t.OfType, f, subselection, rv.Index(i).Interface(), append(path, i))
+			if !ok {
+				return nil, true
+			}
+			items[i] = item
+		}
+		return items, true
+	case *Scalar:
+		out, err := t.Serialize(value)
+		if err != nil {
+			e.fieldError(f, path, err)
+			return nil, true
+		}
+		return out, true
+	case *Enum:
+		for _, ev := range t.Values {
+			if reflect.DeepEqual(ev.Value, value) {
+				return ev.Name, true
+			}
+		}
+		e.fieldError(f, path, fmt.Errorf("Enum %q cannot represent value: %v", t.Name, value))
+		return nil, true
+	case *Object:
+		result, ok := e.executeSelectionSet(t, value, subselection, path)
+		if !ok {
+			return nil, true
+		}
+		return result, true
+	}
+	return nil, true
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// defaultResolve reads a map key or a struct field matching the field name
+func defaultResolve(source any, name string) (any, error) {
+	if m, ok := source.(map[string]any); ok {
+		return m[name], nil
+	}
+	rv := reflect.ValueOf(source)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	fv := rv.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, name) })
+	if !fv.IsValid() || !fv.CanInterface() {
+		return nil, nil
+	}
+	return fv.Interface(), nil
+}
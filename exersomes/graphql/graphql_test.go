@@ -0,0 +1,163 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testTissue struct {
+	Name      string
+	Exerkines []string
+}
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+	regulation := &Enum{Name: "Regulation", Values: []EnumValue{
+		{Name: "UP", Value: "Up"},
+		{Name: "DOWN", Value: "Down"},
+	}}
+	tissue := NewObject("Tissue", "An organ or tissue",
+		&Field{Name: "name", Type: NonNullOf(String)},
+		&Field{Name: "exerkines", Type: NonNullOf(ListOf(NonNullOf(String)))},
+		&Field{Name: "broken", Type: NonNullOf(String), Resolve: func(ResolveParams) (any, error) {
+			return nil, errors.New("boom")
+		}},
+	)
+	tissues := []testTissue{
+		{Name: "Muscle", Exerkines: []string{"IL-6", "Irisin"}},
+		{Name: "Liver", Exerkines: []string{"FGF21"}},
+	}
+	query := NewObject("Query", "",
+		&Field{Name: "tissues", Type: NonNullOf(ListOf(NonNullOf(tissue))),
+			Args: []Argument{{Name: "limit", Type: Int, DefaultValue: 10}},
+			Resolve: func(p ResolveParams) (any, error) {
+				n := min(p.Args["limit"].(int), len(tissues))
+				return tissues[:n], nil
+			}},
+		&Field{Name: "tissue", Type: tissue,
+			Args: []Argument{{Name: "name", Type: NonNullOf(String)}},
+			Resolve: func(p ResolveParams) (any, error) {
+				for _, ts := range tissues {
+					if ts.Name == p.Args["name"] {
+						return ts, nil
+					}
+				}
+				return nil, nil
+			}},
+		&Field{Name: "echo", Type: regulation,
+			Args:    []Argument{{Name: "direction", Type: NonNullOf(regulation)}},
+			Resolve: func(p ResolveParams) (any, error) { return p.Args["direction"], nil }},
+	)
+	s, err := NewSchema(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func run(t *testing.T, s *Schema, query string, vars map[string]any) map[string]any {
+	t.Helper()
+	raw, err := json.Marshal(s.Execute(Params{Query: query, Variables: vars}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	json.Unmarshal(raw, &out)
+	return out
+}
+
+// Test arguments, variables, aliases, fragments and directives
+func TestExecute(t *testing.T) {
+	s := testSchema(t)
+
+	out := run(t, s, `query Q($n: Int, $skip: Boolean!) {
+		first: tissues(limit: $n) { ...names }
+		liver: tissue(name: "Liver") { name exerkines @skip(if: $skip) }
+		echo(direction: DOWN)
+	}
+	fragment names on Tissue { name __typename }`, map[string]any{"n": 1.0, "skip": true})
+	if out["errors"] != nil {
+		t.Fatalf("Unexpected errors: %v", out["errors"])
+	}
+	data := out["data"].(map[string]any)
+	first := data["first"].([]any)
+	if len(first) != 1 || first[0].(map[string]any)["__typename"] != "Tissue" {
+		t.Errorf("Expected one Tissue from limit variable, got %v", first)
+	}
+	if liver := data["liver"].(map[string]any); liver["exerkines"] != nil || liver["name"] != "Liver" {
+		t.Errorf("Expected exerkines skipped, got %v", liver)
+	}
+	if data["echo"] != "DOWN" {
+		t.Errorf("Expected enum round trip, got %v", data["echo"])
+	}
+}
+
+// Test validation errors and null propagation from non-null fields
+func TestErrors(t *testing.T) {
+	s := testSchema(t)
+
+	for _, query := range []string{
+		`{ tissues { name`,
+		`{ tissues { mass } }`,
+		`{ tissue { name } }`,
+		`{ tissues }`,
+		`{ echo(direction: SIDEWAYS) }`,
+		`query($x: Int) { tissues { name } }`,
+	} {
+		out := run(t, s, query, nil)
+		if out["errors"] == nil || out["data"] != nil {
+			t.Errorf("Expected request error for %q, got %v", query, out)
+		}
+	}
+
+	out := run(t, s, `{ tissue(name: "Muscle") { name broken } }`, nil)
+	data := out["data"].(map[string]any)
+	if data["tissue"] != nil {
+		t.Errorf("Expected nullable parent to absorb the non-null failure, got %v", data)
+	}
+	errs := out["errors"].([]any)
+	if len(errs) != 1 || errs[0].(map[string]any)["message"] != "boom" {
+		t.Errorf("Expected one resolver error, got %v", errs)
+	}
+	path := errs[0].(map[string]any)["path"].([]any)
+	if len(path) != 2 || path[1] != "broken" {
+		t.Errorf("Expected error path [tissue broken], got %v", path)
+	}
+}
+
+// Test introspection and the HTTP handler
+func TestIntrospectionAndHandler(t *testing.T) {
+	s := testSchema(t)
+
+	out := run(t, s, `{ __type(name: "Tissue") { kind fields { name type { kind ofType { name } } } } }`, nil)
+	typ := out["data"].(map[string]any)["__type"].(map[string]any)
+	if typ["kind"] != "OBJECT" || len(typ["fields"].([]any)) != 3 {
+		t.Errorf("Unexpected Tissue introspection: %v", typ)
+	}
+	out = run(t, s, `{ __schema { queryType { name } types { name } } }`, nil)
+	schema := out["data"].(map[string]any)["__schema"].(map[string]any)
+	if schema["queryType"].(map[string]any)["name"] != "Query" {
+		t.Errorf("Expected Query root, got %v", schema)
+	}
+
+	if sdl := s.SDL(); !strings.Contains(sdl, "tissues(limit: Int = 10): [Tissue!]!") ||
+		!strings.Contains(sdl, "echo(direction: Regulation!): Regulation") {
+		t.Errorf("Unexpected SDL:\n%s", sdl)
+	}
+
+	h := Handler(s)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/graphql",
+		strings.NewReader(`{"query": "query($d: Regulation!) { echo(direction: $d) }", "variables": {"d": "UP"}}`)))
+	if rec.Code != 200 || !strings.Contains(rec.Body.String(), `"echo":"UP"`) {
+		t.Errorf("Expected POST to execute, got %d %s", rec.Code, rec.Body)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/graphql?query=%7B+nope+%7D", nil))
+	if rec.Code != 400 {
+		t.Errorf("Expected 400 for invalid query, got %d", rec.Code)
+	}
+}
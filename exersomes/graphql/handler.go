@@ -0,0 +1,158 @@
+package graphql
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// maxRequestBytes caps POST bodies
+const maxRequestBytes = 1 << 20
+
+// request is a GraphQL-over-HTTP request body
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Handler serves queries over HTTP: GET with query, variables and operationName
+// parameters, or POST with a JSON body or an application/graphql query
+func Handler(schema *Schema) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+			req.Query = q.Get("query")
+			req.OperationName = q.Get("operationName")
+			if v := q.Get("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					writeResult(w, http.StatusBadRequest, &Result{Errors: []*Error{{Message: "variables must be a JSON object"}}})
+					return
+				}
+			}
+		case http.MethodPost:
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+			if err != nil {
+				writeResult(w, http.StatusRequestEntityTooLarge, &Result{Errors: []*Error{{Message: "request body too large"}}})
+				return
+			}
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediaType == "application/graphql" {
+				req.Query = string(body)
+			} else if err := json.Unmarshal(body, &req); err != nil {
+				writeResult(w, http.StatusBadRequest, &Result{Errors: []*Error{{Message: "invalid JSON body: " + err.Error()}}})
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeResult(w, http.StatusMethodNotAllowed, &Result{Errors: []*Error{{Message: "use GET or POST"}}})
+			return
+		}
+		if req.Query == "" {
+			writeResult(w, http.StatusBadRequest, &Result{Errors: []*Error{{Message: "missing query"}}})
+			return
+		}
+
+		result := schema.Execute(Params{
+			Context:       r.Context(),
+			Query:         req.Query,
+			OperationName: req.OperationName,
+			Variables:     req.Variables,
+		})
+		status := http.StatusOK
+		if !result.executed {
+			status = http.StatusBadRequest
+		}
+		writeResult(w, status, result)
+	})
+}
+
+func writeResult(w http.ResponseWriter, status int, result *Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
+
+// SDLHandler serves the schema definition as text
+func SDLHandler(schema *Schema) http.Handler {
+	sdl := schema.SDL()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, sdl)
+	})
+}
+
+// PlaygroundHandler serves a self-contained query editor posting to endpoint.
+// It loads nothing from the network, so it works offline.
+func PlaygroundHandler(schema *Schema, endpoint, example string) http.Handler {
+	data := struct {
+		Endpoint string
+		Example  string
+		SDL      string
+	}{endpoint, example, schema.SDL()}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		playground.Execute(w, data)
+	})
+}
+
+var playground = template.Must(template.New("playground").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Exersomes GraphQL</title>
+<style>
+  body { margin: 0; font: 14px system-ui, sans-serif; display: grid; grid-template-columns: 1fr 1fr 22rem; height: 100vh; }
+  section { display: flex; flex-direction: column; border-right: 1px solid #ddd; min-height: 0; }
+  header { padding: .5rem; background: #f4f4f4; border-bottom: 1px solid #ddd; display: flex; gap: .5rem; align-items: center; }
+  textarea, pre { flex: 1; margin: 0; padding: .5rem; border: 0; font: 13px ui-monospace, monospace; resize: none; overflow: auto; }
+  #variables { flex: 0 0 8rem; border-top: 1px solid #ddd; }
+  button { padding: .25rem 1rem; }
+</style>
+</head>
+<body>
+<section>
+  <header><strong>Query</strong><button id="run" title="Ctrl+Enter">Run</button></header>
+  <textarea id="query" spellcheck="false">{{.Example}}</textarea>
+  <header><strong>Variables</strong></header>
+  <textarea id="variables" spellcheck="false">{}</textarea>
+</section>
+<section>
+  <header><strong>Result</strong><span id="status"></span></header>
+  <pre id="result"></pre>
+</section>
+<section>
+  <header><strong>Schema</strong></header>
+  <pre>{{.SDL}}</pre>
+</section>
+<script>
+const endpoint = {{.Endpoint}};
+async function run() {
+  const status = document.getElementById("status");
+  const result = document.getElementById("result");
+  let variables = {};
+  try {
+    variables = JSON.parse(document.getElementById("variables").value || "{}");
+  } catch (e) {
+    result.textContent = "Variables are not valid JSON: " + e.message;
+    return;
+  }
+  status.textContent = "running...";
+  const res = await fetch(endpoint, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({ query: document.getElementById("query").value, variables }),
+  });
+  status.textContent = res.status + " " + res.statusText;
+  result.textContent = JSON.stringify(await res.json(), null, 2);
+}
+document.getElementById("run").onclick = run;
+document.addEventListener("keydown", e => { if (e.ctrlKey && e.key === "Enter") run(); });
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,216 @@
+package graphql
+
+import (
+	"errors"
+	"strings"
+)
+
+// Introspection types (__Schema, __Type, ...) so GraphQL tooling can explore the schema
+
+var (
+	typeKindEnum = &Enum{Name: "__TypeKind", Values: []EnumValue{
+		{Name: "SCALAR", Value: "SCALAR"},
+		{Name: "OBJECT", Value: "OBJECT"},
+		{Name: "INTERFACE", Value: "INTERFACE"},
+		{Name: "UNION", Value: "UNION"},
+		{Name: "ENUM", Value: "ENUM"},
+		{Name: "INPUT_OBJECT", Value: "INPUT_OBJECT"},
+		{Name: "LIST", Value: "LIST"},
+		{Name: "NON_NULL", Value: "NON_NULL"},
+	}}
+	directiveLocationEnum = &Enum{Name: "__DirectiveLocation", Values: []EnumValue{
+		{Name: "FIELD", Value: "FIELD"},
+		{Name: "FRAGMENT_SPREAD", Value: "FRAGMENT_SPREAD"},
+		{Name: "INLINE_FRAGMENT", Value: "INLINE_FRAGMENT"},
+	}}
+
+	schemaType      = NewObject("__Schema", "")
+	typeType        = NewObject("__Type", "")
+	fieldType       = NewObject("__Field", "")
+	inputValueType  = NewObject("__InputValue", "")
+	enumValueType   = NewObject("__EnumValue", "")
+	directiveType   = NewObject("__Directive", "")
+	typenameField   = &Field{Name: "__typename", Type: NonNullOf(String)}
+	schemaMetaField = &Field{Name: "__schema", Type: NonNullOf(schemaType),
+		Resolve: func(p ResolveParams) (any, error) { return p.schema, nil }}
+	typeMetaField = &Field{Name: "__type", Type: typeType,
+		Args: []Argument{{Name: "name", Type: NonNullOf(String)}},
+		Resolve: func(p ResolveParams) (any, error) {
+			if t, ok := p.schema.types[p.Args["name"].(string)]; ok {
+				return t, nil
+			}
+			return nil, nil
+		}}
+)
+
+// directiveDef describes a built-in directive for introspection
+type directiveDef struct {
+	name        string
+	description string
+	args        []Argument
+}
+
+var builtinDirectives = []directiveDef{
+	{name: "include", description: "Include this field or fragment only when the argument is true.",
+		args: []Argument{{Name: "if", Type: NonNullOf(Boolean)}}},
+	{name: "skip", description: "Skip this field or fragment when the argument is true.",
+		args: []Argument{{Name: "if", Type: NonNullOf(Boolean)}}},
+}
+
+func init() {
+	str := func(get func(p ResolveParams) string) ResolveFunc {
+		return func(p ResolveParams) (any, error) {
+			s := get(p)
+			if s == "" {
+				return nil, nil
+			}
+			return s, nil
+		}
+	}
+	constant := func(v any) ResolveFunc {
+		return func(ResolveParams) (any, error) { return v, nil }
+	}
+
+	schemaType.AddField(&Field{Name: "description", Type: String, Resolve: constant(nil)})
+	schemaType.AddField(&Field{Name: "types", Type: NonNullOf(ListOf(NonNullOf(typeType))),
+		Resolve: func(p ResolveParams) (any, error) {
+			s := p.Source.(*Schema)
+			var types []Type
+			for _, name := range s.typeNames() {
+				types = append(types, s.types[name])
+			}
+			return types, nil
+		}})
+	schemaType.AddField(&Field{Name: "queryType", Type: NonNullOf(typeType),
+		Resolve: func(p ResolveParams) (any, error) { return p.Source.(*Schema).Query, nil }})
+	schemaType.AddField(&Field{Name: "mutationType", Type: typeType, Resolve: constant(nil)})
+	schemaType.AddField(&Field{Name: "subscriptionType", Type: typeType, Resolve: constant(nil)})
+	schemaType.AddField(&Field{Name: "directives", Type: NonNullOf(ListOf(NonNullOf(directiveType))),
+		Resolve: constant(builtinDirectives)})
+
+	typeType.AddField(&Field{Name: "kind", Type: NonNullOf(typeKindEnum),
+		Resolve: func(p ResolveParams) (any, error) {
+			switch p.Source.(type) {
+			case *Scalar:
+				return "SCALAR", nil
+			case *Enum:
+				return "ENUM", nil
+			case *Object:
+				return "OBJECT", nil
+			case *List:
+				return "LIST", nil
+			case *NonNull:
+				return "NON_NULL", nil
+			}
+			return nil, errors.New("unknown type kind")
+		}})
+	typeType.AddField(&Field{Name: "name", Type: String, Resolve: str(func(p ResolveParams) string {
+		if n, ok := p.Source.(namedType); ok {
+			return n.typeName()
+		}
+		return ""
+	})})
+	typeType.AddField(&Field{Name: "description", Type: String, Resolve: str(func(p ResolveParams) string {
+		if n, ok := p.Source.(namedType); ok {
+			return n.typeDescription()
+		}
+		return ""
+	})})
+	typeType.AddField(&Field{Name: "specifiedByURL", Type: String, Resolve: constant(nil)})
+	typeType.AddField(&Field{Name: "fields", Type: ListOf(NonNullOf(fieldType)),
+		Args: []Argument{{Name: "includeDeprecated", Type: Boolean, DefaultValue: false}},
+		Resolve: func(p ResolveParams) (any, error) {
+			if o, ok := p.Source.(*Object); ok {
+				var fields []*Field
+				for _, f := range o.fields {
+					if !strings.HasPrefix(f.Name, "__") {
+						fields = append(fields, f)
+					}
+				}
+				return fields, nil
+			}
+			return nil, nil
+		}})
+	typeType.AddField(&Field{Name: "interfaces", Type: ListOf(NonNullOf(typeType)),
+		Resolve: func(p ResolveParams) (any, error) {
+			if _, ok := p.Source.(*Object); ok {
+				return []Type{}, nil
+			}
+			return nil, nil
+		}})
+	typeType.AddField(&Field{Name: "possibleTypes", Type: ListOf(NonNullOf(typeType)), Resolve: constant(nil)})
+	typeType.AddField(&Field{Name: "enumValues", Type: ListOf(NonNullOf(enumValueType)),
+		Args: []Argument{{Name: "includeDeprecated", Type: Boolean, DefaultValue: false}},
+		Resolve: func(p ResolveParams) (any, error) {
+			if e, ok := p.Source.(*Enum); ok {
+				return e.Values, nil
+			}
+			return nil, nil
+		}})
+	typeType.AddField(&Field{Name: "inputFields", Type: ListOf(NonNullOf(inputValueType)),
+		Args:    []Argument{{Name: "includeDeprecated", Type: Boolean, DefaultValue: false}},
+		Resolve: constant(nil)})
+	typeType.AddField(&Field{Name: "ofType", Type: typeType,
+		Resolve: func(p ResolveParams) (any, error) {
+			switch t := p.Source.(type) {
+			case *List:
+				return t.OfType, nil
+			case *NonNull:
+				return t.OfType, nil
+			}
+			return nil, nil
+		}})
+
+	fieldType.AddField(&Field{Name: "name", Type: NonNullOf(String),
+		Resolve: func(p ResolveParams) (any, error) { return p.Source.(*Field).Name, nil }})
+	fieldType.AddField(&Field{Name: "description", Type: String,
+		Resolve: str(func(p ResolveParams) string { return p.Source.(*Field).Description })})
+	fieldType.AddField(&Field{Name: "args", Type: NonNullOf(ListOf(NonNullOf(inputValueType))),
+		Args: []Argument{{Name: "includeDeprecated", Type: Boolean, DefaultValue: false}},
+		Resolve: func(p ResolveParams) (any, error) {
+			args := p.Source.(*Field).Args
+			if args == nil {
+				args = []Argument{}
+			}
+			return args, nil
+		}})
+	fieldType.AddField(&Field{Name: "type", Type: NonNullOf(typeType),
+		Resolve: func(p ResolveParams) (any, error) { return p.Source.(*Field).Type, nil }})
+	fieldType.AddField(&Field{Name: "isDeprecated", Type: NonNullOf(Boolean), Resolve: constant(false)})
+	fieldType.AddField(&Field{Name: "deprecationReason", Type: String, Resolve: constant(nil)})
+
+	inputValueType.AddField(&Field{Name: "name", Type: NonNullOf(String),
+		Resolve: func(p ResolveParams) (any, error) { return p.Source.(Argument).Name, nil }})
+	inputValueType.AddField(&Field{Name: "description", Type: String,
+		Resolve: str(func(p ResolveParams) string { return p.Source.(Argument).Description })})
+	inputValueType.AddField(&Field{Name: "type", Type: NonNullOf(typeType),
+		Resolve: func(p ResolveParams) (any, error) { return p.Source.(Argument).Type, nil }})
+	inputValueType.AddField(&Field{Name: "defaultValue", Type: String,
+		Resolve: func(p ResolveParams) (any, error) {
+			a := p.Source.(Argument)
+			if a.DefaultValue == nil {
+				return nil, nil
+			}
+			return literal(a.Type, a.DefaultValue), nil
+		}})
+	inputValueType.AddField(&Field{Name: "isDeprecated", Type: NonNullOf(Boolean), Resolve: constant(false)})
+	inputValueType.AddField(&Field{Name: "deprecationReason", Type: String, Resolve: constant(nil)})
+
+	enumValueType.AddField(&Field{Name: "name", Type: NonNullOf(String),
+		Resolve: func(p ResolveParams) (any, error) { return p.Source.(EnumValue).Name, nil }})
+	enumValueType.AddField(&Field{Name: "description", Type: String,
+		Resolve: str(func(p ResolveParams) string { return p.Source.(EnumValue).Description })})
+	enumValueType.AddField(&Field{Name: "isDeprecated", Type: NonNullOf(Boolean), Resolve: constant(false)})
+	enumValueType.AddField(&Field{Name: "deprecationReason", Type: String, Resolve: constant(nil)})
+
+	directiveType.AddField(&Field{Name: "name", Type: NonNullOf(String),
+		Resolve: func(p ResolveParams) (any, error) { return p.Source.(directiveDef).name, nil }})
+	directiveType.AddField(&Field{Name: "description", Type: String,
+		Resolve: str(func(p ResolveParams) string { return p.Source.(directiveDef).description })})
+	directiveType.AddField(&Field{Name: "locations", Type: NonNullOf(ListOf(NonNullOf(directiveLocationEnum))),
+		Resolve: constant([]string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"})})
+	directiveType.AddField(&Field{Name: "args", Type: NonNullOf(ListOf(NonNullOf(inputValueType))),
+		Args:    []Argument{{Name: "includeDeprecated", Type: Boolean, DefaultValue: false}},
+		Resolve: func(p ResolveParams) (any, error) { return p.Source.(directiveDef).args, nil }})
+	directiveType.AddField(&Field{Name: "isRepeatable", Type: NonNullOf(Boolean), Resolve: constant(false)})
+}
@@ -0,0 +1,722 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Token kinds produced by the lexer
+const (
+	tokEOF = iota
+	tokName
+	tokInt
+	tokFloat
+	tokString
+	tokPunct
+)
+
+type token struct {
+	kind  int
+	text  string
+	value string // Decoded string value for tokString
+	line  int
+	col   int
+}
+
+// Location is a 1-based position in a query document
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// lexer splits a GraphQL document into tokens, skipping whitespace, commas and comments
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func (l *lexer) errorf(format string, args ...any) error {
+	return &Error{Message: "Syntax Error: " + fmt.Sprintf(format, args...),
+		Locations: []Location{{Line: l.line, Column: l.col}}}
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			l.advance(1)
+		} else if c == '#' {
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		} else if strings.HasPrefix(l.src[l.pos:], "\uFEFF") {
+			l.pos += len("\uFEFF")
+		} else {
+			break
+		}
+	}
+
+	t := token{line: l.line, col: l.col}
+	if l.pos >= len(l.src) {
+		t.kind = tokEOF
+		return t, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		t.kind, t.text = tokPunct, "..."
+	case strings.ContainsRune("!$()&:=@[]{}|", rune(c)):
+		l.advance(1)
+		t.kind, t.text = tokPunct, string(c)
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		t.kind, t.text = tokName, l.src[start:l.pos]
+	case c == '-' || isDigit(c):
+		t.kind = tokInt
+		if c == '-' {
+			l.advance(1)
+		}
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.advance(1)
+		}
+		if l.pos < len(l.src) && l.src[l.pos] == '.' {
+			t.kind = tokFloat
+			l.advance(1)
+			for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+				l.advance(1)
+			}
+		}
+		if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+			t.kind = tokFloat
+			l.advance(1)
+			if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+				l.advance(1)
+			}
+			for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+				l.advance(1)
+			}
+		}
+		t.text = l.src[start:l.pos]
+		if t.text == "-" {
+			return t, l.errorf("invalid number")
+		}
+	case strings.HasPrefix(l.src[l.pos:], `"""`):
+		l.advance(3)
+		end := strings.Index(l.src[l.pos:], `"""`)
+		if end < 0 {
+			return t, l.errorf("unterminated block string")
+		}
+		t.value = blockStringValue(l.src[l.pos : l.pos+end])
+		l.advance(end + 3)
+		t.kind, t.text = tokString, l.src[start:l.pos]
+	case c == '"':
+		l.advance(1)
+		var b strings.Builder
+		for {
+			if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+				return t, l.errorf("unterminated string")
+			}
+			ch := l.src[l.pos]
+			if ch == '"' {
+				l.advance(1)
+				break
+			}
+			if ch == '\\' {
+				if l.pos+1 >= len(l.src) {
+					return t, l.errorf("unterminated string")
+				}
+				esc := l.src[l.pos+1]
+				switch esc {
+				case '"', '\\', '/':
+					b.WriteByte(esc)
+				case 'b':
+					b.WriteByte('\b')
+				case 'f':
+					b.WriteByte('\f')
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				case 'u':
+					if l.pos+6 > len(l.src) {
+						return t, l.errorf("invalid unicode escape")
+					}
+					r, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 32)
+					if err != nil {
+						return t, l.errorf("invalid unicode escape")
+					}
+					b.WriteRune(rune(r))
+					l.advance(4)
+				default:
+					return t, l.errorf("invalid escape \\%c", esc)
+				}
+				l.advance(2)
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteRune(r)
+			l.pos += size - 1
+			l.advance(1)
+		}
+		t.kind, t.text, t.value = tokString, l.src[start:l.pos], b.String()
+	default:
+		return t, l.errorf("unexpected character %q", c)
+	}
+	return t, nil
+}
+
+// blockStringValue strips the common indentation and blank edge lines of a block string
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, `\"""`, `"""`), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// Query document AST
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragmentDef
+}
+
+type operation struct {
+	kind      string // "query", "mutation" or "subscription"
+	name      string
+	variables []variableDef
+	selection []selection
+	loc       Location
+}
+
+type variableDef struct {
+	name       string
+	typ        *typeRef
+	defaultVal any
+	hasDefault bool
+}
+
+// typeRef is a type as written in a variable definition
+type typeRef struct {
+	name    string
+	list    *typeRef
+	nonNull bool
+}
+
+func (t *typeRef) String() string {
+	s := t.name
+	if t.list != nil {
+		s = "[" + t.list.String() + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+type selection interface{}
+
+type field struct {
+	alias      string
+	name       string
+	arguments  []argument
+	directives []directive
+	selection  []selection
+	loc        Location
+}
+
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []directive
+	loc        Location
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []directive
+	selection     []selection
+}
+
+type fragmentDef struct {
+	name          string
+	typeCondition string
+	selection     []selection
+	loc           Location
+}
+
+type argument struct {
+	name  string
+	value any
+}
+
+type directive struct {
+	name      string
+	arguments []argument
+}
+
+// Value literals: variable references and enum names get their own types; lists
+// are []any and input objects map[string]any
+type variableRef string
+type enumLiteral string
+
+type parser struct {
+	lex lexer
+	tok token
+}
+
+// parse parses an executable GraphQL document
+func parse(src string) (*document, error) {
+	p := &parser{lex: lexer{src: src, line: 1, col: 1}}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragmentDef)}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.is("{"):
+			loc := p.loc()
+			sel, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selection: sel, loc: loc})
+		case p.tok.kind == tokName && (p.tok.text == "query" || p.tok.text == "mutation" || p.tok.text == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.tok.kind == tokName && p.tok.text == "fragment":
+			f, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := doc.fragments[f.name]; dup {
+				return nil, &Error{Message: fmt.Sprintf("There can be only one fragment named %q.", f.name),
+					Locations: []Location{f.loc}}
+			}
+			doc.fragments[f.name] = f
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, &Error{Message: "Syntax Error: document contains no operations"}
+	}
+	return doc, nil
+}
+
+func (p *parser) read() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *parser) loc() Location {
+	return Location{Line: p.tok.line, Column: p.tok.col}
+}
+
+func (p *parser) is(punct string) bool {
+	return p.tok.kind == tokPunct && p.tok.text == punct
+}
+
+func (p *parser) unexpected() error {
+	desc := p.tok.text
+	if p.tok.kind == tokEOF {
+		desc = "<EOF>"
+	}
+	return &Error{Message: "Syntax Error: Unexpected " + desc, Locations: []Location{p.loc()}}
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.is(punct) {
+		if p.tok.kind == tokEOF {
+			return &Error{Message: fmt.Sprintf("Syntax Error: Expected %q, found <EOF>", punct), Locations: []Location{p.loc()}}
+		}
+		return &Error{Message: fmt.Sprintf("Syntax Error: Expected %q, found %q", punct, p.tok.text), Locations: []Location{p.loc()}}
+	}
+	return p.read()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.unexpected()
+	}
+	n := p.tok.text
+	return n, p.read()
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: p.tok.text, loc: p.loc()}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokName {
+		op.name = p.tok.text
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("(") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		for !p.is(")") {
+			if err := p.expect("$"); err != nil {
+				return nil, err
+			}
+			var v variableDef
+			var err error
+			if v.name, err = p.name(); err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if v.typ, err = p.typeRef(); err != nil {
+				return nil, err
+			}
+			if p.is("=") {
+				if err := p.read(); err != nil {
+					return nil, err
+				}
+				if v.defaultVal, err = p.value(true); err != nil {
+					return nil, err
+				}
+				v.hasDefault = true
+			}
+			op.variables = append(op.variables, v)
+		}
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	sel, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selection = sel
+	return op, nil
+}
+
+func (p *parser) fragment() (*fragmentDef, error) {
+	f := &fragmentDef{loc: p.loc()}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	var err error
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.name == "on" {
+		return nil, &Error{Message: "Syntax Error: Unexpected Name \"on\"", Locations: []Location{f.loc}}
+	}
+	if p.tok.kind != tokName || p.tok.text != "on" {
+		return nil, p.unexpected()
+	}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	if f.typeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	if f.selection, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *parser) typeRef() (*typeRef, error) {
+	t := &typeRef{}
+	if p.is("[") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		inner, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		t.list = inner
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if t.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("!") {
+		t.nonNull = true
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var set []selection
+	for !p.is("}") {
+		if p.tok.kind == tokEOF {
+			return nil, p.unexpected()
+		}
+		s, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, s)
+	}
+	if len(set) == 0 {
+		return nil, p.unexpected()
+	}
+	return set, p.read()
+}
+
+func (p *parser) selection() (selection, error) {
+	if p.is("...") {
+		loc := p.loc()
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokName && p.tok.text != "on" {
+			s := &fragmentSpread{name: p.tok.text, loc: loc}
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			var err error
+			s.directives, err = p.directives()
+			return s, err
+		}
+		f := &inlineFragment{}
+		if p.tok.kind == tokName && p.tok.text == "on" {
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			var err error
+			if f.typeCondition, err = p.name(); err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		if f.directives, err = p.directives(); err != nil {
+			return nil, err
+		}
+		f.selection, err = p.selectionSet()
+		return f, err
+	}
+
+	f := &field{loc: p.loc()}
+	var err error
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if p.is(":") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		f.alias = f.name
+		if f.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if f.arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.is("{") {
+		if f.selection, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) arguments(constant bool) ([]argument, error) {
+	if !p.is("(") {
+		return nil, nil
+	}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+	var args []argument
+	for !p.is(")") {
+		var a argument
+		var err error
+		if a.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if a.value, err = p.value(constant); err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+	}
+	if len(args) == 0 {
+		return nil, p.unexpected()
+	}
+	return args, p.read()
+}
+
+func (p *parser) directives() ([]directive, error) {
+	var ds []directive
+	for p.is("@") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		var d directive
+		var err error
+		if d.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if d.arguments, err = p.arguments(false); err != nil {
+			return nil, err
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+func (p *parser) value(constant bool) (any, error) {
+	t := p.tok
+	switch {
+	case p.is("$") && !constant:
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		n, err := p.name()
+		return variableRef(n), err
+	case p.is("["):
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		list := []any{}
+		for !p.is("]") {
+			if p.tok.kind == tokEOF {
+				return nil, p.unexpected()
+			}
+			v, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, p.read()
+	case p.is("{"):
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		obj := map[string]any{}
+		for !p.is("}") {
+			n, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if obj[n], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		return obj, p.read()
+	case t.kind == tokInt:
+		n, err := strconv.ParseInt(t.text, 10, 32)
+		if err != nil {
+			return nil, &Error{Message: "Syntax Error: Int cannot represent " + t.text, Locations: []Location{p.loc()}}
+		}
+		return int(n), p.read()
+	case t.kind == tokFloat:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, &Error{Message: "Syntax Error: invalid Float " + t.text, Locations: []Location{p.loc()}}
+		}
+		return f, p.read()
+	case t.kind == tokString:
+		return t.value, p.read()
+	case t.kind == tokName:
+		var v any
+		switch t.text {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = enumLiteral(t.text)
+		}
+		return v, p.read()
+	}
+	return nil, p.unexpected()
+}
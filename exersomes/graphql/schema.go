@@ -0,0 +1,445 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Type is a GraphQL output or input type: *Scalar, *Enum, *Object, *List or *NonNull
+type Type interface {
+	String() string
+}
+
+// namedType is a type with its own name in the schema
+type namedType interface {
+	Type
+	typeName() string
+	typeDescription() string
+}
+
+// Scalar is a leaf type; Coerce validates input values and Serialize output values
+type Scalar struct {
+	Name        string
+	Description string
+	Coerce      func(v any) (any, error)
+	Serialize   func(v any) (any, error)
+}
+
+func (s *Scalar) String() string          { return s.Name }
+func (s *Scalar) typeName() string        { return s.Name }
+func (s *Scalar) typeDescription() string { return s.Description }
+
+// EnumValue maps a GraphQL enum name to the Go value resolvers see
+type EnumValue struct {
+	Name        string
+	Value       any
+	Description string
+}
+
+// Enum is a leaf type restricted to named values
+type Enum struct {
+	Name        string
+	Description string
+	Values      []EnumValue
+}
+
+func (e *Enum) String() string          { return e.Name }
+func (e *Enum) typeName() string        { return e.Name }
+func (e *Enum) typeDescription() string { return e.Description }
+
+func (e *Enum) byName(name string) (EnumValue, bool) {
+	for _, v := range e.Values {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return EnumValue{}, false
+}
+
+// ResolveParams is passed to field resolvers
+type ResolveParams struct {
+	Context context.Context
+	Source  any            // Parent object value
+	Args    map[string]any // Coerced arguments, with defaults applied
+	schema  *Schema
+}
+
+// ResolveFunc resolves a field value
+type ResolveFunc func(p ResolveParams) (any, error)
+
+// Argument is a field argument
+type Argument struct {
+	Name         string
+	Type         Type
+	Description  string
+	DefaultValue any // Go value used when the argument is omitted; nil for none
+}
+
+// Field is an object field. A nil Resolve reads the same-named key of a map
+// source or the exported struct field matching the name case-insensitively.
+type Field struct {
+	Name        string
+	Type        Type
+	Description string
+	Args        []Argument
+	Resolve     ResolveFunc
+}
+
+// Object is a composite output type. Fields may be added after construction so
+// objects can refer to each other.
+type Object struct {
+	Name        string
+	Description string
+	fields      []*Field
+	byName      map[string]*Field
+}
+
+// NewObject returns an object type with the given fields
+func NewObject(name, description string, fields ...*Field) *Object {
+	o := &Object{Name: name, Description: description, byName: make(map[string]*Field)}
+	for _, f := range fields {
+		o.AddField(f)
+	}
+	return o
+}
+
+// AddField adds or replaces a field
+func (o *Object) AddField(f *Field) {
+	if o.byName == nil {
+		o.byName = make(map[string]*Field)
+	}
+	if _, exists := o.byName[f.Name]; !exists {
+		o.fields = append(o.fields, f)
+	} else {
+		for i, existing := range o.fields {
+			if existing.Name == f.Name {
+				o.fields[i] = f
+			}
+		}
+	}
+	o.byName[f.Name] = f
+}
+
+// Fields returns the fields in declaration order
+func (o *Object) Fields() []*Field {
+	return o.fields
+}
+
+// Field returns a field by name
+func (o *Object) Field(name string) (*Field, bool) {
+	f, ok := o.byName[name]
+	return f, ok
+}
+
+func (o *Object) String() string          { return o.Name }
+func (o *Object) typeName() string        { return o.Name }
+func (o *Object) typeDescription() string { return o.Description }
+
+// List wraps a type in a list
+type List struct {
+	OfType Type
+}
+
+func (l *List) String() string { return "[" + l.OfType.String() + "]" }
+
+// NonNull marks a type as non-nullable
+type NonNull struct {
+	OfType Type
+}
+
+func (n *NonNull) String() string { return n.OfType.String() + "!" }
+
+// ListOf is shorthand for &List{OfType: t}
+func ListOf(t Type) *List { return &List{OfType: t} }
+
+// NonNullOf is shorthand for &NonNull{OfType: t}
+func NonNullOf(t Type) *NonNull { return &NonNull{OfType: t} }
+
+// Built-in scalars
+var (
+	String = &Scalar{
+		Name:        "String",
+		Description: "UTF-8 character sequence",
+		Coerce: func(v any) (any, error) {
+			if s, ok := v.(string); ok {
+				return s, nil
+			}
+			return nil, fmt.Errorf("String cannot represent a non string value: %v", v)
+		},
+		Serialize: func(v any) (any, error) {
+			switch s := v.(type) {
+			case string:
+				return s, nil
+			case fmt.Stringer:
+				return s.String(), nil
+			case bool, int, int32, int64, float32, float64:
+				return fmt.Sprint(s), nil
+			}
+			return nil, fmt.Errorf("String cannot represent value: %v", v)
+		},
+	}
+	Int = &Scalar{
+		Name:        "Int",
+		Description: "Signed 32-bit integer",
+		Coerce: func(v any) (any, error) {
+			switch n := v.(type) {
+			case int:
+				if n >= math.MinInt32 && n <= math.MaxInt32 {
+					return n, nil
+				}
+			case float64: // JSON variables decode as float64
+				if n == math.Trunc(n) && n >= math.MinInt32 && n <= math.MaxInt32 {
+					return int(n), nil
+				}
+			}
+			return nil, fmt.Errorf("Int cannot represent non-integer value: %v", v)
+		},
+		Serialize: func(v any) (any, error) {
+			switch n := v.(type) {
+			case int:
+				return n, nil
+			case int32:
+				return int(n), nil
+			case int64:
+				return int(n), nil
+			case float64:
+				if n == math.Trunc(n) {
+					return int(n), nil
+				}
+			case bool:
+				if n {
+					return 1, nil
+				}
+				return 0, nil
+			}
+			return nil, fmt.Errorf("Int cannot represent value: %v", v)
+		},
+	}
+	Float = &Scalar{
+		Name:        "Float",
+		Description: "Double-precision floating point value",
+		Coerce: func(v any) (any, error) {
+			switch n := v.(type) {
+			case int:
+				return float64(n), nil
+			case float64:
+				return n, nil
+			}
+			return nil, fmt.Errorf("Float cannot represent non numeric value: %v", v)
+		},
+		Serialize: func(v any) (any, error) {
+			switch n := v.(type) {
+			case float64:
+				if math.IsNaN(n) || math.IsInf(n, 0) {
+					return nil, fmt.Errorf("Float cannot represent non-finite value: %v", n)
+				}
+				return n, nil
+			case float32:
+				return float64(n), nil
+			case int:
+				return float64(n), nil
+			case int64:
+				return float64(n), nil
+			}
+			return nil, fmt.Errorf("Float cannot represent value: %v", v)
+		},
+	}
+	Boolean = &Scalar{
+		Name:        "Boolean",
+		Description: "true or false",
+		Coerce: func(v any) (any, error) {
+			if b, ok := v.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %v", v)
+		},
+		Serialize: func(v any) (any, error) {
+			if b, ok := v.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("Boolean cannot represent value: %v", v)
+		},
+	}
+	ID = &Scalar{
+		Name:        "ID",
+		Description: "Unique identifier",
+		Coerce: func(v any) (any, error) {
+			switch id := v.(type) {
+			case string:
+				return id, nil
+			case int:
+				return fmt.Sprint(id), nil
+			}
+			return nil, fmt.Errorf("ID cannot represent value: %v", v)
+		},
+		Serialize: func(v any) (any, error) {
+			switch id := v.(type) {
+			case string:
+				return id, nil
+			case int, int64:
+				return fmt.Sprint(id), nil
+			}
+			return nil, fmt.Errorf("ID cannot represent value: %v", v)
+		},
+	}
+)
+
+// Schema is a query-only GraphQL schema
+type Schema struct {
+	Query *Object
+	types map[string]namedType
+}
+
+// NewSchema collects every type reachable from the query root
+func NewSchema(query *Object) (*Schema, error) {
+	s := &Schema{Query: query, types: make(map[string]namedType)}
+	for _, t := range []namedType{String, Int, Float, Boolean, ID} {
+		s.types[t.typeName()] = t
+	}
+	for _, t := range []Type{query, schemaType} {
+		if err := s.collect(t); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *Schema) collect(t Type) error {
+	switch t := t.(type) {
+	case *List:
+		return s.collect(t.OfType)
+	case *NonNull:
+		return s.collect(t.OfType)
+	case namedType:
+		if existing, ok := s.types[t.typeName()]; ok {
+			if existing != t {
+				return fmt.Errorf("graphql: two different types named %q", t.typeName())
+			}
+			return nil
+		}
+		s.types[t.typeName()] = t
+		if o, ok := t.(*Object); ok {
+			for _, f := range o.fields {
+				if err := s.collect(f.Type); err != nil {
+					return err
+				}
+				for _, a := range f.Args {
+					if err := s.collect(a.Type); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Type returns a named type
+func (s *Schema) Type(name string) (Type, bool) {
+	t, ok := s.types[name]
+	return t, ok
+}
+
+// typeNames returns user-visible type names, sorted
+func (s *Schema) typeNames() []string {
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SDL renders the schema in the GraphQL schema definition language
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	builtin := map[string]bool{"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true}
+
+	writeDescription := func(d, indent string) {
+		if d != "" {
+			fmt.Fprintf(&b, "%s\"\"\"%s\"\"\"\n", indent, d)
+		}
+	}
+
+	names := s.typeNames()
+	// Query first, then the remaining types alphabetically
+	sort.SliceStable(names, func(i, j int) bool { return names[i] == s.Query.Name && names[j] != s.Query.Name })
+
+	first := true
+	for _, name := range names {
+		if builtin[name] || strings.HasPrefix(name, "__") {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+
+		switch t := s.types[name].(type) {
+		case *Scalar:
+			writeDescription(t.Description, "")
+			fmt.Fprintf(&b, "scalar %s\n", t.Name)
+		case *Enum:
+			writeDescription(t.Description, "")
+			fmt.Fprintf(&b, "enum %s {\n", t.Name)
+			for _, v := range t.Values {
+				writeDescription(v.Description, "  ")
+				fmt.Fprintf(&b, "  %s\n", v.Name)
+			}
+			b.WriteString("}\n")
+		case *Object:
+			writeDescription(t.Description, "")
+			fmt.Fprintf(&b, "type %s {\n", t.Name)
+			for _, f := range t.fields {
+				writeDescription(f.Description, "  ")
+				fmt.Fprintf(&b, "  %s", f.Name)
+				if len(f.Args) > 0 {
+					var args []string
+					for _, a := range f.Args {
+						arg := a.Name + ": " + a.Type.String()
+						if a.DefaultValue != nil {
+							arg += " = " + literal(a.Type, a.DefaultValue)
+						}
+						args = append(args, arg)
+					}
+					fmt.Fprintf(&b, "(%s)", strings.Join(args, ", "))
+				}
+				fmt.Fprintf(&b, ": %s\n", f.Type)
+			}
+			b.WriteString("}\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "\nschema {\n  query: %s\n}\n", s.Query.Name)
+	return b.String()
+}
+
+// literal renders a Go default value as a GraphQL literal of the given type
+func literal(t Type, v any) string {
+	switch t := t.(type) {
+	case *NonNull:
+		return literal(t.OfType, v)
+	case *List:
+		if items, ok := v.([]any); ok {
+			parts := make([]string, len(items))
+			for i, item := range items {
+				parts[i] = literal(t.OfType, item)
+			}
+			return "[" + strings.Join(parts, ", ") + "]"
+		}
+		return literal(t.OfType, v)
+	case *Enum:
+		for _, ev := range t.Values {
+			if ev.Value == v {
+				return ev.Name
+			}
+		}
+	}
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
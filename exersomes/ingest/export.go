@@ -0,0 +1,122 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"exersomes/molecular_types"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Export writes exerkines with the mapping's first column for each mapped field.
+// Loading the output with the same mapping reproduces the mapped fields exactly;
+// CurationMapping maps every field, so it round-trips whole records.
+//
+// The pipeline presets are lossy, because Exerkine has no field for most of
+// their columns and rows naming the same exerkine are merged into one:
+//   - GeneReferencesMapping writes only Symbol and Description, dropping Query,
+//     Gene_ID, Chromosome and MapLocation; rows sharing a symbol become one row.
+//   - ProteinInfoMapping writes only Query, dropping Protein_ID, Accession, Name,
+//     Length and Molecular_Weight.
+//
+// Defaults such as the presets' Category are not written either. Use LoadTable
+// and Table.Export to write a pipeline file back with every column and row.
+func Export(w io.Writer, exerkines []molecular_types.Exerkine, m Mapping, comma rune) error {
+	fields, columns := m.exportColumns()
+	if len(fields) == 0 || fields[0] != FieldName {
+		return fmt.Errorf("mapping has no Name column")
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	for _, e := range exerkines {
+		record := make([]string, len(fields))
+		for i, f := range fields {
+			record[i] = fieldValue(e, f, m.separators())
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Export writes the header and every record as loaded, so loading the output
+// yields the same header, records and exerkines. The bytes can differ from the
+// source: encoding/csv writes "\n" line endings and quotes fields as it needs to,
+// and lines that failed to parse are not kept.
+func (t *Table) Export(w io.Writer) error {
+	if t.Header == nil {
+		return nil
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = t.Comma
+	if err := writer.Write(t.Header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// ExportFile writes exerkines to path, comma-separated for .csv and tab-separated otherwise
+func ExportFile(path string, exerkines []molecular_types.Exerkine, m Mapping) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	comma := '\t'
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		comma = ','
+	}
+	if err := Export(f, exerkines, m, comma); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func fieldValue(e molecular_types.Exerkine, field, separators string) string {
+	switch field {
+	case FieldName:
+		return e.Name
+	case FieldCategory:
+		return e.Category
+	case FieldTissueSources:
+		return JoinMulti(e.TissueSources, separators)
+	case FieldBiologicalFunc:
+		return e.BiologicalFunc
+	case FieldSequence:
+		return e.Sequence
+	case FieldPDBID:
+		return e.PDBID
+	case FieldReceptors:
+		return JoinMulti(e.Receptors, separators)
+	}
+	return ""
+}
+
+// JoinMulti joins items with the first separator, escaping separators and
+// backslashes inside items so SplitMulti recovers them
+func JoinMulti(items []string, separators string) string {
+	escaped := make([]string, len(items))
+	for i, item := range items {
+		var b strings.Builder
+		for _, r := range item {
+			if r == '\\' || strings.ContainsRune(separators, r) {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+		}
+		escaped[i] = b.String()
+	}
+	sep := string([]rune(separators)[0])
+	return strings.Join(escaped, sep+" ")
+}
@@ -0,0 +1,155 @@
+package ingest
+
+import (
+	"bytes"
+	"errors"
+	"exersomes/molecular_types"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test exporting and reloading with the curation mapping is lossless
+func TestRoundTrip(t *testing.T) {
+	exerkines := []molecular_types.Exerkine{
+		{
+			Name:           "IL-6",
+			Category:       "Myokine",
+			TissueSources:  []string{"Skeletal Muscle", "Adipose; visceral"},
+			BiologicalFunc: "Anti-inflammatory, \"acute\" signaling",
+			Sequence:       "MNSFSTSAFG",
+			PDBID:          "1ALU",
+			Receptors:      []string{"IL6R", `gp130\IL6ST`},
+		},
+		{Name: "Lactate", Category: "Metabolite", Sequence: "C3H6O3", Receptors: []string{"HCAR1"}},
+	}
+
+	for _, comma := range []rune{'\t', ','} {
+		var buf bytes.Buffer
+		if err := Export(&buf, exerkines, CurationMapping, comma); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := Load(&buf, Options{Mapping: &CurationMapping})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded, exerkines) {
+			t.Errorf("Round trip with %q changed records:\n got %+v\nwant %+v", comma, loaded, exerkines)
+		}
+	}
+}
+
+// Test tables with merged rows, unmapped columns and CRLF line endings export
+// every record, while the mapped export keeps only the preset's columns
+func TestTableRoundTrip(t *testing.T) {
+	source, err := os.ReadFile("../gene_references.tsv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crlf := "Query,Gene_ID,Symbol\r\nIL6,3569, IL6\r\n\r\n\"IL-6\",3569,IL6\r\n"
+	for name, input := range map[string]string{"gene_references.tsv": string(source), "crlf.csv": crlf} {
+		table, err := LoadTable(strings.NewReader(input), Options{})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(table.Exerkines) >= len(table.Rows) {
+			t.Errorf("%s: expected repeated queries to merge, got %d exerkines from %d rows", name, len(table.Exerkines), len(table.Rows))
+		}
+
+		var buf bytes.Buffer
+		if err := table.Export(&buf); err != nil {
+			t.Fatal(err)
+		}
+		reloaded, err := LoadTable(&buf, Options{})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(reloaded.Header, table.Header) || !reflect.DeepEqual(reloaded.Rows, table.Rows) ||
+			!reflect.DeepEqual(reloaded.Exerkines, table.Exerkines) {
+			t.Errorf("%s: expected the exported table to load unchanged", name)
+		}
+	}
+
+	// The mapped export keeps only the preset's mapped columns
+	exerkines, err := Load(bytes.NewReader(source), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := Export(&buf, exerkines, GeneReferencesMapping, '\t'); err != nil {
+		t.Fatal(err)
+	}
+	if header, _, _ := strings.Cut(buf.String(), "\n"); header != "Symbol\tDescription" {
+		t.Errorf("Expected the documented Symbol and Description columns, got %q", header)
+	}
+}
+
+// Test pipeline headers select a preset that merges repeated queries
+func TestInferPreset(t *testing.T) {
+	input := "Query\tGene_ID\tSymbol\tDescription\tChromosome\tMapLocation\n" +
+		"IL6\t3569\tIL6\tinterleukin 6\t7\t7p15.3\n" +
+		"interleukin-6\t3569\t\t\t7\t7p15.3\n" +
+		"APLN\t8862\tAPLN\tapelin\tX\tXq26.1\n"
+	exerkines, err := Load(strings.NewReader(input), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exerkines) != 2 {
+		t.Fatalf("Expected IL6 rows to merge into 2 exerkines, got %+v", exerkines)
+	}
+	if e := exerkines[0]; e.Name != "IL6" || e.Category != "Protein" || e.BiologicalFunc != "interleukin 6" {
+		t.Errorf("Unexpected merged exerkine %+v", e)
+	}
+
+	m, err := InferMapping([]string{"Exerkine", "Source Tissue", "Receptor(s)", "Notes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Columns[FieldTissueSources][0] != "Source Tissue" || m.Columns[FieldReceptors][0] != "Receptor(s)" {
+		t.Errorf("Unexpected inferred mapping %+v", m.Columns)
+	}
+	if _, err := InferMapping([]string{"Notes"}); err == nil {
+		t.Error("Expected an error for a header without a name column")
+	}
+}
+
+// Test invalid rows are reported with line numbers while valid rows load
+func TestRowErrors(t *testing.T) {
+	input := "name,category,tissues,pdb\n" +
+		"Irisin,Myokine,Muscle|Bone,4LSD\n" +
+		",Myokine,Muscle,\n" +
+		"\n" +
+		"Apelin,Myokine,Muscle,apelin\n" +
+		"irisin,Myokine,Muscle,\n"
+	exerkines, err := Load(strings.NewReader(input), Options{})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+	if len(exerkines) != 1 || !reflect.DeepEqual(exerkines[0].TissueSources, []string{"Muscle", "Bone"}) {
+		t.Errorf("Expected Irisin with two tissues, got %+v", exerkines)
+	}
+
+	want := []RowError{
+		{Line: 3, Column: "name", Message: "name is required"},
+		{Line: 5, Column: "pdb"},
+		{Line: 6, Column: "name"},
+	}
+	if len(verr.Rows) != len(want) {
+		t.Fatalf("Expected %d row errors, got %v", len(want), verr)
+	}
+	for i, w := range want {
+		got := verr.Rows[i]
+		if got.Line != w.Line || got.Column != w.Column || (w.Message != "" && got.Message != w.Message) {
+			t.Errorf("Row error %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+	if !strings.Contains(verr.Rows[2].Message, "first on line 2") {
+		t.Errorf("Expected duplicate to cite line 2, got %q", verr.Rows[2].Message)
+	}
+
+	if _, err := Load(strings.NewReader("Name\tTissue\n"), Options{Mapping: &CurationMapping}); err == nil {
+		t.Error("Expected an error for mapped columns missing from the header")
+	}
+}
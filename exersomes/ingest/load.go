@@ -0,0 +1,317 @@
+package ingest
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"exersomes/molecular_types"
+	"exersomes/network"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// RowError is a validation failure on one line of the input
+type RowError struct {
+	Line    int
+	Column  string // Empty for errors about the whole row
+	Message string
+}
+
+func (e RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("line %d, column %s: %s", e.Line, e.Column, e.Message)
+}
+
+// ValidationError collects every row error in a file
+type ValidationError struct {
+	Rows []RowError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Rows))
+	for _, r := range e.Rows {
+		msgs = append(msgs, r.Error())
+	}
+	return fmt.Sprintf("%d invalid rows:\n  %s", len(e.Rows), strings.Join(msgs, "\n  "))
+}
+
+// Options configure Load. Zero values infer the delimiter and mapping.
+type Options struct {
+	Comma   rune     // ',' or '\t'; 0 sniffs the header line
+	Mapping *Mapping // nil infers from the header
+}
+
+var (
+	pdbIDPattern    = regexp.MustCompile(`^[0-9][A-Za-z0-9]{3}$`)
+	sequencePattern = regexp.MustCompile(`^[A-Za-z0-9()\[\]+\-*=#.]+$`) // Residues or a chemical formula
+)
+
+// LoadFile loads exerkines from a TSV or CSV file. The delimiter follows the
+// extension (.csv is comma-separated) unless opts sets one.
+func LoadFile(path string, opts Options) ([]molecular_types.Exerkine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if opts.Comma == 0 {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			opts.Comma = ','
+		case ".tsv", ".tab":
+			opts.Comma = '\t'
+		}
+	}
+	exerkines, err := Load(f, opts)
+	if err != nil {
+		return exerkines, fmt.Errorf("%s: %w", path, err)
+	}
+	return exerkines, nil
+}
+
+// Table is a loaded file with its header and records kept field for field,
+// including rows that merge into one exerkine and columns the mapping does not
+// read, so Table.Export writes every column back
+type Table struct {
+	Comma     rune
+	Header    []string
+	Rows      [][]string
+	Exerkines []molecular_types.Exerkine
+}
+
+// Load reads exerkines from delimited text. Valid rows are returned even when
+// others fail; the error is then a *ValidationError listing each bad row.
+func Load(r io.Reader, opts Options) ([]molecular_types.Exerkine, error) {
+	t, err := LoadTable(r, opts)
+	if t == nil {
+		return nil, err
+	}
+	return t.Exerkines, err
+}
+
+// LoadTable reads like Load and also keeps the header and every record that parsed
+func LoadTable(r io.Reader, opts Options) (*Table, error) {
+	br := bufio.NewReader(r)
+	if opts.Comma == 0 {
+		opts.Comma = sniffComma(br)
+	}
+	reader := csv.NewReader(br)
+	reader.Comma = opts.Comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return &Table{Comma: opts.Comma}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{Comma: opts.Comma, Header: header}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.TrimPrefix(strings.TrimSpace(h), "\uFEFF")] = i
+	}
+
+	var mapping Mapping
+	if opts.Mapping != nil {
+		mapping = *opts.Mapping
+		for _, field := range Fields {
+			for _, c := range mapping.Columns[field] {
+				if _, ok := columns[c]; !ok {
+					return nil, fmt.Errorf("mapped column %q for %s is not in the header", c, field)
+				}
+			}
+		}
+		if len(mapping.Columns[FieldName]) == 0 {
+			return nil, errors.New("mapping has no Name column")
+		}
+	} else if mapping, err = InferMapping(header); err != nil {
+		return nil, err
+	}
+
+	var (
+		exerkines []molecular_types.Exerkine
+		rowErrors []RowError
+		byKey     = make(map[string]int) // Canonical name -> index in exerkines
+		firstLine = make(map[string]int)
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, RowError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
+			}
+			t.Exerkines = exerkines
+			return t, err
+		}
+		t.Rows = append(t.Rows, record)
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue // Blank line
+		}
+
+		e, errs := parseRow(record, columns, mapping, line)
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+
+		key := network.CanonicalLigand(e.Name)
+		if i, dup := byKey[key]; dup {
+			if !mapping.MergeDuplicates {
+				rowErrors = append(rowErrors, RowError{Line: line, Column: mapping.Columns[FieldName][0],
+					Message: fmt.Sprintf("duplicate exerkine %q (first on line %d)", e.Name, firstLine[key])})
+				continue
+			}
+			exerkines[i] = merge(exerkines[i], e)
+			continue
+		}
+		byKey[key] = len(exerkines)
+		firstLine[key] = line
+		exerkines = append(exerkines, e)
+	}
+
+	t.Exerkines = exerkines
+	if len(rowErrors) > 0 {
+		return t, &ValidationError{Rows: rowErrors}
+	}
+	return t, nil
+}
+
+// sniffComma picks tab or comma by which appears more often in the header line
+func sniffComma(br *bufio.Reader) rune {
+	peek, _ := br.Peek(4096)
+	first, _, _ := strings.Cut(string(peek), "\n")
+	if strings.Count(first, ",") > strings.Count(first, "\t") {
+		return ','
+	}
+	return '\t'
+}
+
+// parseRow fills an exerkine from one record and validates it
+func parseRow(record []string, columns map[string]int, m Mapping, line int) (molecular_types.Exerkine, []RowError) {
+	var errs []RowError
+	cell := func(field string) (value, column string) {
+		for _, c := range m.Columns[field] {
+			if i := columns[c]; i < len(record) {
+				if v := strings.TrimSpace(record[i]); v != "" {
+					return v, c
+				}
+			}
+		}
+		if len(m.Columns[field]) > 0 {
+			column = m.Columns[field][0]
+		}
+		return m.Defaults[field], column
+	}
+	list := func(field string) []string {
+		v, _ := cell(field)
+		return SplitMulti(v, m.separators())
+	}
+
+	var e molecular_types.Exerkine
+	var column string
+	e.Name, column = cell(FieldName)
+	if e.Name == "" {
+		errs = append(errs, RowError{Line: line, Column: column, Message: "name is required"})
+	}
+	e.Category, _ = cell(FieldCategory)
+	e.TissueSources = list(FieldTissueSources)
+	e.BiologicalFunc, _ = cell(FieldBiologicalFunc)
+	e.Sequence, column = cell(FieldSequence)
+	if e.Sequence != "" && !sequencePattern.MatchString(e.Sequence) {
+		errs = append(errs, RowError{Line: line, Column: column,
+			Message: fmt.Sprintf("sequence %q is neither residues nor a chemical formula", truncate(e.Sequence, 20))})
+	}
+	e.PDBID, column = cell(FieldPDBID)
+	if e.PDBID != "" && !pdbIDPattern.MatchString(e.PDBID) {
+		errs = append(errs, RowError{Line: line, Column: column,
+			Message: fmt.Sprintf("PDB ID %q must be a digit followed by three letters or digits", e.PDBID)})
+	}
+	e.Receptors = list(FieldReceptors)
+	return e, errs
+}
+
+// SplitMulti splits a multi-value cell on any separator rune, honouring
+// backslash escapes, and drops empty items
+func SplitMulti(cell, separators string) []string {
+	var items []string
+	var b strings.Builder
+	escaped := false
+	flush := func() {
+		if item := strings.TrimSpace(b.String()); item != "" {
+			items = append(items, item)
+		}
+		b.Reset()
+	}
+	for _, r := range cell {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case strings.ContainsRune(separators, r):
+			flush()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if escaped {
+		b.WriteRune('\\')
+	}
+	flush()
+	return items
+}
+
+// merge fills empty fields of a from b and unions the lists
+func merge(a, b molecular_types.Exerkine) molecular_types.Exerkine {
+	first := func(x, y string) string {
+		if x != "" {
+			return x
+		}
+		return y
+	}
+	a.Category = first(a.Category, b.Category)
+	a.BiologicalFunc = first(a.BiologicalFunc, b.BiologicalFunc)
+	a.Sequence = first(a.Sequence, b.Sequence)
+	a.PDBID = first(a.PDBID, b.PDBID)
+	a.TissueSources = union(a.TissueSources, b.TissueSources)
+	a.Receptors = union(a.Receptors, b.Receptors)
+	return a
+}
+
+func union(a, b []string) []string {
+	for _, v := range b {
+		found := false
+		for _, existing := range a {
+			if strings.EqualFold(existing, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			a = append(a, v)
+		}
+	}
+	return a
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,149 @@
+package ingest
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Exerkine fields a column can map to
+const (
+	FieldName           = "Name"
+	FieldCategory       = "Category"
+	FieldTissueSources  = "TissueSources"
+	FieldBiologicalFunc = "BiologicalFunc"
+	FieldSequence       = "Sequence"
+	FieldPDBID          = "PDBID"
+	FieldReceptors      = "Receptors"
+)
+
+// Fields lists every mappable field in export column order
+var Fields = []string{FieldName, FieldCategory, FieldTissueSources, FieldBiologicalFunc,
+	FieldSequence, FieldPDBID, FieldReceptors}
+
+// multiValued fields hold lists split from one cell
+var multiValued = map[string]bool{FieldTissueSources: true, FieldReceptors: true}
+
+// DefaultSeparators split multi-value cells such as "Liver; Adipose"
+const DefaultSeparators = ";,|"
+
+// Mapping says which columns fill which Exerkine fields
+type Mapping struct {
+	Columns         map[string][]string // Field -> candidate columns; the first non-empty cell wins
+	Defaults        map[string]string   // Values for fields whose cells are all empty
+	Separators      string              // Multi-value separators; the first is used on export
+	MergeDuplicates bool                // Merge rows naming the same exerkine instead of rejecting them
+}
+
+// Preset mappings for the retrieval pipeline outputs and the curation sheet format
+var (
+	CurationMapping = Mapping{
+		Columns: map[string][]string{
+			FieldName:           {"Name"},
+			FieldCategory:       {"Category"},
+			FieldTissueSources:  {"TissueSources"},
+			FieldBiologicalFunc: {"BiologicalFunc"},
+			FieldSequence:       {"Sequence"},
+			FieldPDBID:          {"PDBID"},
+			FieldReceptors:      {"Receptors"},
+		},
+	}
+
+	GeneReferencesMapping = Mapping{
+		Columns: map[string][]string{
+			FieldName:           {"Symbol", "Query"},
+			FieldBiologicalFunc: {"Description"},
+		},
+		Defaults:        map[string]string{FieldCategory: "Protein"},
+		MergeDuplicates: true,
+	}
+
+	ProteinInfoMapping = Mapping{
+		Columns: map[string][]string{
+			FieldName: {"Query"},
+		},
+		Defaults:        map[string]string{FieldCategory: "Protein"},
+		MergeDuplicates: true,
+	}
+)
+
+// headerSynonyms maps normalized header spellings to fields for inference
+var headerSynonyms = map[string]string{
+	"name": FieldName, "exerkine": FieldName, "ligand": FieldName, "symbol": FieldName,
+	"genesymbol": FieldName, "gene": FieldName, "query": FieldName,
+	"category": FieldCategory, "class": FieldCategory, "moleculetype": FieldCategory, "type": FieldCategory,
+	"tissuesources": FieldTissueSources, "sourcetissues": FieldTissueSources, "sourcetissue": FieldTissueSources,
+	"tissues": FieldTissueSources, "tissue": FieldTissueSources, "source": FieldTissueSources,
+	"biologicalfunc": FieldBiologicalFunc, "biologicalfunction": FieldBiologicalFunc,
+	"function": FieldBiologicalFunc, "description": FieldBiologicalFunc,
+	"sequence": FieldSequence, "seq": FieldSequence, "formula": FieldSequence,
+	"pdbid": FieldPDBID, "pdb": FieldPDBID,
+	"receptors": FieldReceptors, "receptor": FieldReceptors, "targetreceptors": FieldReceptors,
+}
+
+// normalizeHeader lowercases and drops everything but letters and digits
+func normalizeHeader(h string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimPrefix(h, "\uFEFF")) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// PresetFor returns the preset mapping whose columns match a header exactly, if any
+func PresetFor(header []string) (Mapping, bool) {
+	has := make(map[string]bool, len(header))
+	for _, h := range header {
+		has[strings.TrimPrefix(strings.TrimSpace(h), "\uFEFF")] = true
+	}
+	switch {
+	case has["Query"] && has["Gene_ID"] && has["Symbol"]:
+		return GeneReferencesMapping, true
+	case has["Query"] && has["Protein_ID"] && has["Accession"]:
+		return ProteinInfoMapping, true
+	}
+	return Mapping{}, false
+}
+
+// InferMapping maps headers to fields by name, using PresetFor when a pipeline
+// header is recognized. Headers matching the same field become candidates in
+// header order.
+func InferMapping(header []string) (Mapping, error) {
+	if m, ok := PresetFor(header); ok {
+		return m, nil
+	}
+
+	m := Mapping{Columns: make(map[string][]string)}
+	for _, h := range header {
+		field, ok := headerSynonyms[normalizeHeader(h)]
+		if !ok {
+			continue
+		}
+		column := strings.TrimPrefix(strings.TrimSpace(h), "\uFEFF")
+		m.Columns[field] = append(m.Columns[field], column)
+	}
+	if len(m.Columns[FieldName]) == 0 {
+		return m, fmt.Errorf("no name column among %q", header)
+	}
+	return m, nil
+}
+
+func (m Mapping) separators() string {
+	if m.Separators == "" {
+		return DefaultSeparators
+	}
+	return m.Separators
+}
+
+// exportColumns returns the mapped fields in export order with their first column name
+func (m Mapping) exportColumns() (fields, columns []string) {
+	for _, f := range Fields {
+		if cols := m.Columns[f]; len(cols) > 0 {
+			fields = append(fields, f)
+			columns = append(columns, cols[0])
+		}
+	}
+	return fields, columns
+}
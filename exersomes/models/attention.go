@@ -0,0 +1,73 @@
+package models
+
+import (
+	"math"
+)
+
+// MultiHeadAttention is scaled dot-product attention over Heads heads, each
+// seeing Dim/Heads columns of the projected queries, keys and values
+type MultiHeadAttention struct {
+	Heads  int
+	Query  Linear
+	Key    Linear
+	Value  Linear
+	Output Linear
+}
+
+func newAttention(dim, heads int) MultiHeadAttention {
+	return MultiHeadAttention{
+		Heads: heads,
+		Query: newLinear(dim, dim), Key: newLinear(dim, dim), Value: newLinear(dim, dim),
+		Output: newLinear(dim, dim),
+	}
+}
+
+// Forward attends from each row of query to the rows of context. The mask,
+// if it has rows, is added to the attention scores; see PaddingMask.
+func (a *MultiHeadAttention) Forward(query, context Matrix, mask Matrix) Matrix {
+	q := a.Query.Forward(query)
+	k := a.Key.Forward(context)
+	v := a.Value.Forward(context)
+	headDim := q.Cols / a.Heads
+	scale := 1 / math.Sqrt(float64(headDim))
+
+	heads := NewMatrix(query.Rows, q.Cols)
+	scores := make([]float64, context.Rows)
+	for h := 0; h < a.Heads; h++ {
+		lo, hi := h*headDim, (h+1)*headDim
+		for i := 0; i < q.Rows; i++ {
+			qi := q.Row(i)[lo:hi]
+			for j := range scores {
+				scores[j] = dot(qi, k.Row(j)[lo:hi]) * scale
+				if mask.Rows > 0 {
+					scores[j] += mask.At(i, j)
+				}
+			}
+			softmax(scores)
+			out := heads.Row(i)[lo:hi]
+			for j, w := range scores {
+				if w == 0 {
+					continue
+				}
+				for c, x := range v.Row(j)[lo:hi] {
+					out[c] += w * x
+				}
+			}
+		}
+	}
+	return a.Output.Forward(heads)
+}
+
+// FeedForward is the position-wise two-layer network of a transformer block
+type FeedForward struct {
+	Hidden     Linear
+	Output     Linear
+	Activation string // ReLU or GELU
+}
+
+// Forward applies the network to every row of x
+func (f *FeedForward) Forward(x Matrix) Matrix {
+	h := f.Hidden.Forward(x)
+	activate(f.Activation, h)
+	return f.Output.Forward(h)
+}
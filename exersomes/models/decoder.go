@@ -0,0 +1,70 @@
+package models
+
+// DecoderLayer is a post-norm block: causal self-attention, cross-attention to
+// the encoder memory and a feed-forward network, each followed by a residual
+// connection and layer norm
+type DecoderLayer struct {
+	SelfAttention       MultiHeadAttention
+	CrossAttention      MultiHeadAttention
+	FeedForward         FeedForward
+	Norm1, Norm2, Norm3 LayerNorm
+}
+
+// Forward runs the block over one target sequence
+func (l *DecoderLayer) Forward(x, memory Matrix, selfMask, memoryMask Matrix) Matrix {
+	x = l.Norm1.Forward(add(x, l.SelfAttention.Forward(x, x, selfMask)))
+	x = l.Norm2.Forward(add(x, l.CrossAttention.Forward(x, memory, memoryMask)))
+	return l.Norm3.Forward(add(x, l.FeedForward.Forward(x)))
+}
+
+// Decoder predicts the next token at every target position from the target
+// prefix and the encoder memory
+type Decoder struct {
+	Embedding Matrix // Vocab × Dim
+	Layers    []DecoderLayer
+	Norm      LayerNorm
+	Output    Linear // Dim → Vocab logits
+}
+
+func newDecoder(c Config) Decoder {
+	d := Decoder{
+		Embedding: NewMatrix(c.Vocab, c.Dim),
+		Layers:    make([]DecoderLayer, c.DecoderLayers),
+		Norm:      newLayerNorm(c.Dim, c.eps()),
+		Output:    newLinear(c.Dim, c.Vocab),
+	}
+	for i := range d.Layers {
+		d.Layers[i] = DecoderLayer{
+			SelfAttention:  newAttention(c.Dim, c.Heads),
+			CrossAttention: newAttention(c.Dim, c.Heads),
+			FeedForward:    newFeedForward(c),
+			Norm1:          newLayerNorm(c.Dim, c.eps()),
+			Norm2:          newLayerNorm(c.Dim, c.eps()),
+			Norm3:          newLayerNorm(c.Dim, c.eps()),
+		}
+	}
+	return d
+}
+
+// Forward returns the logits, one row per target position, of a target whose
+// first length tokens are real. The memory's first memoryLength rows are the
+// real source positions.
+func (d *Decoder) Forward(ids []int, length int, memory Matrix, memoryLength int) Matrix {
+	x := embed(d.Embedding, ids)
+	selfMask := CausalMask(len(ids), length)
+	memoryMask := PaddingMask(len(ids), memory.Rows, memoryLength)
+	for i := range d.Layers {
+		x = d.Layers[i].Forward(x, memory, selfMask, memoryMask)
+	}
+	return d.Output.Forward(d.Norm.Forward(x))
+}
+
+// Decode returns the logits of every target in a batch against the memory of
+// the matching source
+func (d *Decoder) Decode(targets Batch, memory []Matrix, sources Batch) []Matrix {
+	logits := make([]Matrix, len(targets.IDs))
+	for i, ids := range targets.IDs {
+		logits[i] = d.Forward(ids, targets.Lengths[i], memory[i], sources.Lengths[i])
+	}
+	return logits
+}
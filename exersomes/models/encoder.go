@@ -0,0 +1,100 @@
+package models
+
+import (
+	"fmt"
+)
+
+// Config sizes an encoder-decoder transformer
+type Config struct {
+	Vocab         int     `json:"vocab"`          // Tokenizer size
+	Dim           int     `json:"dim"`            // Model width
+	Heads         int     `json:"heads"`          // Attention heads; must divide Dim
+	FeedForward   int     `json:"feed_forward"`   // Hidden width of the feed-forward blocks
+	EncoderLayers int     `json:"encoder_layers"` // Encoder blocks
+	DecoderLayers int     `json:"decoder_layers"` // Decoder blocks
+	Activation    string  `json:"activation"`     // ReLU or GELU; empty is ReLU
+	Eps           float64 `json:"eps"`            // Layer norm epsilon; 0 is 1e-5
+}
+
+// Validate reports whether the sizes describe a model
+func (c Config) Validate() error {
+	switch {
+	case c.Vocab <= len(specialTokens):
+		return fmt.Errorf("vocabulary of %d tokens has no residues", c.Vocab)
+	case c.Dim <= 0 || c.Heads <= 0 || c.Dim%c.Heads != 0:
+		return fmt.Errorf("width %d is not divisible into %d heads", c.Dim, c.Heads)
+	case c.FeedForward <= 0:
+		return fmt.Errorf("feed-forward width must be positive, got %d", c.FeedForward)
+	case c.EncoderLayers < 0 || c.DecoderLayers <= 0:
+		return fmt.Errorf("need a decoder block, got %d encoder and %d decoder blocks", c.EncoderLayers, c.DecoderLayers)
+	case c.Activation != "" && c.Activation != ReLU && c.Activation != GELU:
+		return fmt.Errorf("unknown activation %q", c.Activation)
+	}
+	return nil
+}
+
+func (c Config) eps() float64 {
+	if c.Eps == 0 {
+		return 1e-5
+	}
+	return c.Eps
+}
+
+// EncoderLayer is a post-norm block: self-attention and a feed-forward network,
+// each followed by a residual connection and layer norm
+type EncoderLayer struct {
+	SelfAttention MultiHeadAttention
+	FeedForward   FeedForward
+	Norm1, Norm2  LayerNorm
+}
+
+// Forward runs the block over one sequence
+func (l *EncoderLayer) Forward(x Matrix, mask Matrix) Matrix {
+	x = l.Norm1.Forward(add(x, l.SelfAttention.Forward(x, x, mask)))
+	return l.Norm2.Forward(add(x, l.FeedForward.Forward(x)))
+}
+
+// Encoder embeds a source sequence and runs the encoder blocks, producing the
+// memory the decoder attends to
+type Encoder struct {
+	Embedding Matrix // Vocab × Dim
+	Layers    []EncoderLayer
+	Norm      LayerNorm
+}
+
+func newEncoder(c Config) Encoder {
+	e := Encoder{Embedding: NewMatrix(c.Vocab, c.Dim), Layers: make([]EncoderLayer, c.EncoderLayers), Norm: newLayerNorm(c.Dim, c.eps())}
+	for i := range e.Layers {
+		e.Layers[i] = EncoderLayer{
+			SelfAttention: newAttention(c.Dim, c.Heads),
+			FeedForward:   newFeedForward(c),
+			Norm1:         newLayerNorm(c.Dim, c.eps()),
+			Norm2:         newLayerNorm(c.Dim, c.eps()),
+		}
+	}
+	return e
+}
+
+func newFeedForward(c Config) FeedForward {
+	return FeedForward{Hidden: newLinear(c.Dim, c.FeedForward), Output: newLinear(c.FeedForward, c.Dim), Activation: c.Activation}
+}
+
+// Forward encodes one sequence of which the first length tokens are real and
+// the rest padding. Rows past length are computed but meaningless.
+func (e *Encoder) Forward(ids []int, length int) Matrix {
+	x := embed(e.Embedding, ids)
+	mask := PaddingMask(len(ids), len(ids), length)
+	for i := range e.Layers {
+		x = e.Layers[i].Forward(x, mask)
+	}
+	return e.Norm.Forward(x)
+}
+
+// Encode encodes every sequence of a batch
+func (e *Encoder) Encode(b Batch) []Matrix {
+	memory := make([]Matrix, len(b.IDs))
+	for i, ids := range b.IDs {
+		memory[i] = e.Forward(ids, b.Lengths[i])
+	}
+	return memory
+}
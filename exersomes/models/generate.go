@@ -0,0 +1,294 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Decoding strategies
+const (
+	Greedy = "greedy"
+	TopK   = "top-k"
+	TopP   = "top-p"
+	Beam   = "beam"
+)
+
+// GenerateOptions control decoding. Zero values take the defaults noted.
+type GenerateOptions struct {
+	Strategy    string  // Greedy, TopK, TopP or Beam; empty is Greedy
+	MaxLength   int     // Residues to generate at most, beyond the prefix; 256
+	MinLength   int     // Residues before EOS is allowed, including the prefix
+	TopK        int     // Tokens kept by TopK sampling; 20
+	TopP        float64 // Probability mass kept by TopP sampling; 0.9
+	Temperature float64 // Divides the logits before sampling; 1
+	Beams       int     // Hypotheses kept by Beam search, and candidates returned; 4
+}
+
+func (o GenerateOptions) withDefaults() GenerateOptions {
+	if o.Strategy == "" {
+		o.Strategy = Greedy
+	}
+	if o.MaxLength == 0 {
+		o.MaxLength = 256
+	}
+	if o.TopK == 0 {
+		o.TopK = 20
+	}
+	if o.TopP == 0 {
+		o.TopP = 0.9
+	}
+	if o.Temperature == 0 {
+		o.Temperature = 1
+	}
+	if o.Beams == 0 {
+		o.Beams = 4
+	}
+	return o
+}
+
+// Generation is a decoded sequence with its log-likelihood under the model
+// (at temperature 1), counting the EOS token when the sequence ended on one
+type Generation struct {
+	IDs           []int // Residue tokens, including the prefix
+	Sequence      string
+	LogLikelihood float64 // Of the generated tokens, not the prefix
+	Tokens        int     // Generated tokens scored, including EOS
+	Finished      bool    // Ended on EOS rather than MaxLength
+}
+
+// Perplexity is exp(-LogLikelihood / Tokens)
+func (g Generation) Perplexity() float64 {
+	return perplexity(g.LogLikelihood, g.Tokens)
+}
+
+func perplexity(logLikelihood float64, tokens int) float64 {
+	if tokens == 0 {
+		return math.NaN()
+	}
+	return math.Exp(-logLikelihood / float64(tokens))
+}
+
+// FamilyTag is the tokenizer tag that conditions generation on a family
+func FamilyTag(family string) string {
+	return "<" + family + ">"
+}
+
+// Families lists the families a model was trained to condition on
+func (m *Model) Families() []string {
+	var families []string
+	for _, tag := range m.Tokenizer.Tags {
+		if strings.HasPrefix(tag, "<") && strings.HasSuffix(tag, ">") {
+			families = append(families, tag[1:len(tag)-1])
+		}
+	}
+	return families
+}
+
+// Prompt returns the encoder source for a family: its tag, or nothing for an
+// unconditional prompt when family is empty
+func (m *Model) Prompt(family string) ([]int, error) {
+	if family == "" {
+		return nil, nil
+	}
+	id, ok := m.Tokenizer.Tag(FamilyTag(family))
+	if !ok {
+		return nil, fmt.Errorf("model has no family %q; it knows %s", family, strings.Join(m.Families(), ", "))
+	}
+	return []int{id}, nil
+}
+
+// nextLogProbs returns log probabilities of the next token after target, with
+// tokens that cannot be generated at -Inf
+func (m *Model) nextLogProbs(memory Matrix, source, target []int, residues int, opts GenerateOptions) []float64 {
+	logits := m.Decoder.Forward(target, len(target), memory, len(source))
+	lp := LogSoftmax(logits.Row(logits.Rows - 1))
+	for id := range lp {
+		residue := id >= len(specialTokens) && id < len(specialTokens)+len(m.Tokenizer.Alphabet)
+		if !residue && (id != EOS || residues < opts.MinLength) {
+			lp[id] = math.Inf(-1)
+		}
+	}
+	return lp
+}
+
+// Generate decodes residues after the prefix, conditioned on the source. The
+// sampling strategies return one generation drawn with rng; Beam returns up to
+// Beams generations, best first by log-likelihood per token.
+func (m *Model) Generate(source, prefix []int, opts GenerateOptions, rng *rand.Rand) ([]Generation, error) {
+	opts = opts.withDefaults()
+	memory := m.Encoder.Forward(source, len(source))
+	switch opts.Strategy {
+	case Beam:
+		return m.beamSearch(memory, source, prefix, opts), nil
+	case Greedy, TopK, TopP:
+	default:
+		return nil, fmt.Errorf("unknown decoding strategy %q; use %s, %s, %s or %s", opts.Strategy, Greedy, TopK, TopP, Beam)
+	}
+
+	target := append([]int{BOS}, prefix...)
+	g := Generation{}
+	for step := 0; step < opts.MaxLength; step++ {
+		lp := m.nextLogProbs(memory, source, target, len(target)-1, opts)
+		var next int
+		if opts.Strategy == Greedy {
+			next = argmax(lp)
+		} else {
+			next = sample(lp, opts, rng)
+		}
+		g.LogLikelihood += lp[next]
+		g.Tokens++
+		if next == EOS {
+			g.Finished = true
+			break
+		}
+		target = append(target, next)
+	}
+	g.IDs = target[1:]
+	g.Sequence = m.Tokenizer.Decode(g.IDs)
+	return []Generation{g}, nil
+}
+
+func argmax(x []float64) int {
+	best := 0
+	for i, v := range x {
+		if v > x[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// sample draws a token from log probabilities after temperature scaling,
+// restricted to the top-k tokens or the top-p nucleus
+func sample(lp []float64, opts GenerateOptions, rng *rand.Rand) int {
+	order := make([]int, 0, len(lp))
+	for id, v := range lp {
+		if !math.IsInf(v, -1) {
+			order = append(order, id)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool { return lp[order[a]] > lp[order[b]] })
+
+	scaled := make([]float64, len(order))
+	for i, id := range order {
+		scaled[i] = lp[id] / opts.Temperature
+	}
+	softmax(scaled)
+
+	keep := len(order)
+	switch opts.Strategy {
+	case TopK:
+		keep = min(keep, opts.TopK)
+	case TopP:
+		var mass float64
+		for i, p := range scaled {
+			mass += p
+			if mass >= opts.TopP {
+				keep = i + 1
+				break
+			}
+		}
+	}
+	var total float64
+	for _, p := range scaled[:keep] {
+		total += p
+	}
+	u := rng.Float64() * total
+	for i, p := range scaled[:keep] {
+		if u -= p; u < 0 {
+			return order[i]
+		}
+	}
+	return order[keep-1]
+}
+
+type hypothesis struct {
+	target        []int
+	logLikelihood float64
+	tokens        int
+	finished      bool
+}
+
+func (h hypothesis) score() float64 {
+	return h.logLikelihood / float64(max(h.tokens, 1))
+}
+
+// beamSearch keeps the Beams most likely prefixes at each step and returns the
+// finished hypotheses, topped up with unfinished ones at MaxLength
+func (m *Model) beamSearch(memory Matrix, source, prefix []int, opts GenerateOptions) []Generation {
+	beams := []hypothesis{{target: append([]int{BOS}, prefix...)}}
+	var finished []hypothesis
+	for step := 0; step < opts.MaxLength && len(beams) > 0 && len(finished) < opts.Beams; step++ {
+		var candidates []hypothesis
+		for _, h := range beams {
+			lp := m.nextLogProbs(memory, source, h.target, len(h.target)-1, opts)
+			for id, v := range lp {
+				if math.IsInf(v, -1) {
+					continue
+				}
+				c := hypothesis{logLikelihood: h.logLikelihood + v, tokens: h.tokens + 1, finished: id == EOS}
+				c.target = append(append([]int(nil), h.target...), id)
+				candidates = append(candidates, c)
+			}
+		}
+		sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].logLikelihood > candidates[b].logLikelihood })
+		beams = beams[:0]
+		for _, c := range candidates {
+			if len(beams)+len(finished) >= opts.Beams {
+				break
+			}
+			if c.finished {
+				c.target = c.target[:len(c.target)-1]
+				finished = append(finished, c)
+			} else {
+				beams = append(beams, c)
+			}
+		}
+	}
+	finished = append(finished, beams...)
+	sort.SliceStable(finished, func(a, b int) bool { return finished[a].score() > finished[b].score() })
+	if len(finished) > opts.Beams {
+		finished = finished[:opts.Beams]
+	}
+
+	generations := make([]Generation, len(finished))
+	for i, h := range finished {
+		ids := h.target[1:]
+		generations[i] = Generation{
+			IDs: ids, Sequence: m.Tokenizer.Decode(ids),
+			LogLikelihood: h.logLikelihood, Tokens: h.tokens, Finished: h.finished,
+		}
+	}
+	return generations
+}
+
+// Score is a sequence's log-likelihood under the model
+type Score struct {
+	LogLikelihood float64 // Natural log, over the residues and the closing EOS
+	Tokens        int
+}
+
+// Perplexity is exp(-LogLikelihood / Tokens)
+func (s Score) Perplexity() float64 {
+	return perplexity(s.LogLikelihood, s.Tokens)
+}
+
+// Score returns the log-likelihood of residues followed by EOS, conditioned on
+// the source, from a single teacher-forced decoder pass
+func (m *Model) Score(source, residues []int) Score {
+	target := append([]int{BOS}, residues...)
+	memory := m.Encoder.Forward(source, len(source))
+	logits := m.Decoder.Forward(target, len(target), memory, len(source))
+	s := Score{Tokens: len(residues) + 1}
+	for i := 0; i < logits.Rows; i++ {
+		next := EOS
+		if i < len(residues) {
+			next = residues[i]
+		}
+		s.LogLikelihood += LogSoftmax(logits.Row(i))[next]
+	}
+	return s
+}
@@ -0,0 +1,233 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// Model is an encoder-decoder transformer over a tokenizer's vocabulary
+type Model struct {
+	Config    Config
+	Tokenizer *Tokenizer
+	Encoder   Encoder
+	Decoder   Decoder
+}
+
+// NewModel returns a model with zero weights, unit layer-norm gains and a
+// vocabulary sized to the tokenizer; see Randomize and Load
+func NewModel(c Config, t *Tokenizer) (*Model, error) {
+	if c.Vocab == 0 {
+		c.Vocab = t.Size()
+	}
+	if c.Vocab != t.Size() {
+		return nil, fmt.Errorf("config vocabulary of %d tokens does not match the tokenizer's %d", c.Vocab, t.Size())
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &Model{Config: c, Tokenizer: t, Encoder: newEncoder(c), Decoder: newDecoder(c)}, nil
+}
+
+// Logits encodes the source and returns the decoder's next-token logits at
+// every target position
+func (m *Model) Logits(source, target []int) Matrix {
+	memory := m.Encoder.Forward(source, len(source))
+	return m.Decoder.Forward(target, len(target), memory, len(source))
+}
+
+// parameter is a named weight tensor sharing the model's storage
+type parameter struct {
+	name  string
+	shape []int
+	data  []float64
+	init  initializer
+}
+
+// initializer says how Randomize draws a parameter
+type initializer int
+
+const (
+	keep      initializer = iota // Biases and layer norms
+	xavier                       // Projections
+	embedding                    // Token embeddings
+)
+
+// parameters lists every weight under its safetensors name
+func (m *Model) parameters() []parameter {
+	var ps []parameter
+	matrix := func(name string, w Matrix, init initializer) {
+		ps = append(ps, parameter{name, []int{w.Rows, w.Cols}, w.Data, init})
+	}
+	vector := func(name string, v []float64) {
+		ps = append(ps, parameter{name, []int{len(v)}, v, keep})
+	}
+	linear := func(name string, l Linear) {
+		matrix(name+".weight", l.Weight, xavier)
+		vector(name+".bias", l.Bias)
+	}
+	norm := func(name string, n LayerNorm) {
+		vector(name+".weight", n.Gain)
+		vector(name+".bias", n.Bias)
+	}
+	attention := func(name string, a MultiHeadAttention) {
+		linear(name+".q", a.Query)
+		linear(name+".k", a.Key)
+		linear(name+".v", a.Value)
+		linear(name+".out", a.Output)
+	}
+	feedForward := func(name string, f FeedForward) {
+		linear(name+".hidden", f.Hidden)
+		linear(name+".output", f.Output)
+	}
+
+	matrix("encoder.embedding.weight", m.Encoder.Embedding, embedding)
+	for i, l := range m.Encoder.Layers {
+		prefix := "encoder.layers." + strconv.Itoa(i)
+		attention(prefix+".self_attn", l.SelfAttention)
+		feedForward(prefix+".ff", l.FeedForward)
+		norm(prefix+".norm1", l.Norm1)
+		norm(prefix+".norm2", l.Norm2)
+	}
+	norm("encoder.norm", m.Encoder.Norm)
+
+	matrix("decoder.embedding.weight", m.Decoder.Embedding, embedding)
+	for i, l := range m.Decoder.Layers {
+		prefix := "decoder.layers." + strconv.Itoa(i)
+		attention(prefix+".self_attn", l.SelfAttention)
+		attention(prefix+".cross_attn", l.CrossAttention)
+		feedForward(prefix+".ff", l.FeedForward)
+		norm(prefix+".norm1", l.Norm1)
+		norm(prefix+".norm2", l.Norm2)
+		norm(prefix+".norm3", l.Norm3)
+	}
+	norm("decoder.norm", m.Decoder.Norm)
+	linear("decoder.output", m.Decoder.Output)
+	return ps
+}
+
+// Randomize draws Xavier-uniform projection weights and normal embeddings with
+// standard deviation 1/√Dim, leaving biases and layer norms as they are
+func (m *Model) Randomize(rng *rand.Rand) {
+	for _, p := range m.parameters() {
+		switch p.init {
+		case xavier:
+			limit := math.Sqrt(6 / float64(p.shape[0]+p.shape[1]))
+			for i := range p.data {
+				p.data[i] = (2*rng.Float64() - 1) * limit
+			}
+		case embedding:
+			for i := range p.data {
+				p.data[i] = rng.NormFloat64() / math.Sqrt(float64(m.Config.Dim))
+			}
+		}
+	}
+}
+
+// Tensors returns a copy of every weight by name
+func (m *Model) Tensors() map[string]Tensor {
+	tensors := make(map[string]Tensor)
+	for _, p := range m.parameters() {
+		tensors[p.name] = Tensor{Shape: append([]int(nil), p.shape...), Data: append([]float64(nil), p.data...)}
+	}
+	return tensors
+}
+
+// SetTensors copies weights into the model. Every weight must be present with
+// the model's shape; unknown tensors are an error too, as they usually mean a
+// mismatched config.
+func (m *Model) SetTensors(tensors map[string]Tensor) error {
+	params := m.parameters()
+	for _, p := range params {
+		t, ok := tensors[p.name]
+		if !ok {
+			return fmt.Errorf("missing tensor %s", p.name)
+		}
+		if !slices.Equal(t.Shape, p.shape) {
+			return fmt.Errorf("tensor %s has shape %v, expected %v", p.name, t.Shape, p.shape)
+		}
+		copy(p.data, t.Data)
+	}
+	if len(tensors) != len(params) {
+		known := make(map[string]bool, len(params))
+		for _, p := range params {
+			known[p.name] = true
+		}
+		for name := range tensors {
+			if !known[name] {
+				return fmt.Errorf("unexpected tensor %s", name)
+			}
+		}
+	}
+	return nil
+}
+
+// Metadata keys holding the config and tokenizer in a safetensors file
+const (
+	metadataConfig   = "config"
+	metadataAlphabet = "alphabet"
+	metadataTags     = "tags"
+)
+
+// Save writes the weights, config and tokenizer to a safetensors file
+func (m *Model) Save(path string) error {
+	config, err := json.Marshal(m.Config)
+	if err != nil {
+		return err
+	}
+	tags, err := json.Marshal(m.Tokenizer.Tags)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	metadata := map[string]string{metadataConfig: string(config), metadataAlphabet: m.Tokenizer.Alphabet, metadataTags: string(tags)}
+	if err := WriteSafetensors(f, m.Tensors(), metadata); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// Load reads a model written by Save, or any safetensors file with the same
+// tensor names and config and tokenizer metadata
+func Load(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	tensors, metadata, err := ReadSafetensors(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var c Config
+	if err := json.Unmarshal([]byte(metadata[metadataConfig]), &c); err != nil {
+		return nil, fmt.Errorf("%s: config metadata: %w", path, err)
+	}
+	var tags []string
+	if raw, ok := metadata[metadataTags]; ok && raw != "" {
+		if err := json.Unmarshal([]byte(raw), &tags); err != nil {
+			return nil, fmt.Errorf("%s: tags metadata: %w", path, err)
+		}
+	}
+	t, err := NewTokenizer(metadata[metadataAlphabet], tags...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	m, err := NewModel(c, t)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := m.SetTensors(tensors); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
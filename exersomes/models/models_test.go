@@ -0,0 +1,274 @@
+package models
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+// tinyModel is a one-block RNA model of width 4 with 2 heads
+func tinyModel(t *testing.T) *Model {
+	t.Helper()
+	tok, err := NewTokenizer(RNA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewModel(Config{Dim: 4, Heads: 2, FeedForward: 6, EncoderLayers: 1, DecoderLayers: 1, Activation: GELU}, tok)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// fill sets every weight from a fixed formula, so an independent implementation
+// can reproduce the model
+func fill(m *Model) {
+	for i, p := range m.parameters() {
+		for k := range p.data {
+			p.data[k] = 0.3 * math.Sin(1.7*float64(i+1)+0.61*float64(k))
+		}
+	}
+}
+
+func closeTo(t *testing.T, what string, got, want []float64, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d values, want %d", what, len(got), len(want))
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > tol {
+			t.Errorf("%s[%d] = %.12g, want %.12g", what, i, got[i], want[i])
+		}
+	}
+}
+
+// Test the tokenizer's vocabulary layout and round trip
+func TestTokenizer(t *testing.T) {
+	tok, err := NewTokenizer(RNA, "<miRNA>", "<lncRNA>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.Size() != 10 {
+		t.Errorf("Expected 4 special, 4 residue and 2 tag tokens, got %d", tok.Size())
+	}
+	ids := tok.Encode("acg tuN")
+	want := []int{4, 5, 6, 7, 7, Unk}
+	for i := range want {
+		if i >= len(ids) || ids[i] != want[i] {
+			t.Fatalf("Encode = %v, want %v", ids, want)
+		}
+	}
+	if got := tok.Decode(append([]int{BOS, 8}, append(ids, EOS, 4)...)); got != "ACGUU" {
+		t.Errorf("Decode = %q, want ACGUU", got)
+	}
+	if id, ok := tok.Tag("<lncRNA>"); !ok || id != 9 {
+		t.Errorf("Tag(<lncRNA>) = %d, %v", id, ok)
+	}
+	if _, ok := tok.Tag("A"); ok {
+		t.Error("A is a residue, not a tag")
+	}
+	if _, err := NewTokenizer(Protein, "A"); err == nil {
+		t.Error("Expected a duplicate token error")
+	}
+}
+
+// Test the masks block padding and future positions
+func TestMasks(t *testing.T) {
+	inf := math.Inf(-1)
+	closeTo(t, "padding", PaddingMask(2, 3, 2).Data, []float64{0, 0, inf, 0, 0, inf}, 0)
+	closeTo(t, "causal", CausalMask(3, 2).Data, []float64{0, inf, inf, 0, 0, inf, 0, 0, inf}, 0)
+
+	b := NewBatch([][]int{{4, 5}, {4, 5, 6, 7}})
+	if len(b.IDs[0]) != 4 || b.IDs[0][3] != Pad || b.Lengths[0] != 2 || b.Lengths[1] != 4 {
+		t.Errorf("Unexpected batch %+v", b)
+	}
+}
+
+// Test attention against a hand-computed case: with identity projections and
+// one head, the first query attends only to the unmasked keys
+func TestAttention(t *testing.T) {
+	identity := func() Linear {
+		l := newLinear(2, 2)
+		l.Weight.Data[0], l.Weight.Data[3] = 1, 1
+		return l
+	}
+	a := MultiHeadAttention{Heads: 1, Query: identity(), Key: identity(), Value: identity(), Output: identity()}
+	x := Matrix{Rows: 3, Cols: 2, Data: []float64{1, 0, 0, 1, 5, 5}}
+
+	// Query (1, 0) scores keys 1/√2 and 0; the third key is padding
+	w := math.Exp(1/math.Sqrt2) / (math.Exp(1/math.Sqrt2) + 1)
+	got := a.Forward(x, x, PaddingMask(3, 3, 2))
+	closeTo(t, "row 0", got.Row(0), []float64{w, 1 - w}, 1e-12)
+}
+
+// Test the full model against logits from an independent reference
+// implementation with the same weights
+func TestReferenceOutputs(t *testing.T) {
+	m := tinyModel(t)
+	fill(m)
+	logits := m.Logits(m.Tokenizer.Encode("ACGUU"), []int{BOS, 6, 4})
+	want := [][]float64{
+		{-0.118459852974, -0.328893991162, -0.0519923920522, -0.527445962383, -0.00338045273516, 0.0567522047551, -0.00227247795307, 0.555431368949},
+		{-0.0553966292358, -0.291482322446, -0.172206818392, -0.381214418405, -0.106553779023, 0.0681313709126, 0.083517688139, 0.412996536692},
+		{-0.0806987763632, -0.284871469217, -0.157003611655, -0.411050152487, -0.0761790112474, 0.0515656862203, 0.0784491639646, 0.437305053017},
+	}
+	for i := range want {
+		closeTo(t, "logits", logits.Row(i), want[i], 1e-9)
+	}
+}
+
+// Test padding and future tokens do not change the outputs at real positions
+func TestPaddingInvariance(t *testing.T) {
+	m := tinyModel(t)
+	m.Randomize(rand.New(rand.NewSource(1)))
+	source, target := m.Tokenizer.Encode("GAUC"), []int{BOS, 4, 5}
+	want := m.Logits(source, target)
+
+	sources := NewBatch([][]int{source, m.Tokenizer.Encode("ACGUACGU")})
+	targets := NewBatch([][]int{target, {BOS, 4, 5, 6, 7, 4}})
+	got := m.Decoder.Decode(targets, m.Encoder.Encode(sources), sources)[0]
+	for i := range target {
+		closeTo(t, "padded", got.Row(i), want.Row(i), 1e-12)
+	}
+
+	// Later target tokens are invisible to earlier positions
+	longer := m.Logits(source, []int{BOS, 4, 5, 7, 7})
+	for i := range target {
+		closeTo(t, "causal", longer.Row(i), want.Row(i), 1e-12)
+	}
+}
+
+// Test weights survive a safetensors round trip at F32 precision
+func TestSaveLoad(t *testing.T) {
+	m := tinyModel(t)
+	m.Randomize(rand.New(rand.NewSource(2)))
+	path := filepath.Join(t.TempDir(), "tiny.safetensors")
+	if err := m.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Config != m.Config || loaded.Tokenizer.Size() != m.Tokenizer.Size() {
+		t.Errorf("Loaded config %+v, want %+v", loaded.Config, m.Config)
+	}
+	source, target := m.Tokenizer.Encode("ACGU"), []int{BOS, 7}
+	closeTo(t, "loaded logits", loaded.Logits(source, target).Data, m.Logits(source, target).Data, 1e-5)
+
+	tensors := m.Tensors()
+	delete(tensors, "decoder.norm.bias")
+	if err := loaded.SetTensors(tensors); err == nil {
+		t.Error("Expected an error for a missing tensor")
+	}
+}
+
+// Test reading the half-precision dtypes
+func TestSafetensorsDTypes(t *testing.T) {
+	// Header, then 1.5 as BF16 (0x3fc0) and -2 as F16 (0xc000)
+	header := `{"a":{"dtype":"BF16","shape":[1],"data_offsets":[0,2]},"b":{"dtype":"F16","shape":[1],"data_offsets":[2,4]}}`
+	var b bytes.Buffer
+	b.Write([]byte{byte(len(header)), 0, 0, 0, 0, 0, 0, 0})
+	b.WriteString(header)
+	b.Write([]byte{0xc0, 0x3f, 0x00, 0xc0})
+	tensors, _, err := ReadSafetensors(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tensors["a"].Data[0] != 1.5 || tensors["b"].Data[0] != -2 {
+		t.Errorf("Decoded %v and %v, want 1.5 and -2", tensors["a"].Data, tensors["b"].Data)
+	}
+}
+
+// familyModel is a random protein model conditioned on two families, biased
+// towards short sequences
+func familyModel(t *testing.T) *Model {
+	t.Helper()
+	tok, err := NewTokenizer(Protein, FamilyTag("FGF"), FamilyTag("myokine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewModel(Config{Dim: 8, Heads: 2, FeedForward: 16, EncoderLayers: 1, DecoderLayers: 2}, tok)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Randomize(rand.New(rand.NewSource(3)))
+	m.Decoder.Output.Bias[EOS] = 2
+	return m
+}
+
+// Test an untrained model is uniform, so its perplexity is the vocabulary size
+func TestScoreUniform(t *testing.T) {
+	m := tinyModel(t)
+	s := m.Score(nil, m.Tokenizer.Encode("ACGUACGU"))
+	if s.Tokens != 9 || math.Abs(s.Perplexity()-float64(m.Tokenizer.Size())) > 1e-9 {
+		t.Errorf("Expected perplexity %d over 9 tokens, got %g over %d", m.Tokenizer.Size(), s.Perplexity(), s.Tokens)
+	}
+}
+
+// Test the decoding strategies agree where they should and report the
+// likelihood that Score assigns
+func TestGenerate(t *testing.T) {
+	m := familyModel(t)
+	source, err := m.Prompt("FGF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Prompt("Wnt"); err == nil {
+		t.Error("Expected an error for an unknown family")
+	}
+	generate := func(opts GenerateOptions, seed int64) Generation {
+		t.Helper()
+		opts.MaxLength = 30
+		gens, err := m.Generate(source, []int{4}, opts, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gens[0]
+	}
+
+	greedy := generate(GenerateOptions{}, 1)
+	if !greedy.Finished || greedy.Sequence[0] != 'A' {
+		t.Fatalf("Expected a finished generation after the prefix, got %+v", greedy)
+	}
+	for _, opts := range []GenerateOptions{{Strategy: TopK, TopK: 1}, {Strategy: TopP, TopP: 1e-9}, {Strategy: Beam, Beams: 1}} {
+		if g := generate(opts, 2); g.Sequence != greedy.Sequence {
+			t.Errorf("%s: got %s, want the greedy %s", opts.Strategy, g.Sequence, greedy.Sequence)
+		}
+	}
+
+	// A finished generation scores the same, less the prefix it was given
+	prefix := LogSoftmax(m.Logits(source, []int{BOS}).Row(0))[4]
+	checked := 0
+	for seed := int64(0); seed < 5; seed++ {
+		g := generate(GenerateOptions{Strategy: TopP}, seed)
+		if !g.Finished {
+			continue
+		}
+		checked++
+		if s := m.Score(source, g.IDs); math.Abs(s.LogLikelihood-prefix-g.LogLikelihood) > 1e-9 {
+			t.Errorf("Generation log-likelihood %g does not match its score %g", g.LogLikelihood, s.LogLikelihood-prefix)
+		}
+	}
+	if checked == 0 {
+		t.Error("No top-p generation finished")
+	}
+
+	beams, err := m.Generate(source, nil, GenerateOptions{Strategy: Beam, Beams: 3, MaxLength: 30}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beams) != 3 {
+		t.Fatalf("Expected 3 beam candidates, got %d", len(beams))
+	}
+	for i := 1; i < len(beams); i++ {
+		if beams[i].LogLikelihood/float64(beams[i].Tokens) > beams[i-1].LogLikelihood/float64(beams[i-1].Tokens) {
+			t.Error("Beam candidates are not ranked by log-likelihood per token")
+		}
+	}
+	if _, err := m.Generate(source, nil, GenerateOptions{Strategy: "ancestral"}, nil); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Tensor is a named weight as stored in a safetensors file
+type Tensor struct {
+	Shape []int
+	Data  []float64
+}
+
+// size returns the number of elements the shape holds
+func (t Tensor) size() int {
+	n := 1
+	for _, d := range t.Shape {
+		n *= d
+	}
+	return n
+}
+
+// safetensorsEntry is a tensor's header record
+type safetensorsEntry struct {
+	DType       string   `json:"dtype"`
+	Shape       []int    `json:"shape"`
+	DataOffsets [2]int64 `json:"data_offsets"`
+}
+
+// maxHeader bounds the JSON header, as the reference implementation does
+const maxHeader = 100 << 20
+
+// ReadSafetensors reads a safetensors file: an 8-byte little-endian header
+// length, a JSON header of tensors and "__metadata__", then the raw data.
+// F64, F32, F16 and BF16 tensors are widened to float64.
+func ReadSafetensors(r io.Reader) (map[string]Tensor, map[string]string, error) {
+	var headerLen uint64
+	if err := binary.Read(r, binary.LittleEndian, &headerLen); err != nil {
+		return nil, nil, fmt.Errorf("reading header length: %w", err)
+	}
+	if headerLen > maxHeader {
+		return nil, nil, fmt.Errorf("header of %d bytes is too large", headerLen)
+	}
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, nil, fmt.Errorf("reading header: %w", err)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(header, &raw); err != nil {
+		return nil, nil, fmt.Errorf("parsing header: %w", err)
+	}
+	var metadata map[string]string
+	entries := make(map[string]safetensorsEntry, len(raw))
+	var end int64
+	for name, msg := range raw {
+		if name == "__metadata__" {
+			if err := json.Unmarshal(msg, &metadata); err != nil {
+				return nil, nil, fmt.Errorf("parsing metadata: %w", err)
+			}
+			continue
+		}
+		var e safetensorsEntry
+		if err := json.Unmarshal(msg, &e); err != nil {
+			return nil, nil, fmt.Errorf("tensor %s: %w", name, err)
+		}
+		entries[name] = e
+		end = max(end, e.DataOffsets[1])
+	}
+	data := make([]byte, end)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, nil, fmt.Errorf("reading tensor data: %w", err)
+	}
+
+	tensors := make(map[string]Tensor, len(entries))
+	for name, e := range entries {
+		t := Tensor{Shape: e.Shape}
+		width, ok := dtypeWidths[e.DType]
+		if !ok {
+			return nil, nil, fmt.Errorf("tensor %s: unsupported dtype %s", name, e.DType)
+		}
+		begin, stop := e.DataOffsets[0], e.DataOffsets[1]
+		if begin < 0 || stop < begin || stop-begin != int64(t.size()*width) {
+			return nil, nil, fmt.Errorf("tensor %s: data offsets %v do not fit shape %v of %s", name, e.DataOffsets, e.Shape, e.DType)
+		}
+		t.Data = decode(e.DType, data[begin:stop])
+		tensors[name] = t
+	}
+	return tensors, metadata, nil
+}
+
+var dtypeWidths = map[string]int{"F64": 8, "F32": 4, "F16": 2, "BF16": 2}
+
+func decode(dtype string, b []byte) []float64 {
+	width := dtypeWidths[dtype]
+	out := make([]float64, len(b)/width)
+	for i := range out {
+		chunk := b[i*width : (i+1)*width]
+		switch dtype {
+		case "F64":
+			out[i] = math.Float64frombits(binary.LittleEndian.Uint64(chunk))
+		case "F32":
+			out[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(chunk)))
+		case "BF16":
+			out[i] = float64(math.Float32frombits(uint32(binary.LittleEndian.Uint16(chunk)) << 16))
+		case "F16":
+			out[i] = halfToFloat(binary.LittleEndian.Uint16(chunk))
+		}
+	}
+	return out
+}
+
+// halfToFloat widens an IEEE 754 half-precision value
+func halfToFloat(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h>>10) & 0x1f
+	frac := float64(h & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac != 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(sign))
+	}
+	return sign * math.Ldexp(1+frac/1024, exp-15)
+}
+
+// WriteSafetensors writes tensors as F32 in name order, with optional metadata
+func WriteSafetensors(w io.Writer, tensors map[string]Tensor, metadata map[string]string) error {
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		if name == "__metadata__" {
+			return errors.New("__metadata__ is reserved")
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	header := make(map[string]any, len(tensors)+1)
+	if len(metadata) > 0 {
+		header["__metadata__"] = metadata
+	}
+	var offset int64
+	for _, name := range names {
+		t := tensors[name]
+		if t.size() != len(t.Data) {
+			return fmt.Errorf("tensor %s: shape %v does not hold %d values", name, t.Shape, len(t.Data))
+		}
+		n := int64(4 * len(t.Data))
+		header[name] = safetensorsEntry{DType: "F32", Shape: t.Shape, DataOffsets: [2]int64{offset, offset + n}}
+		offset += n
+	}
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	// Pad the header with spaces so the data starts 8-byte aligned
+	if pad := len(encoded) % 8; pad != 0 {
+		encoded = append(encoded, strings.Repeat(" ", 8-pad)...)
+	}
+
+	bw := bufio.NewWriter(w)
+	binary.Write(bw, binary.LittleEndian, uint64(len(encoded)))
+	bw.Write(encoded)
+	buf := make([]byte, 4)
+	for _, name := range names {
+		for _, v := range tensors[name].Data {
+			binary.LittleEndian.PutUint32(buf, math.Float32bits(float32(v)))
+			bw.Write(buf)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"math"
+)
+
+// Matrix is a dense row-major matrix; a sequence is one row per position
+type Matrix struct {
+	Rows, Cols int
+	Data       []float64
+}
+
+// NewMatrix returns a zero matrix
+func NewMatrix(rows, cols int) Matrix {
+	return Matrix{Rows: rows, Cols: cols, Data: make([]float64, rows*cols)}
+}
+
+// Row returns row i, sharing the matrix's storage
+func (m Matrix) Row(i int) []float64 {
+	return m.Data[i*m.Cols : (i+1)*m.Cols]
+}
+
+// At returns the element in row i, column j
+func (m Matrix) At(i, j int) float64 {
+	return m.Data[i*m.Cols+j]
+}
+
+// add returns a + b elementwise
+func add(a, b Matrix) Matrix {
+	out := NewMatrix(a.Rows, a.Cols)
+	for i := range out.Data {
+		out.Data[i] = a.Data[i] + b.Data[i]
+	}
+	return out
+}
+
+// Linear is an affine map y = x·Wᵀ + b, with Weight stored out × in as in PyTorch
+type Linear struct {
+	Weight Matrix
+	Bias   []float64
+}
+
+func newLinear(in, out int) Linear {
+	return Linear{Weight: NewMatrix(out, in), Bias: make([]float64, out)}
+}
+
+// Forward applies the map to every row of x
+func (l Linear) Forward(x Matrix) Matrix {
+	out := NewMatrix(x.Rows, l.Weight.Rows)
+	for i := 0; i < x.Rows; i++ {
+		xi := x.Row(i)
+		yi := out.Row(i)
+		for j := range yi {
+			yi[j] = l.Bias[j] + dot(xi, l.Weight.Row(j))
+		}
+	}
+	return out
+}
+
+// LayerNorm normalizes each row to zero mean and unit variance, then scales and shifts it
+type LayerNorm struct {
+	Gain []float64
+	Bias []float64
+	Eps  float64
+}
+
+func newLayerNorm(dim int, eps float64) LayerNorm {
+	n := LayerNorm{Gain: make([]float64, dim), Bias: make([]float64, dim), Eps: eps}
+	for i := range n.Gain {
+		n.Gain[i] = 1
+	}
+	return n
+}
+
+// Forward normalizes every row of x
+func (n LayerNorm) Forward(x Matrix) Matrix {
+	out := NewMatrix(x.Rows, x.Cols)
+	for i := 0; i < x.Rows; i++ {
+		xi := x.Row(i)
+		var mean, variance float64
+		for _, v := range xi {
+			mean += v
+		}
+		mean /= float64(len(xi))
+		for _, v := range xi {
+			variance += (v - mean) * (v - mean)
+		}
+		variance /= float64(len(xi))
+		scale := 1 / math.Sqrt(variance+n.Eps)
+		yi := out.Row(i)
+		for j, v := range xi {
+			yi[j] = (v-mean)*scale*n.Gain[j] + n.Bias[j]
+		}
+	}
+	return out
+}
+
+// Activation functions of the feed-forward blocks
+const (
+	ReLU = "relu"
+	GELU = "gelu"
+)
+
+func activate(name string, x Matrix) {
+	for i, v := range x.Data {
+		switch name {
+		case GELU:
+			x.Data[i] = 0.5 * v * (1 + math.Erf(v/math.Sqrt2))
+		default:
+			x.Data[i] = math.Max(v, 0)
+		}
+	}
+}
+
+// softmax normalizes x in place. Entries of -Inf get zero weight, and a row
+// with every entry at -Inf becomes all zeros rather than NaN.
+func softmax(x []float64) {
+	peak := math.Inf(-1)
+	for _, v := range x {
+		peak = math.Max(peak, v)
+	}
+	if math.IsInf(peak, -1) {
+		for i := range x {
+			x[i] = 0
+		}
+		return
+	}
+	var sum float64
+	for i, v := range x {
+		x[i] = math.Exp(v - peak)
+		sum += x[i]
+	}
+	for i := range x {
+		x[i] /= sum
+	}
+}
+
+// LogSoftmax returns log probabilities from a row of logits
+func LogSoftmax(logits []float64) []float64 {
+	peak := math.Inf(-1)
+	for _, v := range logits {
+		peak = math.Max(peak, v)
+	}
+	var sum float64
+	for _, v := range logits {
+		sum += math.Exp(v - peak)
+	}
+	norm := peak + math.Log(sum)
+	out := make([]float64, len(logits))
+	for i, v := range logits {
+		out[i] = v - norm
+	}
+	return out
+}
+
+func dot(a, b []float64) float64 {
+	var s float64
+	for i, v := range a {
+		s += v * b[i]
+	}
+	return s
+}
+
+// positionalEncoding is the sinusoidal encoding of "Attention Is All You Need"
+func positionalEncoding(positions, dim int) Matrix {
+	pe := NewMatrix(positions, dim)
+	for pos := 0; pos < positions; pos++ {
+		row := pe.Row(pos)
+		for i := 0; i < dim; i += 2 {
+			angle := float64(pos) / math.Pow(10000, float64(i)/float64(dim))
+			row[i] = math.Sin(angle)
+			if i+1 < dim {
+				row[i+1] = math.Cos(angle)
+			}
+		}
+	}
+	return pe
+}
+
+// embed looks up token embeddings, scales them by √dim and adds positions
+func embed(table Matrix, ids []int) Matrix {
+	x := positionalEncoding(len(ids), table.Cols)
+	scale := math.Sqrt(float64(table.Cols))
+	for pos, id := range ids {
+		row := x.Row(pos)
+		for j, v := range table.Row(id) {
+			row[j] += v * scale
+		}
+	}
+	return x
+}
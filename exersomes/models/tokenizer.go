@@ -0,0 +1,160 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Alphabets of residues, one token per letter
+const (
+	DNA     = "ACGT"
+	RNA     = "ACGU"
+	Protein = "ACDEFGHIKLMNPQRSTVWY"
+)
+
+// Special tokens, which take the first IDs of every vocabulary
+const (
+	Pad = iota
+	BOS
+	EOS
+	Unk
+)
+
+var specialTokens = []string{"<pad>", "<bos>", "<eos>", "<unk>"}
+
+// Tokenizer maps sequences over an alphabet to token IDs. Tags are extra
+// whole-word tokens, such as "<miRNA>", for conditioning prompts.
+type Tokenizer struct {
+	Alphabet string
+	Tags     []string
+	vocab    []string
+	index    map[string]int
+}
+
+// NewTokenizer returns a tokenizer with the special tokens, then one token per
+// residue of the alphabet, then the tags
+func NewTokenizer(alphabet string, tags ...string) (*Tokenizer, error) {
+	t := &Tokenizer{Alphabet: strings.ToUpper(alphabet), Tags: tags, index: make(map[string]int)}
+	vocab := append([]string(nil), specialTokens...)
+	for _, r := range t.Alphabet {
+		vocab = append(vocab, string(r))
+	}
+	vocab = append(vocab, tags...)
+	for _, token := range vocab {
+		if _, ok := t.index[token]; ok {
+			return nil, fmt.Errorf("duplicate token %q", token)
+		}
+		t.index[token] = len(t.vocab)
+		t.vocab = append(t.vocab, token)
+	}
+	return t, nil
+}
+
+// Size returns the number of tokens in the vocabulary
+func (t *Tokenizer) Size() int {
+	return len(t.vocab)
+}
+
+// Token returns the text of a token ID
+func (t *Tokenizer) Token(id int) string {
+	if id < 0 || id >= len(t.vocab) {
+		return specialTokens[Unk]
+	}
+	return t.vocab[id]
+}
+
+// Tag returns the ID of a tag token
+func (t *Tokenizer) Tag(name string) (int, bool) {
+	id, ok := t.index[name]
+	return id, ok && id >= len(specialTokens)+len(t.Alphabet)
+}
+
+// Encode returns the token IDs of a sequence's residues. Case and whitespace
+// are ignored, T and U are interchangeable when the alphabet has only one of
+// them, and any other letter becomes Unk.
+func (t *Tokenizer) Encode(seq string) []int {
+	hasT, hasU := strings.ContainsRune(t.Alphabet, 'T'), strings.ContainsRune(t.Alphabet, 'U')
+	ids := make([]int, 0, len(seq))
+	for _, r := range strings.ToUpper(seq) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		switch {
+		case r == 'T' && !hasT && hasU:
+			r = 'U'
+		case r == 'U' && !hasU && hasT:
+			r = 'T'
+		}
+		id, ok := t.index[string(r)]
+		if !ok || id < len(specialTokens) {
+			id = Unk
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Decode returns the residues of a token sequence, stopping at EOS and
+// skipping the other special tokens and tags
+func (t *Tokenizer) Decode(ids []int) string {
+	var b strings.Builder
+	for _, id := range ids {
+		if id == EOS {
+			break
+		}
+		if id >= len(specialTokens) && id < len(specialTokens)+len(t.Alphabet) {
+			b.WriteString(t.vocab[id])
+		}
+	}
+	return b.String()
+}
+
+// Batch is a set of token sequences padded with Pad to a common length
+type Batch struct {
+	IDs     [][]int
+	Lengths []int // Unpadded length of each sequence
+}
+
+// NewBatch pads sequences to the length of the longest
+func NewBatch(seqs [][]int) Batch {
+	width := 0
+	for _, s := range seqs {
+		width = max(width, len(s))
+	}
+	b := Batch{IDs: make([][]int, len(seqs)), Lengths: make([]int, len(seqs))}
+	for i, s := range seqs {
+		b.IDs[i] = make([]int, width)
+		copy(b.IDs[i], s)
+		b.Lengths[i] = len(s)
+	}
+	return b
+}
+
+// Masks are additive attention biases with one row per query and one column
+// per key: 0 where the query may attend to the key and -Inf where it may not.
+
+// PaddingMask lets every query attend to the first length keys only
+func PaddingMask(queries, keys, length int) Matrix {
+	m := NewMatrix(queries, keys)
+	for i := 0; i < queries; i++ {
+		row := m.Row(i)
+		for j := length; j < keys; j++ {
+			row[j] = math.Inf(-1)
+		}
+	}
+	return m
+}
+
+// CausalMask lets query i attend to keys up to i that are within length
+func CausalMask(n, length int) Matrix {
+	m := PaddingMask(n, n, length)
+	for i := 0; i < n; i++ {
+		row := m.Row(i)
+		for j := i + 1; j < n; j++ {
+			row[j] = math.Inf(-1)
+		}
+	}
+	return m
+}
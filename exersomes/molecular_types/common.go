@@ -16,12 +16,23 @@ type ExerkineNetwork struct {
     Interactions  []Interaction
 }
 
+// Interaction types
+const (
+	LigandReceptor = "Ligand-receptor" // A secreted ligand binding its receptor
+	VesicleCargo   = "EV cargo"        // Cargo delivered by extracellular vesicle uptake
+)
+
 type Interaction struct {
-    Ligand      string
-    Receptor    string
-    Tissue      string
-    Pathway     string
-    Strength    float64 // Optional confidence score
+    Type         string  // LigandReceptor (also when empty) or VesicleCargo
+    Ligand       string
+    Receptor     string
+    Tissue       string  // Tissue where the receptor is engaged
+    SourceTissue string  // Tissue secreting the ligand
+    TargetTissue string  // Tissue expressing the receptor
+    Pathway      string
+    Strength     float64 // Optional confidence score
+    Sources      []string // Catalogs and databases reporting the pair
+    Evidence     int      // Distinct references supporting the pair
 }
 
 // ExerciseMolecule represents a common interface for all exercise-responsive molecules
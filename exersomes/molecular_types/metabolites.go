@@ -1,4 +1,4 @@
-package metabolites
+package molecular_types
 
 type Metabolite struct {
     ID   string
@@ -65,6 +65,7 @@ var (
 func PredictMetaboliteResponse(metabolite ExerciseMetabolite, exerciseType string,
 	intensity float64, duration int) float64 {
 	// Implementation for predicting metabolite response based on exercise parameters
+	return 1.0 // No change until a response model is implemented
 }
 
 // Functions for metabolite signaling, tissue specificity, etc.
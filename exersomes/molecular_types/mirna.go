@@ -1,4 +1,4 @@
-package mirna
+package molecular_types
 
 type MiRNA struct {
     ID   string
@@ -59,13 +59,94 @@ var (
 		ExerciseType: []string{"Resistance", "HIIT"},
 	}
 
-	// Add other key miRNAs: miR-133a, miR-1, miR-206, miR-126, miR-21, etc.
+	MiR1 = ExerciseMiRNA{
+		ID:               "hsa-miR-1-3p",
+		Name:             "miR-1",
+		Sequence:         "UGGAAUGUAAAGAAGUAUGUAU",
+		PrimarySource:    "Skeletal muscle",
+		SecondarySource:  []string{"Heart"},
+		ExerciseResponse: "Up",
+		TemporalDynamics: "Rises during prolonged endurance exercise, returns to baseline by 24h",
+		TransportMechanism: []string{
+			"Exosome",
+			"Protein-bound (Argonaute)",
+		},
+		TargetPathways: []string{
+			"IGF-1/Akt signaling",
+			"Myogenic differentiation",
+			"Cardiac conduction",
+		},
+		TargetGenes: []string{"HDAC4", "IGF1", "KCNJ2", "GJA1", "HAND2"},
+		PhysiologicalEffect: []string{
+			"Promotes myoblast differentiation",
+			"Restrains IGF-1 driven growth",
+			"Regulates cardiac excitability",
+		},
+		ExerciseType: []string{"Aerobic"},
+	}
+
+	MiR133a = ExerciseMiRNA{
+		ID:               "hsa-miR-133a-3p",
+		Name:             "miR-133a",
+		Sequence:         "UUUGGUCCCCUUCAACCAGCUG",
+		PrimarySource:    "Skeletal muscle",
+		SecondarySource:  []string{"Heart"},
+		ExerciseResponse: "Up",
+		TemporalDynamics: "Peaks immediately post-exercise, returns to baseline within 24h",
+		TransportMechanism: []string{
+			"Exosome",
+			"Protein-bound (Argonaute)",
+		},
+		TargetPathways: []string{
+			"SRF signaling",
+			"Myoblast proliferation",
+			"Cardiac hypertrophy",
+		},
+		TargetGenes: []string{"SRF", "CCND2", "RHOA", "CDC42", "IGF1R", "KCNH2"},
+		PhysiologicalEffect: []string{
+			"Promotes myoblast proliferation",
+			"Limits cardiac hypertrophy",
+		},
+		ExerciseType: []string{"Aerobic", "Resistance"},
+	}
+
+	MiR206 = ExerciseMiRNA{
+		ID:               "hsa-miR-206",
+		Name:             "miR-206",
+		Sequence:         "UGGAAUGUAAGGAAGUGUGUGG",
+		PrimarySource:    "Skeletal muscle",
+		ExerciseResponse: "Up",
+		TemporalDynamics: "Rises after endurance and eccentric exercise, returns to baseline by 24-48h",
+		TransportMechanism: []string{
+			"Exosome",
+			"Protein-bound (Argonaute)",
+		},
+		TargetPathways: []string{
+			"Satellite cell differentiation",
+			"Neuromuscular junction regeneration",
+		},
+		TargetGenes: []string{"PAX7", "HDAC4", "POLA1", "GJA1", "UTRN", "NOTCH3"},
+		PhysiologicalEffect: []string{
+			"Drives satellite cell differentiation",
+			"Supports reinnervation after injury",
+		},
+		ExerciseType: []string{"Aerobic", "Resistance"},
+	}
+
+	// Add other key miRNAs: miR-126, miR-21, etc.
 )
 
+// MiRNACatalog lists the exercise-responsive miRNAs
+var MiRNACatalog = []ExerciseMiRNA{MiR486, MiR1, MiR133a, MiR206}
+
+// CirculatingMiRNAPanel is the circulating muscle miRNA panel used for target prediction
+var CirculatingMiRNAPanel = []ExerciseMiRNA{MiR1, MiR133a, MiR206, MiR486}
+
 // CalculateExerciseMiRNAResponse estimates changes in miRNA levels with exercise
 func CalculateExerciseMiRNAResponse(miRNA ExerciseMiRNA, exerciseType string,
 	intensity float64, duration int) float64 {
 	// Implementation for predicting miRNA response based on exercise parameters
+	return 1.0 // No change until a response model is implemented
 }
 
 // Implementation functions for pathways, targets, etc.
@@ -1,8 +1,9 @@
-package proteins
+package molecular_types
 
 // ExerciseProtein represents a protein released during exercise
 type ExerciseProtein struct {
 	Name                 string
+	GeneSymbol           string
 	UniprotID            string
 	MolecularWeightKDa   float64
 	Classification       string   // "Myokine", "Hepatokine", "Adipokine", etc.
@@ -22,6 +23,7 @@ type ExerciseProtein struct {
 var (
 	IL6 = ExerciseProtein{
 		Name:                "Interleukin 6",
+		GeneSymbol:          "IL6",
 		UniprotID:           "P05231",
 		MolecularWeightKDa:  21.0,
 		Classification:      "Myokine/Cytokine",
@@ -55,13 +57,49 @@ var (
 		ExerciseTypes: []string{"Endurance", "HIIT", "Prolonged aerobic"},
 	}
 
-	// Add other key proteins: BDNF, Irisin, IL-15, Decorin, Myostatin, etc.
+	Myostatin = ExerciseProtein{
+		Name:                "Myostatin",
+		GeneSymbol:          "MSTN",
+		UniprotID:           "O14793",
+		MolecularWeightKDa:  12.4,
+		Classification:      "Myokine",
+		SourceTissues:       []string{"Skeletal muscle"},
+		Receptors:           []string{"ActRIIB", "ALK4", "ALK5"},
+		ExerciseRegulation:  "Chronic down",
+		TimeToRelease:       "Muscle expression falls within hours of exercise and stays lower with training",
+		CirculationHalfLife: "Circulates largely as a latent complex with its propeptide, follistatin or GASP-1",
+		SignalingPathways: []string{
+			"SMAD2/3",
+			"Akt/mTOR inhibition",
+		},
+		TargetTissues: []string{
+			"Skeletal muscle",
+			"Adipose tissue",
+		},
+		PhysiologicalEffects: []string{
+			"Limits muscle growth",
+			"Lower levels permit hypertrophy",
+			"Promotes fat accumulation",
+		},
+		ClinicalSignificance: []string{
+			"Sarcopenia",
+			"Cachexia",
+			"Obesity",
+		},
+		ExerciseTypes: []string{"Resistance", "Endurance"},
+	}
+
+	// Add other key proteins: BDNF, Irisin, IL-15, Decorin, etc.
 )
 
+// ProteinCatalog lists the exercise-responsive proteins
+var ProteinCatalog = []ExerciseProtein{IL6, Myostatin}
+
 // CalculateProteinResponse estimates changes in protein levels with different exercise protocols
 func CalculateProteinResponse(protein ExerciseProtein, exerciseType string,
 	intensity float64, duration int, chronicWeeks int) float64 {
 	// Implementation for predicting protein response based on exercise parameters
+	return 1.0 // No change until a response model is implemented
 }
 
 // Implementation for receptor binding, tissue effects, etc.
@@ -1,4 +1,4 @@
-package rna
+package molecular_types
 
 // ExerciseRNA represents an RNA affected by exercise (excluding miRNAs)
 type ExerciseRNA struct {
@@ -43,11 +43,39 @@ var (
 		ExerciseTiming: "Gradual increase, peaks several hours post-exercise",
 	}
 
+	PGC1AmRNA = ExerciseRNA{
+		Name:               "PPARGC1A",
+		Type:               "mRNA",
+		Source:             "Skeletal muscle",
+		Length:             6306,
+		ExerciseRegulation: "Up with endurance and high-intensity exercise",
+		TransportMechanism: []string{"Extracellular vesicles"},
+		TargetTissues:      []string{"Liver", "Adipose tissue"},
+		Function: []string{
+			"Encodes PGC-1α, the master regulator of mitochondrial biogenesis",
+			"Drives oxidative fibre-type gene programs",
+		},
+		AssociatedPathways: []string{
+			"AMPK/PGC-1α",
+			"Mitochondrial biogenesis",
+			"Fatty acid oxidation",
+		},
+		RelatedDiseases: []string{
+			"Type 2 diabetes",
+			"Sarcopenia",
+		},
+		ExerciseTiming: "Muscle transcript peaks 2-4h post-exercise",
+	}
+
 	// Add other key RNAs: circular RNAs, other lncRNAs, etc.
 )
 
+// RNACatalog lists the exercise-responsive RNAs, excluding miRNAs
+var RNACatalog = []ExerciseRNA{MALAT1, PGC1AmRNA}
+
 // PredictRNAResponse estimates RNA changes with exercise
 func PredictRNAResponse(rna ExerciseRNA, exerciseType string,
 	intensity float64, duration int) float64 {
 	// Implementation for predicting RNA levels post-exercise
+	return 1.0 // No change until a response model is implemented
 }
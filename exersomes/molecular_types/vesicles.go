@@ -1,19 +1,72 @@
-package vesicles
+package molecular_types
+
+import "math"
+
+// Cargo kinds, naming the catalog a cargo ID refers to
+const (
+	CargoMiRNA   = "miRNA"   // ID is an ExerciseMiRNA.ID
+	CargoRNA     = "RNA"     // ID is an ExerciseRNA.Name
+	CargoProtein = "Protein" // ID is an ExerciseProtein.UniprotID
+)
+
+// Cargo is a molecule loaded into a vesicle, referenced by catalog ID
+type Cargo struct {
+	Kind             string       // CargoMiRNA, CargoRNA or CargoProtein
+	ID               string       // Catalog ID for the kind
+	CopiesPerVesicle float64      // Mean loading; below 1 when most vesicles carry none, 0 if unknown
+	Studies          []Provenance // Imported experiments reporting the cargo
+}
+
+// Provenance is an experiment a vesicle's markers or cargo were reported in
+type Provenance struct {
+	Database   string // "ExoCarta" or "Vesiclepedia"
+	Experiment string // Database experiment ID
+	PubMedID   string
+	Sample     string // Sample or cell type the vesicles were isolated from
+}
+
+// VesicleKinetics describes release into and clearance from plasma, with the
+// same first-order release model as circulating factors
+type VesicleKinetics struct {
+	BaselinePerML   float64 // Resting particles per mL of plasma
+	PeakFoldChange  float64 // Steady-state concentration at 100% intensity relative to rest
+	HalfLifeMinutes float64 // Circulating half-life; vesicles are cleared by tissue uptake
+	TimeToPeak      float64 // Minutes for release to follow rising intensity (~95%)
+	RecoveryTime    float64 // Minutes for release to return to rest after effort (~95%)
+}
+
+// ClearanceRate is the first-order loss from plasma, per minute
+func (k VesicleKinetics) ClearanceRate() float64 {
+	return math.Ln2 / math.Max(k.HalfLifeMinutes, 0.1)
+}
+
+// ActivationRate is how quickly release follows rising intensity, per minute
+func (k VesicleKinetics) ActivationRate() float64 {
+	return 3.0 / math.Max(k.TimeToPeak, 1.0)
+}
+
+// DeactivationRate is how quickly release returns to rest after effort, per minute
+func (k VesicleKinetics) DeactivationRate() float64 {
+	return 3.0 / math.Max(k.RecoveryTime, 1.0)
+}
 
 // ExerciseVesicle represents extracellular vesicles affected by exercise
 type ExerciseVesicle struct {
-	Type               string   // "Exosome", "Microvesicle", "Apoptotic body", etc.
-	SizeRange          string   // Size range in nm
-	PrimarySource      string   // Main tissue source
-	SecondarySource    []string // Other tissue sources
-	ExerciseRegulation string   // How exercise affects their release
-	TemporalDynamics   string   // Time course of appearance in circulation
-	MarkerProteins     []string // Characteristic surface markers
-	CargoTypes         []string // Types of cargo ("miRNA", "protein", "lipids", etc.)
-	KeyCargo           []string // Specific important cargo molecules
-	TargetTissues      []string // Tissues that take up these vesicles
-	FunctionalEffects  []string // Physiological effects
-	BestInducers       []string // Exercise types that best induce these vesicles
+	Type               string             // "Exosome", "Microvesicle", "Apoptotic body", etc.
+	SizeRange          string             // Size range in nm
+	PrimarySource      string             // Main tissue source
+	SecondarySource    []string           // Other tissue sources
+	ExerciseRegulation string             // How exercise affects their release
+	TemporalDynamics   string             // Time course of appearance in circulation
+	MarkerProteins     []string           // Characteristic surface markers
+	CargoTypes         []string           // Types of cargo ("miRNA", "protein", "lipids", etc.)
+	KeyCargo           []Cargo            // Specific important cargo molecules, by catalog ID
+	TargetTissues      []string           // Tissues that take up these vesicles
+	UptakeShare        map[string]float64 // Share of cleared vesicles taken up by each target tissue
+	Kinetics           VesicleKinetics
+	Studies            []Provenance // Imported experiments merged into the record
+	FunctionalEffects  []string     // Physiological effects
+	BestInducers       []string     // Exercise types that best induce these vesicles
 }
 
 // Key exercise-responsive vesicles
@@ -38,13 +91,15 @@ var (
 			"Proteins",
 			"Metabolites",
 		},
-		KeyCargo: []string{
-			"miR-1",
-			"miR-133a",
-			"miR-206",
-			"miR-486",
-			"PGC-1α mRNA",
-			"Myostatin protein",
+		// Even abundant miRNAs are present at well under one copy per exosome
+		// (Chevillet et al., 2014); proteins are loaded more densely
+		KeyCargo: []Cargo{
+			{Kind: CargoMiRNA, ID: "hsa-miR-1-3p", CopiesPerVesicle: 0.01},
+			{Kind: CargoMiRNA, ID: "hsa-miR-133a-3p", CopiesPerVesicle: 0.008},
+			{Kind: CargoMiRNA, ID: "hsa-miR-206", CopiesPerVesicle: 0.004},
+			{Kind: CargoMiRNA, ID: "hsa-miR-486-5p", CopiesPerVesicle: 0.02},
+			{Kind: CargoRNA, ID: "PPARGC1A", CopiesPerVesicle: 0.001},
+			{Kind: CargoProtein, ID: "O14793", CopiesPerVesicle: 0.5},
 		},
 		TargetTissues: []string{
 			"Liver",
@@ -52,6 +107,23 @@ var (
 			"Endothelial cells",
 			"Brain",
 		},
+		// Infused exercise EVs home mainly to the liver (Whitham et al., 2018);
+		// the remainder is cleared by spleen, lung and other tissues
+		UptakeShare: map[string]float64{
+			"Liver":             0.55,
+			"Adipose tissue":    0.10,
+			"Endothelial cells": 0.15,
+			"Brain":             0.01,
+		},
+		// Muscle-derived EVs are a few percent of ~1e10 plasma EVs per mL and
+		// rise 2-3 fold during incremental cycling (Frühbeis et al., 2015)
+		Kinetics: VesicleKinetics{
+			BaselinePerML:   5e8,
+			PeakFoldChange:  2.5,
+			HalfLifeMinutes: 10,
+			TimeToPeak:      30,
+			RecoveryTime:    120,
+		},
 		FunctionalEffects: []string{
 			"Improved glucose metabolism in recipient tissues",
 			"Enhanced angiogenesis",
@@ -68,10 +140,68 @@ var (
 	// Add other vesicle types
 )
 
-// PredictVesicleResponse estimates vesicle changes with exercise
+// VesicleCatalog lists the exercise-responsive vesicle populations
+var VesicleCatalog = []ExerciseVesicle{MuscleDerivedExosomes}
+
+// CargoName resolves a cargo's catalog ID to the molecule's name
+func CargoName(c Cargo) (string, bool) {
+	switch c.Kind {
+	case CargoMiRNA:
+		for _, m := range MiRNACatalog {
+			if m.ID == c.ID {
+				return m.Name, true
+			}
+		}
+	case CargoRNA:
+		for _, r := range RNACatalog {
+			if r.Name == c.ID {
+				return r.Name, true
+			}
+		}
+	case CargoProtein:
+		for _, p := range ProteinCatalog {
+			if p.UniprotID == c.ID {
+				return p.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// CargoCategory returns the node category of a cargo molecule: "miRNA", the
+// RNA's Type, or "Protein"
+func CargoCategory(c Cargo) string {
+	if c.Kind == CargoRNA {
+		for _, r := range RNACatalog {
+			if r.Name == c.ID {
+				return r.Type
+			}
+		}
+	}
+	return c.Kind
+}
+
+// PredictVesicleResponse returns the fold change in circulating vesicles at the
+// end of a continuous bout of duration minutes at intensity percent of max. It is
+// the closed-form solution of the release model for one constant-intensity
+// segment from rest; release tracks effort, so exerciseType does not enter.
 func PredictVesicleResponse(vesicle ExerciseVesicle, exerciseType string,
 	intensity float64, duration int) float64 {
-	// Implementation for predicting vesicle release based on exercise parameters
+	if duration <= 0 || intensity <= 0 {
+		return 1.0
+	}
+	kin := vesicle.Kinetics
+	k := kin.ClearanceRate()
+	r := kin.ActivationRate()
+	t := float64(duration)
+	amplitude := (kin.PeakFoldChange - 1.0) * intensity / 100.0
+
+	// Release activation a(t) = A(1 - e^-rt) drives dC/dt = kB(1 + Gain*a) - kC
+	lag := k * t * math.Exp(-k*t)
+	if math.Abs(k-r) > 1e-9 {
+		lag = k / (k - r) * (math.Exp(-r*t) - math.Exp(-k*t))
+	}
+	return 1.0 + amplitude*((1.0-math.Exp(-k*t))-lag)
 }
 
-// Functions for cargo loading, vesicle biodistribution, etc.
+// Functions for vesicle biodistribution, etc.
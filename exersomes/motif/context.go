@@ -0,0 +1,95 @@
+package motif
+
+import (
+	"math"
+)
+
+// Context scores follow the additive model of TargetScan's context score
+// (Grimson et al., 2007): a site-type baseline plus local AU, 3′ supplementary
+// pairing and position contributions. They approximate log2 fold repression,
+// so more negative means stronger targeting, and are capped at zero.
+
+// siteTypeScore is the mean repression of each site type before context
+var siteTypeScore = map[SiteType]float64{
+	Site8mer:   -0.310,
+	Site7merM8: -0.161,
+	Site7merA1: -0.099,
+	Site6mer:   -0.030,
+}
+
+// Context model coefficients
+const (
+	auWindow       = 30     // nt of flank either side scored for AU content
+	auSlope        = -0.40  // Per unit of weighted AU fraction above 0.5
+	pairingWindow  = 15     // nt upstream of the site searched for 3′ pairing
+	pairingPerNt   = -0.015 // Per contiguous pair with miRNA nt 13-16 beyond 2
+	positionSpread = 0.05   // Range of the position term, from UTR ends to middle
+)
+
+// contextScore scores a site in a normalized UTR for a normalized mature miRNA
+func contextScore(mature, utr string, s Site) float64 {
+	if s.TooClose {
+		return 0
+	}
+	score := siteTypeScore[s.Type] +
+		auSlope*(localAU(utr, s)-0.5) +
+		pairingPerNt*float64(max(supplementaryPairing(mature, utr, s.Start)-2, 0)) +
+		positionScore(len(utr), s)
+	return math.Min(score, 0)
+}
+
+// localAU is the AU fraction of the flanks, each base weighted by the inverse
+// of its distance from the site
+func localAU(utr string, s Site) float64 {
+	var au, total float64
+	for d := 1; d <= auWindow; d++ {
+		w := 1 / float64(d)
+		for _, i := range []int{s.Start - d, s.End - 1 + d} {
+			if i < 0 || i >= len(utr) {
+				continue
+			}
+			total += w
+			if utr[i] == 'A' || utr[i] == 'U' {
+				au += w
+			}
+		}
+	}
+	if total == 0 {
+		return 0.5
+	}
+	return au / total
+}
+
+// supplementaryPairing returns the longest run of contiguous Watson-Crick pairs
+// between miRNA nt 13-16 and the UTR just upstream of the site
+func supplementaryPairing(mature, utr string, start int) int {
+	if len(mature) < 16 {
+		return 0
+	}
+	// The UTR pairs antiparallel, so its 5′→3′ reading matches the reverse
+	// complement of miRNA nt 13-16
+	target := ReverseComplement(mature[12:16])
+	lo := max(start-pairingWindow, 0)
+	region := utr[lo:start]
+	best := 0
+	for i := range region {
+		for j := range target {
+			n := 0
+			for i+n < len(region) && j+n < len(target) && region[i+n] == target[j+n] {
+				n++
+			}
+			best = max(best, n)
+		}
+	}
+	return best
+}
+
+// positionScore favours sites near either end of the UTR over those in the
+// middle of long UTRs
+func positionScore(length int, s Site) float64 {
+	if length == 0 {
+		return 0
+	}
+	distance := min(s.Start, length-s.End)
+	return positionSpread * (2*float64(distance)/float64(length) - 0.5)
+}
@@ -0,0 +1,134 @@
+package motif
+
+import (
+	"exersomes/molecular_types"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// spacer is GC-only filler with no miR-1 site
+var spacer = strings.Repeat("GC", 15)
+
+// Test seeds are nucleotides 2-8 and shared seeds form families
+func TestSeed(t *testing.T) {
+	seed, err := Seed(molecular_types.MiR486.Sequence)
+	if err != nil || seed != "CCUGUAC" {
+		t.Errorf("Seed(miR-486-5p) = %q, %v; want CCUGUAC", seed, err)
+	}
+	if _, err := Seed("UGGAAU"); err == nil {
+		t.Error("Expected an error for a sequence shorter than 8 nt")
+	}
+	families, err := SeedFamilies(molecular_types.CirculatingMiRNAPanel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(families["GGAAUGU"], []string{"miR-1", "miR-206"}) {
+		t.Errorf("Expected miR-1 and miR-206 to share a seed, got %v", families)
+	}
+	if got := ReverseComplement("ACGTN"); got != "NACGU" {
+		t.Errorf("ReverseComplement = %q", got)
+	}
+}
+
+// Test each canonical site type is found once, at the right span
+func TestFindSites(t *testing.T) {
+	// miR-1 pairs nt 2-7 with CAUUCC and nt 8 with the A before it
+	utr := spacer + "ACAUUCCA" + spacer + "ACAUUCCG" + spacer + "GCAUUCCA" + spacer + "GCAUUCCG" + spacer
+	sites, err := FindSites(molecular_types.MiR1.Sequence, strings.ReplaceAll(utr, "U", "T"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		typ        SiteType
+		start, end int
+	}{
+		{Site8mer, 30, 38},
+		{Site7merM8, 68, 75},
+		{Site7merA1, 107, 114},
+		{Site6mer, 145, 151},
+	}
+	if len(sites) != len(want) {
+		t.Fatalf("Expected %d sites, got %+v", len(want), sites)
+	}
+	for i, w := range want {
+		if s := sites[i]; s.Type != w.typ || s.Start != w.start || s.End != w.end {
+			t.Errorf("Site %d = %s [%d,%d), want %s [%d,%d)", i, s.Type, s.Start, s.End, w.typ, w.start, w.end)
+		}
+	}
+	for i := 1; i < len(sites); i++ {
+		if sites[i].Context <= sites[i-1].Context && sites[i].Context != 0 {
+			t.Errorf("%s site scored %g, stronger than %s at %g", sites[i].Type, sites[i].Context, sites[i-1].Type, sites[i-1].Context)
+		}
+	}
+
+	near, _ := FindSites(molecular_types.MiR1.Sequence, "GGACAUUCCA"+spacer)
+	if len(near) != 1 || !near[0].TooClose || near[0].Context != 0 {
+		t.Errorf("Expected a site beside the stop codon to be flagged and unscored, got %+v", near)
+	}
+}
+
+// Test AU-rich flanks and 3′ supplementary pairing strengthen a site
+func TestContext(t *testing.T) {
+	score := func(utr string) float64 {
+		sites, err := FindSites(molecular_types.MiR1.Sequence, utr)
+		if err != nil || len(sites) != 1 {
+			t.Fatalf("Expected one site, got %+v, %v", sites, err)
+		}
+		return sites[0].Context
+	}
+	site := "ACAUUCCA"
+	gc := score(spacer + site + spacer)
+	au := score(strings.Repeat("AU", 15) + site + strings.Repeat("AU", 15))
+	if au >= gc {
+		t.Errorf("Expected AU-rich flanks to score below GC-rich ones, got %g and %g", au, gc)
+	}
+	// miR-1 nt 13-16 (AGUA) pair with UACU upstream of the site
+	paired := score(spacer + "UACU" + "GCGCG" + site + spacer)
+	unpaired := score(spacer + "GCGC" + "GCGCG" + site + spacer)
+	if paired >= unpaired {
+		t.Errorf("Expected 3′ pairing to strengthen the site, got %g and %g", paired, unpaired)
+	}
+}
+
+// Test predictions are cross-checked against curated targets
+func TestCheck(t *testing.T) {
+	fasta := ">NM_002.1 HDAC4 3'UTR\n" + spacer + "ACAUUCCA" + spacer + "\n" +
+		">ENSG1|ENST1|GJA1\n" + spacer + "GCAUUCCG" + spacer + "\n" +
+		">NOVEL\n" + spacer + "ACAUUCCG" + spacer + "ACAUUCCA" + spacer + "\n" +
+		">HDAC4-short HDAC4\n" + spacer + "GCAUUCCA" + spacer + "\n"
+	utrs, err := ReadUTRs(strings.NewReader(fasta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genes := []string{utrs[0].Gene, utrs[1].Gene, utrs[2].Gene}; !reflect.DeepEqual(genes, []string{"HDAC4", "GJA1", "NOVEL"}) {
+		t.Errorf("Unexpected genes from headers: %v", genes)
+	}
+
+	c, err := Check(molecular_types.MiR1, utrs, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var predicted []string
+	for _, target := range c.Predicted {
+		predicted = append(predicted, target.Gene)
+	}
+	if !reflect.DeepEqual(predicted, []string{"NOVEL", "HDAC4"}) {
+		t.Errorf("Expected NOVEL then HDAC4, got %v", predicted)
+	}
+	if c.Predicted[1].Transcript != "NM_002.1" || !c.Predicted[1].Curated {
+		t.Errorf("Expected HDAC4 scored on its 8mer transcript and marked curated, got %+v", c.Predicted[1])
+	}
+	if !reflect.DeepEqual(c.Confirmed, []string{"HDAC4"}) || !reflect.DeepEqual(c.Missed, []string{"GJA1"}) {
+		t.Errorf("Unexpected cross-check %+v", c)
+	}
+	if !reflect.DeepEqual(c.NoUTR, []string{"IGF1", "KCNJ2", "HAND2"}) {
+		t.Errorf("Unexpected curated genes without UTRs: %v", c.NoUTR)
+	}
+
+	// A 6mer counts when the threshold allows it
+	c, _ = Check(molecular_types.MiR1, utrs, Options{MinSite: Site6mer})
+	if len(c.Missed) != 0 || len(c.Confirmed) != 2 {
+		t.Errorf("Expected GJA1's 6mer to confirm it, got %+v", c)
+	}
+}
@@ -0,0 +1,130 @@
+package motif
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SiteType is a canonical miRNA target site, named as in TargetScan
+type SiteType string
+
+const (
+	Site8mer   SiteType = "8mer"    // Seed match to nt 2-8 plus an A opposite nt 1
+	Site7merM8 SiteType = "7mer-m8" // Seed match to nt 2-8
+	Site7merA1 SiteType = "7mer-A1" // Seed match to nt 2-7 plus an A opposite nt 1
+	Site6mer   SiteType = "6mer"    // Seed match to nt 2-7
+)
+
+// SiteTypes lists the site types from most to least effective
+var SiteTypes = []SiteType{Site8mer, Site7merM8, Site7merA1, Site6mer}
+
+// rank orders site types, most effective first
+func (t SiteType) rank() int {
+	for i, s := range SiteTypes {
+		if s == t {
+			return i
+		}
+	}
+	return len(SiteTypes)
+}
+
+// AtLeast reports whether t is as effective as min or more
+func (t SiteType) AtLeast(min SiteType) bool {
+	return t.rank() <= min.rank()
+}
+
+// normalize upper-cases an RNA or DNA sequence, reads T as U and drops whitespace
+func normalize(seq string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(seq) {
+		switch {
+		case r == 'T':
+			b.WriteRune('U')
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Seed returns nucleotides 2-8 of a mature miRNA, 5′ to 3′
+func Seed(mature string) (string, error) {
+	m := normalize(mature)
+	if len(m) < 8 {
+		return "", fmt.Errorf("mature miRNA %q is shorter than 8 nt", mature)
+	}
+	return m[1:8], nil
+}
+
+var complement = map[byte]byte{'A': 'U', 'U': 'A', 'G': 'C', 'C': 'G'}
+
+// ReverseComplement returns the RNA reverse complement; unknown bases become N
+func ReverseComplement(seq string) string {
+	s := normalize(seq)
+	out := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		c, ok := complement[s[len(s)-1-i]]
+		if !ok {
+			c = 'N'
+		}
+		out[i] = c
+	}
+	return string(out)
+}
+
+// Site is a seed match in a 3′UTR
+type Site struct {
+	Type     SiteType
+	Start    int // 0-based offset of the site's first nucleotide in the UTR
+	End      int // Offset just past the site
+	Context  float64
+	TooClose bool // Within 15 nt of the stop codon, where the ribosome displaces RISC
+}
+
+// minStopDistance is the closest a site can start to the stop codon and still repress
+const minStopDistance = 15
+
+// FindSites scans a 3′UTR, given 5′ to 3′ with the stop codon upstream of its
+// first base, for canonical sites of a mature miRNA and scores each one. Sites
+// are reported once each, as their most effective type.
+func FindSites(mature, utr string) ([]Site, error) {
+	seed, err := Seed(mature)
+	if err != nil {
+		return nil, err
+	}
+	m := normalize(mature)
+	u := normalize(utr)
+	core := ReverseComplement(seed[:6]) // Pairs with nt 2-7
+	m8 := complement[seed[6]]           // Pairs with nt 8
+
+	var sites []Site
+	for i := 0; i+len(core) <= len(u); i++ {
+		if u[i:i+len(core)] != core {
+			continue
+		}
+		hasM8 := i > 0 && u[i-1] == m8
+		hasA1 := i+len(core) < len(u) && u[i+len(core)] == 'A'
+		s := Site{Start: i, End: i + len(core)}
+		switch {
+		case hasM8 && hasA1:
+			s.Type = Site8mer
+		case hasM8:
+			s.Type = Site7merM8
+		case hasA1:
+			s.Type = Site7merA1
+		default:
+			s.Type = Site6mer
+		}
+		if hasM8 {
+			s.Start--
+		}
+		if hasA1 {
+			s.End++
+		}
+		s.TooClose = s.Start < minStopDistance
+		s.Context = contextScore(m, u, s)
+		sites = append(sites, s)
+	}
+	return sites, nil
+}
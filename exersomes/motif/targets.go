@@ -0,0 +1,204 @@
+package motif
+
+import (
+	"exersomes/molecular_types"
+	"exersomes/store"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// UTR is a transcript's 3′UTR
+type UTR struct {
+	Gene       string
+	Transcript string
+	Sequence   string
+}
+
+// ReadUTRs reads 3′UTRs from FASTA. The gene symbol comes from the header:
+// ">GENE", ">TRANSCRIPT GENE ..." or pipe-separated ">ENSG|ENST|GENE".
+func ReadUTRs(r io.Reader) ([]UTR, error) {
+	var utrs []UTR
+	err := store.ReadFASTA(r, func(seq store.Sequence) error {
+		utrs = append(utrs, UTR{Gene: utrGene(seq), Transcript: seq.ID, Sequence: seq.Residues})
+		return nil
+	})
+	return utrs, err
+}
+
+func utrGene(seq store.Sequence) string {
+	switch {
+	case seq.Query != "":
+		return seq.Query
+	case seq.Description != "":
+		return strings.Fields(seq.Description)[0]
+	case strings.Contains(seq.ID, "|"):
+		parts := strings.Split(seq.ID, "|")
+		for i := len(parts) - 1; i >= 0; i-- {
+			if parts[i] != "" {
+				return parts[i]
+			}
+		}
+	}
+	return seq.ID
+}
+
+// Target is a gene with seed sites for a miRNA, scored on its best transcript
+type Target struct {
+	Gene         string
+	Transcript   string
+	Sites        []Site
+	ContextScore float64 // Sum of the site context scores
+	Curated      bool    // Listed in the miRNA's TargetGenes
+}
+
+// Count returns the number of sites of a type
+func (t Target) Count(typ SiteType) int {
+	n := 0
+	for _, s := range t.Sites {
+		if s.Type == typ {
+			n++
+		}
+	}
+	return n
+}
+
+// Options control target prediction
+type Options struct {
+	MinSite SiteType // Weakest site type that makes a target; empty is 7mer-A1
+}
+
+// PredictTargets scans every UTR for the miRNA's sites and returns the genes
+// with a site of at least MinSite, strongest total context score first. A gene
+// with several transcripts is scored on its strongest.
+func PredictTargets(m molecular_types.ExerciseMiRNA, utrs []UTR, opts Options) ([]Target, error) {
+	if opts.MinSite == "" {
+		opts.MinSite = Site7merA1
+	}
+	curated := make(map[string]bool)
+	for _, g := range m.TargetGenes {
+		curated[strings.ToUpper(g)] = true
+	}
+
+	best := make(map[string]Target)
+	for _, u := range utrs {
+		sites, err := FindSites(m.Sequence, u.Sequence)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		t := Target{Gene: u.Gene, Transcript: u.Transcript, Curated: curated[strings.ToUpper(u.Gene)]}
+		qualifies := false
+		for _, s := range sites {
+			if s.TooClose {
+				continue
+			}
+			t.Sites = append(t.Sites, s)
+			t.ContextScore += s.Context
+			qualifies = qualifies || s.Type.AtLeast(opts.MinSite)
+		}
+		if !qualifies {
+			continue
+		}
+		if prev, ok := best[u.Gene]; !ok || t.ContextScore < prev.ContextScore {
+			best[u.Gene] = t
+		}
+	}
+
+	targets := make([]Target, 0, len(best))
+	for _, t := range best {
+		targets = append(targets, t)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].ContextScore != targets[j].ContextScore {
+			return targets[i].ContextScore < targets[j].ContextScore
+		}
+		return targets[i].Gene < targets[j].Gene
+	})
+	return targets, nil
+}
+
+// CrossCheck compares predicted targets with a miRNA's curated TargetGenes
+type CrossCheck struct {
+	MiRNA     string
+	Seed      string
+	Predicted []Target
+	Confirmed []string // Curated genes predicted as targets
+	Missed    []string // Curated genes with a UTR but no qualifying site
+	NoUTR     []string // Curated genes absent from the UTR set
+}
+
+// Check predicts targets for a miRNA and cross-checks them with its curated genes
+func Check(m molecular_types.ExerciseMiRNA, utrs []UTR, opts Options) (CrossCheck, error) {
+	seed, err := Seed(m.Sequence)
+	if err != nil {
+		return CrossCheck{}, fmt.Errorf("%s: %w", m.Name, err)
+	}
+	predicted, err := PredictTargets(m, utrs, opts)
+	if err != nil {
+		return CrossCheck{}, err
+	}
+	c := CrossCheck{MiRNA: m.Name, Seed: seed, Predicted: predicted}
+
+	isTarget := make(map[string]bool)
+	for _, t := range predicted {
+		isTarget[strings.ToUpper(t.Gene)] = true
+	}
+	hasUTR := make(map[string]bool)
+	for _, u := range utrs {
+		hasUTR[strings.ToUpper(u.Gene)] = true
+	}
+	for _, g := range m.TargetGenes {
+		switch {
+		case isTarget[strings.ToUpper(g)]:
+			c.Confirmed = append(c.Confirmed, g)
+		case hasUTR[strings.ToUpper(g)]:
+			c.Missed = append(c.Missed, g)
+		default:
+			c.NoUTR = append(c.NoUTR, g)
+		}
+	}
+	return c, nil
+}
+
+// SeedFamilies groups miRNAs by seed; members of a family share predicted targets
+func SeedFamilies(mirnas []molecular_types.ExerciseMiRNA) (map[string][]string, error) {
+	families := make(map[string][]string)
+	for _, m := range mirnas {
+		seed, err := Seed(m.Sequence)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		families[seed] = append(families[seed], m.Name)
+	}
+	return families, nil
+}
+
+// WriteTargets writes predicted targets as TSV, one row per miRNA and gene
+func WriteTargets(w io.Writer, checks []CrossCheck) error {
+	header := []string{"MiRNA", "Seed", "Gene", "Transcript"}
+	for _, t := range SiteTypes {
+		header = append(header, string(t))
+	}
+	header = append(header, "ContextScore", "Curated")
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return err
+	}
+	for _, c := range checks {
+		for _, t := range c.Predicted {
+			row := []string{c.MiRNA, c.Seed, t.Gene, t.Transcript}
+			for _, typ := range SiteTypes {
+				row = append(row, fmt.Sprint(t.Count(typ)))
+			}
+			curated := "no"
+			if t.Curated {
+				curated = "yes"
+			}
+			row = append(row, fmt.Sprintf("%.3f", t.ContextScore), curated)
+			if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
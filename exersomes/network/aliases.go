@@ -0,0 +1,34 @@
+package network
+
+import (
+	"exersomes/canonical"
+	"strings"
+)
+
+// CanonicalLigand returns the alias-normalized key for a ligand name
+func CanonicalLigand(name string) string {
+	return canonical.Ligand(name)
+}
+
+// CanonicalReceptor returns the alias-normalized key for a receptor name
+func CanonicalReceptor(name string) string {
+	return canonical.Receptor(name)
+}
+
+// NormalizeTissue maps a tissue or cell-type spelling to the network's tissue names.
+// Unknown tissues are returned trimmed but otherwise unchanged.
+func NormalizeTissue(tissue string) string {
+	return canonical.Tissue(tissue)
+}
+
+// splitNames splits multi-valued catalog cells such as "BMP2, BMP4"
+func splitNames(value string) []string {
+	var names []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			names = append(names, part)
+		}
+	}
+	return names
+}
@@ -0,0 +1,279 @@
+package network
+
+import (
+	"exersomes/canonical"
+	"exersomes/molecular_types"
+	"sort"
+)
+
+// Edge strength contributions
+const (
+	baseStrength       = 0.5  // Pair named by one side only
+	mutualBonus        = 0.25 // Pair named by both the ligand and the receptor entry
+	sharedPathwayBonus = 0.25 // Ligand and receptor entries list a common pathway
+)
+
+// mergedLigand is every catalog entry for one canonical ligand combined
+type mergedLigand struct {
+	LigandEntry
+	key       string
+	receptors map[string]string // canonical receptor -> first name seen
+}
+
+// mergedReceptor is every catalog entry for one canonical receptor in one tissue
+type mergedReceptor struct {
+	ReceptorEntry
+	key     string
+	ligands map[string]bool // canonical ligand keys
+}
+
+// Build returns the organ-to-organ exerkine network for the component catalog,
+// extended with any curated exerkine records passed in and with the cargo
+// delivered by exercise-responsive extracellular vesicles
+func Build(exerkines []molecular_types.Exerkine) molecular_types.ExerkineNetwork {
+	ligands := append(CatalogLigands(), ExerkineLigands(exerkines)...)
+	return AddVesicles(BuildFromEntries(ligands, CatalogReceptors()), molecular_types.VesicleCatalog)
+}
+
+// BuildFromEntries matches ligands to receptors by alias-normalized name, in both
+// directions: receptors listing a ligand, and ligands listing a receptor. Each
+// interaction is one (ligand, receptor, source tissue, target tissue) combination.
+func BuildFromEntries(ligandEntries []LigandEntry, receptorEntries []ReceptorEntry) molecular_types.ExerkineNetwork {
+	ligands := mergeLigands(ligandEntries)
+	receptors := mergeReceptors(receptorEntries)
+
+	receptorsByKey := make(map[string][]*mergedReceptor)
+	for _, r := range receptors {
+		receptorsByKey[r.key] = append(receptorsByKey[r.key], r)
+	}
+
+	var interactions []molecular_types.Interaction
+	seen := make(map[string]bool)
+	addEdges := func(l *mergedLigand, receptorName string, targetTissue string, receptorPathways []string, mutual bool) {
+		pathway := sharedPathway(l.Pathways, receptorPathways)
+		strength := baseStrength
+		if mutual {
+			strength += mutualBonus
+		}
+		if pathway != "" {
+			strength += sharedPathwayBonus
+		} else if len(receptorPathways) > 0 {
+			pathway = receptorPathways[0]
+		} else if len(l.Pathways) > 0 {
+			pathway = l.Pathways[0]
+		}
+
+		for _, source := range l.SourceTissues {
+			id := l.key + "|" + CanonicalReceptor(receptorName) + "|" + source + "|" + targetTissue
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			interactions = append(interactions, molecular_types.Interaction{
+				Type:         molecular_types.LigandReceptor,
+				Ligand:       l.Name,
+				Receptor:     receptorName,
+				Tissue:       targetTissue,
+				SourceTissue: source,
+				TargetTissue: targetTissue,
+				Pathway:      pathway,
+				Strength:     strength,
+				Sources:      []string{CuratedSource},
+			})
+		}
+	}
+
+	for _, l := range ligands {
+		// Receptors in the catalog that name this ligand, or that it names
+		for _, r := range receptors {
+			_, ligandNamesReceptor := l.receptors[r.key]
+			receptorNamesLigand := r.ligands[l.key]
+			if !ligandNamesReceptor && !receptorNamesLigand {
+				continue
+			}
+			addEdges(l, r.Name, r.Tissue, r.Pathways, ligandNamesReceptor && receptorNamesLigand)
+		}
+
+		// Receptors the ligand names that no component package describes; fall back
+		// to the ligand's own target tissues so the organ edge is not lost
+		for key, name := range l.receptors {
+			if len(receptorsByKey[key]) > 0 {
+				continue
+			}
+			targets := l.TargetTissues
+			if len(targets) == 0 {
+				targets = []string{""}
+			}
+			for _, target := range targets {
+				addEdges(l, name, target, nil, false)
+			}
+		}
+	}
+
+	sortInteractions(interactions)
+
+	nodes := make([]molecular_types.Exerkine, 0, len(ligands))
+	for _, l := range ligands {
+		var receptorNames []string
+		for _, name := range l.receptors {
+			receptorNames = append(receptorNames, name)
+		}
+		sort.Strings(receptorNames)
+		nodes = append(nodes, molecular_types.Exerkine{
+			Name:          l.Name,
+			Category:      l.Category,
+			TissueSources: l.SourceTissues,
+			Receptors:     receptorNames,
+		})
+	}
+
+	return molecular_types.ExerkineNetwork{
+		Nodes:        nodes,
+		Interactions: interactions,
+	}
+}
+
+// sortInteractions orders interactions by ligand, receptor, source and target tissue
+func sortInteractions(interactions []molecular_types.Interaction) {
+	sort.SliceStable(interactions, func(i, j int) bool {
+		a, b := interactions[i], interactions[j]
+		if a.Ligand != b.Ligand {
+			return a.Ligand < b.Ligand
+		}
+		if a.Receptor != b.Receptor {
+			return a.Receptor < b.Receptor
+		}
+		if a.SourceTissue != b.SourceTissue {
+			return a.SourceTissue < b.SourceTissue
+		}
+		return a.TargetTissue < b.TargetTissue
+	})
+}
+
+// mergeLigands combines entries sharing a canonical name, keeping the first name
+// seen and the union of tissues, receptors and pathways
+func mergeLigands(entries []LigandEntry) []*mergedLigand {
+	byKey := make(map[string]*mergedLigand)
+	var order []string
+
+	for _, e := range entries {
+		key := CanonicalLigand(e.Name)
+		m, ok := byKey[key]
+		if !ok {
+			m = &mergedLigand{
+				LigandEntry: LigandEntry{Name: e.Name, Category: e.Category, Regulation: e.Regulation},
+				key:         key,
+				receptors:   make(map[string]string),
+			}
+			byKey[key] = m
+			order = append(order, key)
+		}
+		m.SourceTissues = appendUnique(m.SourceTissues, normalizeTissues(e.SourceTissues)...)
+		m.TargetTissues = appendUnique(m.TargetTissues, normalizeTissues(e.TargetTissues)...)
+		m.Pathways = appendUnique(m.Pathways, e.Pathways...)
+		for _, r := range e.Receptors {
+			rk := CanonicalReceptor(r)
+			if _, exists := m.receptors[rk]; !exists {
+				m.receptors[rk] = r
+			}
+		}
+	}
+
+	sort.Strings(order)
+	merged := make([]*mergedLigand, 0, len(order))
+	for _, key := range order {
+		merged = append(merged, byKey[key])
+	}
+	return merged
+}
+
+// MergeLigandEntries combines entries naming the same ligand into one entry per
+// canonical name, sorted by key, with the union of tissues, receptors and pathways
+func MergeLigandEntries(entries []LigandEntry) []LigandEntry {
+	merged := mergeLigands(entries)
+	out := make([]LigandEntry, 0, len(merged))
+	for _, m := range merged {
+		e := m.LigandEntry
+		for _, name := range m.receptors {
+			e.Receptors = append(e.Receptors, name)
+		}
+		sort.Strings(e.Receptors)
+		out = append(out, e)
+	}
+	return out
+}
+
+// mergeReceptors combines entries sharing a canonical name and tissue
+func mergeReceptors(entries []ReceptorEntry) []*mergedReceptor {
+	byKey := make(map[string]*mergedReceptor)
+	var order []string
+
+	for _, e := range entries {
+		key := CanonicalReceptor(e.Name)
+		tissue := NormalizeTissue(e.Tissue)
+		id := key + "|" + tissue
+		m, ok := byKey[id]
+		if !ok {
+			m = &mergedReceptor{
+				ReceptorEntry: ReceptorEntry{Name: e.Name, Tissue: tissue},
+				key:           key,
+				ligands:       make(map[string]bool),
+			}
+			byKey[id] = m
+			order = append(order, id)
+		}
+		m.Pathways = appendUnique(m.Pathways, e.Pathways...)
+		for _, l := range e.Ligands {
+			m.Ligands = appendUnique(m.Ligands, l)
+			m.ligands[CanonicalLigand(l)] = true
+		}
+	}
+
+	sort.Strings(order)
+	merged := make([]*mergedReceptor, 0, len(order))
+	for _, id := range order {
+		merged = append(merged, byKey[id])
+	}
+	return merged
+}
+
+// sharedPathway returns the first receptor pathway also listed for the ligand
+func sharedPathway(ligandPathways, receptorPathways []string) string {
+	ligandKeys := make(map[string]bool)
+	for _, p := range ligandPathways {
+		ligandKeys[canonical.Key(p)] = true
+	}
+	for _, p := range receptorPathways {
+		if ligandKeys[canonical.Key(p)] {
+			return p
+		}
+	}
+	return ""
+}
+
+func normalizeTissues(tissues []string) []string {
+	normalized := make([]string, 0, len(tissues))
+	for _, t := range tissues {
+		normalized = append(normalized, NormalizeTissue(t))
+	}
+	return normalized
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,136 @@
+package network
+
+import (
+	"exersomes/molecular_types"
+	"testing"
+)
+
+// Test alias normalization across catalog spellings
+func TestCanonicalNames(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Interleukin-6", "IL-6"},
+		{"TNF-α", "Tumor Necrosis Factor Alpha"},
+		{"Irisin (FNDC5)", "FNDC5"},
+		{"Fibroblast Growth Factor 21 (Liver-derived)", "FGF21"},
+		{"Myostatin (GDF-8)", "GDF8"},
+	}
+	for _, test := range tests {
+		if CanonicalLigand(test.a) != CanonicalLigand(test.b) {
+			t.Errorf("Expected %q and %q to share a key, got %q and %q",
+				test.a, test.b, CanonicalLigand(test.a), CanonicalLigand(test.b))
+		}
+	}
+
+	if CanonicalReceptor("IL-6 Receptor") != CanonicalReceptor("IL6R") {
+		t.Errorf("Expected IL-6 Receptor and IL6R to share a key")
+	}
+	for spelling, tissue := range map[string]string{
+		"Skeletal muscle": "Muscle", "Myocytes": "Muscle", "Muscle cells": "Muscle", "Muscle itself": "Muscle",
+		"Hepatocytes": "Liver", "Adipocytes": "Adipose", "Brain cells": "Brain",
+	} {
+		if got := NormalizeTissue(spelling); got != tissue {
+			t.Errorf("Expected %q to normalize to %s, got %s", spelling, tissue, got)
+		}
+	}
+}
+
+// Test that receptor- and ligand-declared pairs become annotated tissue edges
+func TestBuildFromEntries(t *testing.T) {
+	ligands := []LigandEntry{
+		{Name: "FGF21", SourceTissues: []string{"Liver"}, Pathways: []string{"ERK1/2"}},
+		{Name: "Fibroblast Growth Factor 21 (Liver-derived)", SourceTissues: []string{"Liver"},
+			Receptors: []string{"FGFR1c/β-Klotho Complex"}},
+		{Name: "Interleukin-6", SourceTissues: []string{"Skeletal muscle"},
+			TargetTissues: []string{"Liver", "Adipose tissue"}, Receptors: []string{"IL-6 Receptor", "IL6R"}},
+	}
+	receptors := []ReceptorEntry{
+		{Name: "FGFR1c/β-Klotho Complex", Tissue: "Adipose", Ligands: []string{"FGF21"},
+			Pathways: []string{"ERK1/2", "PI3K/Akt"}},
+	}
+
+	network := BuildFromEntries(ligands, receptors)
+
+	if len(network.Nodes) != 2 {
+		t.Fatalf("Expected FGF21 aliases to merge into 2 nodes, got %d", len(network.Nodes))
+	}
+
+	var fgf21, il6 int
+	for _, edge := range network.Interactions {
+		switch CanonicalLigand(edge.Ligand) {
+		case "fgf21":
+			fgf21++
+			if edge.SourceTissue != "Liver" || edge.TargetTissue != "Adipose" {
+				t.Errorf("Unexpected FGF21 tissues %s -> %s", edge.SourceTissue, edge.TargetTissue)
+			}
+			if edge.Pathway != "ERK1/2" {
+				t.Errorf("Expected shared pathway ERK1/2, got %q", edge.Pathway)
+			}
+			if edge.Strength != baseStrength+mutualBonus+sharedPathwayBonus {
+				t.Errorf("Expected mutual, pathway-supported strength, got %.2f", edge.Strength)
+			}
+		case "il6":
+			il6++
+			if edge.SourceTissue != "Muscle" {
+				t.Errorf("Expected IL-6 from Muscle, got %s", edge.SourceTissue)
+			}
+		}
+	}
+
+	if fgf21 != 1 {
+		t.Errorf("Expected 1 FGF21 edge, got %d", fgf21)
+	}
+	// IL-6 Receptor and IL6R are the same receptor, one edge per target tissue
+	if il6 != 2 {
+		t.Errorf("Expected 2 IL-6 edges, got %d", il6)
+	}
+}
+
+// Test that vesicle cargo becomes typed EV edges to each uptake tissue, sharing
+// the node of a ligand already in the network
+func TestAddVesicles(t *testing.T) {
+	base := BuildFromEntries([]LigandEntry{
+		{Name: "Myostatin (GDF-8)", SourceTissues: []string{"Skeletal muscle"}, Receptors: []string{"ActRIIB"}},
+	}, nil)
+	v := molecular_types.MuscleDerivedExosomes
+	network := AddVesicles(base, []molecular_types.ExerciseVesicle{v})
+
+	want := len(base.Interactions) + len(v.KeyCargo)*len(v.UptakeShare)
+	if len(network.Interactions) != want {
+		t.Fatalf("Expected %d interactions, got %d", want, len(network.Interactions))
+	}
+	if len(network.Nodes) != len(base.Nodes)+len(v.KeyCargo)-1 {
+		t.Errorf("Expected myostatin cargo to share the ligand node, got %d nodes", len(network.Nodes))
+	}
+
+	var liver int
+	for _, edge := range network.Interactions {
+		if edge.Type != molecular_types.VesicleCargo {
+			if edge.Type != molecular_types.LigandReceptor {
+				t.Errorf("Unexpected edge type %q", edge.Type)
+			}
+			continue
+		}
+		if edge.Receptor != "Exosome uptake" || edge.SourceTissue != "Muscle" {
+			t.Errorf("Unexpected EV edge %+v", edge)
+		}
+		if CanonicalLigand(edge.Ligand) == CanonicalLigand("Myostatin") && edge.Ligand != "Myostatin (GDF-8)" {
+			t.Errorf("Expected myostatin cargo to use the existing node name, got %q", edge.Ligand)
+		}
+		if edge.TargetTissue == "Liver" {
+			liver++
+			if edge.Strength != v.UptakeShare["Liver"] {
+				t.Errorf("Expected liver edges weighted by uptake share, got %.2f", edge.Strength)
+			}
+		}
+	}
+	if liver != len(v.KeyCargo) {
+		t.Errorf("Expected one liver edge per cargo, got %d", liver)
+	}
+
+	v.KeyCargo = append(v.KeyCargo, molecular_types.Cargo{Kind: molecular_types.CargoMiRNA, ID: "hsa-miR-0"})
+	if _, err := VesicleInteractions([]molecular_types.ExerciseVesicle{v}); err == nil {
+		t.Error("Expected an error for cargo missing from the catalog")
+	}
+}
@@ -0,0 +1,214 @@
+package network
+
+import (
+	"exersomes/components/bone"
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/cardiovascular/heart"
+	"exersomes/components/immune"
+	"exersomes/components/metabolic/adipose"
+	"exersomes/components/metabolic/liver"
+	"exersomes/components/metabolic/pancreas"
+	musculoskeletal "exersomes/components/muscle"
+	"exersomes/molecular_types"
+)
+
+// LigandEntry is a secreted factor from a component package, reduced to the
+// fields needed to match it against receptors
+type LigandEntry struct {
+	Name          string
+	Category      string   // "Myokine", "Hepatokine", "Adipokine", etc.
+	SourceTissues []string // Tissues secreting the ligand
+	TargetTissues []string // Tissues the catalog says it acts on, if known
+	Receptors     []string // Receptors named by the ligand's own entry
+	Pathways      []string
+	Regulation    string // "Up", "Down", "Biphasic"
+}
+
+// ReceptorEntry is a receptor from a component package with the tissue it is expressed in
+type ReceptorEntry struct {
+	Name     string
+	Tissue   string
+	Ligands  []string // Ligands named by the receptor's own entry
+	Pathways []string
+}
+
+// CatalogLigands collects ligands from every component package
+func CatalogLigands() []LigandEntry {
+	var entries []LigandEntry
+
+	for _, l := range musculoskeletal.GetExerciseResponsiveLigands() {
+		entries = append(entries, LigandEntry{
+			Name:          l.Name,
+			Category:      "Myokine",
+			SourceTissues: l.SourceTissues,
+			Receptors:     l.TargetReceptors,
+			Regulation:    l.ExerciseRegulation,
+		})
+	}
+
+	for _, m := range append(musculoskeletal.GetEnduranceExerciseMyokines(), musculoskeletal.GetResistanceExerciseMyokines()...) {
+		entries = append(entries, LigandEntry{
+			Name:          m.Name,
+			Category:      "Myokine",
+			SourceTissues: []string{"Skeletal muscle"},
+			TargetTissues: m.TargetTissues,
+			Pathways:      m.SignalingPathways,
+			Regulation:    m.ExerciseResponse,
+		})
+	}
+
+	for _, h := range liver.GetExerciseResponsiveHepatokines() {
+		entries = append(entries, LigandEntry{
+			Name:          h.Name,
+			Category:      "Hepatokine",
+			SourceTissues: []string{"Liver"},
+			TargetTissues: h.TargetTissues,
+			Pathways:      h.SignalingPathways,
+			Regulation:    h.ExerciseResponse,
+		})
+	}
+
+	for _, a := range append(adipose.GetExerciseUpregulatedAdipokines(), adipose.GetExerciseDownregulatedAdipokines()...) {
+		entries = append(entries, LigandEntry{
+			Name:          a.Name,
+			Category:      "Adipokine",
+			SourceTissues: []string{"Adipose"},
+			TargetTissues: a.TargetOrgans,
+			Regulation:    a.ExerciseRegulation,
+		})
+	}
+
+	for _, c := range append(heart.GetExerciseUpregulatedCardiokines(), heart.GetExerciseDownregulatedCardiokines()...) {
+		entries = append(entries, LigandEntry{
+			Name:          c.Name,
+			Category:      "Cardiokine",
+			SourceTissues: []string{"Heart"},
+			TargetTissues: c.TargetOrgans,
+			Regulation:    c.ExerciseRegulation,
+		})
+	}
+
+	for _, p := range pancreas.GetExerciseResponsiveExerkines() {
+		entries = append(entries, LigandEntry{
+			Name:          p.Name,
+			Category:      "Pancreatic hormone",
+			SourceTissues: []string{"Pancreas"},
+			TargetTissues: p.TargetOrgans,
+			Regulation:    p.ExerciseRegulation,
+		})
+	}
+
+	for _, o := range bone.GetOsteokines() {
+		entries = append(entries, LigandEntry{
+			Name:          o.Name,
+			Category:      "Osteokine",
+			SourceTissues: []string{"Bone"},
+			TargetTissues: o.TargetOrgans,
+			Pathways:      []string{o.SignalingPathway},
+		})
+	}
+
+	for _, l := range bone.GetBoneLigands() {
+		entries = append(entries, LigandEntry{
+			Name:          l.Name,
+			Category:      "Osteokine",
+			SourceTissues: []string{"Bone"},
+			Receptors:     splitNames(l.Receptor),
+			Pathways:      []string{l.SignalingPathway},
+		})
+	}
+
+	for _, f := range bloodstream.GetExerciseResponsiveFactors() {
+		entries = append(entries, LigandEntry{
+			Name:          f.Name,
+			Category:      f.Type,
+			SourceTissues: f.PrimarySource,
+			Regulation:    f.ExerciseResponse,
+		})
+	}
+
+	seen := make(map[string]bool)
+	cytokines := append(immune.GetAcutelyUpregulatedCytokines(), immune.GetAntiInflammatoryCytokines()...)
+	for _, c := range cytokines {
+		if seen[c.Name] {
+			continue
+		}
+		seen[c.Name] = true
+		entries = append(entries, LigandEntry{
+			Name:          c.Name,
+			Category:      "Cytokine",
+			SourceTissues: c.SourceCells,
+			TargetTissues: c.TargetCells,
+			Regulation:    c.AcuteRegulation,
+		})
+	}
+
+	return entries
+}
+
+// CatalogReceptors collects receptors from every component package
+func CatalogReceptors() []ReceptorEntry {
+	var entries []ReceptorEntry
+
+	for _, r := range heart.GetExerciseResponsiveReceptors() {
+		entries = append(entries, ReceptorEntry{
+			Name:     r.Name,
+			Tissue:   "Heart",
+			Ligands:  r.Ligands,
+			Pathways: r.SignalingPathways,
+		})
+	}
+
+	for _, r := range append(liver.GetExerciseResponsiveReceptors(), liver.GlucagonReceptor) {
+		entries = append(entries, ReceptorEntry{
+			Name:     r.Name,
+			Tissue:   "Liver",
+			Ligands:  r.Ligands,
+			Pathways: r.SignalingPathways,
+		})
+	}
+
+	for _, r := range pancreas.GetExerciseResponsiveReceptors() {
+		entries = append(entries, ReceptorEntry{
+			Name:     r.Name,
+			Tissue:   "Pancreas",
+			Ligands:  r.Ligands,
+			Pathways: r.SignalingPathway,
+		})
+	}
+
+	// An empty adipose type returns every adipose receptor
+	for _, r := range adipose.GetReceptorsByAdiposeType("") {
+		entries = append(entries, ReceptorEntry{
+			Name:     r.Name,
+			Tissue:   "Adipose",
+			Ligands:  r.Ligands,
+			Pathways: r.SignalingPathways,
+		})
+	}
+
+	for _, r := range bone.GetBoneReceptors() {
+		entries = append(entries, ReceptorEntry{
+			Name:     r.Name,
+			Tissue:   "Bone",
+			Ligands:  splitNames(r.Ligand),
+			Pathways: []string{r.SignalingPathway},
+		})
+	}
+
+	return entries
+}
+
+// ExerkineLigands converts curated exerkine records into ligand entries
+func ExerkineLigands(exerkines []molecular_types.Exerkine) []LigandEntry {
+	entries := make([]LigandEntry, 0, len(exerkines))
+	for _, e := range exerkines {
+		entries = append(entries, LigandEntry{
+			Name:          e.Name,
+			Category:      e.Category,
+			SourceTissues: e.TissueSources,
+			Receptors:     e.Receptors,
+		})
+	}
+	return entries
+}
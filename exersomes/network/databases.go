@@ -0,0 +1,226 @@
+package network
+
+import (
+	"exersomes/molecular_types"
+	"sort"
+	"strings"
+)
+
+// Provenance labels for interaction sources
+const (
+	CuratedSource     = "Curated"
+	CellPhoneDBSource = "CellPhoneDB"
+	CellChatDBSource  = "CellChatDB"
+	OmniPathSource    = "OmniPath"
+	VesicleSource     = "Vesicle cargo"
+)
+
+// evidenceHalfSaturation is the evidence count at which an imported pair reaches
+// half of its maximum contribution to edge strength
+const evidenceHalfSaturation = 3.0
+
+// DatabaseInteraction is a ligand-receptor pair imported from an external resource.
+// Multi-subunit complexes list their gene symbols in the Subunits fields, e.g. the
+// IL-6 receptor complex has ReceptorSubunits IL6R and IL6ST.
+type DatabaseInteraction struct {
+	Ligand           string   // Name as given by the resource (gene symbol or complex name)
+	Receptor         string   // Name as given by the resource (gene symbol or complex name)
+	LigandSubunits   []string // Gene symbols; a single entry unless the ligand is a complex
+	ReceptorSubunits []string // Gene symbols; a single entry unless the receptor is a complex
+	Pathway          string
+	Sources          []string // Resources reporting the pair
+	References       []string // Distinct identifiers in "NS:id" form, such as "PMID:123"
+}
+
+// LigandKey returns the canonical key of the ligand, joining complex subunits with "+"
+func (d DatabaseInteraction) LigandKey() string {
+	return complexKey(d.Ligand, d.LigandSubunits, CanonicalLigand)
+}
+
+// ReceptorKey returns the canonical key of the receptor, joining complex subunits
+// with "+" so that IL6R+IL6ST and IL6ST+IL6R are the same receptor
+func (d DatabaseInteraction) ReceptorKey() string {
+	return complexKey(d.Receptor, d.ReceptorSubunits, CanonicalReceptor)
+}
+
+// Evidence returns the number of distinct references, or the number of reporting
+// resources when none of them cite references
+func (d DatabaseInteraction) Evidence() int {
+	if len(d.References) > 0 {
+		return len(d.References)
+	}
+	return len(d.Sources)
+}
+
+// complexKey canonicalizes each subunit and joins the sorted keys with "+"
+func complexKey(name string, subunits []string, canon func(string) string) string {
+	if len(subunits) <= 1 {
+		return canon(name)
+	}
+	keys := make([]string, 0, len(subunits))
+	for _, s := range subunits {
+		keys = appendUnique(keys, canon(s))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "+")
+}
+
+// subunitKeys returns the canonical keys of every subunit plus the complex key itself
+func subunitKeys(name string, subunits []string, canon func(string) string) map[string]bool {
+	keys := map[string]bool{complexKey(name, subunits, canon): true}
+	for _, s := range subunits {
+		keys[canon(s)] = true
+	}
+	return keys
+}
+
+// CombineInteractions merges records describing the same ligand-receptor pair,
+// across resources, into one record with the union of sources and references
+func CombineInteractions(records []DatabaseInteraction) []DatabaseInteraction {
+	byKey := make(map[string]*DatabaseInteraction)
+	var order []string
+
+	for _, r := range records {
+		key := r.LigandKey() + "|" + r.ReceptorKey()
+		c, ok := byKey[key]
+		if !ok {
+			c = &DatabaseInteraction{
+				Ligand:           r.Ligand,
+				Receptor:         r.Receptor,
+				LigandSubunits:   r.LigandSubunits,
+				ReceptorSubunits: r.ReceptorSubunits,
+			}
+			byKey[key] = c
+			order = append(order, key)
+		}
+		if c.Pathway == "" {
+			c.Pathway = r.Pathway
+		}
+		c.Sources = appendUnique(c.Sources, r.Sources...)
+		c.References = appendUnique(c.References, r.References...)
+	}
+
+	sort.Strings(order)
+	combined := make([]DatabaseInteraction, 0, len(order))
+	for _, key := range order {
+		combined = append(combined, *byKey[key])
+	}
+	return combined
+}
+
+// evidenceScore maps an evidence count to 0-1 with diminishing returns
+func evidenceScore(evidence int) float64 {
+	return float64(evidence) / (float64(evidence) + evidenceHalfSaturation)
+}
+
+// MergeDatabases annotates a curated network with imported ligand-receptor pairs.
+// Pairs whose ligand is already a network node are kept, so the network stays
+// restricted to exercise-annotated ligands:
+//   - a pair matching a curated edge adds its sources and references to that edge
+//     and raises its strength toward 1.0 by evidence count
+//   - any other pair becomes a new edge from each of the ligand's source tissues,
+//     with strength below curated edges and no target tissue
+//
+// Ligands and receptors match on canonical name or, for complexes, on any subunit,
+// so the curated "IL-6 Receptor" is confirmed by a database IL6R+IL6ST complex.
+func MergeDatabases(network molecular_types.ExerkineNetwork, records []DatabaseInteraction) molecular_types.ExerkineNetwork {
+	nodes := make([]molecular_types.Exerkine, len(network.Nodes))
+	nodeByKey := make(map[string]int)
+	for i, n := range network.Nodes {
+		nodes[i] = n
+		nodes[i].Receptors = append([]string(nil), n.Receptors...)
+		nodeByKey[CanonicalLigand(n.Name)] = i
+	}
+
+	interactions := make([]molecular_types.Interaction, len(network.Interactions))
+	references := make([][]string, len(network.Interactions))
+	curatedStrength := make([]float64, len(network.Interactions))
+	for i, in := range network.Interactions {
+		interactions[i] = in
+		interactions[i].Sources = append([]string(nil), in.Sources...)
+		if len(interactions[i].Sources) == 0 {
+			interactions[i].Sources = []string{CuratedSource}
+		}
+		curatedStrength[i] = in.Strength
+	}
+	curatedCount := len(interactions)
+
+	added := make(map[string]bool)
+	for _, pair := range CombineInteractions(records) {
+		ligandKeys := subunitKeys(pair.Ligand, pair.LigandSubunits, CanonicalLigand)
+		receptorKeys := subunitKeys(pair.Receptor, pair.ReceptorSubunits, CanonicalReceptor)
+
+		node := -1
+		for key := range ligandKeys {
+			if i, ok := nodeByKey[key]; ok && (node < 0 || i < node) {
+				node = i
+			}
+		}
+		if node < 0 {
+			continue
+		}
+
+		matched := false
+		for i := 0; i < curatedCount; i++ {
+			in := &interactions[i]
+			if !ligandKeys[CanonicalLigand(in.Ligand)] || !receptorKeys[CanonicalReceptor(in.Receptor)] {
+				continue
+			}
+			matched = true
+			in.Sources = appendUnique(in.Sources, pair.Sources...)
+			references[i] = appendUnique(references[i], pair.References...)
+			if len(pair.References) == 0 {
+				references[i] = appendUnique(references[i], pair.Sources...)
+			}
+			if in.Pathway == "" {
+				in.Pathway = pair.Pathway
+			}
+		}
+		if matched {
+			continue
+		}
+
+		n := &nodes[node]
+		n.Receptors = appendUnique(n.Receptors, pair.Receptor)
+		sources := n.TissueSources
+		if len(sources) == 0 {
+			sources = []string{""}
+		}
+		for _, source := range sources {
+			id := CanonicalLigand(n.Name) + "|" + pair.ReceptorKey() + "|" + source
+			if added[id] {
+				continue
+			}
+			added[id] = true
+			interactions = append(interactions, molecular_types.Interaction{
+				Type:         molecular_types.LigandReceptor,
+				Ligand:       n.Name,
+				Receptor:     pair.Receptor,
+				SourceTissue: source,
+				Pathway:      pair.Pathway,
+				Strength:     baseStrength * evidenceScore(pair.Evidence()),
+				Sources:      append([]string(nil), pair.Sources...),
+				Evidence:     pair.Evidence(),
+			})
+		}
+	}
+
+	for i := 0; i < curatedCount; i++ {
+		if len(references[i]) == 0 {
+			continue
+		}
+		interactions[i].Evidence = len(references[i])
+		base := curatedStrength[i]
+		interactions[i].Strength = base + (1.0-base)*evidenceScore(interactions[i].Evidence)
+	}
+
+	for i := range nodes {
+		sort.Strings(nodes[i].Receptors)
+	}
+	sortInteractions(interactions)
+
+	return molecular_types.ExerkineNetwork{
+		Nodes:        nodes,
+		Interactions: interactions,
+	}
+}
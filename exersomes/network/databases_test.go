@@ -0,0 +1,146 @@
+package network
+
+import (
+	"exersomes/molecular_types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Test that each importer resolves multi-subunit receptor complexes to the same key
+func TestImportComplexes(t *testing.T) {
+	cpdb := t.TempDir()
+	writeFile(t, cpdb, "gene_input.csv", "gene_name,uniprot,hgnc_symbol,ensembl\n"+
+		"IL6,P05231,IL6,ENSG00000136244\nIL6R,P08887,IL6R,ENSG00000160712\n"+
+		"IL6ST,P40189,IL6ST,ENSG00000134352\nFGF21,Q9NSA1,FGF21,ENSG00000105550\n"+
+		"FGFR1,P11362,FGFR1,ENSG00000077782\nKLB,Q86Z14,KLB,ENSG00000134962\n")
+	writeFile(t, cpdb, "complex_input.csv", "complex_name,uniprot_1,uniprot_2,uniprot_3\n"+
+		"IL6_receptor,P08887,P40189,\nFGFR1_KLB,P11362,Q86Z14,\n")
+	writeFile(t, cpdb, "interaction_input.csv", "partner_a,partner_b,source,directionality,classification\n"+
+		"P05231,IL6_receptor,PMID:8702;curated,Ligand-Receptor,Signaling by Interleukin\n"+
+		"FGFR1_KLB,Q9NSA1,PMID:1745,Receptor-Ligand,Signaling by FGF\n"+
+		"P08887,P40189,uniprot,Adhesion-Adhesion,\n")
+
+	cellchat := t.TempDir()
+	writeFile(t, cellchat, "complex.csv", "\"\",subunit_1,subunit_2,subunit_3,subunit_4\n"+
+		"IL6R_IL6ST,IL6R,IL6ST,,\n")
+	writeFile(t, cellchat, "interaction.csv", "\"\",interaction_name,pathway_name,ligand,receptor,evidence,annotation\n"+
+		"IL6_IL6R_IL6ST,IL6_IL6R_IL6ST,IL6,IL6,IL6R_IL6ST,PMID: 8702; KEGG: hsa04630,Secreted Signaling\n")
+
+	omnipath := writeFile(t, t.TempDir(), "omnipath.tsv",
+		"source\ttarget\tsource_genesymbol\ttarget_genesymbol\tis_directed\tsources\treferences\n"+
+			"P05231\tCOMPLEX:P08887_P40189\tIL6\tIL6ST_IL6R\t1\tSIGNOR;CellPhoneDB\tSIGNOR:8702;HPRD:2011\n"+
+			"P05231\tP08887\tIL6\tIL6R\t0\tHPRD\tHPRD:2011\n")
+
+	records, err := LoadDatabases(DatabaseFiles{CellPhoneDB: cpdb, CellChatDB: cellchat, OmniPath: omnipath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("Expected 4 ligand-receptor records, got %d", len(records))
+	}
+
+	for _, r := range records {
+		switch r.LigandKey() {
+		case "il6":
+			if r.ReceptorKey() != "il6r+il6st" {
+				t.Errorf("%s: expected IL6R+IL6ST complex, got %q", r.Sources[0], r.ReceptorKey())
+			}
+		case "fgf21":
+			if r.ReceptorKey() != CanonicalReceptor("FGFR1c/β-Klotho Complex") {
+				t.Errorf("Expected FGFR1+KLB to match the curated complex name, got %q", r.ReceptorKey())
+			}
+		default:
+			t.Errorf("Unexpected ligand %q", r.Ligand)
+		}
+	}
+
+	combined := CombineInteractions(records)
+	if len(combined) != 2 {
+		t.Fatalf("Expected records to combine into 2 pairs, got %d", len(combined))
+	}
+	for _, c := range combined {
+		if c.LigandKey() == "il6" && (len(c.Sources) < 3 || c.Evidence() != 4) {
+			t.Errorf("Expected IL-6 provenance from all resources with 4 references, got %v %v",
+				c.Sources, c.References)
+		}
+	}
+}
+
+// Test references keep their namespace and drop resource names
+func TestSplitReferences(t *testing.T) {
+	cases := map[string][]string{
+		"PMID:8702;curated":          {"PMID:8702"},
+		"PMID: 8702; KEGG: hsa04630": {"PMID:8702", "KEGG:hsa04630"},
+		"SIGNOR:8702;HPRD:2011":      {"SIGNOR:8702", "HPRD:2011"},
+		"uniprot,reactome":           nil,
+		"8702|pmid:8702":             {"PMID:8702"},
+	}
+	for cell, want := range cases {
+		got := splitReferences(cell)
+		if len(got) != len(want) {
+			t.Errorf("%q: expected %v, got %v", cell, want, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%q: expected %v, got %v", cell, want, got)
+				break
+			}
+		}
+	}
+}
+
+// Test merging imported pairs into curated edges with provenance and evidence scores
+func TestMergeDatabases(t *testing.T) {
+	curated := molecular_types.ExerkineNetwork{
+		Nodes: []molecular_types.Exerkine{{Name: "Interleukin-6", TissueSources: []string{"Muscle"}}},
+		Interactions: []molecular_types.Interaction{{
+			Ligand: "Interleukin-6", Receptor: "IL-6 Receptor", Tissue: "Liver",
+			SourceTissue: "Muscle", TargetTissue: "Liver", Strength: 0.75,
+		}},
+	}
+	records := []DatabaseInteraction{
+		{Ligand: "IL6", Receptor: "IL6R_IL6ST", ReceptorSubunits: []string{"IL6R", "IL6ST"},
+			Sources: []string{CellChatDBSource}, References: []string{"PMID:8702", "HPRD:2011"}},
+		{Ligand: "IL6", Receptor: "SORL1", Sources: []string{OmniPathSource}},
+		{Ligand: "CCL2", Receptor: "CCR2", Sources: []string{OmniPathSource}},
+	}
+
+	merged := MergeDatabases(curated, records)
+	if len(merged.Interactions) != 2 {
+		t.Fatalf("Expected curated edge plus one new IL-6 edge, got %d", len(merged.Interactions))
+	}
+
+	for _, in := range merged.Interactions {
+		switch in.Receptor {
+		case "IL-6 Receptor":
+			if len(in.Sources) != 2 || in.Evidence != 2 {
+				t.Errorf("Expected curated edge confirmed by CellChatDB with 2 references, got %v %d",
+					in.Sources, in.Evidence)
+			}
+			if in.Strength <= 0.75 || in.Strength > 1.0 {
+				t.Errorf("Expected evidence to raise strength within (0.75, 1], got %.3f", in.Strength)
+			}
+		case "SORL1":
+			if in.SourceTissue != "Muscle" || in.Strength >= 0.5 {
+				t.Errorf("Expected a weak database-only edge from Muscle, got %+v", in)
+			}
+		default:
+			t.Errorf("Unexpected edge %+v", in)
+		}
+	}
+
+	if len(curated.Interactions[0].Sources) != 0 {
+		t.Errorf("MergeDatabases should not modify its input")
+	}
+}
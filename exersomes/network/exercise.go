@@ -0,0 +1,89 @@
+package network
+
+import (
+	"exersomes/components/cardiovascular/bloodstream"
+	musculoskeletal "exersomes/components/muscle"
+	"exersomes/molecular_types"
+	"exersomes/session"
+	"math"
+)
+
+// ExerciseResponse predicts the signed log2 fold change of each ligand after a
+// session of the given exercise type, keyed by canonical ligand name. Where
+// several predictors cover one ligand, the largest change wins.
+func ExerciseResponse(exerciseType string) map[string]float64 {
+	s := session.ForExerciseType(exerciseType)
+	response := make(map[string]float64)
+
+	// Circulating factors: fold change at the end of the session
+	for _, f := range bloodstream.GetExerciseResponsiveFactors() {
+		baseline := f.Model().Baseline
+		if baseline <= 0 {
+			continue
+		}
+		level := f.CalculateSessionResponse(s, s.TotalDuration())
+		strongest(response, CanonicalLigand(f.Name), math.Log2(level/baseline))
+	}
+
+	// Muscle ligands: post-session concentration against baseline midpoint
+	for _, l := range musculoskeletal.GetExerciseResponsiveLigands() {
+		baseline := (l.BaselineConc.Min + l.BaselineConc.Max) / 2
+		level := l.CalculateSessionResponse(s)
+		if baseline > 0 && level > 0 {
+			strongest(response, CanonicalLigand(l.Name), math.Log2(level/baseline))
+		}
+	}
+
+	// Myokines the catalog associates with this modality, at a nominal half log2 step
+	var myokines []musculoskeletal.Myokine
+	switch exerciseType {
+	case "Resistance", "Strength":
+		myokines = musculoskeletal.GetResistanceExerciseMyokines()
+	default:
+		myokines = musculoskeletal.GetEnduranceExerciseMyokines()
+	}
+	for _, m := range myokines {
+		change := 0.5
+		if m.ExerciseResponse == "Down" {
+			change = -0.5
+		}
+		strongest(response, CanonicalLigand(m.Name), change)
+	}
+
+	return response
+}
+
+// ExerciseRelevance scores how strongly each ligand responds to an exercise type,
+// keyed by canonical ligand name. Scores are 1 + |log2 fold change| from
+// ExerciseResponse, so 1.0 means no change or no information.
+func ExerciseRelevance(exerciseType string) map[string]float64 {
+	relevance := make(map[string]float64)
+	for key, change := range ExerciseResponse(exerciseType) {
+		relevance[key] = 1.0 + math.Abs(change)
+	}
+	return relevance
+}
+
+// NodeRelevance maps ExerciseRelevance onto the node names of a network
+func NodeRelevance(network molecular_types.ExerkineNetwork, exerciseType string) map[string]float64 {
+	byKey := ExerciseRelevance(exerciseType)
+	relevance := make(map[string]float64)
+	for _, node := range network.Nodes {
+		if w, ok := byKey[CanonicalLigand(node.Name)]; ok {
+			relevance[node.Name] = w
+		}
+	}
+	for _, in := range network.Interactions {
+		if w, ok := byKey[CanonicalLigand(in.Ligand)]; ok {
+			relevance[in.Ligand] = w
+		}
+	}
+	return relevance
+}
+
+// strongest keeps the change with the largest magnitude
+func strongest(changes map[string]float64, key string, change float64) {
+	if current, ok := changes[key]; !ok || math.Abs(change) > math.Abs(current) {
+		changes[key] = change
+	}
+}
@@ -0,0 +1,334 @@
+package network
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// table is a delimited file with columns looked up by header name
+type table struct {
+	path    string
+	columns map[string]int
+	rows    [][]string
+}
+
+// readTable reads a CSV or TSV file with a header row. Header names are matched
+// case-insensitively; an empty first header (R row names) is named "rowname".
+func readTable(path string, comma rune) (*table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = comma
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%s: empty file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	t := &table{path: path, columns: make(map[string]int)}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		if name == "" && i == 0 {
+			name = "rowname"
+		}
+		t.columns[name] = i
+	}
+
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+// require returns an error naming the first missing column
+func (t *table) require(columns ...string) error {
+	for _, c := range columns {
+		if _, ok := t.columns[c]; !ok {
+			return fmt.Errorf("%s: missing column %q", t.path, c)
+		}
+	}
+	return nil
+}
+
+// get returns a trimmed cell by column name, or "" if the column or cell is absent
+func (t *table) get(row []string, column string) string {
+	i, ok := t.columns[column]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// splitReferences splits cells such as "PMID: 123; KEGG: hsa04630" or
+// "HPRD:123;SIGNOR:123" into distinct "NS:id" identifiers. Bare numbers are
+// taken as PMIDs; other bare tokens such as "curated" or "uniprot" name a
+// resource rather than a reference and are dropped.
+func splitReferences(cell string) []string {
+	var refs []string
+	for _, part := range strings.FieldsFunc(cell, func(r rune) bool { return r == ';' || r == ',' || r == '|' }) {
+		var ns, id string
+		if i := strings.Index(part, ":"); i >= 0 {
+			ns, id = strings.ToUpper(strings.TrimSpace(part[:i])), strings.TrimSpace(part[i+1:])
+		} else if id = strings.TrimSpace(part); id != "" && strings.Trim(id, "0123456789") == "" {
+			ns = "PMID"
+		}
+		if ns == "" || id == "" {
+			continue
+		}
+		refs = appendUnique(refs, ns+":"+id)
+	}
+	return refs
+}
+
+// ReadCellPhoneDB imports a CellPhoneDB database directory containing
+// interaction_input.csv and complex_input.csv, plus gene_input.csv to map UniProt
+// accessions to gene symbols. Partner A is the ligand unless the directionality
+// column says "Receptor-Ligand"; adhesion and other non-secreted pairs are skipped.
+func ReadCellPhoneDB(dir string) ([]DatabaseInteraction, error) {
+	symbols := make(map[string]string) // UniProt accession -> gene symbol
+	if genes, err := readTable(filepath.Join(dir, "gene_input.csv"), ','); err == nil {
+		for _, row := range genes.rows {
+			uniprot := genes.get(row, "uniprot")
+			symbol := genes.get(row, "hgnc_symbol")
+			if symbol == "" {
+				symbol = genes.get(row, "gene_name")
+			}
+			if uniprot != "" && symbol != "" {
+				if _, exists := symbols[uniprot]; !exists {
+					symbols[uniprot] = symbol
+				}
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	symbol := func(id string) string {
+		if s, ok := symbols[id]; ok {
+			return s
+		}
+		return id
+	}
+
+	complexes := make(map[string][]string) // complex name -> subunit gene symbols
+	if ct, err := readTable(filepath.Join(dir, "complex_input.csv"), ','); err == nil {
+		if err := ct.require("complex_name"); err != nil {
+			return nil, err
+		}
+		for _, row := range ct.rows {
+			var subunits []string
+			for i := 1; i <= 5; i++ {
+				if id := ct.get(row, fmt.Sprintf("uniprot_%d", i)); id != "" {
+					subunits = append(subunits, symbol(id))
+				}
+			}
+			complexes[ct.get(row, "complex_name")] = subunits
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	partner := func(id string) (string, []string) {
+		if subunits, ok := complexes[id]; ok {
+			return id, subunits
+		}
+		return symbol(id), []string{symbol(id)}
+	}
+
+	it, err := readTable(filepath.Join(dir, "interaction_input.csv"), ',')
+	if err != nil {
+		return nil, err
+	}
+	if err := it.require("partner_a", "partner_b"); err != nil {
+		return nil, err
+	}
+
+	var records []DatabaseInteraction
+	for _, row := range it.rows {
+		a, b := it.get(row, "partner_a"), it.get(row, "partner_b")
+		if a == "" || b == "" {
+			continue
+		}
+		switch strings.ToLower(it.get(row, "directionality")) {
+		case "", "ligand-receptor":
+		case "receptor-ligand":
+			a, b = b, a
+		default:
+			continue
+		}
+
+		ligand, ligandSubunits := partner(a)
+		receptor, receptorSubunits := partner(b)
+		records = append(records, DatabaseInteraction{
+			Ligand:           ligand,
+			Receptor:         receptor,
+			LigandSubunits:   ligandSubunits,
+			ReceptorSubunits: receptorSubunits,
+			Pathway:          it.get(row, "classification"),
+			Sources:          []string{CellPhoneDBSource},
+			References:       splitReferences(it.get(row, "source")),
+		})
+	}
+	return records, nil
+}
+
+// ReadCellChatDB imports a CellChatDB export directory containing interaction.csv
+// and complex.csv, as written by write.csv(CellChatDB.human$interaction) and
+// write.csv(CellChatDB.human$complex). Complex names not listed in complex.csv are
+// split on "_", the separator CellChatDB uses for heteromeric ligands and receptors.
+func ReadCellChatDB(dir string) ([]DatabaseInteraction, error) {
+	complexes := make(map[string][]string)
+	if ct, err := readTable(filepath.Join(dir, "complex.csv"), ','); err == nil {
+		if err := ct.require("rowname"); err != nil {
+			return nil, err
+		}
+		for _, row := range ct.rows {
+			var subunits []string
+			for i := 1; i <= 5; i++ {
+				if s := ct.get(row, fmt.Sprintf("subunit_%d", i)); s != "" {
+					subunits = append(subunits, s)
+				}
+			}
+			complexes[ct.get(row, "rowname")] = subunits
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	partner := func(name string) []string {
+		if subunits, ok := complexes[name]; ok && len(subunits) > 0 {
+			return subunits
+		}
+		return strings.Split(name, "_")
+	}
+
+	it, err := readTable(filepath.Join(dir, "interaction.csv"), ',')
+	if err != nil {
+		return nil, err
+	}
+	if err := it.require("ligand", "receptor"); err != nil {
+		return nil, err
+	}
+
+	var records []DatabaseInteraction
+	for _, row := range it.rows {
+		ligand, receptor := it.get(row, "ligand"), it.get(row, "receptor")
+		if ligand == "" || receptor == "" {
+			continue
+		}
+		if annotation := it.get(row, "annotation"); annotation == "Cell-Cell Contact" {
+			continue
+		}
+		records = append(records, DatabaseInteraction{
+			Ligand:           ligand,
+			Receptor:         receptor,
+			LigandSubunits:   partner(ligand),
+			ReceptorSubunits: partner(receptor),
+			Pathway:          it.get(row, "pathway_name"),
+			Sources:          []string{CellChatDBSource},
+			References:       splitReferences(it.get(row, "evidence")),
+		})
+	}
+	return records, nil
+}
+
+// ReadOmniPath imports an OmniPath interactions TSV export with source_genesymbol and
+// target_genesymbol columns, the source being the ligand. Complexes appear as
+// "COMPLEX:..." identifiers with gene symbols joined by "_". Undirected records are
+// skipped. The references and sources columns provide provenance.
+func ReadOmniPath(path string) ([]DatabaseInteraction, error) {
+	t, err := readTable(path, '\t')
+	if err != nil {
+		return nil, err
+	}
+	if err := t.require("source_genesymbol", "target_genesymbol"); err != nil {
+		return nil, err
+	}
+
+	partner := func(id, symbol string) []string {
+		if strings.HasPrefix(id, "COMPLEX:") || strings.Contains(symbol, "_") {
+			return strings.Split(symbol, "_")
+		}
+		return []string{symbol}
+	}
+
+	var records []DatabaseInteraction
+	for _, row := range t.rows {
+		ligand, receptor := t.get(row, "source_genesymbol"), t.get(row, "target_genesymbol")
+		if ligand == "" || receptor == "" {
+			continue
+		}
+		if directed := strings.ToLower(t.get(row, "is_directed")); directed == "0" || directed == "false" {
+			continue
+		}
+
+		sources := []string{OmniPathSource}
+		for _, s := range strings.Split(t.get(row, "sources"), ";") {
+			if s = strings.TrimSpace(s); s != "" {
+				sources = appendUnique(sources, OmniPathSource+"/"+s)
+			}
+		}
+
+		records = append(records, DatabaseInteraction{
+			Ligand:           ligand,
+			Receptor:         receptor,
+			LigandSubunits:   partner(t.get(row, "source"), ligand),
+			ReceptorSubunits: partner(t.get(row, "target"), receptor),
+			Sources:          sources,
+			References:       splitReferences(t.get(row, "references")),
+		})
+	}
+	return records, nil
+}
+
+// DatabaseFiles locates local copies of the supported ligand-receptor resources.
+// Empty fields are skipped.
+type DatabaseFiles struct {
+	CellPhoneDB string // Directory with interaction_input.csv and complex_input.csv
+	CellChatDB  string // Directory with interaction.csv and complex.csv
+	OmniPath    string // Interactions TSV export
+}
+
+// LoadDatabases reads every configured resource
+func LoadDatabases(files DatabaseFiles) ([]DatabaseInteraction, error) {
+	var records []DatabaseInteraction
+	if files.CellPhoneDB != "" {
+		r, err := ReadCellPhoneDB(files.CellPhoneDB)
+		if err != nil {
+			return nil, fmt.Errorf("CellPhoneDB: %w", err)
+		}
+		records = append(records, r...)
+	}
+	if files.CellChatDB != "" {
+		r, err := ReadCellChatDB(files.CellChatDB)
+		if err != nil {
+			return nil, fmt.Errorf("CellChatDB: %w", err)
+		}
+		records = append(records, r...)
+	}
+	if files.OmniPath != "" {
+		r, err := ReadOmniPath(files.OmniPath)
+		if err != nil {
+			return nil, fmt.Errorf("OmniPath: %w", err)
+		}
+		records = append(records, r...)
+	}
+	return records, nil
+}
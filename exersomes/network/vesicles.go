@@ -0,0 +1,97 @@
+package network
+
+import (
+	"exersomes/molecular_types"
+	"fmt"
+	"sort"
+)
+
+// VesicleReceptor names the uptake route standing in for a receptor on
+// EV-mediated edges, e.g. "Exosome uptake"
+func VesicleReceptor(v molecular_types.ExerciseVesicle) string {
+	return v.Type + " uptake"
+}
+
+// VesicleInteractions returns one EV-mediated edge per cargo molecule, source
+// tissue and target tissue. Edge strength is the share of cleared vesicles the
+// target tissue takes up. Cargo IDs missing from the catalog are an error.
+func VesicleInteractions(vesicles []molecular_types.ExerciseVesicle) ([]molecular_types.Interaction, error) {
+	var interactions []molecular_types.Interaction
+	for _, v := range vesicles {
+		sources := normalizeTissues(append([]string{v.PrimarySource}, v.SecondarySource...))
+		targets := make([]string, 0, len(v.UptakeShare))
+		for t := range v.UptakeShare {
+			targets = append(targets, t)
+		}
+		sort.Strings(targets)
+
+		for _, c := range v.KeyCargo {
+			name, ok := molecular_types.CargoName(c)
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown %s cargo %q", v.Type, c.Kind, c.ID)
+			}
+			for _, source := range sources {
+				for _, target := range targets {
+					tissue := NormalizeTissue(target)
+					interactions = append(interactions, molecular_types.Interaction{
+						Type:         molecular_types.VesicleCargo,
+						Ligand:       name,
+						Receptor:     VesicleReceptor(v),
+						Tissue:       tissue,
+						SourceTissue: source,
+						TargetTissue: tissue,
+						Strength:     v.UptakeShare[target],
+						Sources:      []string{VesicleSource},
+					})
+				}
+			}
+		}
+	}
+	return interactions, nil
+}
+
+// AddVesicles adds the EV-mediated edges of the vesicles to a network, with a
+// node for each cargo molecule. Cargo already in the network as a ligand, such
+// as myostatin, shares its node. Vesicles with cargo missing from the catalog
+// are skipped.
+func AddVesicles(network molecular_types.ExerkineNetwork, vesicles []molecular_types.ExerciseVesicle) molecular_types.ExerkineNetwork {
+	nodes := append([]molecular_types.Exerkine(nil), network.Nodes...)
+	nodeByKey := make(map[string]int)
+	for i, n := range nodes {
+		nodeByKey[CanonicalLigand(n.Name)] = i
+	}
+	interactions := append([]molecular_types.Interaction(nil), network.Interactions...)
+
+	for _, v := range vesicles {
+		edges, err := VesicleInteractions([]molecular_types.ExerciseVesicle{v})
+		if err != nil {
+			continue
+		}
+		for _, c := range v.KeyCargo {
+			name, _ := molecular_types.CargoName(c)
+			key := CanonicalLigand(name)
+			i, ok := nodeByKey[key]
+			if !ok {
+				i = len(nodes)
+				nodeByKey[key] = i
+				nodes = append(nodes, molecular_types.Exerkine{Name: name, Category: molecular_types.CargoCategory(c)})
+			}
+			nodes[i].TissueSources = appendUnique(append([]string(nil), nodes[i].TissueSources...),
+				normalizeTissues(append([]string{v.PrimarySource}, v.SecondarySource...))...)
+			for j := range edges {
+				if edges[j].Ligand == name {
+					edges[j].Ligand = nodes[i].Name
+				}
+			}
+		}
+		interactions = append(interactions, edges...)
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool { return CanonicalLigand(nodes[i].Name) < CanonicalLigand(nodes[j].Name) })
+	sortInteractions(interactions)
+
+	return molecular_types.ExerkineNetwork{
+		Nodes:        nodes,
+		Interactions: interactions,
+	}
+}
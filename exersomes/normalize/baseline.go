@@ -0,0 +1,110 @@
+package normalize
+
+import (
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/immune"
+	"exersomes/network"
+	"math"
+)
+
+// Baseline is a resting reference range for one analyte
+type Baseline struct {
+	Analyte           string
+	Source            string // Catalog the range came from
+	Min               float64
+	Max               float64
+	Unit              string
+	MolecularWeightDa float64 // 0 when unknown or not meaningful
+}
+
+// Mean is the midpoint of the range
+func (b Baseline) Mean() float64 {
+	return (b.Min + b.Max) / 2
+}
+
+// SD treats the range as a 95% reference interval of a normal distribution
+func (b Baseline) SD() float64 {
+	return (b.Max - b.Min) / (2 * 1.96)
+}
+
+// kDaTypes are circulating factor types whose MolecularWeight is recorded in kDa
+var kDaTypes = map[string]bool{"Cytokine": true, "Neurotrophin": true, "Myokine": true, "Growth factor": true}
+
+// circulatingMolecularWeightDa reads a circulating factor's molecular weight,
+// which the catalog records in Da for small molecules and kDa for proteins
+func circulatingMolecularWeightDa(cf bloodstream.CirculatingFactor) float64 {
+	switch {
+	case kDaTypes[cf.Type]:
+		return cf.MolecularWeight * 1000
+	case cf.BaselineRange.Unit != "" && isCount(cf.BaselineRange.Unit):
+		return 0 // Pooled species such as miRNAs have no single weight
+	}
+	return cf.MolecularWeight
+}
+
+func isCount(unit string) bool {
+	u, err := ParseUnit(unit)
+	return err == nil && u.Dimension == Count
+}
+
+// CatalogBaselines returns the resting ranges of the circulating factor and
+// cytokine catalogs. Analytes present in both keep the circulating factor range.
+func CatalogBaselines() []Baseline {
+	var baselines []Baseline
+	seen := make(map[string]bool)
+	add := func(b Baseline) {
+		key := network.CanonicalLigand(b.Analyte)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		baselines = append(baselines, b)
+	}
+
+	for _, cf := range bloodstream.GetExerciseResponsiveFactors() {
+		add(Baseline{
+			Analyte:           cf.Name,
+			Source:            "Circulating factors",
+			Min:               cf.BaselineRange.Min,
+			Max:               cf.BaselineRange.Max,
+			Unit:              cf.BaselineRange.Unit,
+			MolecularWeightDa: circulatingMolecularWeightDa(cf),
+		})
+	}
+	for _, c := range immune.GetAcutelyUpregulatedCytokines() {
+		add(Baseline{
+			Analyte:           c.Name,
+			Source:            "Cytokines",
+			Min:               c.ConcentrationRange.Baseline.Min,
+			Max:               c.ConcentrationRange.Baseline.Max,
+			Unit:              c.ConcentrationRange.Baseline.Unit,
+			MolecularWeightDa: c.MolecularWeight * 1000, // Cytokine weights are in kDa
+		})
+	}
+	return baselines
+}
+
+// FindBaseline returns the baseline for an analyte by name or alias
+func FindBaseline(baselines []Baseline, analyte string) (Baseline, bool) {
+	key := network.CanonicalLigand(analyte)
+	for _, b := range baselines {
+		if network.CanonicalLigand(b.Analyte) == key {
+			return b, true
+		}
+	}
+	return Baseline{}, false
+}
+
+// InUnit returns the baseline converted to another unit
+func (b Baseline) InUnit(unit string) (Baseline, error) {
+	lo, err := Convert(b.Min, b.Unit, unit, b.MolecularWeightDa)
+	if err != nil {
+		return b, err
+	}
+	hi, err := Convert(b.Max, b.Unit, unit, b.MolecularWeightDa)
+	if err != nil {
+		return b, err
+	}
+	b.Min, b.Max, b.Unit = math.Min(lo, hi), math.Max(lo, hi), unit
+	return b, nil
+}
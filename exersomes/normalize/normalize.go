@@ -0,0 +1,256 @@
+package normalize
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Measurement is one analyte concentration in one sample
+type Measurement struct {
+	Sample            string
+	Analyte           string
+	Value             float64
+	Unit              string
+	MolecularWeightDa float64 // Overrides the catalog weight when set
+}
+
+// Transform records one step applied to a dataset and the values it used
+type Transform struct {
+	Name   string
+	Params map[string]string
+}
+
+// Metadata describes how a dataset's values were derived
+type Metadata struct {
+	Transforms []Transform
+}
+
+// Dataset is a panel of measurements with the transforms applied so far
+type Dataset struct {
+	Measurements []Measurement
+	Metadata     Metadata
+}
+
+// Step transforms a dataset in place against catalog baselines and describes what it did
+type Step func(d *Dataset, baselines []Baseline) (Transform, error)
+
+// Normalize applies steps in order to a copy of the measurements and records
+// each in the metadata. A nil baselines slice uses CatalogBaselines.
+func Normalize(measurements []Measurement, baselines []Baseline, steps ...Step) (Dataset, error) {
+	if baselines == nil {
+		baselines = CatalogBaselines()
+	}
+	d := Dataset{Measurements: append([]Measurement(nil), measurements...)}
+	for _, step := range steps {
+		t, err := step(&d, baselines)
+		if err != nil {
+			return d, fmt.Errorf("%s: %w", t.Name, err)
+		}
+		d.Metadata.Transforms = append(d.Metadata.Transforms, t)
+	}
+	return d, nil
+}
+
+// molecularWeight prefers the measurement's own weight over the catalog's
+func molecularWeight(m Measurement, baselines []Baseline) float64 {
+	if m.MolecularWeightDa > 0 {
+		return m.MolecularWeightDa
+	}
+	if b, ok := FindBaseline(baselines, m.Analyte); ok {
+		return b.MolecularWeightDa
+	}
+	return 0
+}
+
+// ConvertTo converts every measurement to one unit, using molecular weights
+// for mass-molar conversions
+func ConvertTo(unit string) Step {
+	return func(d *Dataset, baselines []Baseline) (Transform, error) {
+		t := Transform{Name: "convert", Params: map[string]string{"unit": unit}}
+		for i, m := range d.Measurements {
+			v, err := Convert(m.Value, m.Unit, unit, molecularWeight(m, baselines))
+			if err != nil {
+				return t, fmt.Errorf("%s in %s: %w", m.Analyte, m.Sample, err)
+			}
+			d.Measurements[i].Value, d.Measurements[i].Unit = v, unit
+		}
+		return t, nil
+	}
+}
+
+// CatalogUnits converts each measurement to its analyte's baseline unit.
+// Analytes without a baseline keep their unit and are listed as unmatched.
+func CatalogUnits() Step {
+	return func(d *Dataset, baselines []Baseline) (Transform, error) {
+		t := Transform{Name: "catalog-units", Params: map[string]string{}}
+		var unmatched []string
+		for i, m := range d.Measurements {
+			b, ok := FindBaseline(baselines, m.Analyte)
+			if !ok {
+				unmatched = appendUnique(unmatched, m.Analyte)
+				continue
+			}
+			v, err := Convert(m.Value, m.Unit, b.Unit, molecularWeight(m, baselines))
+			if err != nil {
+				return t, fmt.Errorf("%s in %s: %w", m.Analyte, m.Sample, err)
+			}
+			d.Measurements[i].Value, d.Measurements[i].Unit = v, b.Unit
+			t.Params[m.Analyte] = b.Unit
+		}
+		if len(unmatched) > 0 {
+			t.Params["unmatched"] = strings.Join(unmatched, ", ")
+		}
+		return t, nil
+	}
+}
+
+// baselineFor returns the analyte's baseline in the measurement's unit
+func baselineFor(m Measurement, baselines []Baseline) (Baseline, error) {
+	b, ok := FindBaseline(baselines, m.Analyte)
+	if !ok {
+		return b, fmt.Errorf("no baseline for %s", m.Analyte)
+	}
+	if m.MolecularWeightDa > 0 {
+		b.MolecularWeightDa = m.MolecularWeightDa
+	}
+	return b.InUnit(m.Unit)
+}
+
+// FoldChange divides each concentration by the midpoint of its baseline range
+func FoldChange() Step {
+	return func(d *Dataset, baselines []Baseline) (Transform, error) {
+		t := Transform{Name: "fold-change", Params: map[string]string{}}
+		for i, m := range d.Measurements {
+			b, err := baselineFor(m, baselines)
+			if err != nil {
+				return t, err
+			}
+			if b.Mean() == 0 {
+				return t, fmt.Errorf("baseline of %s is zero", m.Analyte)
+			}
+			d.Measurements[i].Value, d.Measurements[i].Unit = m.Value/b.Mean(), "fold"
+			t.Params[m.Analyte] = "mean=" + formatValue(b.Mean()) + " " + b.Unit
+		}
+		return t, nil
+	}
+}
+
+// ZScore standardizes each concentration against its baseline range, read as a
+// 95% reference interval
+func ZScore() Step {
+	return func(d *Dataset, baselines []Baseline) (Transform, error) {
+		t := Transform{Name: "z-score", Params: map[string]string{}}
+		for i, m := range d.Measurements {
+			b, err := baselineFor(m, baselines)
+			if err != nil {
+				return t, err
+			}
+			if b.SD() == 0 {
+				return t, fmt.Errorf("baseline range of %s has no width", m.Analyte)
+			}
+			d.Measurements[i].Value, d.Measurements[i].Unit = (m.Value-b.Mean())/b.SD(), "z"
+			t.Params[m.Analyte] = "mean=" + formatValue(b.Mean()) + " sd=" + formatValue(b.SD()) + " " + b.Unit
+		}
+		return t, nil
+	}
+}
+
+// Log2 replaces each value v with log2(v + pseudocount)
+func Log2(pseudocount float64) Step {
+	return func(d *Dataset, baselines []Baseline) (Transform, error) {
+		t := Transform{Name: "log2", Params: map[string]string{"pseudocount": formatValue(pseudocount)}}
+		for i, m := range d.Measurements {
+			if m.Value+pseudocount <= 0 {
+				return t, fmt.Errorf("%s in %s: cannot take log2 of %g", m.Analyte, m.Sample, m.Value+pseudocount)
+			}
+			d.Measurements[i].Value = math.Log2(m.Value + pseudocount)
+			d.Measurements[i].Unit = "log2(" + m.Unit + ")"
+		}
+		return t, nil
+	}
+}
+
+// Quantile gives every sample the same value distribution: each value becomes
+// the mean across samples of the values at its rank. Tied values share the mean
+// of their ranks. Every sample must measure the same analytes.
+func Quantile() Step {
+	return func(d *Dataset, baselines []Baseline) (Transform, error) {
+		t := Transform{Name: "quantile"}
+		samples := make(map[string][]int) // Sample -> measurement indices
+		var order []string
+		for i, m := range d.Measurements {
+			if _, ok := samples[m.Sample]; !ok {
+				order = append(order, m.Sample)
+			}
+			samples[m.Sample] = append(samples[m.Sample], i)
+		}
+		if len(order) < 2 {
+			return t, fmt.Errorf("needs at least two samples, got %d", len(order))
+		}
+		want := analyteSet(d.Measurements, samples[order[0]])
+		for _, s := range order[1:] {
+			if got := analyteSet(d.Measurements, samples[s]); got != want {
+				return t, fmt.Errorf("sample %s measures %s, but %s measures %s", s, got, order[0], want)
+			}
+		}
+
+		// Sort each sample's indices by value, then average across samples rank by rank
+		n := len(samples[order[0]])
+		means := make([]float64, n)
+		for _, s := range order {
+			idx := samples[s]
+			sort.SliceStable(idx, func(a, b int) bool {
+				return d.Measurements[idx[a]].Value < d.Measurements[idx[b]].Value
+			})
+			for r, i := range idx {
+				means[r] += d.Measurements[i].Value / float64(len(order))
+			}
+		}
+		for _, s := range order {
+			idx := samples[s]
+			for start := 0; start < n; {
+				end := start + 1
+				for end < n && d.Measurements[idx[end]].Value == d.Measurements[idx[start]].Value {
+					end++
+				}
+				tied := 0.0
+				for r := start; r < end; r++ {
+					tied += means[r]
+				}
+				tied /= float64(end - start)
+				for r := start; r < end; r++ {
+					d.Measurements[idx[r]].Value = tied
+				}
+				start = end
+			}
+		}
+		t.Params = map[string]string{"samples": strconv.Itoa(len(order)), "analytes": strconv.Itoa(n)}
+		return t, nil
+	}
+}
+
+// analyteSet is a canonical description of the analytes at indices, for comparing samples
+func analyteSet(measurements []Measurement, indices []int) string {
+	names := make([]string, len(indices))
+	for i, idx := range indices {
+		names[i] = measurements[idx].Analyte
+	}
+	sort.Strings(names)
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, existing := range list {
+		if existing == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
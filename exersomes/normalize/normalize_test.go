@@ -0,0 +1,128 @@
+package normalize
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+// Test unit parsing and conversions within and across dimensions
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		mw       float64
+		want     float64
+	}{
+		{1, "ng/mL", "pg/mL", 0, 1000},
+		{10, "μg/dL", "ng/mL", 0, 100},
+		{1.5, "mmol/L", "μmol/L", 0, 1500},
+		{2, "nM", "pmol/L", 0, 2000},
+		{89.1, "mg/L", "mmol/L", 89.1, 1}, // Lactate
+		{21, "pg/mL", "pmol/L", 21000, 1}, // IL-6
+		{1, "fmol/L", "copies/μL", 0, 602.214076},
+		{1, "ug/ml", "µg/mL", 0, 1},
+	}
+	for _, tt := range tests {
+		got, err := Convert(tt.value, tt.from, tt.to, tt.mw)
+		if err != nil {
+			t.Errorf("Convert %s -> %s: %v", tt.from, tt.to, err)
+			continue
+		}
+		if !near(got, tt.want) {
+			t.Errorf("Convert %g %s -> %s: expected %g, got %g", tt.value, tt.from, tt.to, tt.want, got)
+		}
+	}
+
+	if _, err := Convert(1, "pg/mL", "pmol/L", 0); err == nil {
+		t.Error("Expected mass to molar without a molecular weight to fail")
+	}
+	for _, bad := range []string{"", "bpm", "xg/mL", "pg/furlong"} {
+		if _, err := ParseUnit(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+}
+
+// Test catalog baselines carry units and weights in Da
+func TestCatalogBaselines(t *testing.T) {
+	baselines := CatalogBaselines()
+	il6, ok := FindBaseline(baselines, "IL-6")
+	if !ok || il6.Unit != "pg/mL" || il6.MolecularWeightDa != 21000 {
+		t.Errorf("Unexpected IL-6 baseline %+v", il6)
+	}
+	if lactate, _ := FindBaseline(baselines, "Lactate"); lactate.MolecularWeightDa != 89.1 {
+		t.Errorf("Expected lactate weight in Da, got %+v", lactate)
+	}
+	if _, ok := FindBaseline(baselines, "TNF"); !ok {
+		t.Error("Expected a TNF baseline from the cytokine catalog")
+	}
+}
+
+// Test fold change and z-score use the baseline in the measurement's unit, and
+// each step is recorded
+func TestNormalizeSteps(t *testing.T) {
+	baselines := []Baseline{{Analyte: "Interleukin-6", Min: 1, Max: 5, Unit: "pg/mL", MolecularWeightDa: 21000}}
+	measurements := []Measurement{
+		{Sample: "S1", Analyte: "IL-6", Value: 0.006, Unit: "ng/mL"},
+		{Sample: "S2", Analyte: "IL6", Value: 3, Unit: "pg/mL"},
+	}
+
+	d, err := Normalize(measurements, baselines, FoldChange(), Log2(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(d.Measurements[0].Value, 1) || !near(d.Measurements[1].Value, 0) {
+		t.Errorf("Expected log2 fold changes 1 and 0, got %+v", d.Measurements)
+	}
+	if d.Measurements[0].Unit != "log2(fold)" || len(d.Metadata.Transforms) != 2 ||
+		d.Metadata.Transforms[0].Name != "fold-change" || d.Metadata.Transforms[1].Name != "log2" {
+		t.Errorf("Unexpected metadata %+v, unit %q", d.Metadata, d.Measurements[0].Unit)
+	}
+	if measurements[0].Value != 0.006 {
+		t.Error("Normalize modified its input")
+	}
+
+	d, err = Normalize(measurements, baselines, ConvertTo("pmol/L"), ZScore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd := 4 / (2 * 1.96)
+	if !near(d.Measurements[0].Value, 3/sd) || !near(d.Measurements[1].Value, 0) {
+		t.Errorf("Unexpected z-scores %+v", d.Measurements)
+	}
+
+	if _, err := Normalize([]Measurement{{Analyte: "Unknown", Value: 1, Unit: "pg/mL"}}, baselines, FoldChange()); err == nil {
+		t.Error("Expected fold change without a baseline to fail")
+	}
+}
+
+// Test quantile normalization equalizes sample distributions
+func TestQuantile(t *testing.T) {
+	panel := func(sample string, a, b, c float64) []Measurement {
+		return []Measurement{
+			{Sample: sample, Analyte: "A", Value: a},
+			{Sample: sample, Analyte: "B", Value: b},
+			{Sample: sample, Analyte: "C", Value: c},
+		}
+	}
+	measurements := append(panel("S1", 5, 2, 3), panel("S2", 4, 1, 4)...)
+	d, err := Normalize(measurements, []Baseline{}, Quantile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rank means are 1.5, 3.5, 4.5; S2's tied 4s share (3.5+4.5)/2
+	want := []float64{4.5, 1.5, 3.5, 4, 1.5, 4}
+	for i, m := range d.Measurements {
+		if !near(m.Value, want[i]) {
+			t.Errorf("%s/%s: expected %g, got %g", m.Sample, m.Analyte, want[i], m.Value)
+		}
+	}
+
+	if _, err := Normalize(append(panel("S1", 1, 2, 3), panel("S2", 1, 2, 3)[:2]...), []Baseline{}, Quantile()); err == nil {
+		t.Error("Expected mismatched panels to fail")
+	}
+}
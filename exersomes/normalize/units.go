@@ -0,0 +1,149 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimension is what a concentration unit measures per volume
+type Dimension int
+
+const (
+	Mass  Dimension = iota // g/L
+	Molar                  // mol/L
+	Count                  // copies/L
+)
+
+func (d Dimension) String() string {
+	switch d {
+	case Mass:
+		return "mass"
+	case Molar:
+		return "molar"
+	case Count:
+		return "count"
+	}
+	return "unknown"
+}
+
+// Avogadro is molecules per mole, used to convert copy numbers to molar concentrations
+const Avogadro = 6.02214076e23
+
+// Unit is a parsed concentration unit
+type Unit struct {
+	Symbol    string
+	Dimension Dimension
+	Scale     float64 // Multiplier to g/L, mol/L or copies/L
+}
+
+// siPrefixes are the prefixes seen in assay units; "u" and "mc" spell micro in ASCII
+var siPrefixes = map[string]float64{
+	"": 1, "k": 1e3, "d": 1e-1, "c": 1e-2, "m": 1e-3,
+	"μ": 1e-6, "µ": 1e-6, "u": 1e-6, "mc": 1e-6, "n": 1e-9, "p": 1e-12, "f": 1e-15,
+}
+
+// ParseUnit parses units such as "pg/mL", "μg/dL", "mmol/L", "nM", "copies/μL" and "cells/μL"
+func ParseUnit(symbol string) (Unit, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(symbol), " ", "")
+	if s == "" {
+		return Unit{}, fmt.Errorf("empty unit")
+	}
+
+	// Molar shorthand: M, mM, μM, nM, pM
+	if prefix, ok := strings.CutSuffix(s, "M"); ok && !strings.Contains(s, "/") {
+		scale, known := siPrefixes[prefix]
+		if !known {
+			return Unit{}, fmt.Errorf("unknown unit %q", symbol)
+		}
+		return Unit{Symbol: symbol, Dimension: Molar, Scale: scale}, nil
+	}
+
+	numerator, denominator, ok := strings.Cut(s, "/")
+	if !ok {
+		return Unit{}, fmt.Errorf("unit %q is not a concentration", symbol)
+	}
+	volume, err := parsePrefixed(denominator, "L")
+	if err != nil {
+		return Unit{}, fmt.Errorf("unknown volume in unit %q", symbol)
+	}
+
+	lower := strings.ToLower(numerator)
+	switch {
+	case lower == "copies" || lower == "copy" || lower == "particles" || lower == "cells":
+		return Unit{Symbol: symbol, Dimension: Count, Scale: 1 / volume}, nil
+	case strings.HasSuffix(numerator, "mol"):
+		amount, err := parsePrefixed(numerator, "mol")
+		if err != nil {
+			return Unit{}, fmt.Errorf("unknown amount in unit %q", symbol)
+		}
+		return Unit{Symbol: symbol, Dimension: Molar, Scale: amount / volume}, nil
+	case strings.HasSuffix(numerator, "g"):
+		mass, err := parsePrefixed(numerator, "g")
+		if err != nil {
+			return Unit{}, fmt.Errorf("unknown mass in unit %q", symbol)
+		}
+		return Unit{Symbol: symbol, Dimension: Mass, Scale: mass / volume}, nil
+	}
+	return Unit{}, fmt.Errorf("unknown unit %q", symbol)
+}
+
+// parsePrefixed returns the SI multiplier of s, which must be base with an optional prefix
+func parsePrefixed(s, base string) (float64, error) {
+	prefix, ok := strings.CutSuffix(s, base)
+	if !ok && base == "L" {
+		prefix, ok = strings.CutSuffix(s, "l")
+	}
+	if !ok {
+		return 0, fmt.Errorf("%q is not in %s", s, base)
+	}
+	scale, known := siPrefixes[prefix]
+	if !known {
+		return 0, fmt.Errorf("unknown prefix %q", prefix)
+	}
+	return scale, nil
+}
+
+// Convert converts a concentration between units. Mass and molar or count units
+// convert through the molecular weight in Da (g/mol), which is otherwise ignored.
+func Convert(value float64, from, to string, molecularWeightDa float64) (float64, error) {
+	src, err := ParseUnit(from)
+	if err != nil {
+		return 0, err
+	}
+	dst, err := ParseUnit(to)
+	if err != nil {
+		return 0, err
+	}
+	return src.convert(value, dst, molecularWeightDa)
+}
+
+func (u Unit) convert(value float64, to Unit, molecularWeightDa float64) (float64, error) {
+	base := value * u.Scale
+	if u.Dimension != to.Dimension {
+		// Go through mol/L
+		var molar float64
+		switch u.Dimension {
+		case Molar:
+			molar = base
+		case Count:
+			molar = base / Avogadro
+		case Mass:
+			if molecularWeightDa <= 0 {
+				return 0, fmt.Errorf("converting %s to %s needs a molecular weight", u.Symbol, to.Symbol)
+			}
+			molar = base / molecularWeightDa
+		}
+		switch to.Dimension {
+		case Molar:
+			base = molar
+		case Count:
+			base = molar * Avogadro
+		case Mass:
+			if molecularWeightDa <= 0 {
+				return 0, fmt.Errorf("converting %s to %s needs a molecular weight", u.Symbol, to.Symbol)
+			}
+			base = molar * molecularWeightDa
+		}
+	}
+	return base / to.Scale, nil
+}
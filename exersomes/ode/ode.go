@@ -0,0 +1,195 @@
+package ode
+
+import (
+	"errors"
+	"math"
+)
+
+// Func evaluates dy/dt at time t into dydt. It must not retain y or dydt.
+type Func func(t float64, y []float64, dydt []float64)
+
+// Options controls the adaptive solver
+type Options struct {
+	InitialStep float64 // First trial step; defaults to 1% of the interval
+	MinStep     float64 // Smallest step allowed before giving up
+	MaxStep     float64 // Largest step allowed; 0 means the whole interval
+	RelTol      float64 // Relative error tolerance per step
+	AbsTol      float64 // Absolute error tolerance per step
+}
+
+// DefaultOptions are tolerances suitable for concentration-scale problems
+var DefaultOptions = Options{
+	MinStep: 1e-9,
+	RelTol:  1e-6,
+	AbsTol:  1e-9,
+}
+
+// ErrStepTooSmall is returned when the adaptive solver cannot meet its tolerance
+var ErrStepTooSmall = errors.New("ode: step size fell below MinStep")
+
+// ErrNonFinite is returned when the derivative produces NaN or infinite values
+var ErrNonFinite = errors.New("ode: solution is not finite")
+
+// RK4 integrates y from t0 to t1 with the classic fixed-step fourth-order Runge-Kutta
+// method. The final step is shortened so the solution lands exactly on t1.
+func RK4(f Func, y0 []float64, t0, t1, h float64) []float64 {
+	n := len(y0)
+	y := append([]float64(nil), y0...)
+	if h <= 0 || t1 <= t0 {
+		return y
+	}
+
+	k1 := make([]float64, n)
+	k2 := make([]float64, n)
+	k3 := make([]float64, n)
+	k4 := make([]float64, n)
+	tmp := make([]float64, n)
+
+	for t := t0; t < t1; {
+		step := math.Min(h, t1-t)
+
+		f(t, y, k1)
+		for i := range y {
+			tmp[i] = y[i] + 0.5*step*k1[i]
+		}
+		f(t+0.5*step, tmp, k2)
+		for i := range y {
+			tmp[i] = y[i] + 0.5*step*k2[i]
+		}
+		f(t+0.5*step, tmp, k3)
+		for i := range y {
+			tmp[i] = y[i] + step*k3[i]
+		}
+		f(t+step, tmp, k4)
+
+		for i := range y {
+			y[i] += step / 6.0 * (k1[i] + 2*k2[i] + 2*k3[i] + k4[i])
+		}
+		t += step
+	}
+
+	return y
+}
+
+// Dormand-Prince 5(4) tableau
+var (
+	dpC = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	dpA = [7][6]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	dpB5 = [7]float64{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84, 0}
+	dpB4 = [7]float64{5179.0 / 57600, 0, 7571.0 / 16695, 393.0 / 640, -92097.0 / 339200, 187.0 / 2100, 1.0 / 40}
+)
+
+// RK45 integrates y from t0 to t1 with the adaptive Dormand-Prince 5(4) method.
+// Step control is deterministic: the same inputs always take the same steps.
+// If a step yields NaN or infinite values, RK45 stops and returns the last
+// finite state with ErrNonFinite.
+func RK45(f Func, y0 []float64, t0, t1 float64, opts Options) ([]float64, error) {
+	n := len(y0)
+	y := append([]float64(nil), y0...)
+	if t1 <= t0 {
+		return y, nil
+	}
+
+	span := t1 - t0
+	h := opts.InitialStep
+	if h <= 0 {
+		h = span / 100
+	}
+	maxStep := opts.MaxStep
+	if maxStep <= 0 {
+		maxStep = span
+	}
+	minStep := opts.MinStep
+	if minStep <= 0 {
+		minStep = DefaultOptions.MinStep
+	}
+	relTol, absTol := opts.RelTol, opts.AbsTol
+	if relTol <= 0 {
+		relTol = DefaultOptions.RelTol
+	}
+	if absTol <= 0 {
+		absTol = DefaultOptions.AbsTol
+	}
+
+	var k [7][]float64
+	for i := range k {
+		k[i] = make([]float64, n)
+	}
+	tmp := make([]float64, n)
+	y5 := make([]float64, n)
+
+	t := t0
+	for t < t1 {
+		h = math.Min(h, maxStep)
+		if t+h > t1 {
+			h = t1 - t
+		}
+
+		f(t, y, k[0])
+		for s := 1; s < 7; s++ {
+			for i := range y {
+				sum := 0.0
+				for j := 0; j < s; j++ {
+					sum += dpA[s][j] * k[j][i]
+				}
+				tmp[i] = y[i] + h*sum
+			}
+			f(t+dpC[s]*h, tmp, k[s])
+		}
+
+		errNorm := 0.0
+		for i := range y {
+			sum5, sum4 := 0.0, 0.0
+			for s := 0; s < 7; s++ {
+				sum5 += dpB5[s] * k[s][i]
+				sum4 += dpB4[s] * k[s][i]
+			}
+			y5[i] = y[i] + h*sum5
+			scale := absTol + relTol*math.Max(math.Abs(y[i]), math.Abs(y5[i]))
+			e := h * (sum5 - sum4) / scale
+			errNorm += e * e
+		}
+		if n > 0 {
+			errNorm = math.Sqrt(errNorm / float64(n))
+		}
+		if math.IsNaN(errNorm) || math.IsInf(errNorm, 0) || !finite(y5) {
+			return y, ErrNonFinite
+		}
+
+		if errNorm <= 1.0 {
+			t += h
+			copy(y, y5)
+		}
+
+		// Standard step-size update with safety factor and growth limits
+		factor := 5.0
+		if errNorm > 0 {
+			factor = math.Min(5.0, math.Max(0.2, 0.9*math.Pow(errNorm, -0.2)))
+		}
+		h *= factor
+
+		if h < minStep && t < t1 {
+			return y, ErrStepTooSmall
+		}
+	}
+
+	return y, nil
+}
+
+// finite reports whether every element of y is a finite number
+func finite(y []float64) bool {
+	for _, v := range y {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package ode
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func exponentialDecay(k float64) Func {
+	return func(t float64, y []float64, dydt []float64) {
+		dydt[0] = -k * y[0]
+	}
+}
+
+// Test fixed-step RK4 against the analytic exponential decay
+func TestRK4ExponentialDecay(t *testing.T) {
+	k := 0.3
+	y := RK4(exponentialDecay(k), []float64{10.0}, 0, 5, 0.01)
+
+	expected := 10.0 * math.Exp(-k*5)
+	if math.Abs(y[0]-expected) > 1e-8 {
+		t.Errorf("RK4 = %.10f; expected %.10f", y[0], expected)
+	}
+}
+
+// Test adaptive RK45 against the analytic exponential decay
+func TestRK45ExponentialDecay(t *testing.T) {
+	k := 0.3
+	y, err := RK45(exponentialDecay(k), []float64{10.0}, 0, 20, DefaultOptions)
+	if err != nil {
+		t.Fatalf("RK45 returned error: %v", err)
+	}
+
+	expected := 10.0 * math.Exp(-k*20)
+	if math.Abs(y[0]-expected) > 1e-5 {
+		t.Errorf("RK45 = %.10f; expected %.10f", y[0], expected)
+	}
+}
+
+// Test that a harmonic oscillator keeps its phase over one period
+func TestRK45Oscillator(t *testing.T) {
+	f := func(t float64, y []float64, dydt []float64) {
+		dydt[0] = y[1]
+		dydt[1] = -y[0]
+	}
+
+	y, err := RK45(f, []float64{1, 0}, 0, 2*math.Pi, DefaultOptions)
+	if err != nil {
+		t.Fatalf("RK45 returned error: %v", err)
+	}
+	if math.Abs(y[0]-1) > 1e-4 || math.Abs(y[1]) > 1e-4 {
+		t.Errorf("Expected (1, 0) after one period, got (%.6f, %.6f)", y[0], y[1])
+	}
+}
+
+// Test that a derivative producing NaN stops RK45 with an error instead of looping
+func TestRK45NonFinite(t *testing.T) {
+	f := func(t float64, y []float64, dydt []float64) {
+		dydt[0] = math.Sqrt(y[0] - 1)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := RK45(f, []float64{0}, 0, 1, DefaultOptions)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrNonFinite {
+			t.Errorf("Expected ErrNonFinite, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RK45 did not return for a NaN derivative")
+	}
+}
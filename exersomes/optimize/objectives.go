@@ -0,0 +1,188 @@
+package optimize
+
+import (
+	"exersomes/components/bone"
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/metabolic/adipose"
+	"exersomes/components/metabolic/liver"
+	"exersomes/profile"
+	"math"
+)
+
+// Modalities mixed by the optimizer, spelled as the prescriptions' PrimaryType
+const (
+	Aerobic    = "Aerobic"
+	HIIT       = "HIIT"
+	Resistance = "Resistance"
+	Impact     = "Impact"
+)
+
+// Modalities lists every modality in display order
+var Modalities = []string{Aerobic, HIIT, Resistance, Impact}
+
+// Protocol is a tissue's standard prescription reduced to what the optimizer
+// matches and varies
+type Protocol struct {
+	Name              string
+	PrimaryType       string
+	SecondaryType     string
+	IntensityPercent  int
+	DurationMinutes   int
+	FrequencyPerWeek  int
+	Contraindications []string
+}
+
+// WeeklyMinutes is the training volume the protocol prescribes
+func (p Protocol) WeeklyMinutes() int {
+	return p.DurationMinutes * p.FrequencyPerWeek
+}
+
+// Dose is the fraction of a protocol's predicted effect a modality earns from
+// the minutes it receives each week: the minutes over the protocol's weekly
+// volume, at most 1
+func Dose(minutes float64, p Protocol) float64 {
+	if p.WeeklyMinutes() <= 0 {
+		return 0
+	}
+	return math.Min(minutes/float64(p.WeeklyMinutes()), 1)
+}
+
+// Objective is one predicted tissue outcome. Predict receives one of Protocols
+// carrying the candidate's intensity and session length, and returns Output
+// after the given weeks of training at the protocol's own weekly volume.
+type Objective struct {
+	Name      string // e.g. "bone"
+	Tissue    string
+	Output    string // Response key, e.g. "Bone mineral density"
+	Unit      string
+	Maximize  bool // False when a decrease is the benefit, as for liver fat
+	Protocols []Protocol
+	Predict   func(p Protocol, weeks int) float64
+}
+
+// ProtocolFor returns the protocol a modality is trained with for this tissue:
+// the first of that primary type, else the first with it as secondary type,
+// else, for aerobic and resistance work, the first Combined protocol
+func (o Objective) ProtocolFor(modality string) (Protocol, bool) {
+	for _, p := range o.Protocols {
+		if p.PrimaryType == modality {
+			return p, true
+		}
+	}
+	for _, p := range o.Protocols {
+		if p.SecondaryType == modality {
+			return p, true
+		}
+	}
+	if modality == Aerobic || modality == Resistance {
+		for _, p := range o.Protocols {
+			if p.PrimaryType == "Combined" {
+				return p, true
+			}
+		}
+	}
+	return Protocol{}, false
+}
+
+// Objectives returns the default tissue objectives for the reference profile
+func Objectives() []Objective {
+	return ObjectivesFor(profile.Reference)
+}
+
+// ObjectivesFor returns the default tissue objectives for an individual: bone
+// mineral density, flow-mediated dilation, liver fat and fat mass, each
+// predicted from its tissue's standard prescriptions
+func ObjectivesFor(p profile.Profile) []Objective {
+	return []Objective{BoneObjective(p), VascularObjective(p), LiverObjective(p), AdiposeObjective(p)}
+}
+
+// BoneObjective predicts bone mineral density with PredictBoneResponseForProfile
+func BoneObjective(subject profile.Profile) Objective {
+	standard := bone.GetStandardPrescriptions()
+	o := Objective{Name: "bone", Tissue: "Bone", Output: "Bone mineral density", Unit: "% change", Maximize: true}
+	for _, p := range standard {
+		o.Protocols = append(o.Protocols, Protocol{
+			Name: p.Name, PrimaryType: p.PrimaryType, IntensityPercent: p.IntensityPercent,
+			DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek, Contraindications: p.Contraindications,
+		})
+	}
+	o.Predict = func(p Protocol, weeks int) float64 {
+		for _, s := range standard {
+			if s.Name == p.Name {
+				s.IntensityPercent, s.DurationMinutes = p.IntensityPercent, p.DurationMinutes
+				return bone.PredictBoneResponseForProfile(s, weeks, subject).StructuralMetrics[o.Output]
+			}
+		}
+		return 0
+	}
+	return o
+}
+
+// VascularObjective predicts flow-mediated dilation with PredictCirculatoryResponseForProfile
+func VascularObjective(subject profile.Profile) Objective {
+	standard := bloodstream.GetStandardPrescriptions()
+	o := Objective{Name: "vascular", Tissue: "Endothelium", Output: "Flow-mediated dilation", Unit: "% change", Maximize: true}
+	for _, p := range standard {
+		o.Protocols = append(o.Protocols, Protocol{
+			Name: p.Name, PrimaryType: p.PrimaryType, IntensityPercent: p.IntensityPercent,
+			DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek, Contraindications: p.Contraindications,
+		})
+	}
+	o.Predict = func(p Protocol, weeks int) float64 {
+		for _, s := range standard {
+			if s.Name == p.Name {
+				s.IntensityPercent, s.DurationMinutes = p.IntensityPercent, p.DurationMinutes
+				return bloodstream.PredictCirculatoryResponseForProfile(s, weeks, subject).FlowMetrics[o.Output]
+			}
+		}
+		return 0
+	}
+	return o
+}
+
+// LiverObjective predicts the liver fat change with PredictLiverResponseForProfile
+func LiverObjective(subject profile.Profile) Objective {
+	standard := liver.GetStandardPrescriptions()
+	o := Objective{Name: "liver", Tissue: "Liver", Output: "liver_fat_percent_change", Unit: "% change"}
+	for _, p := range standard {
+		o.Protocols = append(o.Protocols, Protocol{
+			Name: p.Name, PrimaryType: p.PrimaryType, SecondaryType: p.SecondaryType, IntensityPercent: p.IntensityPercent,
+			DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek, Contraindications: p.Contraindications,
+		})
+	}
+	o.Predict = func(p Protocol, weeks int) float64 {
+		for _, s := range standard {
+			if s.Name == p.Name {
+				s.IntensityPercent, s.DurationMinutes = p.IntensityPercent, p.DurationMinutes
+				change, _ := liver.PredictLiverResponseForProfile(s, weeks, subject)[o.Output].(float64)
+				return change
+			}
+		}
+		return 0
+	}
+	return o
+}
+
+// AdiposeObjective predicts the fat mass change with
+// PredictAdiposeResponseForProfile. The adipose prescriptions list no
+// contraindications.
+func AdiposeObjective(subject profile.Profile) Objective {
+	standard := adipose.GetStandardPrescriptions()
+	o := Objective{Name: "adipose", Tissue: "Adipose", Output: "Fat mass change (kg)", Unit: "kg"}
+	for _, p := range standard {
+		o.Protocols = append(o.Protocols, Protocol{
+			Name: p.Name, PrimaryType: p.PrimaryType, SecondaryType: p.SecondaryType, IntensityPercent: p.IntensityPercent,
+			DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek,
+		})
+	}
+	o.Predict = func(p Protocol, weeks int) float64 {
+		for _, s := range standard {
+			if s.Name == p.Name {
+				s.IntensityPercent, s.DurationMinutes = p.IntensityPercent, p.DurationMinutes
+				return adipose.PredictAdiposeResponseForProfile(s, weeks, subject)[o.Output]
+			}
+		}
+		return 0
+	}
+	return o
+}
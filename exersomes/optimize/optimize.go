@@ -0,0 +1,339 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Candidate is a weekly prescription: how training time is split between
+// modalities, and the intensity, session length and sessions per week
+type Candidate struct {
+	Mix              map[string]float64 // Share of weekly minutes by modality, summing to 1
+	IntensityPercent int
+	DurationMinutes  int
+	FrequencyPerWeek int
+}
+
+// WeeklyMinutes is the candidate's training time per week
+func (c Candidate) WeeklyMinutes() int {
+	return c.DurationMinutes * c.FrequencyPerWeek
+}
+
+// String describes the candidate, e.g. "75% Aerobic + 25% Resistance, 80%, 30 min × 5/week"
+func (c Candidate) String() string {
+	var parts []string
+	for _, m := range Modalities {
+		if share := c.Mix[m]; share > 0 {
+			parts = append(parts, fmt.Sprintf("%.0f%% %s", 100*share, m))
+		}
+	}
+	return fmt.Sprintf("%s, %d%%, %d min × %d/week", strings.Join(parts, " + "), c.IntensityPercent, c.DurationMinutes, c.FrequencyPerWeek)
+}
+
+// Constraints bound the search. Zero values take the defaults noted.
+type Constraints struct {
+	WeeklyMinutes     int      // Time budget; 150
+	MinIntensity      int      // 40
+	MaxIntensity      int      // 95
+	MinSessionMinutes int      // 10
+	MaxSessionMinutes int      // 90
+	MaxFrequency      int      // Sessions per week; 7
+	Modalities        []string // Allowed modalities; all
+	Conditions        []string // Rule out modalities whose protocols list any as a contraindication
+}
+
+// Options configure Optimize. Zero values take the defaults noted.
+type Options struct {
+	Weeks         int                // Training horizon; 12
+	Weights       map[string]float64 // By objective name; nil weights each 1, and a missing or zero weight drops the objective
+	MixStep       float64            // Grid of modality shares; 0.25
+	IntensityStep int                // 5
+	DurationStep  int                // 5
+}
+
+// Solution is a prescription on the Pareto front
+type Solution struct {
+	Candidate
+	Outcomes map[string]float64 // Predicted output by objective name
+	Score    float64            // Weighted mean of the outcomes, each scaled to [0, 1] over the feasible candidates
+}
+
+// Result is the Pareto front of the feasible candidates
+type Result struct {
+	Objectives []Objective           // Those optimized, with positive weight
+	Front      []Solution            // Best score first
+	Evaluated  int                   // Feasible candidates
+	Excluded   map[string][]string   // Contraindicated modalities, with the conditions and protocols ruling them out
+	Protocols  map[string][]string   // Protocol trained for each modality, one per objective ("" where the tissue has none)
+	Ranges     map[string][2]float64 // Min and max of each outcome over the feasible candidates
+}
+
+// Optimize searches modality mixes, intensities, session lengths and weekly
+// frequencies within the constraints and returns the prescriptions no other
+// candidate improves on for every objective.
+//
+// Each objective is predicted per modality from the tissue protocol the
+// modality is trained with, at the candidate's intensity and session length.
+// The predictors describe a protocol followed at its own weekly volume, so a
+// modality's prediction counts in proportion to the minutes it receives,
+// up to that volume, and modalities add. Modalities without a protocol for a
+// tissue do not affect it. Candidates with the same outcomes keep the one
+// with the least weekly time, then the lowest intensity.
+func Optimize(objectives []Objective, c Constraints, opts Options) (Result, error) {
+	c, opts = withDefaults(c, opts)
+	if opts.MixStep <= 0 || opts.MixStep > 1 || math.Abs(1/opts.MixStep-math.Round(1/opts.MixStep)) > 1e-9 {
+		return Result{}, fmt.Errorf("mix step %g does not divide 1", opts.MixStep)
+	}
+	if c.MinIntensity > c.MaxIntensity || c.MinSessionMinutes > c.MaxSessionMinutes || c.MaxFrequency < 1 {
+		return Result{}, fmt.Errorf("empty intensity, session length or frequency range")
+	}
+
+	result := Result{Excluded: make(map[string][]string), Protocols: make(map[string][]string), Ranges: make(map[string][2]float64)}
+	var weights []float64
+	for _, o := range objectives {
+		w := 1.0
+		if opts.Weights != nil {
+			w = opts.Weights[o.Name]
+		}
+		if w < 0 {
+			return Result{}, fmt.Errorf("objective %s: negative weight %g", o.Name, w)
+		}
+		if w > 0 {
+			result.Objectives = append(result.Objectives, o)
+			weights = append(weights, w)
+		}
+	}
+	if len(result.Objectives) == 0 {
+		return Result{}, fmt.Errorf("no objective with a positive weight")
+	}
+
+	allowed := c.Modalities
+	if len(allowed) == 0 {
+		allowed = Modalities
+	}
+	var modalities []string
+	for _, m := range allowed {
+		used := false
+		for _, o := range result.Objectives {
+			p, ok := o.ProtocolFor(m)
+			result.Protocols[m] = append(result.Protocols[m], p.Name)
+			used = used || ok
+			for _, condition := range c.Conditions {
+				for _, contraindication := range p.Contraindications {
+					if strings.EqualFold(strings.TrimSpace(condition), contraindication) {
+						result.Excluded[m] = append(result.Excluded[m], fmt.Sprintf("%s (%s)", contraindication, p.Name))
+					}
+				}
+			}
+		}
+		if used && len(result.Excluded[m]) == 0 {
+			modalities = append(modalities, m)
+		}
+	}
+	if len(modalities) == 0 {
+		return result, fmt.Errorf("no modality left to train: every allowed one is contraindicated or affects no objective")
+	}
+
+	// Predictions depend on the modality, intensity and session length only,
+	// so each is made once and rescaled by the minutes a candidate gives it
+	type key struct {
+		objective, intensity, duration int
+		modality                       string
+	}
+	cache := make(map[key]float64)
+	predict := func(i int, m string, intensity, duration int) (float64, Protocol) {
+		p, ok := result.Objectives[i].ProtocolFor(m)
+		if !ok {
+			return 0, p
+		}
+		k := key{i, intensity, duration, m}
+		v, ok := cache[k]
+		if !ok {
+			p.IntensityPercent, p.DurationMinutes = intensity, duration
+			v = result.Objectives[i].Predict(p, opts.Weeks)
+			cache[k] = v
+		}
+		return v, p
+	}
+
+	type point struct {
+		candidate Candidate
+		outcomes  []float64 // Raw outcomes, by objective
+		oriented  []float64 // Rounded and signed so that larger is better
+	}
+	unique := make(map[string]*point)
+	lo := make([]float64, len(result.Objectives))
+	hi := make([]float64, len(result.Objectives))
+	for i := range lo {
+		lo[i], hi[i] = math.Inf(1), math.Inf(-1)
+	}
+
+	for _, mix := range mixes(modalities, int(math.Round(1/opts.MixStep))) {
+		for intensity := c.MinIntensity; intensity <= c.MaxIntensity; intensity += opts.IntensityStep {
+			for duration := c.MinSessionMinutes; duration <= c.MaxSessionMinutes; duration += opts.DurationStep {
+				for frequency := 1; frequency <= c.MaxFrequency && duration*frequency <= c.WeeklyMinutes; frequency++ {
+					cand := Candidate{Mix: mix, IntensityPercent: intensity, DurationMinutes: duration, FrequencyPerWeek: frequency}
+					pt := &point{candidate: cand, outcomes: make([]float64, len(result.Objectives)), oriented: make([]float64, len(result.Objectives))}
+					for i, o := range result.Objectives {
+						for _, m := range modalities {
+							if mix[m] == 0 {
+								continue
+							}
+							v, p := predict(i, m, intensity, duration)
+							pt.outcomes[i] += v * Dose(mix[m]*float64(cand.WeeklyMinutes()), p)
+						}
+						pt.oriented[i] = math.Round(pt.outcomes[i]*1e6) / 1e6
+						if !o.Maximize {
+							pt.oriented[i] = -pt.oriented[i]
+						}
+						lo[i], hi[i] = math.Min(lo[i], pt.outcomes[i]), math.Max(hi[i], pt.outcomes[i])
+					}
+					result.Evaluated++
+
+					k := fmt.Sprint(pt.oriented)
+					if prev, ok := unique[k]; !ok || cheaper(cand, prev.candidate) {
+						unique[k] = pt
+					}
+				}
+			}
+		}
+	}
+	if result.Evaluated == 0 {
+		return result, fmt.Errorf("no session of %d minutes or more fits the %d minute weekly budget", c.MinSessionMinutes, c.WeeklyMinutes)
+	}
+	for i, o := range result.Objectives {
+		result.Ranges[o.Name] = [2]float64{lo[i], hi[i]}
+	}
+
+	// Any point dominating another sorts before it, and so does its own
+	// dominator, so each point need only be checked against the front so far
+	points := make([]*point, 0, len(unique))
+	for _, pt := range unique {
+		points = append(points, pt)
+	}
+	sort.Slice(points, func(a, b int) bool {
+		for i := range points[a].oriented {
+			if points[a].oriented[i] != points[b].oriented[i] {
+				return points[a].oriented[i] > points[b].oriented[i]
+			}
+		}
+		return false
+	})
+	var front []*point
+	for _, pt := range points {
+		dominated := false
+		for _, f := range front {
+			if dominates(f.oriented, pt.oriented) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, pt)
+		}
+	}
+
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	for _, pt := range front {
+		s := Solution{Candidate: pt.candidate, Outcomes: make(map[string]float64)}
+		for i, o := range result.Objectives {
+			s.Outcomes[o.Name] = pt.outcomes[i]
+			if span := hi[i] - lo[i]; span > 0 {
+				scaled := (pt.outcomes[i] - lo[i]) / span
+				if !o.Maximize {
+					scaled = 1 - scaled
+				}
+				s.Score += weights[i] * scaled / total
+			}
+		}
+		result.Front = append(result.Front, s)
+	}
+	sort.SliceStable(result.Front, func(a, b int) bool {
+		if result.Front[a].Score != result.Front[b].Score {
+			return result.Front[a].Score > result.Front[b].Score
+		}
+		return cheaper(result.Front[a].Candidate, result.Front[b].Candidate)
+	})
+	return result, nil
+}
+
+func withDefaults(c Constraints, opts Options) (Constraints, Options) {
+	defaults := []struct {
+		value *int
+		def   int
+	}{
+		{&c.WeeklyMinutes, 150},
+		{&c.MinIntensity, 40},
+		{&c.MaxIntensity, 95},
+		{&c.MinSessionMinutes, 10},
+		{&c.MaxSessionMinutes, 90},
+		{&c.MaxFrequency, 7},
+		{&opts.Weeks, 12},
+		{&opts.IntensityStep, 5},
+		{&opts.DurationStep, 5},
+	}
+	for _, d := range defaults {
+		if *d.value == 0 {
+			*d.value = d.def
+		}
+	}
+	if opts.MixStep == 0 {
+		opts.MixStep = 0.25
+	}
+	return c, opts
+}
+
+// mixes returns every split of the modalities into shares that are multiples
+// of 1/steps
+func mixes(modalities []string, steps int) []map[string]float64 {
+	var out []map[string]float64
+	counts := make([]int, len(modalities))
+	var split func(i, left int)
+	split = func(i, left int) {
+		if i == len(modalities)-1 {
+			counts[i] = left
+			mix := make(map[string]float64)
+			for j, m := range modalities {
+				if counts[j] > 0 {
+					mix[m] = float64(counts[j]) / float64(steps)
+				}
+			}
+			out = append(out, mix)
+			return
+		}
+		for n := left; n >= 0; n-- {
+			counts[i] = n
+			split(i+1, left-n)
+		}
+	}
+	split(0, steps)
+	return out
+}
+
+// cheaper orders candidates by weekly time, intensity and sessions per week
+func cheaper(a, b Candidate) bool {
+	if a.WeeklyMinutes() != b.WeeklyMinutes() {
+		return a.WeeklyMinutes() < b.WeeklyMinutes()
+	}
+	if a.IntensityPercent != b.IntensityPercent {
+		return a.IntensityPercent < b.IntensityPercent
+	}
+	return a.FrequencyPerWeek < b.FrequencyPerWeek
+}
+
+// dominates reports whether a is at least as good as b everywhere and better somewhere
+func dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		}
+		better = better || a[i] > b[i]
+	}
+	return better
+}
@@ -0,0 +1,91 @@
+package optimize
+
+import (
+	"exersomes/profile"
+	"math"
+	"strings"
+	"testing"
+)
+
+// linear is an objective with one protocol per modality whose prediction is
+// the given value per intensity point
+func linear(name string, maximize bool, perIntensity map[string]float64) Objective {
+	o := Objective{Name: name, Maximize: maximize}
+	for m := range perIntensity {
+		o.Protocols = append(o.Protocols, Protocol{Name: m, PrimaryType: m, DurationMinutes: 30, FrequencyPerWeek: 2})
+	}
+	o.Predict = func(p Protocol, weeks int) float64 {
+		return perIntensity[p.Name] * float64(p.IntensityPercent)
+	}
+	return o
+}
+
+// Test the front of two conflicting objectives is every mix at full intensity
+// and budget, and weights pick the best compromise
+func TestOptimizeFront(t *testing.T) {
+	objectives := []Objective{
+		linear("a", true, map[string]float64{Aerobic: 1, Resistance: 0.5}),
+		linear("b", false, map[string]float64{Resistance: -1}),
+	}
+	c := Constraints{WeeklyMinutes: 60, Modalities: []string{Aerobic, Resistance}, MaxIntensity: 80}
+	r, err := Optimize(objectives, c, Options{Weights: map[string]float64{"a": 1, "b": 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Front) != 5 {
+		t.Fatalf("Expected one solution per mix in quarters, got %d", len(r.Front))
+	}
+	for i, s := range r.Front {
+		if s.IntensityPercent != 80 || s.WeeklyMinutes() != 60 {
+			t.Errorf("Expected full intensity and the whole budget, got %v", s.Candidate)
+		}
+		for j, other := range r.Front {
+			if i != j && s.Outcomes["a"] >= other.Outcomes["a"] && s.Outcomes["b"] <= other.Outcomes["b"] {
+				t.Errorf("%v dominates %v", s.Candidate, other.Candidate)
+			}
+		}
+	}
+	// b, weighted three times, gains twice as much as a from resistance
+	if best := r.Front[0]; best.Mix[Resistance] != 1 || math.Abs(best.Outcomes["a"]-40) > 1e-9 || math.Abs(best.Outcomes["b"]+80) > 1e-9 {
+		t.Errorf("Unexpected best solution %v %v", best.Candidate, best.Outcomes)
+	}
+
+	if _, err := Optimize(objectives, Constraints{WeeklyMinutes: 5}, Options{}); err == nil {
+		t.Error("Expected an error for a budget shorter than any session")
+	}
+	if _, err := Optimize(objectives, c, Options{Weights: map[string]float64{"c": 1}}); err == nil {
+		t.Error("Expected an error when no objective is weighted")
+	}
+}
+
+// Test the tissue objectives respect the budget and contraindications, and
+// bone favours loading modalities
+func TestOptimizeTissues(t *testing.T) {
+	c := Constraints{WeeklyMinutes: 150, Conditions: []string{"recent fracture"}}
+	r, err := Optimize(Objectives(), c, Options{MixStep: 0.5, IntensityStep: 15, DurationStep: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Excluded[Resistance]) == 0 || !strings.Contains(r.Excluded[Resistance][0], BoneObjective(profile.Reference).Protocols[0].Name) {
+		t.Errorf("Expected resistance to be ruled out by the bone mineral density protocol, got %v", r.Excluded)
+	}
+	if len(r.Front) == 0 {
+		t.Fatal("Expected a non-empty front")
+	}
+	for _, s := range r.Front {
+		if s.WeeklyMinutes() > 150 || s.Mix[Resistance] > 0 {
+			t.Errorf("Infeasible solution %v", s.Candidate)
+		}
+		if s.Outcomes["liver"] > 0 || s.Outcomes["adipose"] > 0 {
+			t.Errorf("Expected liver fat and fat mass to fall, got %v", s.Outcomes)
+		}
+	}
+
+	bone, err := Optimize(Objectives(), Constraints{}, Options{Weights: map[string]float64{"bone": 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best := bone.Front[0]; best.Mix[HIIT] > 0 || best.Mix[Resistance]+best.Mix[Impact] < 0.5 || best.IntensityPercent != 95 {
+		t.Errorf("Expected bone alone to favour loading at full intensity, got %v", best.Candidate)
+	}
+}
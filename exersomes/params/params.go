@@ -0,0 +1,193 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Parameter is one model coefficient and the bounds fitting may move it within
+type Parameter struct {
+	Name        string
+	Value       float64
+	Min         float64
+	Max         float64
+	Fixed       bool   `json:",omitempty"` // Thresholds and other values fitting leaves alone
+	Description string `json:",omitempty"`
+}
+
+// Set is a named group of coefficients read by one model. Models read values
+// on every call, so loading a parameter file changes their behavior at runtime.
+type Set struct {
+	Name string
+
+	mu     sync.RWMutex
+	params []Parameter
+	index  map[string]int
+}
+
+// NewSet creates a set from its default parameters
+func NewSet(name string, parameters ...Parameter) *Set {
+	s := &Set{Name: name, params: parameters, index: make(map[string]int, len(parameters))}
+	for i, p := range parameters {
+		if _, dup := s.index[p.Name]; dup {
+			panic(fmt.Sprintf("params: duplicate parameter %s in %s", p.Name, name))
+		}
+		s.index[p.Name] = i
+	}
+	return s
+}
+
+// Get returns a parameter's current value. Unknown names are programming errors.
+func (s *Set) Get(name string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i, ok := s.index[name]
+	if !ok {
+		panic(fmt.Sprintf("params: %s has no parameter %s", s.Name, name))
+	}
+	return s.params[i].Value
+}
+
+// Set changes a parameter's value within its bounds
+func (s *Set) Set(name string, value float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.index[name]
+	if !ok {
+		return fmt.Errorf("%s has no parameter %s", s.Name, name)
+	}
+	p := s.params[i]
+	if value < p.Min || value > p.Max {
+		return fmt.Errorf("%s.%s = %g is outside [%g, %g]", s.Name, name, value, p.Min, p.Max)
+	}
+	s.params[i].Value = value
+	return nil
+}
+
+// Clone returns an unregistered copy of the set that can be changed without
+// affecting models reading the original
+func (s *Set) Clone() *Set {
+	return NewSet(s.Name, s.Parameters()...)
+}
+
+// Parameters returns a copy of the set's parameters
+func (s *Set) Parameters() []Parameter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Parameter(nil), s.params...)
+}
+
+// registry holds every set models have registered, by name
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]*Set)
+)
+
+// Register makes a set available to Lookup and parameter files, and returns it
+func Register(s *Set) *Set {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[s.Name]; dup {
+		panic("params: duplicate set " + s.Name)
+	}
+	registry[s.Name] = s
+	return s
+}
+
+// Lookup returns a registered set by name
+func Lookup(name string) (*Set, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	s, ok := registry[name]
+	return s, ok
+}
+
+// Registered returns every registered set, ordered by name
+func Registered() []*Set {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	sets := make([]*Set, 0, len(registry))
+	for _, s := range registry {
+		sets = append(sets, s)
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Name < sets[j].Name })
+	return sets
+}
+
+// File is the JSON form of parameter sets, with notes on how they were fitted
+type File struct {
+	Fitted map[string]string `json:",omitempty"` // Study, objective, scores, ...
+	Sets   []FileSet
+}
+
+// FileSet is one set in a parameter file
+type FileSet struct {
+	Name       string
+	Parameters []Parameter
+}
+
+// Snapshot captures the current values of sets for writing to a file
+func Snapshot(sets ...*Set) File {
+	var f File
+	for _, s := range sets {
+		f.Sets = append(f.Sets, FileSet{Name: s.Name, Parameters: s.Parameters()})
+	}
+	return f
+}
+
+// WriteFile writes a parameter file
+func WriteFile(path string, f File) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadFile applies the values in a parameter file to the registered sets.
+// Parameters the file omits keep their current values.
+func LoadFile(path string) (File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, err
+	}
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := Apply(f); err != nil {
+		return f, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Apply sets registered parameters to the values in f. Nothing is changed if
+// any set or parameter is unknown or any value is out of bounds.
+func Apply(f File) error {
+	for _, fs := range f.Sets {
+		s, ok := Lookup(fs.Name)
+		if !ok {
+			return fmt.Errorf("unknown parameter set %s", fs.Name)
+		}
+		for _, p := range fs.Parameters {
+			current := s.Parameters()
+			i, ok := s.index[p.Name]
+			if !ok {
+				return fmt.Errorf("%s has no parameter %s", s.Name, p.Name)
+			}
+			if p.Value < current[i].Min || p.Value > current[i].Max {
+				return fmt.Errorf("%s.%s = %g is outside [%g, %g]", s.Name, p.Name, p.Value, current[i].Min, current[i].Max)
+			}
+		}
+	}
+	for _, fs := range f.Sets {
+		s, _ := Lookup(fs.Name)
+		for _, p := range fs.Parameters {
+			s.Set(p.Name, p.Value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package params
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test values stay within bounds and files apply all-or-nothing
+func TestSetsAndFiles(t *testing.T) {
+	s := Register(NewSet("test.params",
+		Parameter{Name: "gain", Value: 2, Min: 0, Max: 10},
+		Parameter{Name: "threshold", Value: 0.5, Min: 0, Max: 1, Fixed: true},
+	))
+	if err := s.Set("gain", 11); err == nil {
+		t.Error("Expected an out-of-bounds value to be rejected")
+	}
+	if err := s.Set("gain", 3); err != nil || s.Get("gain") != 3 {
+		t.Errorf("Expected gain 3, got %g (%v)", s.Get("gain"), err)
+	}
+
+	path := filepath.Join(t.TempDir(), "fitted.json")
+	f := Snapshot(s)
+	f.Fitted = map[string]string{"study": "unit test"}
+	if err := WriteFile(path, f); err != nil {
+		t.Fatal(err)
+	}
+	s.Set("gain", 7)
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Get("gain") != 3 || loaded.Fitted["study"] != "unit test" {
+		t.Errorf("Expected the file to restore gain 3, got %g", s.Get("gain"))
+	}
+
+	bad := File{Sets: []FileSet{{Name: "test.params", Parameters: []Parameter{{Name: "gain", Value: 5}, {Name: "threshold", Value: 2}}}}}
+	if err := Apply(bad); err == nil || !strings.Contains(err.Error(), "outside") {
+		t.Errorf("Expected an out-of-bounds error, got %v", err)
+	}
+	if s.Get("gain") != 3 {
+		t.Error("Expected a failed Apply to change nothing")
+	}
+	if err := Apply(File{Sets: []FileSet{{Name: "missing"}}}); err == nil {
+		t.Error("Expected an unknown set to be rejected")
+	}
+}
@@ -0,0 +1,102 @@
+package plan
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Formats maps file extensions to writers
+var Formats = map[string]func(w io.Writer, p Plan) error{
+	".json": WriteJSON,
+	".ics":  WriteICalendar,
+}
+
+// WriteJSON writes the plan as indented JSON with the Plan field names
+func WriteJSON(w io.Writer, p Plan) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// WriteICalendar writes the plan as an RFC 5545 calendar with one event per
+// session. Sessions are in floating local time, so they keep their time of day
+// in whichever time zone the calendar is opened; each event's description
+// gives the week's prescription and projected outcomes.
+func WriteICalendar(w io.Writer, p Plan) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		folded(bw, name+":"+value)
+	}
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	slug := strings.Join(strings.Fields(strings.ToLower(p.Name)), "-")
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Exersomes//Training plan//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("X-WR-CALNAME", escapeText(p.Name))
+	for _, wk := range p.Weeks {
+		description := []string{fmt.Sprintf("Mesocycle %d, %s week: %s", wk.Mesocycle, strings.ToLower(wk.Phase), wk.Candidate)}
+		for _, o := range p.Outcomes {
+			description = append(description, fmt.Sprintf("Projected %s by week end: %.3f %s", o.Output, wk.Projected[o.Name], o.Unit))
+		}
+		for i, s := range wk.Sessions {
+			line("BEGIN", "VEVENT")
+			line("UID", fmt.Sprintf("%s-%s-w%d-s%d@exersomes", slug, p.Start.Format("20060102"), wk.Number, i+1))
+			line("DTSTAMP", stamp)
+			line("DTSTART", s.Format("20060102T150405"))
+			line("DURATION", fmt.Sprintf("PT%dM", wk.DurationMinutes))
+			line("SUMMARY", escapeText(fmt.Sprintf("%s: week %d %s, session %d of %d", p.Name, wk.Number, strings.ToLower(wk.Phase), i+1, len(wk.Sessions))))
+			line("DESCRIPTION", escapeText(strings.Join(description, "\n")))
+			line("CATEGORIES", escapeText(wk.Phase))
+			line("END", "VEVENT")
+		}
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// WriteFile writes the plan in the format named by the path's extension:
+// .json or .ics (iCalendar)
+func WriteFile(path string, p Plan) error {
+	write, ok := Formats[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return fmt.Errorf("%s: unsupported plan format, use .json or .ics", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, p); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// escapeText escapes an iCalendar TEXT value
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// folded writes a content line, folding it at 75 octets without splitting a
+// UTF-8 sequence, and ends each physical line with CRLF
+func folded(w io.Writer, s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		fmt.Fprintf(w, "%s\r\n ", s[:cut])
+		s = s[cut:]
+		limit = 74 // The leading space of a continuation counts
+	}
+	fmt.Fprintf(w, "%s\r\n", s)
+}
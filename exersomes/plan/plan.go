@@ -0,0 +1,278 @@
+package plan
+
+import (
+	"exersomes/optimize"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Phases of a training week
+const (
+	Load   = "Load"   // Progressive overload
+	Deload = "Deload" // Reduced volume closing a mesocycle
+	Taper  = "Taper"  // Reduced volume at peak intensity closing the plan
+)
+
+// Config describes the plan to build. Zero values take the defaults noted.
+type Config struct {
+	Name             string             // "Periodized plan"
+	Start            time.Time          // Day and time of the first session; required
+	Weeks            int                // 12
+	Mix              map[string]float64 // Share of weekly minutes by modality, summing to 1; required
+	StartIntensity   int                // Intensity of the first week, % of max; 60
+	PeakIntensity    int                // Intensity of the last loading week; 85
+	DurationMinutes  int                // Session length in the first week of each mesocycle; 40
+	FrequencyPerWeek int                // 3
+	MesocycleWeeks   int                // Loading weeks plus the deload week closing each mesocycle; 4
+	VolumeStep       float64            // Session length added each loading week of a mesocycle, as a fraction of the first; 0.1
+	DeloadVolume     float64            // Deload session length relative to the week before; 0.6
+	TaperWeeks       int                // Final weeks at peak intensity and reduced volume; 0 for none
+	TaperVolume      float64            // Taper session length relative to the last loading week; 0.5
+	Days             []time.Weekday     // Training days; spread evenly from the start day
+}
+
+// Outcome describes a projected objective
+type Outcome struct {
+	Name     string
+	Tissue   string
+	Output   string
+	Unit     string
+	Maximize bool
+}
+
+// Week is one week of the plan with its sessions and the cumulative outcomes
+// projected at its end
+type Week struct {
+	Number    int
+	Mesocycle int
+	Phase     string
+	optimize.Candidate
+	Sessions  []time.Time
+	Projected map[string]float64 // By objective name
+}
+
+// Plan is a periodized multi-week training plan
+type Plan struct {
+	Name     string
+	Start    time.Time
+	Outcomes []Outcome // Objectives projected, those with a protocol for a modality of the mix
+	Weeks    []Week
+}
+
+func withDefaults(c Config) Config {
+	defaults := []struct {
+		value *int
+		def   int
+	}{
+		{&c.Weeks, 12},
+		{&c.StartIntensity, 60},
+		{&c.PeakIntensity, 85},
+		{&c.DurationMinutes, 40},
+		{&c.FrequencyPerWeek, 3},
+		{&c.MesocycleWeeks, 4},
+	}
+	for _, d := range defaults {
+		if *d.value == 0 {
+			*d.value = d.def
+		}
+	}
+	for _, d := range []struct {
+		value *float64
+		def   float64
+	}{
+		{&c.VolumeStep, 0.1},
+		{&c.DeloadVolume, 0.6},
+		{&c.TaperVolume, 0.5},
+	} {
+		if *d.value == 0 {
+			*d.value = d.def
+		}
+	}
+	if c.Name == "" {
+		c.Name = "Periodized plan"
+	}
+	return c
+}
+
+func validate(c Config) error {
+	var total float64
+	for m, share := range c.Mix {
+		if share < 0 {
+			return fmt.Errorf("negative share %g of %s", share, m)
+		}
+		total += share
+	}
+	switch {
+	case c.Start.IsZero():
+		return fmt.Errorf("no start date")
+	case math.Abs(total-1) > 1e-9:
+		return fmt.Errorf("modality shares sum to %g, want 1", total)
+	case c.Weeks < 1 || c.Weeks > 104:
+		return fmt.Errorf("%d weeks out of range", c.Weeks)
+	case c.TaperWeeks < 0 || c.TaperWeeks >= c.Weeks:
+		return fmt.Errorf("%d taper weeks leave no loading weeks in %d", c.TaperWeeks, c.Weeks)
+	case c.MesocycleWeeks < 2:
+		return fmt.Errorf("a mesocycle of %d weeks leaves no room to deload", c.MesocycleWeeks)
+	case c.StartIntensity < 1 || c.PeakIntensity > 100 || c.StartIntensity > c.PeakIntensity:
+		return fmt.Errorf("intensities %d%% to %d%% out of range", c.StartIntensity, c.PeakIntensity)
+	case c.DurationMinutes < 1 || c.FrequencyPerWeek < 1 || c.FrequencyPerWeek > 7:
+		return fmt.Errorf("invalid session length or frequency")
+	case c.VolumeStep < 0 || c.DeloadVolume <= 0 || c.DeloadVolume > 1 || c.TaperVolume <= 0 || c.TaperVolume > 1:
+		return fmt.Errorf("volume factors out of range")
+	case len(c.Days) > 0 && len(c.Days) != c.FrequencyPerWeek:
+		return fmt.Errorf("%d training days for %d sessions per week", len(c.Days), c.FrequencyPerWeek)
+	}
+	return nil
+}
+
+// Build lays out a periodized plan and projects each objective's cumulative
+// adaptation week by week.
+//
+// The weeks before the taper form mesocycles of MesocycleWeeks: loading weeks
+// lengthen sessions by VolumeStep, and a deload week at the mesocycle's
+// opening intensity closes each complete one. Intensity rises evenly over the
+// loading weeks of the whole plan from StartIntensity to PeakIntensity, and
+// taper weeks hold the peak at reduced volume.
+//
+// The predictors describe a prescription held for a number of weeks, so week
+// n adds the gain its own prescription makes from week n-1 to n, scaled by
+// its dose as in the optimizer, to the first week's prediction at week 0. A
+// plan that never varies projects exactly the predictor's output; detraining
+// in reduced weeks is not modelled.
+func Build(c Config, objectives []optimize.Objective) (Plan, error) {
+	c = withDefaults(c)
+	if err := validate(c); err != nil {
+		return Plan{}, err
+	}
+
+	p := Plan{Name: c.Name, Start: c.Start}
+	var used []optimize.Objective
+	for _, o := range objectives {
+		for m, share := range c.Mix {
+			if _, ok := o.ProtocolFor(m); ok && share > 0 {
+				used = append(used, o)
+				p.Outcomes = append(p.Outcomes, Outcome{Name: o.Name, Tissue: o.Tissue, Output: o.Output, Unit: o.Unit, Maximize: o.Maximize})
+				break
+			}
+		}
+	}
+
+	loading := 0
+	for n := 1; n <= c.Weeks; n++ {
+		if phase(c, n) == Load {
+			loading++
+		}
+	}
+	days := trainingDays(c)
+	cumulative := make(map[string]float64)
+	load, prev := 0, Week{}
+	for n := 1; n <= c.Weeks; n++ {
+		w := Week{Number: n, Mesocycle: (n-1)/c.MesocycleWeeks + 1, Phase: phase(c, n), Projected: make(map[string]float64)}
+		if w.Phase == Taper {
+			w.Mesocycle = (c.Weeks-c.TaperWeeks-1)/c.MesocycleWeeks + 2
+		}
+		w.Candidate = optimize.Candidate{Mix: c.Mix, FrequencyPerWeek: c.FrequencyPerWeek}
+		switch w.Phase {
+		case Load:
+			w.IntensityPercent = c.StartIntensity
+			if loading > 1 {
+				w.IntensityPercent += int(math.Round(float64((c.PeakIntensity-c.StartIntensity)*load) / float64(loading-1)))
+			}
+			w.DurationMinutes = int(math.Round(float64(c.DurationMinutes) * (1 + c.VolumeStep*float64((n-1)%c.MesocycleWeeks))))
+			load++
+		case Deload:
+			w.IntensityPercent = p.Weeks[n-c.MesocycleWeeks].IntensityPercent
+			w.DurationMinutes = scale(prev.DurationMinutes, c.DeloadVolume)
+		case Taper:
+			w.IntensityPercent = c.PeakIntensity
+			w.DurationMinutes = scale(lastLoad(p.Weeks).DurationMinutes, c.TaperVolume)
+		}
+
+		weekStart := c.Start.AddDate(0, 0, 7*(n-1))
+		for _, offset := range days {
+			w.Sessions = append(w.Sessions, weekStart.AddDate(0, 0, offset))
+		}
+
+		for _, o := range used {
+			var gain float64
+			for m, share := range c.Mix {
+				protocol, ok := o.ProtocolFor(m)
+				if !ok || share == 0 {
+					continue
+				}
+				protocol.IntensityPercent, protocol.DurationMinutes = w.IntensityPercent, w.DurationMinutes
+				dose := optimize.Dose(share*float64(w.WeeklyMinutes()), protocol)
+				if n == 1 {
+					gain += dose * o.Predict(protocol, 0)
+				}
+				gain += dose * (o.Predict(protocol, n) - o.Predict(protocol, n-1))
+			}
+			cumulative[o.Name] += gain
+			w.Projected[o.Name] = cumulative[o.Name]
+		}
+		p.Weeks = append(p.Weeks, w)
+		prev = w
+	}
+	return p, nil
+}
+
+// Reach returns the first week whose projected outcome meets the target,
+// at or above it for a maximized objective and at or below otherwise, or 0
+// when the plan does not reach it
+func (p Plan) Reach(objective string, target float64) int {
+	for _, o := range p.Outcomes {
+		if o.Name != objective {
+			continue
+		}
+		for _, w := range p.Weeks {
+			v, ok := w.Projected[objective]
+			if ok && (o.Maximize && v >= target || !o.Maximize && v <= target) {
+				return w.Number
+			}
+		}
+	}
+	return 0
+}
+
+// phase returns the phase of week n
+func phase(c Config, n int) string {
+	if n > c.Weeks-c.TaperWeeks {
+		return Taper
+	}
+	// A mesocycle cut short by the taper or the end of the plan does not deload
+	if n%c.MesocycleWeeks == 0 {
+		return Deload
+	}
+	return Load
+}
+
+// trainingDays returns the day offsets of a week's sessions from its first day
+func trainingDays(c Config) []int {
+	var offsets []int
+	if len(c.Days) == 0 {
+		for i := 0; i < c.FrequencyPerWeek; i++ {
+			offsets = append(offsets, int(math.Round(float64(7*i)/float64(c.FrequencyPerWeek))))
+		}
+		return offsets
+	}
+	for _, d := range c.Days {
+		offsets = append(offsets, (int(d)-int(c.Start.Weekday())+7)%7)
+	}
+	sort.Ints(offsets)
+	return offsets
+}
+
+func lastLoad(weeks []Week) Week {
+	for i := len(weeks) - 1; i >= 0; i-- {
+		if weeks[i].Phase == Load {
+			return weeks[i]
+		}
+	}
+	return Week{}
+}
+
+func scale(minutes int, factor float64) int {
+	return max(1, int(math.Round(float64(minutes)*factor)))
+}
@@ -0,0 +1,180 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"exersomes/optimize"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// start is Monday 19 October 2026 at 7:00
+var start = time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+
+// growth is an objective whose aerobic protocol gains a tenth of its intensity
+// each week from a baseline of 1, at a weekly volume of 30 minutes
+func growth() optimize.Objective {
+	return optimize.Objective{
+		Name: "growth", Output: "Growth", Unit: "au", Maximize: true,
+		Protocols: []optimize.Protocol{{Name: "p", PrimaryType: optimize.Aerobic, DurationMinutes: 10, FrequencyPerWeek: 3}},
+		Predict: func(p optimize.Protocol, weeks int) float64 {
+			return 1 + 0.1*float64(p.IntensityPercent*weeks)
+		},
+	}
+}
+
+// Test the phases, progression and session days of the default periodization,
+// and that a plan that never varies projects the predictor's own output
+func TestBuild(t *testing.T) {
+	c := Config{Start: start, Mix: map[string]float64{optimize.Aerobic: 1}, TaperWeeks: 1}
+	p, err := Build(c, []optimize.Objective{growth()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Weeks) != 12 {
+		t.Fatalf("Expected 12 weeks, got %d", len(p.Weeks))
+	}
+	for _, tt := range []struct {
+		week, mesocycle int
+		phase           string
+		intensity, dur  int
+	}{
+		{1, 1, Load, 60, 40},
+		{3, 1, Load, 66, 48},
+		{4, 1, Deload, 60, 29},
+		{5, 2, Load, 69, 40},
+		{11, 3, Load, 85, 48},
+		{12, 4, Taper, 85, 24},
+	} {
+		w := p.Weeks[tt.week-1]
+		if w.Number != tt.week || w.Mesocycle != tt.mesocycle || w.Phase != tt.phase || w.IntensityPercent != tt.intensity || w.DurationMinutes != tt.dur {
+			t.Errorf("Week %d: expected mesocycle %d %s at %d%% for %d min, got %d %s at %d%% for %d min",
+				tt.week, tt.mesocycle, tt.phase, tt.intensity, tt.dur, w.Mesocycle, w.Phase, w.IntensityPercent, w.DurationMinutes)
+		}
+	}
+	if s := p.Weeks[1].Sessions; len(s) != 3 || !s[0].Equal(start.AddDate(0, 0, 7)) || s[1].Weekday() != time.Wednesday || s[2].Hour() != 7 {
+		t.Errorf("Unexpected week 2 sessions %v", s)
+	}
+
+	c.Days = []time.Weekday{time.Saturday, time.Tuesday, time.Thursday}
+	if p, err := Build(c, nil); err != nil || p.Weeks[0].Sessions[0].Weekday() != time.Tuesday || p.Weeks[0].Sessions[2].Weekday() != time.Saturday {
+		t.Errorf("Expected sessions on the given days in order, got %v (%v)", p.Weeks[0].Sessions, err)
+	}
+
+	// Every week covers the protocol's volume, and intensity holds at 70%
+	flat := Config{Start: start, Weeks: 10, Mix: map[string]float64{optimize.Aerobic: 1}, StartIntensity: 70, PeakIntensity: 70}
+	p, err = Build(flat, []optimize.Objective{growth()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range p.Weeks {
+		if want := 1 + 7*float64(w.Number); math.Abs(w.Projected["growth"]-want) > 1e-9 {
+			t.Errorf("Week %d: expected %g, got %g", w.Number, want, w.Projected["growth"])
+		}
+	}
+	if w := p.Reach("growth", 50); w != 7 {
+		t.Errorf("Expected growth to reach 50 in week 7, got %d", w)
+	}
+	if w := p.Reach("growth", 100); w != 0 {
+		t.Errorf("Expected growth not to reach 100, got week %d", w)
+	}
+
+	for _, bad := range []Config{
+		{Mix: map[string]float64{optimize.Aerobic: 1}},
+		{Start: start, Mix: map[string]float64{optimize.Aerobic: 0.5}},
+		{Start: start, Mix: map[string]float64{optimize.Aerobic: 1}, TaperWeeks: 12},
+		{Start: start, Mix: map[string]float64{optimize.Aerobic: 1}, StartIntensity: 90},
+		{Start: start, Mix: map[string]float64{optimize.Aerobic: 1}, Days: []time.Weekday{time.Monday}},
+	} {
+		if _, err := Build(bad, nil); err == nil {
+			t.Errorf("Expected an error for %+v", bad)
+		}
+	}
+}
+
+// Test the tissue objectives accumulate adaptation across the plan, and deload
+// weeks gain less than the loading weeks around them
+func TestBuildTissues(t *testing.T) {
+	c := Config{Start: start, Weeks: 8, Mix: map[string]float64{optimize.Aerobic: 0.5, optimize.Resistance: 0.5}, DurationMinutes: 60}
+	p, err := Build(c, optimize.Objectives())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Outcomes) != 4 {
+		t.Fatalf("Expected all four tissue objectives, got %v", p.Outcomes)
+	}
+	gain := func(o Outcome, n int) float64 {
+		g := p.Weeks[n-1].Projected[o.Name]
+		if n > 1 {
+			g -= p.Weeks[n-2].Projected[o.Name]
+		}
+		if !o.Maximize {
+			g = -g
+		}
+		return g
+	}
+	for _, o := range p.Outcomes {
+		for n := 2; n <= 8; n++ {
+			if gain(o, n) < -1e-9 {
+				t.Errorf("%s: projected to regress in week %d", o.Name, n)
+			}
+		}
+	}
+	if bone := p.Outcomes[0]; gain(bone, 4) >= gain(bone, 3) || gain(bone, 4) >= gain(bone, 5) {
+		t.Errorf("Expected the deload week to gain the least bone density, got %g, %g, %g", gain(bone, 3), gain(bone, 4), gain(bone, 5))
+	}
+}
+
+// Test the JSON and iCalendar exports
+func TestExport(t *testing.T) {
+	p, err := Build(Config{Name: "Bone; autumn", Start: start, Weeks: 4, Mix: map[string]float64{optimize.Resistance: 1}}, optimize.Objectives())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, p); err != nil {
+		t.Fatal(err)
+	}
+	var back Plan
+	if err := json.Unmarshal(buf.Bytes(), &back); err != nil || len(back.Weeks) != 4 || !back.Weeks[3].Sessions[0].Equal(p.Weeks[3].Sessions[0]) {
+		t.Errorf("Expected the plan to round-trip through JSON (%v)", err)
+	}
+
+	buf.Reset()
+	if err := WriteICalendar(&buf, p); err != nil {
+		t.Fatal(err)
+	}
+	ics := buf.String()
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Error("Expected a CRLF-delimited calendar")
+	}
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 12 {
+		t.Errorf("Expected one event per session, got %d", n)
+	}
+	for _, want := range []string{"DTSTART:20261019T070000\r\n", "DURATION:PT40M\r\n", "CATEGORIES:Deload\r\n", "SUMMARY:Bone\\; autumn: week 1 load\\, session 1 of 3"} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("Expected %q in the calendar", want)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line of %d octets: %s", len(line), line)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := WriteFile(filepath.Join(dir, "plan.ics"), p); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "plan.ics")); err != nil {
+		t.Error(err)
+	}
+	if err := WriteFile(filepath.Join(dir, "plan.csv"), p); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,214 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Sexes
+const (
+	Female = "Female"
+	Male   = "Male"
+)
+
+// Training statuses, as in study.Subject
+const (
+	Untrained = "Untrained"
+	Trained   = "Trained"
+	Athlete   = "Athlete"
+)
+
+// Conditions the predictors respond to. Profiles may list others, and all are
+// matched case-insensitively.
+const (
+	NAFLD                  = "NAFLD"
+	InsulinResistance      = "Insulin resistance"
+	Type2Diabetes          = "Type 2 diabetes"
+	Osteopenia             = "Osteopenia"
+	Osteoporosis           = "Osteoporosis"
+	ChronicInflammation    = "Chronic inflammation"
+	EndothelialDysfunction = "Endothelial dysfunction"
+	Hypertension           = "Hypertension"
+	HeartDisease           = "Heart disease"
+	Immunocompromised      = "Immunocompromised"
+)
+
+// NeurologicalConditions are the conditions PredictNeuralResponse treats as neurological
+var NeurologicalConditions = []string{
+	"Depression", "Anxiety", "Mild cognitive impairment", "Alzheimer's disease",
+	"Parkinson's disease", "Multiple sclerosis", "Stroke",
+}
+
+// Medications the predictors respond to
+const (
+	Glucocorticoids    = "Glucocorticoids"    // Lower bone density and raise resorption; immunosuppressive
+	Bisphosphonates    = "Bisphosphonates"    // Suppress bone resorption
+	Metformin          = "Metformin"          // Attenuates exercise gains in insulin sensitivity
+	Immunosuppressants = "Immunosuppressants" // Leave the subject immunocompromised
+)
+
+// Profile describes the individual a prediction is made for. Zero numeric
+// fields and an empty training status are taken from Reference; an empty sex
+// is left unknown.
+type Profile struct {
+	AgeYears       int
+	Sex            string // Female or Male
+	BodyMassKg     float64
+	BodyFatPercent float64
+	VO2max         float64  // mL/kg/min
+	TrainingStatus string   // Untrained, Trained or Athlete
+	Conditions     []string `json:",omitempty"`
+	Medications    []string `json:",omitempty"`
+}
+
+// Reference is the subject the predictors assume without a profile: a
+// 40-year-old untrained man of 80 kg with 25% body fat, a VO2max of 35 mL/kg/min
+// and no conditions or medications
+var Reference = Profile{AgeYears: 40, Sex: Male, BodyMassKg: 80, BodyFatPercent: 25, VO2max: 35, TrainingStatus: Untrained}
+
+// WithDefaults fills unset fields from Reference
+func (p Profile) WithDefaults() Profile {
+	if p.AgeYears == 0 {
+		p.AgeYears = Reference.AgeYears
+	}
+	if p.BodyMassKg == 0 {
+		p.BodyMassKg = Reference.BodyMassKg
+	}
+	if p.BodyFatPercent == 0 {
+		p.BodyFatPercent = Reference.BodyFatPercent
+	}
+	if p.VO2max == 0 {
+		p.VO2max = Reference.VO2max
+	}
+	if p.TrainingStatus == "" {
+		p.TrainingStatus = Reference.TrainingStatus
+	}
+	return p
+}
+
+// Validate checks the profile's fields are known and in physiological ranges
+func (p Profile) Validate() error {
+	switch {
+	case p.AgeYears < 0 || p.AgeYears > 120:
+		return fmt.Errorf("age %d years out of range", p.AgeYears)
+	case p.Sex != "" && p.Sex != Female && p.Sex != Male:
+		return fmt.Errorf("unknown sex %q", p.Sex)
+	case p.BodyMassKg < 0 || p.BodyMassKg > 300:
+		return fmt.Errorf("body mass %g kg out of range", p.BodyMassKg)
+	case p.BodyFatPercent < 0 || p.BodyFatPercent > 70:
+		return fmt.Errorf("body fat %g%% out of range", p.BodyFatPercent)
+	case p.VO2max < 0 || p.VO2max > 90:
+		return fmt.Errorf("VO2max %g mL/kg/min out of range", p.VO2max)
+	case p.TrainingStatus != "" && p.TrainingStatus != Untrained && p.TrainingStatus != Trained && p.TrainingStatus != Athlete:
+		return fmt.Errorf("unknown training status %q", p.TrainingStatus)
+	}
+	return nil
+}
+
+// LoadFile reads a profile from a JSON file with the Profile field names
+func LoadFile(path string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Profile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return Profile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Has reports whether the profile lists a condition
+func (p Profile) Has(condition string) bool {
+	return contains(p.Conditions, condition)
+}
+
+// HasAny reports whether the profile lists any of the conditions
+func (p Profile) HasAny(conditions ...string) bool {
+	for _, c := range conditions {
+		if p.Has(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Takes reports whether the profile lists a medication
+func (p Profile) Takes(medication string) bool {
+	return contains(p.Medications, medication)
+}
+
+// Postmenopausal approximates menopause as a woman aged 50 or over
+func (p Profile) Postmenopausal() bool {
+	return p.Sex == Female && p.AgeYears >= 50
+}
+
+// Obese reports body fat above 25% for men, or 35% for women
+func (p Profile) Obese() bool {
+	if p.Sex == Female {
+		return p.BodyFatPercent > 35
+	}
+	return p.BodyFatPercent > 25
+}
+
+// Trainability scales the rate of chronic adaptation relative to Reference.
+// Trained subjects and athletes have less headroom, and so do fitter ones: the
+// reference VO2max over the profile's, kept between 0.8 and 1.25.
+func (p Profile) Trainability() float64 {
+	p = p.WithDefaults()
+	status := 1.0
+	switch p.TrainingStatus {
+	case Trained:
+		status = 0.75
+	case Athlete:
+		status = 0.5
+	}
+	fitness := math.Max(0.8, math.Min(1.25, Reference.VO2max/p.VO2max))
+	return status * fitness
+}
+
+// AcuteResponseScale scales the acute change from baseline during a bout relative
+// to Reference. At the same relative intensity trained subjects mount smaller
+// hormonal and inflammatory responses: 1 untrained, 1/1.3 trained and 0.7/1.3
+// for athletes, the ratios bloodstream.PredictImmuneIndexes uses.
+func (p Profile) AcuteResponseScale() float64 {
+	switch p.WithDefaults().TrainingStatus {
+	case Trained:
+		return 1 / 1.3
+	case Athlete:
+		return 0.7 / 1.3
+	}
+	return 1
+}
+
+// AgeFactor scales the rate of chronic adaptation for age: 1 up to 40, then
+// 1% less per year to at most 30% less
+func (p Profile) AgeFactor() float64 {
+	p = p.WithDefaults()
+	if p.AgeYears <= 40 {
+		return 1
+	}
+	return math.Max(0.7, 1-0.01*float64(p.AgeYears-40))
+}
+
+// EffectiveWeeks converts weeks of training into the weeks the reference
+// subject would need for the same adaptation, scaling by Trainability and
+// AgeFactor as adherence does in the uncertainty models
+func (p Profile) EffectiveWeeks(weeks int) int {
+	return int(math.Round(float64(weeks) * p.Trainability() * p.AgeFactor()))
+}
+
+func contains(list []string, name string) bool {
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimSpace(v), name) {
+			return true
+		}
+	}
+	return false
+}
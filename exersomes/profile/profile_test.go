@@ -0,0 +1,78 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test defaults, condition matching and the adaptation factors of each field
+func TestProfile(t *testing.T) {
+	if p := (Profile{}).WithDefaults(); p.AgeYears != 40 || p.BodyMassKg != 80 || p.TrainingStatus != Untrained || p.Sex != "" {
+		t.Errorf("Unexpected defaults %+v", p)
+	}
+	if w := Reference.EffectiveWeeks(12); w != 12 {
+		t.Errorf("Expected the reference profile to keep 12 weeks, got %d", w)
+	}
+
+	for _, tt := range []struct {
+		name  string
+		p     Profile
+		weeks int
+	}{
+		{"trained", Profile{TrainingStatus: Trained}, 9},
+		{"athlete", Profile{TrainingStatus: Athlete}, 6},
+		{"fit", Profile{VO2max: 50}, 10},
+		{"unfit", Profile{VO2max: 20}, 15},
+		{"older", Profile{AgeYears: 60}, 10},
+		{"elderly", Profile{AgeYears: 90}, 8},
+	} {
+		if w := tt.p.EffectiveWeeks(12); w != tt.weeks {
+			t.Errorf("%s: expected %d effective weeks, got %d", tt.name, tt.weeks, w)
+		}
+	}
+
+	if Reference.AcuteResponseScale() != 1 || (Profile{VO2max: 50}).AcuteResponseScale() != 1 {
+		t.Error("Expected untrained subjects to keep the full acute response whatever their fitness")
+	}
+	if trained, athlete := (Profile{TrainingStatus: Trained}).AcuteResponseScale(), (Profile{TrainingStatus: Athlete}).AcuteResponseScale(); athlete >= trained || trained >= 1 {
+		t.Errorf("Expected training to shrink acute responses, got %g trained and %g athlete", trained, athlete)
+	}
+
+	p := Profile{Sex: Female, AgeYears: 55, BodyFatPercent: 30, Conditions: []string{"type 2 diabetes "}, Medications: []string{"metformin"}}
+	if !p.Has(Type2Diabetes) || p.Has(NAFLD) || !p.HasAny(NAFLD, Type2Diabetes) || !p.Takes(Metformin) {
+		t.Error("Expected conditions and medications to match case-insensitively")
+	}
+	if !p.Postmenopausal() || p.Obese() {
+		t.Error("Expected a postmenopausal woman below the female obesity threshold")
+	}
+	if p.Sex = Male; p.Postmenopausal() || !p.Obese() {
+		t.Error("Expected 30% body fat to be obese for a man")
+	}
+}
+
+// Test profiles load from JSON and invalid ones are rejected
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "profile.json")
+	if err := os.WriteFile(path, []byte(`{"AgeYears": 62, "Sex": "Female", "VO2max": 24, "Conditions": ["Osteopenia"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.AgeYears != 62 || !p.Has(Osteopenia) || p.BodyMassKg != 0 {
+		t.Errorf("Unexpected profile %+v", p)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"TrainingStatus": "Elite"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("Expected an error for an unknown training status")
+	}
+	if err := (Profile{BodyFatPercent: 80}).Validate(); err == nil {
+		t.Error("Expected an error for 80% body fat")
+	}
+}
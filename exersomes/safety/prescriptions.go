@@ -0,0 +1,96 @@
+package safety
+
+import (
+	"exersomes/components/bone"
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/metabolic/adipose"
+	"exersomes/components/metabolic/liver"
+	"exersomes/components/metabolic/pancreas"
+	"exersomes/components/neural"
+	"strings"
+)
+
+// Modalities a prescription's PrimaryType or a rule may name
+var Modalities = []string{"Aerobic", "HIIT", "Resistance", "Impact", "Plyometric", "Combined"}
+
+// Prescription is a tissue's exercise prescription reduced to what the rules
+// screen and adjust
+type Prescription struct {
+	Tissue            string
+	Name              string
+	PrimaryType       string
+	IntensityPercent  int // % of max HR, VO2max or 1RM
+	DurationMinutes   int
+	FrequencyPerWeek  int
+	Contraindications []string `json:",omitempty"` // Conditions the prescription itself rules out
+}
+
+// FromBone converts a bone prescription
+func FromBone(p bone.ExercisePrescription) Prescription {
+	return Prescription{Tissue: "Bone", Name: p.Name, PrimaryType: p.PrimaryType, IntensityPercent: p.IntensityPercent,
+		DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek, Contraindications: p.Contraindications}
+}
+
+// FromBloodstream converts a bloodstream prescription
+func FromBloodstream(p bloodstream.ExercisePrescription) Prescription {
+	return Prescription{Tissue: "Bloodstream", Name: p.Name, PrimaryType: p.PrimaryType, IntensityPercent: p.IntensityPercent,
+		DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek, Contraindications: p.Contraindications}
+}
+
+// FromLiver converts a liver prescription
+func FromLiver(p liver.ExercisePrescription) Prescription {
+	return Prescription{Tissue: "Liver", Name: p.Name, PrimaryType: p.PrimaryType, IntensityPercent: p.IntensityPercent,
+		DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek, Contraindications: p.Contraindications}
+}
+
+// FromPancreas converts a pancreas prescription
+func FromPancreas(p pancreas.ExercisePrescription) Prescription {
+	return Prescription{Tissue: "Pancreas", Name: p.Name, PrimaryType: p.PrimaryType, IntensityPercent: p.IntensityPercent,
+		DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek, Contraindications: p.ContraindicationsFor}
+}
+
+// FromNeural converts a neural prescription
+func FromNeural(p neural.ExercisePrescription) Prescription {
+	return Prescription{Tissue: "Neural", Name: p.Name, PrimaryType: p.PrimaryType, IntensityPercent: p.IntensityPercent,
+		DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek, Contraindications: p.ContraindicationsFor}
+}
+
+// FromAdipose converts an adipose prescription, which lists no contraindications
+func FromAdipose(p adipose.ExercisePrescription) Prescription {
+	return Prescription{Tissue: "Adipose", Name: p.Name, PrimaryType: p.PrimaryType, IntensityPercent: p.IntensityPercent,
+		DurationMinutes: p.DurationMinutes, FrequencyPerWeek: p.FrequencyPerWeek}
+}
+
+// Standard returns every tissue's standard prescriptions, by tissue
+func Standard() []Prescription {
+	var out []Prescription
+	for _, p := range bone.GetStandardPrescriptions() {
+		out = append(out, FromBone(p))
+	}
+	for _, p := range bloodstream.GetStandardPrescriptions() {
+		out = append(out, FromBloodstream(p))
+	}
+	for _, p := range liver.GetStandardPrescriptions() {
+		out = append(out, FromLiver(p))
+	}
+	for _, p := range pancreas.GetStandardPrescriptions() {
+		out = append(out, FromPancreas(p))
+	}
+	for _, p := range adipose.GetStandardPrescriptions() {
+		out = append(out, FromAdipose(p))
+	}
+	for _, p := range neural.GetStandardPrescriptions() {
+		out = append(out, FromNeural(p))
+	}
+	return out
+}
+
+// Lookup finds a standard prescription by name, case-insensitively
+func Lookup(name string) (Prescription, bool) {
+	for _, p := range Standard() {
+		if strings.EqualFold(p.Name, strings.TrimSpace(name)) {
+			return p, true
+		}
+	}
+	return Prescription{}, false
+}
@@ -0,0 +1,183 @@
+package safety
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Severities
+const (
+	Block = "Block" // The prescription must not be followed as written
+	Warn  = "Warn"  // The prescription may be followed with the precaution noted
+)
+
+// Vitals a rule may test, with the units readings are taken in
+const (
+	SystolicBP       = "SystolicBP"       // Resting, mmHg
+	DiastolicBP      = "DiastolicBP"      // Resting, mmHg
+	RestingHeartRate = "RestingHeartRate" // bpm
+	SpO2             = "SpO2"             // Resting oxygen saturation, %
+	Glucose          = "Glucose"          // Pre-exercise blood glucose, mg/dL
+	TemperatureC     = "TemperatureC"     // Body temperature, °C
+)
+
+// vitalRanges are the readings Vitals.Validate accepts
+var vitalRanges = map[string][2]float64{
+	SystolicBP:       {50, 300},
+	DiastolicBP:      {20, 200},
+	RestingHeartRate: {20, 250},
+	SpO2:             {50, 100},
+	Glucose:          {10, 1000},
+	TemperatureC:     {30, 45},
+}
+
+// Vitals are an individual's recent readings by vital name
+type Vitals map[string]float64
+
+// Validate checks every reading names a known vital and is in its physiological range
+func (v Vitals) Validate() error {
+	for name, value := range v {
+		r, ok := vitalRanges[name]
+		if !ok {
+			return fmt.Errorf("unknown vital %s", name)
+		}
+		if value < r[0] || value > r[1] {
+			return fmt.Errorf("%s = %g is outside [%g, %g]", name, value, r[0], r[1])
+		}
+	}
+	return nil
+}
+
+// Suggestion is how a rule would modify a prescription it fires for. Zero
+// fields leave the prescription's value.
+type Suggestion struct {
+	MaxIntensity       int    `json:",omitempty"` // Cap on intensity, % of max
+	MaxDurationMinutes int    `json:",omitempty"`
+	Modality           string `json:",omitempty"` // Primary type to train instead
+}
+
+// Rule is one screening rule. It fires when the individual has any of its
+// conditions, takes any of its medications and has any vital reading at or
+// above its AtLeast threshold or below its Below threshold, for each of those
+// the rule sets, and the prescription is of one of its modalities, if set, at
+// MinIntensity or above. A rule needing a vital that was not measured does
+// not fire.
+type Rule struct {
+	ID           string
+	Severity     string // Block or Warn
+	Message      string
+	Source       string             `json:",omitempty"` // Guideline or study the rule follows
+	Conditions   []string           `json:",omitempty"`
+	Medications  []string           `json:",omitempty"`
+	AtLeast      map[string]float64 `json:",omitempty"`
+	Below        map[string]float64 `json:",omitempty"`
+	Modalities   []string           `json:",omitempty"`
+	MinIntensity int                `json:",omitempty"`
+	Suggest      Suggestion
+}
+
+// Validate checks the rule tests the individual, uses known names, and that
+// its suggestion does not still meet its own intensity and modality criteria
+func (r Rule) Validate() error {
+	switch {
+	case r.ID == "":
+		return fmt.Errorf("rule without an ID")
+	case r.Severity != Block && r.Severity != Warn:
+		return fmt.Errorf("rule %s: unknown severity %q", r.ID, r.Severity)
+	case r.Message == "":
+		return fmt.Errorf("rule %s: no message", r.ID)
+	case len(r.Conditions) == 0 && len(r.Medications) == 0 && len(r.AtLeast) == 0 && len(r.Below) == 0:
+		return fmt.Errorf("rule %s: no condition, medication or vital to test", r.ID)
+	case r.MinIntensity < 0 || r.MinIntensity > 100 || r.Suggest.MaxIntensity < 0 || r.Suggest.MaxIntensity > 100:
+		return fmt.Errorf("rule %s: intensity out of range", r.ID)
+	case r.Suggest.MaxDurationMinutes < 0:
+		return fmt.Errorf("rule %s: negative duration", r.ID)
+	case r.MinIntensity > 0 && r.Suggest.MaxIntensity >= r.MinIntensity:
+		return fmt.Errorf("rule %s: suggested intensity %d%% would still fire the rule at %d%%", r.ID, r.Suggest.MaxIntensity, r.MinIntensity)
+	}
+	for _, thresholds := range []map[string]float64{r.AtLeast, r.Below} {
+		for name := range thresholds {
+			if _, ok := vitalRanges[name]; !ok {
+				return fmt.Errorf("rule %s: unknown vital %s", r.ID, name)
+			}
+		}
+	}
+	for _, m := range append(slices.Clone(r.Modalities), r.Suggest.Modality) {
+		if m != "" && !slices.Contains(Modalities, m) {
+			return fmt.Errorf("rule %s: unknown modality %s", r.ID, m)
+		}
+	}
+	if r.Suggest.Modality != "" && slices.Contains(r.Modalities, r.Suggest.Modality) {
+		return fmt.Errorf("rule %s: suggested modality %s would still fire the rule", r.ID, r.Suggest.Modality)
+	}
+	return nil
+}
+
+// File is the JSON layout of a rules file
+type File struct {
+	Rules []Rule
+}
+
+// Parse reads rules from JSON in the File layout and validates them
+func Parse(data []byte) ([]Rule, error) {
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, r := range f.Rules {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[strings.ToLower(r.ID)] {
+			return nil, fmt.Errorf("duplicate rule %s", r.ID)
+		}
+		seen[strings.ToLower(r.ID)] = true
+	}
+	return f.Rules, nil
+}
+
+// LoadFile reads and validates a rules file
+func LoadFile(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+//go:embed rules.json
+var defaultRules []byte
+
+// Default returns the built-in rules from rules.json, which doubles as the
+// example for rules files
+func Default() []Rule {
+	rules, err := Parse(defaultRules)
+	if err != nil {
+		panic("safety: rules.json: " + err.Error()) // The file is embedded; an error is a programming mistake
+	}
+	return rules
+}
+
+// Merge adds extra rules to base. An extra rule with the ID of a base rule
+// replaces it in place, so rules files can both extend and override.
+func Merge(base, extra []Rule) []Rule {
+	out := slices.Clone(base)
+	for _, r := range extra {
+		i := slices.IndexFunc(out, func(b Rule) bool { return strings.EqualFold(b.ID, r.ID) })
+		if i >= 0 {
+			out[i] = r
+		} else {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+package safety
+
+import (
+	"exersomes/profile"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ListedContraindication is the rule ID of findings for a condition the
+// prescription itself lists as a contraindication
+const ListedContraindication = "listed-contraindication"
+
+// Finding is a rule that fired for a prescription
+type Finding struct {
+	Rule     string // Rule ID, or ListedContraindication
+	Severity string
+	Message  string
+	Source   string `json:",omitempty"`
+	Suggest  Suggestion
+}
+
+// Screening is the outcome of screening one prescription for an individual
+type Screening struct {
+	Prescription Prescription
+	Findings     []Finding      // Blocking findings first, each severity in rule order
+	Blocked      bool           // Whether any finding blocks
+	Suggested    *Prescription  `json:",omitempty"` // The prescription modified as the findings suggest, when that clears every block
+	Alternatives []Prescription `json:",omitempty"` // When blocked, the tissue's other standard prescriptions that pass, modified where suggested
+}
+
+// Screen evaluates a prescription against an individual's conditions,
+// medications and recent vitals. Conditions the prescription lists as
+// contraindications block it, as do blocking rules that fire; warning rules
+// add precautions. Suggestions combine by taking the lowest intensity and
+// duration caps and the first modality switch, blocking rules first.
+func Screen(p Prescription, subject profile.Profile, vitals Vitals, rules []Rule) Screening {
+	s := Screening{Prescription: p, Findings: findings(p, subject, vitals, rules)}
+	s.Blocked = blocked(s.Findings)
+	if suggested, changed := adjust(p, s.Findings); changed && !blocked(findings(suggested, subject, vitals, rules)) {
+		s.Suggested = &suggested
+	}
+	if !s.Blocked || s.Suggested != nil {
+		return s
+	}
+	for _, alt := range Standard() {
+		if alt.Tissue != p.Tissue || strings.EqualFold(alt.Name, p.Name) {
+			continue
+		}
+		fs := findings(alt, subject, vitals, rules)
+		if !blocked(fs) {
+			s.Alternatives = append(s.Alternatives, alt)
+		} else if adjusted, changed := adjust(alt, fs); changed && !blocked(findings(adjusted, subject, vitals, rules)) {
+			s.Alternatives = append(s.Alternatives, adjusted)
+		}
+	}
+	return s
+}
+
+// String describes the prescription, e.g. "Bone Mineral Density Protocol (Bone): Resistance, 75%, 40 min × 3/week"
+func (p Prescription) String() string {
+	return fmt.Sprintf("%s (%s): %s, %d%%, %d min × %d/week", p.Name, p.Tissue, p.PrimaryType, p.IntensityPercent, p.DurationMinutes, p.FrequencyPerWeek)
+}
+
+func findings(p Prescription, subject profile.Profile, vitals Vitals, rules []Rule) []Finding {
+	var out []Finding
+	for _, c := range p.Contraindications {
+		if subject.Has(c) {
+			out = append(out, Finding{Rule: ListedContraindication, Severity: Block, Message: fmt.Sprintf("%s lists %s as a contraindication", p.Name, c)})
+		}
+	}
+	for _, r := range rules {
+		if fires(r, p, subject, vitals) {
+			out = append(out, Finding{Rule: r.ID, Severity: r.Severity, Message: r.Message, Source: r.Source, Suggest: r.Suggest})
+		}
+	}
+	sort.SliceStable(out, func(a, b int) bool { return out[a].Severity == Block && out[b].Severity != Block })
+	return out
+}
+
+func fires(r Rule, p Prescription, subject profile.Profile, vitals Vitals) bool {
+	if len(r.Modalities) > 0 && !slices.ContainsFunc(r.Modalities, func(m string) bool { return strings.EqualFold(m, p.PrimaryType) }) {
+		return false
+	}
+	if p.IntensityPercent < r.MinIntensity {
+		return false
+	}
+	if len(r.Conditions) > 0 && !subject.HasAny(r.Conditions...) {
+		return false
+	}
+	if len(r.Medications) > 0 && !slices.ContainsFunc(r.Medications, subject.Takes) {
+		return false
+	}
+	if len(r.AtLeast) > 0 || len(r.Below) > 0 {
+		crossed := false
+		for name, threshold := range r.AtLeast {
+			if v, ok := vitals[name]; ok && v >= threshold {
+				crossed = true
+			}
+		}
+		for name, threshold := range r.Below {
+			if v, ok := vitals[name]; ok && v < threshold {
+				crossed = true
+			}
+		}
+		if !crossed {
+			return false
+		}
+	}
+	return true
+}
+
+func blocked(fs []Finding) bool {
+	return slices.ContainsFunc(fs, func(f Finding) bool { return f.Severity == Block })
+}
+
+// adjust applies the findings' suggestions to p, reporting whether any changed it
+func adjust(p Prescription, fs []Finding) (Prescription, bool) {
+	out := p
+	switched := false
+	for _, f := range fs {
+		if s := f.Suggest.MaxIntensity; s > 0 && s < out.IntensityPercent {
+			out.IntensityPercent = s
+		}
+		if s := f.Suggest.MaxDurationMinutes; s > 0 && s < out.DurationMinutes {
+			out.DurationMinutes = s
+		}
+		if s := f.Suggest.Modality; s != "" && !switched {
+			out.PrimaryType, switched = s, true
+		}
+	}
+	changed := out.IntensityPercent != p.IntensityPercent || out.DurationMinutes != p.DurationMinutes || out.PrimaryType != p.PrimaryType
+	if changed {
+		out.Name = p.Name + ", modified"
+	}
+	return out, changed
+}
@@ -0,0 +1,140 @@
+package safety
+
+import (
+	"exersomes/profile"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test the built-in rules parse, and rules files extend and override them and
+// reject rules whose suggestion would still fire them
+func TestRules(t *testing.T) {
+	rules := Default()
+	if len(rules) == 0 {
+		t.Fatal("Expected built-in rules")
+	}
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	extra := `{"Rules": [
+		{"ID": "fever", "Severity": "Warn", "Message": "Light activity only", "AtLeast": {"TemperatureC": 37.5}},
+		{"ID": "anticoagulants-impact", "Severity": "Warn", "Message": "Bleeding risk", "Medications": ["Warfarin"], "Modalities": ["Impact"], "Suggest": {"Modality": "Aerobic"}}
+	]}`
+	if err := os.WriteFile(path, []byte(extra), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := Merge(rules, loaded)
+	if len(merged) != len(rules)+1 {
+		t.Fatalf("Expected one rule added and one replaced, got %d rules from %d", len(merged), len(rules))
+	}
+	for _, r := range merged {
+		if r.ID == "fever" && r.Severity != Warn {
+			t.Errorf("Expected the file's fever rule to replace the built-in one, got %+v", r)
+		}
+	}
+
+	for _, bad := range []string{
+		`{"Rules": [{"ID": "a", "Severity": "Stop", "Message": "m", "Conditions": ["NAFLD"]}]}`,
+		`{"Rules": [{"ID": "a", "Severity": "Warn", "Message": "m"}]}`,
+		`{"Rules": [{"ID": "a", "Severity": "Warn", "Message": "m", "AtLeast": {"Pulse": 100}}]}`,
+		`{"Rules": [{"ID": "a", "Severity": "Warn", "Message": "m", "Conditions": ["NAFLD"], "MinIntensity": 70, "Suggest": {"MaxIntensity": 75}}]}`,
+		`{"Rules": [{"ID": "a", "Severity": "Warn", "Message": "m", "Conditions": ["NAFLD"], "Modalities": ["HIIT"], "Suggest": {"Modality": "HIIT"}}]}`,
+		`{"Rules": [{"ID": "a", "Severity": "Warn", "Message": "m", "Conditions": ["NAFLD"]}, {"ID": "A", "Severity": "Block", "Message": "m", "Conditions": ["NAFLD"]}]}`,
+	} {
+		if _, err := Parse([]byte(bad)); err == nil {
+			t.Errorf("Expected an error for %s", bad)
+		}
+	}
+
+	if err := (Vitals{SystolicBP: 130, SpO2: 97}).Validate(); err != nil {
+		t.Error(err)
+	}
+	if err := (Vitals{SpO2: 120}).Validate(); err == nil {
+		t.Error("Expected an error for saturation above 100%")
+	}
+}
+
+// Test findings name the rules that fired, and blocked prescriptions come with
+// a modified prescription or the tissue's alternatives
+func TestScreen(t *testing.T) {
+	rules := Default()
+	impact, ok := Lookup("osteocyte network stimulation")
+	if !ok {
+		t.Fatal("Expected the bone impact protocol")
+	}
+
+	if s := Screen(impact, profile.Reference, nil, rules); s.Blocked || len(s.Findings) != 0 || s.Suggested != nil {
+		t.Errorf("Expected the reference subject to pass, got %+v", s)
+	}
+
+	// Osteoporosis blocks impact work; resistance at the same parameters clears it
+	osteo := profile.Profile{Conditions: []string{profile.Osteoporosis}}
+	s := Screen(impact, osteo, nil, rules)
+	if !s.Blocked || s.Findings[0].Rule != "osteoporosis-impact" {
+		t.Fatalf("Expected the osteoporosis rule to block, got %+v", s.Findings)
+	}
+	if s.Suggested == nil || s.Suggested.PrimaryType != "Resistance" || s.Suggested.IntensityPercent != impact.IntensityPercent {
+		t.Errorf("Expected a switch to resistance, got %+v", s.Suggested)
+	}
+
+	// A listed contraindication cannot be modified away, so other bone
+	// protocols are offered instead
+	bmd, _ := Lookup("Bone Mineral Density Protocol")
+	s = Screen(bmd, profile.Profile{Conditions: []string{"recent fracture"}}, nil, rules)
+	if !s.Blocked || s.Findings[0].Rule != ListedContraindication || s.Suggested != nil {
+		t.Fatalf("Expected the prescription's own contraindication to block, got %+v", s)
+	}
+	if len(s.Alternatives) == 0 {
+		t.Fatal("Expected alternative bone prescriptions")
+	}
+	for _, alt := range s.Alternatives {
+		if alt.Tissue != "Bone" || alt.Name == bmd.Name {
+			t.Errorf("Unexpected alternative %v", alt)
+		}
+	}
+
+	// Neural prescriptions carry their own contraindications
+	executive, ok := Lookup("Executive Function Enhancement")
+	if !ok || executive.Tissue != "Neural" {
+		t.Fatalf("Expected the neural executive function protocol, got %+v", executive)
+	}
+	s = Screen(executive, profile.Profile{Conditions: []string{"Recent concussion"}}, nil, rules)
+	if !s.Blocked || s.Findings[0].Rule != ListedContraindication {
+		t.Errorf("Expected a recent concussion to block, got %+v", s)
+	}
+	for _, alt := range s.Alternatives {
+		if alt.Tissue != "Neural" || alt.Name == executive.Name {
+			t.Errorf("Unexpected alternative %v", alt)
+		}
+	}
+
+	// Vitals: a warning caps intensity, and a block without a suggestion stands
+	hiit, _ := Lookup("Insulin Sensitivity")
+	s = Screen(hiit, profile.Profile{Conditions: []string{profile.Type2Diabetes}}, Vitals{Glucose: 280, SystolicBP: 165}, rules)
+	if s.Blocked || len(s.Findings) != 2 || s.Suggested == nil || s.Suggested.IntensityPercent != 60 {
+		t.Errorf("Expected hyperglycaemia and blood pressure warnings capping intensity at 60%%, got %+v", s)
+	}
+	s = Screen(hiit, profile.Reference, Vitals{TemperatureC: 38.5}, rules)
+	if !s.Blocked || s.Findings[0].Rule != "fever" || s.Suggested != nil {
+		t.Errorf("Expected fever to block outright, got %+v", s)
+	}
+	for _, alt := range s.Alternatives {
+		t.Errorf("Expected no pancreas prescription to pass with a fever, got %v", alt)
+	}
+
+	// Heart disease blocks HIIT in favour of moderate aerobic work, and the
+	// block sorts ahead of the medication warning
+	heart := profile.Profile{Conditions: []string{profile.HeartDisease}, Medications: []string{"beta blockers"}}
+	s = Screen(hiit, heart, nil, rules)
+	if len(s.Findings) != 2 || s.Findings[0].Severity != Block || s.Findings[1].Rule != "beta-blockers" {
+		t.Fatalf("Unexpected findings %+v", s.Findings)
+	}
+	if s.Suggested == nil || s.Suggested.PrimaryType != "Aerobic" || s.Suggested.IntensityPercent != 70 || !strings.Contains(s.Suggested.Name, "modified") {
+		t.Errorf("Expected moderate aerobic work, got %+v", s.Suggested)
+	}
+}
@@ -0,0 +1,115 @@
+package sensitivity
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Factor is an input varied by the analysis, between Min and Max
+type Factor struct {
+	Name    string
+	Min     float64
+	Max     float64
+	Integer bool // Rounded before evaluation, for fields such as DurationMinutes
+}
+
+// Predictor exposes a model to sensitivity analysis: Evaluate receives one
+// value per factor, by name, and returns numeric outputs by response key
+type Predictor struct {
+	Name        string
+	Description string
+	Factors     []Factor
+	Evaluate    func(x map[string]float64) map[string]float64
+}
+
+// Prescription factor names, matching the ExercisePrescription fields
+const (
+	IntensityPercent = "IntensityPercent"
+	DurationMinutes  = "DurationMinutes"
+	FrequencyPerWeek = "FrequencyPerWeek"
+)
+
+// PrescriptionFactors spans the intensities, session lengths and weekly
+// frequencies found across the standard prescriptions
+func PrescriptionFactors() []Factor {
+	return []Factor{
+		{Name: IntensityPercent, Min: 40, Max: 95, Integer: true},
+		{Name: DurationMinutes, Min: 10, Max: 90, Integer: true},
+		{Name: FrequencyPerWeek, Min: 1, Max: 7, Integer: true},
+	}
+}
+
+// ApplyPrescription copies the prescription factors in x onto a prescription's fields
+func ApplyPrescription(x map[string]float64, intensityPercent, durationMinutes, frequencyPerWeek *int) {
+	if v, ok := x[IntensityPercent]; ok {
+		*intensityPercent = int(v)
+	}
+	if v, ok := x[DurationMinutes]; ok {
+		*durationMinutes = int(v)
+	}
+	if v, ok := x[FrequencyPerWeek]; ok {
+		*frequencyPerWeek = int(v)
+	}
+}
+
+// Flatten joins grouped outputs, such as the maps of a tissue response struct,
+// into keys of the form "group/name"
+func Flatten(groups map[string]map[string]float64) map[string]float64 {
+	out := make(map[string]float64)
+	for group, values := range groups {
+		for name, v := range values {
+			out[group+"/"+name] = v
+		}
+	}
+	return out
+}
+
+// at evaluates the predictor at a point of the unit hypercube
+func (p Predictor) at(u []float64) map[string]float64 {
+	x := make(map[string]float64, len(p.Factors))
+	for i, f := range p.Factors {
+		v := f.Min + u[i]*(f.Max-f.Min)
+		if f.Integer {
+			v = math.Round(v)
+		}
+		x[f.Name] = v
+	}
+	return p.Evaluate(x)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Predictor)
+)
+
+// Register makes a predictor available to Lookup, and returns it
+func Register(p Predictor) Predictor {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[p.Name]; dup {
+		panic("sensitivity: duplicate predictor " + p.Name)
+	}
+	registry[p.Name] = p
+	return p
+}
+
+// Lookup returns a registered predictor by name
+func Lookup(name string) (Predictor, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	p, ok := registry[name]
+	return p, ok
+}
+
+// Registered returns every registered predictor, ordered by name
+func Registered() []Predictor {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	predictors := make([]Predictor, 0, len(registry))
+	for _, p := range registry {
+		predictors = append(predictors, p)
+	}
+	sort.Slice(predictors, func(i, j int) bool { return predictors[i].Name < predictors[j].Name })
+	return predictors
+}
@@ -0,0 +1,99 @@
+package sensitivity
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+// ishigami is the standard Sobol benchmark with analytic indices
+var ishigami = Predictor{
+	Name: "ishigami",
+	Factors: []Factor{
+		{Name: "x1", Min: -math.Pi, Max: math.Pi},
+		{Name: "x2", Min: -math.Pi, Max: math.Pi},
+		{Name: "x3", Min: -math.Pi, Max: math.Pi},
+	},
+	Evaluate: func(x map[string]float64) map[string]float64 {
+		y := math.Sin(x["x1"]) + 7*math.Pow(math.Sin(x["x2"]), 2) + 0.1*math.Pow(x["x3"], 4)*math.Sin(x["x1"])
+		return map[string]float64{"y": y, "constant": 1}
+	},
+}
+
+// Test Sobol indices against the Ishigami function's analytic values
+func TestSobol(t *testing.T) {
+	indices, err := Sobol(ishigami, SobolOptions{N: 20000, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][2]float64{
+		"x1": {0.3139, 0.5576},
+		"x2": {0.4424, 0.4424},
+		"x3": {0, 0.2437},
+	}
+	wantRank := map[string]int{"x1": 1, "x2": 2, "x3": 3}
+	for _, ix := range indices {
+		if ix.Key == "constant" {
+			if !math.IsNaN(ix.Total) {
+				t.Errorf("Expected NaN indices for a constant output, got %+v", ix)
+			}
+			continue
+		}
+		w := want[ix.Factor]
+		if math.Abs(ix.First-w[0]) > 0.05 || math.Abs(ix.Total-w[1]) > 0.05 {
+			t.Errorf("%s: expected S1 %.3f ST %.3f, got %.3f %.3f", ix.Factor, w[0], w[1], ix.First, ix.Total)
+		}
+		if ix.Rank != wantRank[ix.Factor] {
+			t.Errorf("%s: expected rank %d, got %d", ix.Factor, wantRank[ix.Factor], ix.Rank)
+		}
+	}
+	if len(indices) != 6 {
+		t.Errorf("Expected 6 indices, got %d", len(indices))
+	}
+}
+
+// Test Morris effects of a linear model, including integer factors
+func TestMorris(t *testing.T) {
+	linear := Predictor{
+		Name: "linear",
+		Factors: []Factor{
+			{Name: "a", Min: 0, Max: 1},
+			{Name: "b", Min: 0, Max: 30, Integer: true},
+			{Name: "c", Min: 0, Max: 1},
+		},
+		Evaluate: func(x map[string]float64) map[string]float64 {
+			return map[string]float64{"y": 10*x["a"] - x["b"]}
+		},
+	}
+	indices, err := Morris(linear, MorrisOptions{Trajectories: 10, Seed: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		factor  string
+		muStar  float64
+		mu      float64
+		ranking int
+	}{{"b", 30, -30, 1}, {"a", 10, 10, 2}, {"c", 0, 0, 3}}
+	for i, w := range want {
+		ix := indices[i]
+		if ix.Factor != w.factor || ix.Rank != w.ranking || math.Abs(ix.MuStar-w.muStar) > 1e-9 ||
+			math.Abs(ix.Mu-w.mu) > 1e-9 || ix.Sigma > 1e-9 {
+			t.Errorf("Expected %s with μ*=%g μ=%g rank %d, got %+v", w.factor, w.muStar, w.mu, w.ranking, ix)
+		}
+	}
+
+	if _, err := Morris(linear, MorrisOptions{Levels: 3}); err == nil {
+		t.Error("Expected an error for odd levels")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMorris(&buf, linear.Name, indices); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || lines[1] != "linear\ty\tb\t1\t30\t-30\t0" {
+		t.Errorf("Unexpected table:\n%s", buf.String())
+	}
+}
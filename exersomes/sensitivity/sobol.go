@@ -0,0 +1,136 @@
+package sensitivity
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// SobolOptions control variance-based sensitivity analysis
+type SobolOptions struct {
+	N    int   // Base samples; the analysis costs N × (factors + 2) evaluations. 0 uses 1024
+	Seed int64 // Samples are identical for the same seed
+}
+
+// SobolIndex is the share of a response key's variance due to one factor. Both
+// indices are NaN for keys that do not vary.
+type SobolIndex struct {
+	Key    string
+	Factor string
+	Rank   int     // 1 is the most influential factor for the key
+	First  float64 // Variance explained by the factor alone
+	Total  float64 // Including interactions with other factors, the ranking measure
+}
+
+// Sobol estimates first-order and total Sobol indices with the Saltelli (2010)
+// and Jansen (1999) estimators over uniform samples of each factor's range
+func Sobol(p Predictor, opts SobolOptions) ([]SobolIndex, error) {
+	if opts.N == 0 {
+		opts.N = 1024
+	}
+	if len(p.Factors) == 0 {
+		return nil, errors.New("predictor has no factors")
+	}
+	if opts.N < 2 {
+		return nil, errors.New("Sobol analysis needs at least 2 samples")
+	}
+
+	k := len(p.Factors)
+	r := rand.New(rand.NewSource(opts.Seed))
+	a := make([][]float64, opts.N)
+	b := make([][]float64, opts.N)
+	for j := range a {
+		a[j] = make([]float64, k)
+		b[j] = make([]float64, k)
+		for i := 0; i < k; i++ {
+			a[j][i] = r.Float64()
+			b[j][i] = r.Float64()
+		}
+	}
+
+	fA := make([]map[string]float64, opts.N)
+	fB := make([]map[string]float64, opts.N)
+	fAB := make([][]map[string]float64, k) // fAB[i]: A with column i taken from B
+	for i := range fAB {
+		fAB[i] = make([]map[string]float64, opts.N)
+	}
+	for j := range a {
+		fA[j] = p.at(a[j])
+		fB[j] = p.at(b[j])
+		for i := 0; i < k; i++ {
+			ab := append([]float64(nil), a[j]...)
+			ab[i] = b[j][i]
+			fAB[i][j] = p.at(ab)
+		}
+	}
+
+	var indices []SobolIndex
+	for key := range fA[0] {
+		yA, okA := column(fA, key)
+		yB, okB := column(fB, key)
+		if !okA || !okB {
+			continue
+		}
+		mean, variance := moments(append(append([]float64(nil), yA...), yB...))
+		group := make([]SobolIndex, k)
+		for i, f := range p.Factors {
+			group[i] = SobolIndex{Key: key, Factor: f.Name, First: math.NaN(), Total: math.NaN()}
+			yAB, ok := column(fAB[i], key)
+			if !ok || variance == 0 {
+				continue
+			}
+			// Centering on the mean keeps the first-order estimator stable for
+			// outputs far from zero
+			var first, total float64
+			for j := range yA {
+				first += (yB[j] - mean) * (yAB[j] - yA[j])
+				total += (yA[j] - yAB[j]) * (yA[j] - yAB[j])
+			}
+			n := float64(len(yA))
+			group[i].First = first / n / variance
+			group[i].Total = total / (2 * n) / variance
+		}
+		ranks := rank(k, func(i int) float64 { return group[i].Total }, func(i int) string { return group[i].Factor })
+		for i := range group {
+			group[i].Rank = ranks[i]
+		}
+		indices = append(indices, group...)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		if indices[i].Key != indices[j].Key {
+			return indices[i].Key < indices[j].Key
+		}
+		return indices[i].Rank < indices[j].Rank
+	})
+	return indices, nil
+}
+
+// column gathers one key across evaluations, reporting false if any lacks it
+func column(outputs []map[string]float64, key string) ([]float64, bool) {
+	values := make([]float64, len(outputs))
+	for j, out := range outputs {
+		v, ok := out[key]
+		if !ok || math.IsNaN(v) {
+			return nil, false
+		}
+		values[j] = v
+	}
+	return values, true
+}
+
+func moments(values []float64) (mean, variance float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values) - 1)
+	// Treat rounding noise on a constant output as no variance
+	if variance <= 1e-24*math.Max(1, mean*mean) {
+		variance = 0
+	}
+	return mean, variance
+}
@@ -0,0 +1,64 @@
+package sensitivity
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// rank orders n factors by descending score, breaking ties by name; NaN
+// scores rank last. It returns each factor's 1-based rank.
+func rank(n int, score func(i int) float64, name func(i int) string) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(x, y int) bool {
+		sx, sy := score(order[x]), score(order[y])
+		switch {
+		case math.IsNaN(sx) != math.IsNaN(sy):
+			return math.IsNaN(sy)
+		case sx != sy && !math.IsNaN(sx):
+			return sx > sy
+		}
+		return name(order[x]) < name(order[y])
+	})
+	ranks := make([]int, n)
+	for r, i := range order {
+		ranks[i] = r + 1
+	}
+	return ranks
+}
+
+// WriteMorris writes Morris indices as a long-format TSV, one row per response
+// key and factor, ready for plotting
+func WriteMorris(w io.Writer, predictor string, indices []MorrisIndex) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "predictor\tkey\tfactor\trank\tmu_star\tmu\tsigma")
+	for _, ix := range indices {
+		fmt.Fprintf(bw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n", predictor, ix.Key, ix.Factor, ix.Rank,
+			formatIndex(ix.MuStar), formatIndex(ix.Mu), formatIndex(ix.Sigma))
+	}
+	return bw.Flush()
+}
+
+// WriteSobol writes Sobol indices as a long-format TSV, one row per response
+// key and factor, ready for plotting
+func WriteSobol(w io.Writer, predictor string, indices []SobolIndex) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "predictor\tkey\tfactor\trank\tfirst_order\ttotal")
+	for _, ix := range indices {
+		fmt.Fprintf(bw, "%s\t%s\t%s\t%d\t%s\t%s\n", predictor, ix.Key, ix.Factor, ix.Rank,
+			formatIndex(ix.First), formatIndex(ix.Total))
+	}
+	return bw.Flush()
+}
+
+func formatIndex(v float64) string {
+	if math.IsNaN(v) {
+		return "NA"
+	}
+	return fmt.Sprintf("%.6g", v)
+}
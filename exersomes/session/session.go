@@ -0,0 +1,335 @@
+package session
+
+import (
+	"strconv"
+	"time"
+)
+
+// Segment represents one contiguous block of an exercise session
+type Segment struct {
+	Label            string // e.g., "Warm-up", "Interval 2 work", "Set 3"
+	Phase            string // "Warm-up", "Work", "Rest", "Cool-down"
+	Duration         time.Duration
+	IntensityPercent float64 // % of max (VO2max, HR max, or 1RM for sets)
+	Reps             int     // Repetitions performed, 0 for time-based work
+}
+
+// Session represents a full exercise bout as an ordered list of segments
+type Session struct {
+	Name         string
+	ExerciseType string // "Aerobic", "HIIT", "Sprint", "Resistance", "Circuit"
+	Segments     []Segment
+}
+
+// Phases used by the session composer
+const (
+	PhaseWarmUp   = "Warm-up"
+	PhaseWork     = "Work"
+	PhaseRest     = "Rest"
+	PhaseCoolDown = "Cool-down"
+)
+
+// DefaultSecondsPerRep is the time under tension assumed for one resistance repetition
+const DefaultSecondsPerRep = 4
+
+// TotalDuration returns the length of the session from start of warm-up to end of cool-down
+func (s Session) TotalDuration() time.Duration {
+	var total time.Duration
+	for _, seg := range s.Segments {
+		total += seg.Duration
+	}
+	return total
+}
+
+// WorkDuration returns the time spent in work segments only
+func (s Session) WorkDuration() time.Duration {
+	var total time.Duration
+	for _, seg := range s.Segments {
+		if seg.Phase == PhaseWork {
+			total += seg.Duration
+		}
+	}
+	return total
+}
+
+// TotalReps returns the number of repetitions across all work segments
+func (s Session) TotalReps() int {
+	reps := 0
+	for _, seg := range s.Segments {
+		reps += seg.Reps
+	}
+	return reps
+}
+
+// PeakIntensity returns the highest segment intensity in the session
+func (s Session) PeakIntensity() float64 {
+	peak := 0.0
+	for _, seg := range s.Segments {
+		if seg.IntensityPercent > peak {
+			peak = seg.IntensityPercent
+		}
+	}
+	return peak
+}
+
+// MeanIntensity returns the time-weighted average intensity over the whole session
+func (s Session) MeanIntensity() float64 {
+	total := s.TotalDuration()
+	if total <= 0 {
+		return 0
+	}
+	return s.Load() / total.Minutes()
+}
+
+// Load returns the intensity-time integral of the session in %·minutes
+func (s Session) Load() float64 {
+	load := 0.0
+	for _, seg := range s.Segments {
+		load += seg.IntensityPercent * seg.Duration.Minutes()
+	}
+	return load
+}
+
+// SegmentAt returns the segment active at the given time from session start.
+// The second return value is false once the session has finished.
+func (s Session) SegmentAt(t time.Duration) (Segment, bool) {
+	if t < 0 {
+		return Segment{}, false
+	}
+	var elapsed time.Duration
+	for _, seg := range s.Segments {
+		elapsed += seg.Duration
+		if t < elapsed {
+			return seg, true
+		}
+	}
+	return Segment{}, false
+}
+
+// IntensityAt returns the intensity at the given time from session start,
+// or zero before the session starts and after it ends
+func (s Session) IntensityAt(t time.Duration) float64 {
+	seg, ok := s.SegmentAt(t)
+	if !ok {
+		return 0
+	}
+	return seg.IntensityPercent
+}
+
+// Scaled returns a copy of the session stretched to last durationMinutes, with every
+// segment's intensity scaled so the peak equals intensityPercent. Segment proportions
+// and repetition counts are kept; a session with no duration or intensity is returned unchanged.
+func (s Session) Scaled(intensityPercent float64, durationMinutes float64) Session {
+	total, peak := s.TotalDuration(), s.PeakIntensity()
+	if total <= 0 || peak <= 0 || intensityPercent <= 0 || durationMinutes <= 0 {
+		return s
+	}
+	timeFactor := durationMinutes * float64(time.Minute) / float64(total)
+	intensityFactor := intensityPercent / peak
+
+	out := Session{Name: s.Name, ExerciseType: s.ExerciseType, Segments: make([]Segment, len(s.Segments))}
+	for i, seg := range s.Segments {
+		seg.Duration = time.Duration(float64(seg.Duration) * timeFactor)
+		seg.IntensityPercent *= intensityFactor
+		out.Segments[i] = seg
+	}
+	return out
+}
+
+// Builder composes a Session segment by segment
+type Builder struct {
+	session Session
+}
+
+// NewBuilder starts a new session with the given name and exercise type
+func NewBuilder(name string, exerciseType string) *Builder {
+	return &Builder{session: Session{Name: name, ExerciseType: exerciseType}}
+}
+
+// WarmUp appends a warm-up segment
+func (b *Builder) WarmUp(duration time.Duration, intensityPercent float64) *Builder {
+	return b.add(Segment{
+		Label:            PhaseWarmUp,
+		Phase:            PhaseWarmUp,
+		Duration:         duration,
+		IntensityPercent: intensityPercent,
+	})
+}
+
+// Continuous appends a single steady-state work segment
+func (b *Builder) Continuous(duration time.Duration, intensityPercent float64) *Builder {
+	return b.add(Segment{
+		Label:            "Continuous",
+		Phase:            PhaseWork,
+		Duration:         duration,
+		IntensityPercent: intensityPercent,
+	})
+}
+
+// Intervals appends work/rest pairs; no rest is added after the final round
+func (b *Builder) Intervals(rounds int, work time.Duration, workIntensity float64,
+	rest time.Duration, restIntensity float64) *Builder {
+
+	for i := 1; i <= rounds; i++ {
+		b.add(Segment{
+			Label:            intervalLabel("Interval", i, "work"),
+			Phase:            PhaseWork,
+			Duration:         work,
+			IntensityPercent: workIntensity,
+		})
+		if i < rounds && rest > 0 {
+			b.add(Segment{
+				Label:            intervalLabel("Interval", i, "rest"),
+				Phase:            PhaseRest,
+				Duration:         rest,
+				IntensityPercent: restIntensity,
+			})
+		}
+	}
+	return b
+}
+
+// Sets appends resistance sets of the given reps, with passive rest between sets.
+// Set duration is derived from reps and secondsPerRep (DefaultSecondsPerRep if zero).
+func (b *Builder) Sets(sets int, reps int, intensityPercent1RM float64,
+	restBetweenSets time.Duration, secondsPerRep int) *Builder {
+
+	if secondsPerRep <= 0 {
+		secondsPerRep = DefaultSecondsPerRep
+	}
+	setDuration := time.Duration(reps*secondsPerRep) * time.Second
+
+	for i := 1; i <= sets; i++ {
+		b.add(Segment{
+			Label:            intervalLabel("Set", i, ""),
+			Phase:            PhaseWork,
+			Duration:         setDuration,
+			IntensityPercent: intensityPercent1RM,
+			Reps:             reps,
+		})
+		if i < sets && restBetweenSets > 0 {
+			b.add(Segment{
+				Label:            intervalLabel("Set", i, "rest"),
+				Phase:            PhaseRest,
+				Duration:         restBetweenSets,
+				IntensityPercent: 0,
+			})
+		}
+	}
+	return b
+}
+
+// Circuit appends rounds of stations performed back to back, with rest between rounds
+func (b *Builder) Circuit(rounds int, stations []Segment, restBetweenRounds time.Duration) *Builder {
+	for i := 1; i <= rounds; i++ {
+		for _, station := range stations {
+			station.Phase = PhaseWork
+			station.Label = intervalLabel("Round", i, station.Label)
+			b.add(station)
+		}
+		if i < rounds && restBetweenRounds > 0 {
+			b.add(Segment{
+				Label:    intervalLabel("Round", i, "rest"),
+				Phase:    PhaseRest,
+				Duration: restBetweenRounds,
+			})
+		}
+	}
+	return b
+}
+
+// Rest appends a standalone rest segment
+func (b *Builder) Rest(duration time.Duration, intensityPercent float64) *Builder {
+	return b.add(Segment{
+		Label:            PhaseRest,
+		Phase:            PhaseRest,
+		Duration:         duration,
+		IntensityPercent: intensityPercent,
+	})
+}
+
+// CoolDown appends a cool-down segment
+func (b *Builder) CoolDown(duration time.Duration, intensityPercent float64) *Builder {
+	return b.add(Segment{
+		Label:            PhaseCoolDown,
+		Phase:            PhaseCoolDown,
+		Duration:         duration,
+		IntensityPercent: intensityPercent,
+	})
+}
+
+// Build returns the composed session
+func (b *Builder) Build() Session {
+	segments := make([]Segment, len(b.session.Segments))
+	copy(segments, b.session.Segments)
+	return Session{
+		Name:         b.session.Name,
+		ExerciseType: b.session.ExerciseType,
+		Segments:     segments,
+	}
+}
+
+func (b *Builder) add(seg Segment) *Builder {
+	if seg.Duration > 0 {
+		b.session.Segments = append(b.session.Segments, seg)
+	}
+	return b
+}
+
+func intervalLabel(prefix string, index int, suffix string) string {
+	label := prefix + " " + strconv.Itoa(index)
+	if suffix != "" {
+		label += " " + suffix
+	}
+	return label
+}
+
+// ContinuousSession wraps a single steady-state bout, matching the
+// intensity/duration pair used by the older predictors
+func ContinuousSession(exerciseType string, intensityPercent float64, durationMinutes float64) Session {
+	return NewBuilder(exerciseType, exerciseType).
+		Continuous(time.Duration(durationMinutes*float64(time.Minute)), intensityPercent).
+		Build()
+}
+
+// FourByFourHIIT is the Norwegian 4x4 protocol: 4 min at ~90% HRmax with 3 min active recovery
+func FourByFourHIIT() Session {
+	return NewBuilder("4x4 HIIT", "HIIT").
+		WarmUp(10*time.Minute, 60).
+		Intervals(4, 4*time.Minute, 90, 3*time.Minute, 65).
+		CoolDown(5*time.Minute, 50).
+		Build()
+}
+
+// TenByOneSprint is 10 x 1 min hard efforts with 1 min easy recovery
+func TenByOneSprint() Session {
+	return NewBuilder("10x1 Sprint Intervals", "Sprint").
+		WarmUp(5*time.Minute, 55).
+		Intervals(10, time.Minute, 95, time.Minute, 40).
+		CoolDown(5*time.Minute, 45).
+		Build()
+}
+
+// FiveByFiveSquat is 5 sets of 5 back squats at ~85% 1RM with 3 min rest
+func FiveByFiveSquat() Session {
+	return NewBuilder("5x5 Squat", "Resistance").
+		WarmUp(5*time.Minute, 40).
+		Sets(5, 5, 85, 3*time.Minute, 0).
+		CoolDown(5*time.Minute, 30).
+		Build()
+}
+
+// ForExerciseType returns a representative session for an exercise type name as used
+// by the component packages ("Aerobic", "Endurance", "HIIT", "Sprint", "Resistance")
+func ForExerciseType(exerciseType string) Session {
+	switch exerciseType {
+	case "HIIT":
+		return FourByFourHIIT()
+	case "Sprint", "SIT":
+		return TenByOneSprint()
+	case "Resistance", "Strength":
+		return FiveByFiveSquat()
+	default:
+		return ContinuousSession(exerciseType, 65, 45)
+	}
+}
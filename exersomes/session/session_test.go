@@ -0,0 +1,109 @@
+package session
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Test the 4x4 protocol's segment layout and the durations and load derived from it
+func TestFourByFourHIIT(t *testing.T) {
+	s := FourByFourHIIT()
+	// Warm-up, four work intervals, three rests and a cool-down
+	if len(s.Segments) != 9 {
+		t.Fatalf("Expected 9 segments, got %d", len(s.Segments))
+	}
+	if got := s.TotalDuration(); got != 40*time.Minute {
+		t.Errorf("Expected 40 min in total, got %v", got)
+	}
+	if got := s.WorkDuration(); got != 16*time.Minute {
+		t.Errorf("Expected 16 min of work, got %v", got)
+	}
+	if last := s.Segments[len(s.Segments)-2]; last.Phase != PhaseWork {
+		t.Errorf("Expected no rest after the final interval, got %s", last.Label)
+	}
+	if got := s.PeakIntensity(); got != 90 {
+		t.Errorf("Expected a peak of 90%%, got %g", got)
+	}
+	// 10x60 + 16x90 + 9x65 + 5x50 %·min
+	if got := s.Load(); got != 2875 {
+		t.Errorf("Expected a load of 2875 %%·min, got %g", got)
+	}
+	if got := s.MeanIntensity(); got != 2875.0/40 {
+		t.Errorf("Expected the time-weighted mean intensity, got %g", got)
+	}
+}
+
+// Test scaling keeps the protocol's shape while matching the requested peak and length
+func TestScaled(t *testing.T) {
+	s := FourByFourHIIT()
+	scaled := s.Scaled(81, 20)
+	if got := scaled.TotalDuration(); got != 20*time.Minute {
+		t.Errorf("Expected 20 min in total, got %v", got)
+	}
+	if got := scaled.PeakIntensity(); math.Abs(got-81) > 1e-9 {
+		t.Errorf("Expected a peak of 81%%, got %g", got)
+	}
+	if got := scaled.WorkDuration(); got != 8*time.Minute {
+		t.Errorf("Expected work to stay 40%% of the session, got %v", got)
+	}
+	if len(scaled.Segments) != len(s.Segments) || s.TotalDuration() != 40*time.Minute || s.PeakIntensity() != 90 {
+		t.Errorf("Expected the original session to be unchanged")
+	}
+	if got := ContinuousSession("Aerobic", 65, 45).Scaled(70, 30); got.PeakIntensity() != 70 || got.TotalDuration() != 30*time.Minute {
+		t.Errorf("Expected a continuous bout to match ContinuousSession, got %+v", got)
+	}
+}
+
+// Test segment lookup at boundaries, before the start and after the end
+func TestSegmentAt(t *testing.T) {
+	s := FourByFourHIIT()
+	for _, tt := range []struct {
+		at    time.Duration
+		phase string
+		ok    bool
+	}{
+		{0, PhaseWarmUp, true},
+		{10 * time.Minute, PhaseWork, true},
+		{14 * time.Minute, PhaseRest, true},
+		{17 * time.Minute, PhaseWork, true},
+		{39 * time.Minute, PhaseCoolDown, true},
+		{40 * time.Minute, "", false},
+		{-time.Second, "", false},
+	} {
+		seg, ok := s.SegmentAt(tt.at)
+		if ok != tt.ok || seg.Phase != tt.phase {
+			t.Errorf("At %v: expected %q (%v), got %q (%v)", tt.at, tt.phase, tt.ok, seg.Phase, ok)
+		}
+	}
+	if got := s.IntensityAt(15 * time.Minute); got != 65 {
+		t.Errorf("Expected 65%% during active recovery, got %g", got)
+	}
+	if got := s.IntensityAt(time.Hour); got != 0 {
+		t.Errorf("Expected zero intensity after the session, got %g", got)
+	}
+}
+
+// Test sets derive their duration from reps, rest carries no load, and empty
+// segments are dropped
+func TestSets(t *testing.T) {
+	s := NewBuilder("Sets", "Resistance").
+		WarmUp(0, 50).
+		Sets(3, 10, 80, 2*time.Minute, 3).
+		Build()
+	if len(s.Segments) != 5 {
+		t.Fatalf("Expected 3 sets and 2 rests, got %d segments", len(s.Segments))
+	}
+	if s.Segments[0].Duration != 30*time.Second || s.Segments[1].Phase != PhaseRest {
+		t.Errorf("Unexpected first segments %+v", s.Segments[:2])
+	}
+	if got := s.TotalReps(); got != 30 {
+		t.Errorf("Expected 30 reps, got %d", got)
+	}
+	if got := s.Load(); got != 80*1.5 {
+		t.Errorf("Expected the rest to add no load, got %g", got)
+	}
+	if got := NewBuilder("Sets", "Resistance").Sets(1, 5, 85, time.Minute, 0).Build().TotalDuration(); got != 20*time.Second {
+		t.Errorf("Expected %d s per rep by default, got %v", DefaultSecondsPerRep, got)
+	}
+}
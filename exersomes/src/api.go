@@ -1,14 +1,43 @@
 // src/api.go
-package main
+package src
 
 import (
     "net/http"
     "encoding/json"
-    "github.com/gomezdj/exersomes/molecular_types"
+    "exersomes/api"
+    "exersomes/molecular_types"
+    "exersomes/network"
 )
 
+// NewAPIHandler returns the versioned REST API (see `exersomes serve`)
+func NewAPIHandler() http.Handler {
+    ligands := append(network.CatalogLigands(), network.ExerkineLigands(StoredExerkines())...)
+    return api.NewServer(network.MergeLigandEntries(ligands), BuildNetwork(nil))
+}
+
+func GetAllExerkines() []molecular_types.Exerkine {
+    // Every ligand in the component catalog and the store, merged across packages
+    return BuildNetwork(nil).Nodes
+}
+
+func GetExerkinesByTissue(tissue string) []molecular_types.Exerkine {
+    // Ligands secreted by the tissue
+    tissue = network.NormalizeTissue(tissue)
+    var exerkines []molecular_types.Exerkine
+    for _, e := range GetAllExerkines() {
+        for _, source := range e.TissueSources {
+            if source == tissue {
+                exerkines = append(exerkines, e)
+                break
+            }
+        }
+    }
+    return exerkines
+}
+
 func ExerkineHandler(w http.ResponseWriter, r *http.Request) {
-    exerkines := GetAllExerkines() // Would load from database/files
+    exerkines := GetAllExerkines()
+    w.Header().Set("Content-Type", "application/json")
     json.NewEncoder(w).Encode(exerkines)
 }
 
@@ -16,5 +45,6 @@ func NetworkHandler(w http.ResponseWriter, r *http.Request) {
     tissue := r.URL.Query().Get("tissue")
     exerkines := GetExerkinesByTissue(tissue)
     network := BuildNetwork(exerkines)
+    w.Header().Set("Content-Type", "application/json")
     json.NewEncoder(w).Encode(network)
 }
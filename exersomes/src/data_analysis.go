@@ -1,22 +1,51 @@
 // src/data_analysis.go
-package main
+package src
 
 import (
-    "github.com/gomezdj/exersomes/molecular_types"
+    "exersomes/graph"
+    "exersomes/molecular_types"
+    "exersomes/network"
 )
 
 func BuildNetwork(exerkines []molecular_types.Exerkine) molecular_types.ExerkineNetwork {
-    network := molecular_types.ExerkineNetwork{
-        Nodes: exerkines,
+    // Match ligands to receptors across every component package, plus the
+    // receptors named on the stored exerkines and those passed in
+    return network.Build(append(StoredExerkines(), exerkines...))
+}
+
+func BuildNetworkWithDatabases(exerkines []molecular_types.Exerkine, files network.DatabaseFiles) (molecular_types.ExerkineNetwork, error) {
+    // Confirm curated edges against CellPhoneDB, CellChatDB and OmniPath exports
+    // and add database-only receptors for the curated exerkines
+    records, err := network.LoadDatabases(files)
+    if err != nil {
+        return molecular_types.ExerkineNetwork{}, err
+    }
+    return network.MergeDatabases(BuildNetwork(exerkines), records), nil
+}
+
+func FindKeyRegulators(net molecular_types.ExerkineNetwork) []string {
+    // Rank ligands by the composite of degree, betweenness, eigenvector and PageRank
+    rankings, err := graph.KeyRegulators(net, graph.MethodComposite, nil)
+    if err != nil {
+        return []string{}
     }
-    
-    // Implement interaction detection logic
-    // Could integrate with CellPhoneDB or other databases
-    
-    return network
+    return rankingNames(rankings)
 }
 
-func FindKeyRegulators(network molecular_types.ExerkineNetwork) []string {
-    // Implement network analysis to find hubs
-    return []string{}
+func FindKeyRegulatorsForExercise(net molecular_types.ExerkineNetwork, exerciseType string) []string {
+    // Weight ligands by their predicted response to the exercise type
+    relevance := network.NodeRelevance(net, exerciseType)
+    rankings, err := graph.KeyRegulators(net, graph.MethodComposite, relevance)
+    if err != nil {
+        return []string{}
+    }
+    return rankingNames(rankings)
+}
+
+func rankingNames(rankings []graph.Ranking) []string {
+    names := make([]string, 0, len(rankings))
+    for _, r := range rankings {
+        names = append(names, r.Node.Name)
+    }
+    return names
 }
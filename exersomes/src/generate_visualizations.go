@@ -1,19 +1,46 @@
 // src/generate_visualizations.go
-package main
+package src
 
 import (
-    "os"
-    "os/exec"
-    "github.com/gomezdj/exersomes/molecular_types"
+    "exersomes/export"
+    "exersomes/graph"
+    "exersomes/molecular_types"
+    "exersomes/visualize"
 )
 
 func GenerateHeatmap(network molecular_types.ExerkineNetwork, outputPath string) error {
-    // Implement or call out to R/Python for visualization
-    cmd := exec.Command("python", "scripts/generate_heatmap.py", outputPath)
-    return cmd.Run()
+    // Exerkine × source tissue log2 fold change after an aerobic session; the
+    // extension of outputPath (.svg or .png) picks the format
+    network = networkOrStored(network)
+    return visualize.ExerkineTissueHeatmap(network, "Aerobic").Figure().WriteFile(outputPath)
 }
 
 func GenerateNetworkGraph(network molecular_types.ExerkineNetwork, outputPath string) error {
-    // Generate Cytoscape-compatible files or render directly
-    return nil
+    // Force-directed layout of source tissues, ligands, receptors and target tissues
+    network = networkOrStored(network)
+    g := graph.FromNetwork(network)
+    positions := visualize.ForceLayout(g, visualize.LayoutOptions{Seed: 1})
+    return visualize.NetworkFigure(g, positions, "Exerkine network").WriteFile(outputPath)
+}
+
+func GenerateTimeCourse(exerciseType string, intensityPercent float64, durationMinutes float64, outputPath string) error {
+    // Circulating factors during a session, as fold change from rest
+    chart := visualize.CirculatingTimeCourse(exerciseType, intensityPercent, durationMinutes, 25)
+    return chart.Figure().WriteFile(outputPath)
+}
+
+func ExportNetwork(network molecular_types.ExerkineNetwork, outputPath string) error {
+    // Cytoscape (.cyjs, .cx2), GraphML, GEXF or SIF by the extension of outputPath,
+    // with regulation after an aerobic session and force-directed layout hints
+    network = networkOrStored(network)
+    g := export.FromNetwork(network, export.Options{Layout: visualize.LayoutOptions{Seed: 1}})
+    return export.WriteFile(outputPath, g)
+}
+
+func networkOrStored(network molecular_types.ExerkineNetwork) molecular_types.ExerkineNetwork {
+    // An empty network means "everything": the catalog plus the stored exerkines
+    if len(network.Nodes) == 0 {
+        return BuildNetwork(nil)
+    }
+    return network
 }
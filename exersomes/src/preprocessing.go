@@ -1,27 +1,31 @@
 // src/preprocessing.go
-package main
+package src
 
 import (
-    "encoding/csv"
-    "os"
-    "log"
+    "exersomes/ingest"
+    "exersomes/molecular_types"
+    "exersomes/normalize"
 )
 
-func LoadExerkineData(path string) ([]Exerkine, error) {
-    file, err := os.Open(path)
-    if err != nil {
-        return nil, err
-    }
-    defer file.Close()
+func LoadExerkineData(path string) ([]molecular_types.Exerkine, error) {
+    // Columns are matched by header: curation sheets use the Exerkine field
+    // names, and retrieval outputs such as gene_references.tsv are recognized.
+    // Valid rows are returned alongside an *ingest.ValidationError listing
+    // each bad row with its line number.
+    return ingest.LoadFile(path, ingest.Options{})
+}
 
-    reader := csv.NewReader(file)
-    reader.Comma = '\t' // For TSV files
-    
-    // Parse CSV/TSV into Exerkine structs
-    // Implementation omitted for brevity
+func ExportExerkineData(path string, exerkines []molecular_types.Exerkine) error {
+    // Writes every field so LoadExerkineData reads the file back unchanged
+    return ingest.ExportFile(path, exerkines, ingest.CurationMapping)
 }
 
-func NormalizeData(exerkines []Exerkine) []Exerkine {
-    // Implement normalization logic
-    return exerkines
+func NormalizeData(measurements []normalize.Measurement, steps ...normalize.Step) (normalize.Dataset, error) {
+    // Without explicit steps, concentrations are converted to the catalog units
+    // and expressed as log2 fold change over the catalog baseline ranges.
+    // Each applied transform is recorded in the dataset metadata.
+    if len(steps) == 0 {
+        steps = []normalize.Step{normalize.CatalogUnits(), normalize.FoldChange(), normalize.Log2(0)}
+    }
+    return normalize.Normalize(measurements, normalize.CatalogBaselines(), steps...)
 }
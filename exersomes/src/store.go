@@ -0,0 +1,42 @@
+// src/store.go
+package src
+
+import (
+    "sync"
+
+    "exersomes/molecular_types"
+    "exersomes/store"
+)
+
+// StorePath is the embedded database the analysis, API and visualization code read from
+var StorePath = store.DefaultPath
+
+var (
+    storeOnce sync.Once
+    storeDB   *store.Store
+    storeErr  error
+)
+
+func OpenStore() (*store.Store, error) {
+    // Opened read-only once and shared; the retrieval pipeline and
+    // `exersomes import` create and migrate the database, so a missing
+    // file is an error here rather than being created
+    storeOnce.Do(func() {
+        storeDB, storeErr = store.OpenReadOnly(StorePath)
+    })
+    return storeDB, storeErr
+}
+
+func StoredExerkines() []molecular_types.Exerkine {
+    // Curated exerkines written by the retrieval pipeline or `exersomes import`;
+    // empty until the store has been populated
+    db, err := OpenStore()
+    if err != nil {
+        return nil
+    }
+    exerkines, err := db.Exerkines()
+    if err != nil {
+        return nil
+    }
+    return exerkines
+}
@@ -0,0 +1,43 @@
+package src
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "exersomes/molecular_types"
+    "exersomes/store"
+)
+
+// Test the analysis and API entry points read exerkines from the shared store
+func TestStoredExerkines(t *testing.T) {
+    StorePath = filepath.Join(t.TempDir(), "exersomes.db")
+    s, err := store.Open(StorePath)
+    if err != nil {
+        t.Fatal(err)
+    }
+    stored := molecular_types.Exerkine{Name: "Storekine", Category: "Myokine", TissueSources: []string{"Skeletal muscle"}}
+    if err := s.UpsertExerkine(stored); err != nil {
+        t.Fatal(err)
+    }
+    s.Close()
+
+    if got := StoredExerkines(); len(got) != 1 || got[0].Name != "Storekine" {
+        t.Fatalf("Expected the stored exerkine, got %+v", got)
+    }
+    found := false
+    for _, e := range GetExerkinesByTissue("Muscle") {
+        found = found || e.Name == "Storekine"
+    }
+    if !found {
+        t.Error("Expected the stored exerkine among muscle exerkines")
+    }
+
+    rec := httptest.NewRecorder()
+    NewAPIHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/exerkines?tissue=Muscle&page_size=100", nil))
+    if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Storekine") {
+        t.Errorf("Expected the API to list the stored exerkine, got %d", rec.Code)
+    }
+}
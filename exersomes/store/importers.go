@@ -0,0 +1,291 @@
+package store
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Files written by the retrieval pipeline, relative to its working directory
+const (
+	GeneReferencesFile     = "gene_references.tsv"
+	ProteinInfoFile        = "protein_info.tsv"
+	ProteinSequencesFile   = "protein_sequences.fasta"
+	PathwayMapsFile        = "pathway_maps.tsv"
+	FunctionalInsightsFile = "functional_insights.tsv"
+)
+
+// tsvRows reads a headered TSV and calls row with each record as a column map.
+// Row errors are reported with their line number.
+func tsvRows(r io.Reader, required []string, row func(line int, get func(string) string) error) error {
+	reader := csv.NewReader(r)
+	reader.Comma = '\t'
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(h, "\uFEFF"))] = i
+	}
+	for _, c := range required {
+		if _, ok := columns[c]; !ok {
+			return fmt.Errorf("missing column %q", c)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		get := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if err := row(line, get); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+// ImportGeneReferences upserts gene_references.tsv rows and returns the number imported
+func (s *Store) ImportGeneReferences(r io.Reader) (int, error) {
+	n := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tsvRows(r, []string{"Query", "Symbol"}, func(line int, get func(string) string) error {
+			g := Gene{
+				Symbol:      get("Symbol"),
+				GeneID:      get("Gene_ID"),
+				Query:       get("Query"),
+				Description: get("Description"),
+				Chromosome:  get("Chromosome"),
+				MapLocation: get("MapLocation"),
+			}
+			if err := putJSON(tx, genesBucket, g.key(), g); err != nil {
+				return err
+			}
+			n++
+			return nil
+		})
+	})
+	return n, err
+}
+
+// ImportProteinInfo upserts protein_info.tsv rows and returns the number imported
+func (s *Store) ImportProteinInfo(r io.Reader) (int, error) {
+	n := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tsvRows(r, []string{"Query", "Protein_ID", "Accession"}, func(line int, get func(string) string) error {
+			p := Protein{
+				Accession: get("Accession"),
+				ProteinID: get("Protein_ID"),
+				Query:     get("Query"),
+				Name:      get("Name"),
+			}
+			if v := get("Length"); v != "" {
+				length, err := strconv.Atoi(v)
+				if err != nil {
+					return fmt.Errorf("invalid Length %q", v)
+				}
+				p.Length = length
+			}
+			if v := get("Molecular_Weight"); v != "" {
+				mw, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return fmt.Errorf("invalid Molecular_Weight %q", v)
+				}
+				p.MolecularWeight = mw
+			}
+			if err := upsertProtein(tx, p); err != nil {
+				return err
+			}
+			n++
+			return nil
+		})
+	})
+	return n, err
+}
+
+// ImportPathwayMaps upserts pathway_maps.tsv rows, merging members of the same
+// pathway, and returns the number of rows imported
+func (s *Store) ImportPathwayMaps(r io.Reader) (int, error) {
+	n := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tsvRows(r, []string{"Gene", "Pathway_ID"}, func(line int, get func(string) string) error {
+			p := Pathway{
+				ID:      get("Pathway_ID"),
+				Name:    get("Pathway_Name"),
+				Source:  get("Pathway_Source"),
+				Members: []PathwayMember{{Gene: get("Gene"), Role: get("Gene_Role")}},
+			}
+			if err := upsertPathway(tx, p); err != nil {
+				return err
+			}
+			n++
+			return nil
+		})
+	})
+	return n, err
+}
+
+// ImportFunctionalInsights upserts functional_insights.tsv rows and returns the number imported
+func (s *Store) ImportFunctionalInsights(r io.Reader) (int, error) {
+	n := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tsvRows(r, []string{"Gene", "Function_Type", "Description"}, func(line int, get func(string) string) error {
+			p := Publication{
+				Gene:         get("Gene"),
+				FunctionType: get("Function_Type"),
+				Description:  get("Description"),
+				Evidence:     get("Evidence"),
+				Reference:    get("Reference_PMID"),
+			}
+			if err := putJSON(tx, publicationsBucket, p.key(), p); err != nil {
+				return err
+			}
+			n++
+			return nil
+		})
+	})
+	return n, err
+}
+
+// ParseFASTAHeader splits a header (without ">") into a sequence ID, query gene and
+// description. Pipeline headers are "gene|gi|accession|name"; others are "id description".
+func ParseFASTAHeader(header string) (id, query, description string) {
+	if parts := strings.Split(header, "|"); len(parts) == 4 {
+		id = parts[2]
+		if id == "" {
+			id = parts[1]
+		}
+		return id, parts[0], parts[3]
+	}
+	id, description, _ = strings.Cut(header, " ")
+	return id, "", strings.TrimSpace(description)
+}
+
+// ReadFASTA calls fn for every sequence in a FASTA file, in file order
+func ReadFASTA(r io.Reader, fn func(Sequence) error) error {
+	var current *Sequence
+	var residues strings.Builder
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		current.Residues = residues.String()
+		residues.Reset()
+		return fn(*current)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, ";"):
+			continue
+		case strings.HasPrefix(text, ">"):
+			if err := flush(); err != nil {
+				return err
+			}
+			id, query, description := ParseFASTAHeader(strings.TrimPrefix(text, ">"))
+			if id == "" {
+				return fmt.Errorf("line %d: FASTA header has no ID", line)
+			}
+			current = &Sequence{ID: id, Query: query, Description: description}
+		default:
+			if current == nil {
+				return fmt.Errorf("line %d: sequence data before the first header", line)
+			}
+			residues.WriteString(text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// WriteFASTA writes one FASTA record, wrapping residues at 60 columns
+func WriteFASTA(w io.Writer, header, residues string) error {
+	if _, err := fmt.Fprintf(w, ">%s\n", header); err != nil {
+		return err
+	}
+	for len(residues) > 0 {
+		n := min(60, len(residues))
+		if _, err := fmt.Fprintln(w, residues[:n]); err != nil {
+			return err
+		}
+		residues = residues[n:]
+	}
+	return nil
+}
+
+// ImportFASTA upserts every sequence in a FASTA file and returns the number imported
+func (s *Store) ImportFASTA(r io.Reader) (int, error) {
+	n := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return ReadFASTA(r, func(seq Sequence) error {
+			n++
+			return putJSON(tx, sequencesBucket, seq.ID, &seq)
+		})
+	})
+	return n, err
+}
+
+// ImportDir imports every pipeline output file present in dir and returns the
+// number of records imported per file. Missing files are skipped.
+func (s *Store) ImportDir(dir string) (map[string]int, error) {
+	importers := []struct {
+		file string
+		run  func(io.Reader) (int, error)
+	}{
+		{GeneReferencesFile, s.ImportGeneReferences},
+		{ProteinInfoFile, s.ImportProteinInfo},
+		{ProteinSequencesFile, s.ImportFASTA},
+		{PathwayMapsFile, s.ImportPathwayMaps},
+		{FunctionalInsightsFile, s.ImportFunctionalInsights},
+	}
+
+	counts := make(map[string]int)
+	for _, imp := range importers {
+		path := filepath.Join(dir, imp.file)
+		f, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return counts, err
+		}
+		n, err := imp.run(f)
+		f.Close()
+		if err != nil {
+			return counts, fmt.Errorf("importing %s: %w", path, err)
+		}
+		counts[imp.file] = n
+	}
+	return counts, nil
+}
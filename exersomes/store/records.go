@@ -0,0 +1,261 @@
+package store
+
+import (
+	"encoding/json"
+	"exersomes/canonical"
+	"exersomes/molecular_types"
+	"sort"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Gene is a row of gene_references.tsv
+type Gene struct {
+	Symbol      string
+	GeneID      string // NCBI Gene ID
+	Query       string // Name searched for, from exerkines_list.txt
+	Description string
+	Chromosome  string
+	MapLocation string
+}
+
+// Protein is a row of protein_info.tsv
+type Protein struct {
+	Accession       string
+	ProteinID       string // NCBI GI
+	Query           string // Gene name searched for
+	Name            string
+	Length          int
+	MolecularWeight float64
+}
+
+// Sequence is one FASTA record
+type Sequence struct {
+	ID          string // Accession, or the first header token
+	Query       string // Gene name, when the header carries one
+	Description string // Header after the ID
+	Residues    string
+}
+
+// PathwayMember is a gene's membership in a pathway
+type PathwayMember struct {
+	Gene string
+	Role string
+}
+
+// Pathway is a pathway with the genes retrieved as its members
+type Pathway struct {
+	ID      string
+	Name    string
+	Source  string // "KEGG", "Reactome", ...
+	Members []PathwayMember
+}
+
+// Publication is a functional annotation of a gene from PubMed or Gene Ontology
+type Publication struct {
+	Gene         string
+	FunctionType string // "Signaling", "Biological Process", ...
+	Description  string
+	Evidence     string // "Literature" or a GO evidence code
+	Reference    string // PMID or annotation source
+}
+
+// geneKey normalizes symbols so "adipor2" and "ADIPOR2" share a record
+func geneKey(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+func (g Gene) key() string {
+	if g.Symbol != "" {
+		return geneKey(g.Symbol)
+	}
+	return geneKey(g.Query)
+}
+
+func (p Protein) key() string {
+	if p.Accession != "" {
+		return p.Accession
+	}
+	return p.ProteinID
+}
+
+func (p Publication) key() string {
+	return strings.Join([]string{geneKey(p.Gene), p.Reference, p.FunctionType, p.Description}, "\x00")
+}
+
+func exerkineKey(name string) string {
+	return canonical.Ligand(name)
+}
+
+// UpsertGene inserts or replaces a gene
+func (s *Store) UpsertGene(g Gene) error {
+	return s.db.Update(func(tx *bolt.Tx) error { return putJSON(tx, genesBucket, g.key(), g) })
+}
+
+// UpsertProtein inserts or replaces a protein and indexes it under its gene
+func (s *Store) UpsertProtein(p Protein) error {
+	return s.db.Update(func(tx *bolt.Tx) error { return upsertProtein(tx, p) })
+}
+
+func upsertProtein(tx *bolt.Tx, p Protein) error {
+	if err := putJSON(tx, proteinsBucket, p.key(), p); err != nil {
+		return err
+	}
+	return indexProtein(tx.Bucket([]byte(geneProteinsBucket)), p, p.key())
+}
+
+// indexProtein records key under the protein's gene; keys are stored as
+// gene\x00protein so one gene can have many proteins
+func indexProtein(index *bolt.Bucket, p Protein, key string) error {
+	if p.Query == "" {
+		return nil
+	}
+	return index.Put([]byte(geneKey(p.Query)+"\x00"+key), nil)
+}
+
+// UpsertSequence inserts or replaces a sequence
+func (s *Store) UpsertSequence(seq Sequence) error {
+	return s.db.Update(func(tx *bolt.Tx) error { return putJSON(tx, sequencesBucket, seq.ID, seq) })
+}
+
+// UpsertPathway inserts a pathway or merges its members and non-empty fields
+// into the stored record
+func (s *Store) UpsertPathway(p Pathway) error {
+	return s.db.Update(func(tx *bolt.Tx) error { return upsertPathway(tx, p) })
+}
+
+func upsertPathway(tx *bolt.Tx, p Pathway) error {
+	if v := tx.Bucket([]byte(pathwaysBucket)).Get([]byte(p.ID)); v != nil {
+		var existing Pathway
+		if err := json.Unmarshal(v, &existing); err != nil {
+			return err
+		}
+		if p.Name == "" {
+			p.Name = existing.Name
+		}
+		if p.Source == "" {
+			p.Source = existing.Source
+		}
+		members := existing.Members
+		for _, m := range p.Members {
+			replaced := false
+			for i, e := range members {
+				if geneKey(e.Gene) == geneKey(m.Gene) {
+					members[i] = m
+					replaced = true
+				}
+			}
+			if !replaced {
+				members = append(members, m)
+			}
+		}
+		p.Members = members
+	}
+	sort.Slice(p.Members, func(i, j int) bool { return p.Members[i].Gene < p.Members[j].Gene })
+	return putJSON(tx, pathwaysBucket, p.ID, p)
+}
+
+// UpsertPublication inserts a publication annotation; identical annotations are stored once
+func (s *Store) UpsertPublication(p Publication) error {
+	return s.db.Update(func(tx *bolt.Tx) error { return putJSON(tx, publicationsBucket, p.key(), p) })
+}
+
+// UpsertExerkine inserts or replaces a curated exerkine, keyed by canonical ligand name
+func (s *Store) UpsertExerkine(e molecular_types.Exerkine) error {
+	return s.db.Update(func(tx *bolt.Tx) error { return putJSON(tx, exerkinesBucket, exerkineKey(e.Name), e) })
+}
+
+// UpsertExerkines inserts or replaces curated exerkines in one transaction
+func (s *Store) UpsertExerkines(exerkines []molecular_types.Exerkine) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, e := range exerkines {
+			if err := putJSON(tx, exerkinesBucket, exerkineKey(e.Name), e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Gene returns a gene by symbol
+func (s *Store) Gene(symbol string) (Gene, bool, error) {
+	return getJSON[Gene](s, genesBucket, geneKey(symbol))
+}
+
+// Genes returns every gene, ordered by symbol
+func (s *Store) Genes() ([]Gene, error) {
+	return listJSON[Gene](s, genesBucket, nil)
+}
+
+// Proteins returns every protein, ordered by accession
+func (s *Store) Proteins() ([]Protein, error) {
+	return listJSON[Protein](s, proteinsBucket, nil)
+}
+
+// ProteinsForGene returns the proteins retrieved for a gene
+func (s *Store) ProteinsForGene(symbol string) ([]Protein, error) {
+	var proteins []Protein
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prefix := []byte(geneKey(symbol) + "\x00")
+		records := tx.Bucket([]byte(proteinsBucket))
+		c := tx.Bucket([]byte(geneProteinsBucket)).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, _ = c.Next() {
+			v := records.Get(k[len(prefix):])
+			if v == nil {
+				continue
+			}
+			var p Protein
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+			proteins = append(proteins, p)
+		}
+		return nil
+	})
+	return proteins, err
+}
+
+// Sequence returns a sequence by ID
+func (s *Store) Sequence(id string) (Sequence, bool, error) {
+	return getJSON[Sequence](s, sequencesBucket, id)
+}
+
+// Sequences returns every sequence, ordered by ID
+func (s *Store) Sequences() ([]Sequence, error) {
+	return listJSON[Sequence](s, sequencesBucket, nil)
+}
+
+// Pathways returns every pathway, ordered by ID
+func (s *Store) Pathways() ([]Pathway, error) {
+	return listJSON[Pathway](s, pathwaysBucket, nil)
+}
+
+// PathwaysForGene returns the pathways listing a gene as a member
+func (s *Store) PathwaysForGene(gene string) ([]Pathway, error) {
+	return listJSON(s, pathwaysBucket, func(p Pathway) bool {
+		for _, m := range p.Members {
+			if geneKey(m.Gene) == geneKey(gene) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Publications returns the annotations of a gene, or every annotation for an empty gene
+func (s *Store) Publications(gene string) ([]Publication, error) {
+	return listJSON(s, publicationsBucket, func(p Publication) bool {
+		return gene == "" || geneKey(p.Gene) == geneKey(gene)
+	})
+}
+
+// Exerkine returns a curated exerkine by name or alias
+func (s *Store) Exerkine(name string) (molecular_types.Exerkine, bool, error) {
+	return getJSON[molecular_types.Exerkine](s, exerkinesBucket, exerkineKey(name))
+}
+
+// Exerkines returns every curated exerkine, ordered by canonical name
+func (s *Store) Exerkines() ([]molecular_types.Exerkine, error) {
+	return listJSON[molecular_types.Exerkine](s, exerkinesBucket, nil)
+}
@@ -0,0 +1,220 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DefaultPath is the database file the retrieval pipeline and commands share
+const DefaultPath = "exersomes.db"
+
+// Bucket names
+const (
+	metaBucket         = "meta"
+	genesBucket        = "genes"
+	proteinsBucket     = "proteins"
+	sequencesBucket    = "sequences"
+	pathwaysBucket     = "pathways"
+	publicationsBucket = "publications"
+	exerkinesBucket    = "exerkines"
+	geneProteinsBucket = "gene_proteins" // Index: gene symbol -> protein keys
+)
+
+// schemaVersionKey holds the last applied migration in the meta bucket
+var schemaVersionKey = []byte("schema_version")
+
+// Store is an embedded key-value database of retrieved gene and protein data
+// and curated exerkine records. Values are JSON-encoded records.
+type Store struct {
+	db *bolt.DB
+}
+
+// migration upgrades the schema by one version
+type migration struct {
+	version     int
+	description string
+	up          func(tx *bolt.Tx) error
+}
+
+// migrations are applied in order; append new ones, never edit applied ones
+var migrations = []migration{
+	{1, "create record buckets", func(tx *bolt.Tx) error {
+		for _, name := range []string{genesBucket, proteinsBucket, sequencesBucket, pathwaysBucket,
+			publicationsBucket, exerkinesBucket} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{2, "index proteins by gene", func(tx *bolt.Tx) error {
+		index, err := tx.CreateBucketIfNotExists([]byte(geneProteinsBucket))
+		if err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(proteinsBucket)).ForEach(func(k, v []byte) error {
+			var p Protein
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+			return indexProtein(index, p, string(k))
+		})
+	}},
+}
+
+// SchemaVersion is the version a freshly migrated store reports
+func SchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// Open opens or creates the database at path and applies pending migrations
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening store %s: %w", path, err)
+	}
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// OpenReadOnly opens an existing database at path for reading. It neither
+// creates the file nor migrates it, so the store must be at the current
+// schema version; several readers may hold it open at once.
+func OpenReadOnly(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("opening store %s: %w", path, err)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("opening store %s: %w", path, err)
+	}
+	s := &Store{db: db}
+	if v, err := s.Version(); err != nil || v != SchemaVersion() {
+		db.Close()
+		if err == nil {
+			err = fmt.Errorf("schema version %d, want %d; open it for writing to migrate", v, SchemaVersion())
+		}
+		return nil, fmt.Errorf("opening store %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Close releases the database file
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Version returns the applied schema version
+func (s *Store) Version() (int, error) {
+	var version int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		version = readVersion(tx)
+		return nil
+	})
+	return version, err
+}
+
+func readVersion(tx *bolt.Tx) int {
+	meta := tx.Bucket([]byte(metaBucket))
+	if meta == nil {
+		return 0
+	}
+	v := meta.Get(schemaVersionKey)
+	if len(v) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(v))
+}
+
+// migrate applies each pending migration in its own transaction
+func (s *Store) migrate() error {
+	for _, m := range migrations {
+		err := s.db.Update(func(tx *bolt.Tx) error {
+			if readVersion(tx) >= m.version {
+				return nil
+			}
+			if err := m.up(tx); err != nil {
+				return err
+			}
+			meta, err := tx.CreateBucketIfNotExists([]byte(metaBucket))
+			if err != nil {
+				return err
+			}
+			v := make([]byte, 8)
+			binary.BigEndian.PutUint64(v, uint64(m.version))
+			return meta.Put(schemaVersionKey, v)
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.description, err)
+		}
+	}
+	return nil
+}
+
+// errEmptyKey rejects records without an identifier
+var errEmptyKey = errors.New("record has no key")
+
+func putJSON(tx *bolt.Tx, bucket, key string, v any) error {
+	if key == "" {
+		return errEmptyKey
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(bucket)).Put([]byte(key), data)
+}
+
+// getJSON decodes the record at key; found is false when it does not exist
+func getJSON[T any](s *Store, bucket, key string) (record T, found bool, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket([]byte(bucket)).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(v, &record)
+	})
+	return record, found, err
+}
+
+// listJSON decodes every record in a bucket, in key order, keeping those keep accepts
+func listJSON[T any](s *Store, bucket string, keep func(T) bool) ([]T, error) {
+	var records []T
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucket)).ForEach(func(k, v []byte) error {
+			var record T
+			if err := json.Unmarshal(v, &record); err != nil {
+				return fmt.Errorf("%s/%s: %w", bucket, k, err)
+			}
+			if keep == nil || keep(record) {
+				records = append(records, record)
+			}
+			return nil
+		})
+	})
+	return records, err
+}
+
+// Counts returns the number of records in each record bucket
+func (s *Store) Counts() (map[string]int, error) {
+	counts := make(map[string]int)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		for _, name := range []string{genesBucket, proteinsBucket, sequencesBucket, pathwaysBucket,
+			publicationsBucket, exerkinesBucket} {
+			counts[name] = tx.Bucket([]byte(name)).Stats().KeyN
+		}
+		return nil
+	})
+	return counts, err
+}
@@ -0,0 +1,157 @@
+package store
+
+import (
+	"errors"
+	"exersomes/molecular_types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func openTemp(t *testing.T) (*Store, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s, path
+}
+
+// Test migrations run once and the version survives reopening
+func TestMigrations(t *testing.T) {
+	s, path := openTemp(t)
+	if v, _ := s.Version(); v != SchemaVersion() {
+		t.Fatalf("Expected schema version %d, got %d", SchemaVersion(), v)
+	}
+	if err := s.UpsertProtein(Protein{Accession: "NP_000591.1", Query: "IL6", Length: 212}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	proteins, err := s.ProteinsForGene("il6")
+	if err != nil || len(proteins) != 1 || proteins[0].Length != 212 {
+		t.Errorf("Expected IL6 protein after reopening, got %+v %v", proteins, err)
+	}
+	if proteins, _ := s.ProteinsForGene("IL6R"); len(proteins) != 0 {
+		t.Errorf("Expected no proteins for IL6R, got %+v", proteins)
+	}
+}
+
+// Test read-only opening leaves a missing database uncreated, reads an existing
+// one alongside another reader, and rejects writes
+func TestOpenReadOnly(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.db")
+	if _, err := OpenReadOnly(missing); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a not-exist error, got %v", err)
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Error("Expected OpenReadOnly not to create the database")
+	}
+
+	s, path := openTemp(t)
+	if err := s.UpsertExerkine(molecular_types.Exerkine{Name: "Irisin"}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	first, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	second, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	if exerkines, err := second.Exerkines(); err != nil || len(exerkines) != 1 {
+		t.Errorf("Expected the stored exerkine, got %+v %v", exerkines, err)
+	}
+	if err := first.UpsertExerkine(molecular_types.Exerkine{Name: "IL-6"}); err == nil {
+		t.Error("Expected a write to a read-only store to fail")
+	}
+}
+
+// Test importing every pipeline output from a directory
+func TestImportDir(t *testing.T) {
+	s, _ := openTemp(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		GeneReferencesFile: "Query\tGene_ID\tSymbol\tDescription\tChromosome\tMapLocation\n" +
+			"IL6\t3569\tIL6\tinterleukin 6\t7\t7p15.3\n" +
+			"il6\t3569\tIL6\tinterleukin 6 (duplicate query)\t7\t7p15.3\n",
+		ProteinInfoFile: "Query\tProtein_ID\tAccession\tName\tLength\tMolecular_Weight\n" +
+			"IL6\t10834984\tNP_000591.1\tIL6_HUMAN\t212\t23718.00\n",
+		ProteinSequencesFile: ">IL6|10834984|NP_000591.1|IL6_HUMAN\nMNSFSTSAFG\nPVAFSLGLLL\n",
+		PathwayMapsFile: "Gene\tPathway_ID\tPathway_Name\tPathway_Source\tGene_Role\n" +
+			"IL6\thsa04630\tJAK-STAT signaling pathway\tKEGG\tMember\n" +
+			"STAT3\thsa04630\t\t\tEffector\n",
+		FunctionalInsightsFile: "Gene\tFunction_Type\tDescription\tEvidence\tReference_PMID\n" +
+			"IL6\tExercise Response\tMuscle-derived IL-6 rises with exercise\tLiterature\t12345678\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts, err := s.ImportDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[GeneReferencesFile] != 2 || counts[ProteinSequencesFile] != 1 {
+		t.Errorf("Unexpected import counts %v", counts)
+	}
+
+	totals, _ := s.Counts()
+	if totals[genesBucket] != 1 || totals[pathwaysBucket] != 1 || totals[publicationsBucket] != 1 {
+		t.Errorf("Expected duplicate rows to upsert, got %v", totals)
+	}
+	seq, ok, _ := s.Sequence("NP_000591.1")
+	if !ok || seq.Query != "IL6" || seq.Residues != "MNSFSTSAFGPVAFSLGLLL" {
+		t.Errorf("Unexpected sequence %+v", seq)
+	}
+	pathways, _ := s.PathwaysForGene("stat3")
+	if len(pathways) != 1 || pathways[0].Name != "JAK-STAT signaling pathway" || len(pathways[0].Members) != 2 {
+		t.Errorf("Expected merged JAK-STAT pathway, got %+v", pathways)
+	}
+
+	// Re-importing is idempotent
+	if _, err := s.ImportDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := s.Counts(); again[pathwaysBucket] != 1 || again[publicationsBucket] != 1 {
+		t.Errorf("Expected re-import to leave counts unchanged, got %v", again)
+	}
+}
+
+// Test row errors carry line numbers and curated exerkines resolve aliases
+func TestRowErrorsAndExerkines(t *testing.T) {
+	s, _ := openTemp(t)
+
+	_, err := s.ImportProteinInfo(strings.NewReader(
+		"Query\tProtein_ID\tAccession\tName\tLength\tMolecular_Weight\n" +
+			"IL6\t1\tNP_1\tA\t212\t1.0\n" +
+			"IL6\t2\tNP_2\tB\tlong\t1.0\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected error on line 3, got %v", err)
+	}
+	if proteins, _ := s.Proteins(); len(proteins) != 0 {
+		t.Errorf("Expected failed import to roll back, got %+v", proteins)
+	}
+
+	if err := s.UpsertExerkines([]molecular_types.Exerkine{{Name: "Interleukin-6", Category: "Myokine"}}); err != nil {
+		t.Fatal(err)
+	}
+	if e, ok, _ := s.Exerkine("IL-6"); !ok || e.Category != "Myokine" {
+		t.Errorf("Expected alias lookup of IL-6, got %+v %v", e, ok)
+	}
+}
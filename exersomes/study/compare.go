@@ -0,0 +1,210 @@
+package study
+
+import (
+	"encoding/csv"
+	"exersomes/normalize"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Residual pairs one observation with its prediction, in the predictor's unit
+type Residual struct {
+	Subject     string
+	Session     string
+	Analyte     string // Predictor analyte name
+	Tissue      string
+	TimeMinutes float64
+	Observed    float64
+	Predicted   float64
+	Residual    float64 // Observed - Predicted
+	Unit        string
+}
+
+// Metrics summarize the fit of one predictor to the observations
+type Metrics struct {
+	Analyte     string
+	Tissue      string
+	Unit        string
+	Source      string
+	N           int
+	RMSE        float64
+	Bias        float64 // Mean residual; positive when the model underpredicts
+	Correlation float64 // Pearson r of observed and predicted; NaN when undefined
+}
+
+// Unmatched is an observation no predictor could be compared with
+type Unmatched struct {
+	Observation Observation
+	Reason      string
+}
+
+// Comparison is the result of checking predictors against a study
+type Comparison struct {
+	Metrics   []Metrics
+	Residuals []Residual
+	Unmatched []Unmatched
+}
+
+// Compare runs the matching predictor for every observed analyte of each session
+// and reports fit metrics per analyte and tissue. Observations are converted to
+// the predictor's unit; those without a predictor or a convertible unit are
+// listed as unmatched. A nil predictors slice uses Predictors.
+func Compare(st Study, predictors []Predictor) Comparison {
+	if predictors == nil {
+		predictors = Predictors()
+	}
+
+	type group struct {
+		predictor Predictor
+		subject   Subject
+		session   Session
+		obs       []Observation
+		observed  []float64
+	}
+	var (
+		cmp    Comparison
+		groups = make(map[string]*group)
+		order  []string
+	)
+	for _, o := range st.Observations {
+		p, ok := FindPredictor(predictors, o.Analyte, o.Tissue)
+		if !ok {
+			cmp.Unmatched = append(cmp.Unmatched, Unmatched{Observation: o, Reason: fmt.Sprintf("no predictor for %s in %s", o.Analyte, o.Tissue)})
+			continue
+		}
+		value, err := normalize.Convert(o.Value, o.Unit, p.Unit, p.MolecularWeightDa)
+		if err != nil {
+			cmp.Unmatched = append(cmp.Unmatched, Unmatched{Observation: o, Reason: err.Error()})
+			continue
+		}
+		sess, _ := st.Session(o.Subject, o.Session)
+		subject, _ := st.Subject(o.Subject)
+
+		key := o.Subject + "\x00" + o.Session + "\x00" + p.Analyte + "\x00" + p.Tissue
+		g, ok := groups[key]
+		if !ok {
+			g = &group{predictor: p, subject: subject, session: sess}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.obs = append(g.obs, o)
+		g.observed = append(g.observed, value)
+	}
+
+	byPredictor := make(map[string][]Residual)
+	var predictorOrder []string
+	sources := make(map[string]string)
+	for _, key := range order {
+		g := groups[key]
+		minutes := make([]float64, len(g.obs))
+		for i, o := range g.obs {
+			minutes[i] = o.TimeMinutes
+		}
+		predicted := g.predictor.Predict(g.session.Protocol(), g.subject, minutes)
+
+		pkey := g.predictor.Analyte + "\x00" + g.predictor.Tissue
+		if _, ok := byPredictor[pkey]; !ok {
+			predictorOrder = append(predictorOrder, pkey)
+			sources[pkey] = g.predictor.Source
+		}
+		for i, o := range g.obs {
+			r := Residual{
+				Subject:     o.Subject,
+				Session:     o.Session,
+				Analyte:     g.predictor.Analyte,
+				Tissue:      g.predictor.Tissue,
+				TimeMinutes: o.TimeMinutes,
+				Observed:    g.observed[i],
+				Predicted:   predicted[i],
+				Residual:    g.observed[i] - predicted[i],
+				Unit:        g.predictor.Unit,
+			}
+			byPredictor[pkey] = append(byPredictor[pkey], r)
+			cmp.Residuals = append(cmp.Residuals, r)
+		}
+	}
+
+	for _, pkey := range predictorOrder {
+		residuals := byPredictor[pkey]
+		m := fit(residuals)
+		m.Analyte, m.Tissue, m.Unit, m.Source = residuals[0].Analyte, residuals[0].Tissue, residuals[0].Unit, sources[pkey]
+		cmp.Metrics = append(cmp.Metrics, m)
+	}
+	// Worst fits first, relative to the scale of the analyte
+	sort.SliceStable(cmp.Metrics, func(i, j int) bool {
+		return relativeRMSE(cmp.Metrics[i], byPredictor) > relativeRMSE(cmp.Metrics[j], byPredictor)
+	})
+	return cmp
+}
+
+// fit computes RMSE, bias and Pearson correlation over residuals
+func fit(residuals []Residual) Metrics {
+	n := float64(len(residuals))
+	var sumSq, sumRes, meanObs, meanPred float64
+	for _, r := range residuals {
+		sumSq += r.Residual * r.Residual
+		sumRes += r.Residual
+		meanObs += r.Observed / n
+		meanPred += r.Predicted / n
+	}
+	var cov, varObs, varPred float64
+	for _, r := range residuals {
+		cov += (r.Observed - meanObs) * (r.Predicted - meanPred)
+		varObs += (r.Observed - meanObs) * (r.Observed - meanObs)
+		varPred += (r.Predicted - meanPred) * (r.Predicted - meanPred)
+	}
+	correlation := math.NaN()
+	if varObs > 0 && varPred > 0 {
+		correlation = cov / math.Sqrt(varObs*varPred)
+	}
+	return Metrics{N: len(residuals), RMSE: math.Sqrt(sumSq / n), Bias: sumRes / n, Correlation: correlation}
+}
+
+func relativeRMSE(m Metrics, byPredictor map[string][]Residual) float64 {
+	var mean float64
+	residuals := byPredictor[m.Analyte+"\x00"+m.Tissue]
+	for _, r := range residuals {
+		mean += math.Abs(r.Observed) / float64(len(residuals))
+	}
+	if mean == 0 {
+		return m.RMSE
+	}
+	return m.RMSE / mean
+}
+
+// WriteMetrics writes the metrics as a TSV table
+func (c Comparison) WriteMetrics(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = '\t'
+	writer.Write([]string{"Analyte", "Tissue", "Unit", "Source", "N", "RMSE", "Bias", "Correlation"})
+	for _, m := range c.Metrics {
+		writer.Write([]string{m.Analyte, m.Tissue, m.Unit, m.Source, strconv.Itoa(m.N),
+			formatFloat(m.RMSE), formatFloat(m.Bias), formatFloat(m.Correlation)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteResiduals writes one row per compared observation as a TSV table, for
+// residual-versus-time and observed-versus-predicted plots
+func (c Comparison) WriteResiduals(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = '\t'
+	writer.Write([]string{"Subject", "Session", "Analyte", "Tissue", "Time_min", "Observed", "Predicted", "Residual", "Unit"})
+	for _, r := range c.Residuals {
+		writer.Write([]string{r.Subject, r.Session, r.Analyte, r.Tissue, formatFloat(r.TimeMinutes),
+			formatFloat(r.Observed), formatFloat(r.Predicted), formatFloat(r.Residual), r.Unit})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatFloat(v float64) string {
+	if math.IsNaN(v) {
+		return "NA"
+	}
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
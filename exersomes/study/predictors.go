@@ -0,0 +1,133 @@
+package study
+
+import (
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/immune"
+	"exersomes/network"
+	"exersomes/normalize"
+	"exersomes/session"
+	"math"
+	"strings"
+	"time"
+)
+
+// Predictor simulates one analyte in one tissue over a session. Predict returns
+// values in Unit at each time point, in minutes from session start.
+type Predictor struct {
+	Analyte           string
+	Tissue            string
+	Unit              string
+	MolecularWeightDa float64 // For converting observations in mass or molar units
+	Source            string  // Model the prediction comes from
+	Predict           func(s session.Session, subject Subject, minutes []float64) []float64
+}
+
+// Predictors returns the circulating factor, immune cell and cytokine models.
+// Analytes covered by more than one model use the first: circulating factors
+// are solved over the session, cytokines only have an acute response.
+func Predictors() []Predictor {
+	var predictors []Predictor
+	seen := make(map[string]bool)
+	add := func(p Predictor) {
+		key := network.CanonicalLigand(p.Analyte)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		predictors = append(predictors, p)
+	}
+
+	baselines := normalize.CatalogBaselines()
+	for _, cf := range bloodstream.GetExerciseResponsiveFactors() {
+		model := cf.Model()
+		b, _ := normalize.FindBaseline(baselines, cf.Name)
+		add(Predictor{
+			Analyte:           cf.Name,
+			Tissue:            DefaultTissue,
+			Unit:              cf.BaselineRange.Unit,
+			MolecularWeightDa: b.MolecularWeightDa,
+			Source:            "bloodstream.PharmacokineticModel",
+			Predict: func(s session.Session, _ Subject, minutes []float64) []float64 {
+				timePoints := make([]time.Duration, len(minutes))
+				for i, m := range minutes {
+					timePoints[i] = time.Duration(math.Max(m, 0) * float64(time.Minute))
+				}
+				return model.Simulate(s, timePoints)
+			},
+		})
+	}
+
+	for _, cell := range bloodstream.GetImmuneCellsAffectedByExercise() {
+		add(Predictor{
+			Analyte: cell.Name,
+			Tissue:  DefaultTissue,
+			Unit:    cell.BaselineCount.Unit,
+			Source:  "bloodstream.CalculateImmuneSessionResponse",
+			Predict: func(s session.Session, _ Subject, minutes []float64) []float64 {
+				values := make([]float64, len(minutes))
+				for i, m := range minutes {
+					values[i] = bloodstream.CalculateImmuneSessionResponse(cell, s, math.Max(m, 0))
+				}
+				return values
+			},
+		})
+	}
+
+	for _, c := range immune.GetAcutelyUpregulatedCytokines() {
+		add(Predictor{
+			Analyte:           c.Name,
+			Tissue:            DefaultTissue,
+			Unit:              c.ConcentrationRange.Baseline.Unit,
+			MolecularWeightDa: c.MolecularWeight * 1000,
+			Source:            "immune.CalculateAcuteResponse",
+			Predict: func(s session.Session, subject Subject, minutes []float64) []float64 {
+				return cytokineTimeCourse(c, s, subject, minutes)
+			},
+		})
+	}
+	return predictors
+}
+
+// cytokineTimeCourse spreads CalculateAcuteResponse over time: the fold change
+// builds linearly during the session, peaks at its end and the excess clears
+// with the cytokine's half-life. Trained subjects start from the trained baseline.
+func cytokineTimeCourse(c immune.Cytokine, s session.Session, subject Subject, minutes []float64) []float64 {
+	r := c.ConcentrationRange
+	baseline := (r.Baseline.Min + r.Baseline.Max) / 2
+	if trained(subject.TrainingStatus) && r.TrainedBaseline.Max > 0 {
+		baseline = (r.TrainedBaseline.Min + r.TrainedBaseline.Max) / 2
+	}
+	end := s.TotalDuration().Minutes()
+	peak := immune.CalculateAcuteResponse(c, int(math.Round(s.MeanIntensity())), int(math.Round(end)), subject.TrainingStatus)
+
+	values := make([]float64, len(minutes))
+	for i, t := range minutes {
+		fold := 1.0
+		switch {
+		case t <= 0 || end <= 0:
+		case t < end:
+			fold = 1 + (peak-1)*t/end
+		case c.HalfLifeMinutes > 0:
+			fold = 1 + (peak-1)*math.Exp2(-(t-end)/c.HalfLifeMinutes)
+		default:
+			fold = peak
+		}
+		values[i] = baseline * fold
+	}
+	return values
+}
+
+func trained(status string) bool {
+	return status == "Trained" || status == "Athlete"
+}
+
+// FindPredictor returns the predictor for an analyte, by name or alias, in a tissue
+func FindPredictor(predictors []Predictor, analyte, tissue string) (Predictor, bool) {
+	key := network.CanonicalLigand(analyte)
+	for _, p := range predictors {
+		if network.CanonicalLigand(p.Analyte) == key && strings.EqualFold(p.Tissue, tissue) {
+			return p, true
+		}
+	}
+	return Predictor{}, false
+}
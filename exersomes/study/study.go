@@ -0,0 +1,281 @@
+package study
+
+import (
+	"encoding/csv"
+	"errors"
+	"exersomes/ingest"
+	"exersomes/session"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultTissue is where an observation was sampled when the file doesn't say
+const DefaultTissue = "Blood"
+
+// Subject is a study participant
+type Subject struct {
+	ID             string
+	TrainingStatus string // "Untrained", "Trained", "Athlete"; empty when not recorded
+}
+
+// Session is one exercise bout performed by a subject
+type Session struct {
+	ID               string
+	Subject          string
+	ExerciseType     string  // "Aerobic", "HIIT", "Sprint", "Resistance"
+	IntensityPercent float64 // 0 uses the representative session for the type
+	DurationMinutes  float64
+}
+
+// Protocol returns the composed session the predictors simulate
+func (s Session) Protocol() session.Session {
+	if s.IntensityPercent > 0 && s.DurationMinutes > 0 {
+		return session.ContinuousSession(s.ExerciseType, s.IntensityPercent, s.DurationMinutes)
+	}
+	return session.ForExerciseType(s.ExerciseType)
+}
+
+// Observation is one measured value at a time point of a session
+type Observation struct {
+	Subject     string
+	Session     string
+	TimeMinutes float64 // From session start; 0 or negative is pre-exercise
+	Analyte     string
+	Tissue      string
+	Value       float64
+	Unit        string
+	Line        int // Source line, for reporting
+}
+
+// Study is a set of subjects, their sessions and the measurements taken
+type Study struct {
+	Subjects     []Subject
+	Sessions     []Session
+	Observations []Observation
+}
+
+// Subject returns a subject by ID
+func (st Study) Subject(id string) (Subject, bool) {
+	for _, s := range st.Subjects {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Subject{}, false
+}
+
+// Session returns a session by subject and ID
+func (st Study) Session(subject, id string) (Session, bool) {
+	for _, s := range st.Sessions {
+		if s.Subject == subject && s.ID == id {
+			return s, true
+		}
+	}
+	return Session{}, false
+}
+
+// Study file columns. Each row is one observation; session and subject columns
+// repeat on every row and must agree within a session.
+const (
+	ColumnSubject        = "subject"
+	ColumnSession        = "session"
+	ColumnExerciseType   = "exercise_type"
+	ColumnIntensity      = "intensity_percent"
+	ColumnDuration       = "duration_min"
+	ColumnTrainingStatus = "training_status"
+	ColumnTime           = "time_min"
+	ColumnAnalyte        = "analyte"
+	ColumnTissue         = "tissue"
+	ColumnValue          = "value"
+	ColumnUnit           = "unit"
+)
+
+// columnSynonyms maps normalized headers to study columns
+var columnSynonyms = map[string]string{
+	"subject": ColumnSubject, "subjectid": ColumnSubject, "participant": ColumnSubject,
+	"session": ColumnSession, "sessionid": ColumnSession, "visit": ColumnSession,
+	"exercisetype": ColumnExerciseType, "exercise": ColumnExerciseType, "protocol": ColumnExerciseType,
+	"intensitypercent": ColumnIntensity, "intensity": ColumnIntensity,
+	"durationmin": ColumnDuration, "duration": ColumnDuration, "durationminutes": ColumnDuration,
+	"trainingstatus": ColumnTrainingStatus, "training": ColumnTrainingStatus,
+	"timemin": ColumnTime, "time": ColumnTime, "timepoint": ColumnTime, "timeminutes": ColumnTime,
+	"analyte": ColumnAnalyte, "marker": ColumnAnalyte, "exerkine": ColumnAnalyte,
+	"tissue": ColumnTissue, "compartment": ColumnTissue,
+	"value": ColumnValue, "concentration": ColumnValue,
+	"unit": ColumnUnit, "units": ColumnUnit,
+}
+
+// required study columns
+var required = []string{ColumnSubject, ColumnSession, ColumnExerciseType, ColumnTime, ColumnAnalyte, ColumnValue, ColumnUnit}
+
+func normalizeHeader(h string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimPrefix(h, "\uFEFF")) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// LoadFile reads a study file, comma-separated for .csv and tab-separated otherwise
+func LoadFile(path string) (Study, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Study{}, err
+	}
+	defer f.Close()
+	comma := '\t'
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		comma = ','
+	}
+	st, err := Load(f, comma)
+	if err != nil {
+		return st, fmt.Errorf("%s: %w", path, err)
+	}
+	return st, nil
+}
+
+// Load reads a study in long format. Valid rows are kept when others fail; the
+// error is then an *ingest.ValidationError listing each bad row.
+func Load(r io.Reader, comma rune) (Study, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return Study{}, nil
+	}
+	if err != nil {
+		return Study{}, err
+	}
+	columns := make(map[string]int)
+	for i, h := range header {
+		if c, ok := columnSynonyms[normalizeHeader(h)]; ok {
+			if _, dup := columns[c]; !dup {
+				columns[c] = i
+			}
+		}
+	}
+	for _, c := range required {
+		if _, ok := columns[c]; !ok {
+			return Study{}, fmt.Errorf("missing column %q", c)
+		}
+	}
+
+	var (
+		st        Study
+		rowErrors []ingest.RowError
+		subjects  = make(map[string]int)
+		sessions  = make(map[[2]string]int)
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, ingest.RowError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
+			}
+			return st, err
+		}
+		get := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if len(record) == 1 && get(ColumnSubject) == "" {
+			continue // Blank line
+		}
+
+		var errs []ingest.RowError
+		number := func(column string, optional bool) float64 {
+			v := get(column)
+			if v == "" {
+				if !optional {
+					errs = append(errs, ingest.RowError{Line: line, Column: header[columns[column]], Message: "value is required"})
+				}
+				return 0
+			}
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errs = append(errs, ingest.RowError{Line: line, Column: header[columns[column]], Message: fmt.Sprintf("%q is not a number", v)})
+			}
+			return f
+		}
+		text := func(column string) string {
+			v := get(column)
+			if v == "" {
+				errs = append(errs, ingest.RowError{Line: line, Column: header[columns[column]], Message: "value is required"})
+			}
+			return v
+		}
+
+		subject := Subject{ID: text(ColumnSubject), TrainingStatus: get(ColumnTrainingStatus)}
+		sess := Session{
+			ID:               text(ColumnSession),
+			Subject:          subject.ID,
+			ExerciseType:     text(ColumnExerciseType),
+			IntensityPercent: number(ColumnIntensity, true),
+			DurationMinutes:  number(ColumnDuration, true),
+		}
+		obs := Observation{
+			Subject:     subject.ID,
+			Session:     sess.ID,
+			TimeMinutes: number(ColumnTime, false),
+			Analyte:     text(ColumnAnalyte),
+			Tissue:      get(ColumnTissue),
+			Value:       number(ColumnValue, false),
+			Unit:        text(ColumnUnit),
+			Line:        line,
+		}
+		if obs.Tissue == "" {
+			obs.Tissue = DefaultTissue
+		}
+		if len(errs) == 0 {
+			if i, ok := subjects[subject.ID]; ok && st.Subjects[i] != subject {
+				errs = append(errs, ingest.RowError{Line: line, Message: fmt.Sprintf("subject %s disagrees with its earlier rows", subject.ID)})
+			}
+			key := [2]string{sess.Subject, sess.ID}
+			if i, ok := sessions[key]; ok && st.Sessions[i] != sess {
+				errs = append(errs, ingest.RowError{Line: line, Message: fmt.Sprintf("session %s of %s disagrees with its earlier rows", sess.ID, sess.Subject)})
+			}
+		}
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+
+		if _, ok := subjects[subject.ID]; !ok {
+			subjects[subject.ID] = len(st.Subjects)
+			st.Subjects = append(st.Subjects, subject)
+		}
+		if key := [2]string{sess.Subject, sess.ID}; !hasKey(sessions, key) {
+			sessions[key] = len(st.Sessions)
+			st.Sessions = append(st.Sessions, sess)
+		}
+		st.Observations = append(st.Observations, obs)
+	}
+
+	if len(rowErrors) > 0 {
+		return st, &ingest.ValidationError{Rows: rowErrors}
+	}
+	return st, nil
+}
+
+func hasKey(m map[[2]string]int, key [2]string) bool {
+	_, ok := m[key]
+	return ok
+}
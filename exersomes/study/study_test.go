@@ -0,0 +1,106 @@
+package study
+
+import (
+	"bytes"
+	"errors"
+	"exersomes/ingest"
+	"math"
+	"strings"
+	"testing"
+)
+
+const sample = "subject\tsession\texercise_type\tintensity_percent\tduration_min\ttraining_status\ttime_min\tanalyte\ttissue\tvalue\tunit\n" +
+	"P1\tS1\tAerobic\t70\t60\tTrained\t0\tIL-6\tBlood\t1.5\tpg/mL\n" +
+	"P1\tS1\tAerobic\t70\t60\tTrained\t60\tIL-6\t\t0.009\tng/mL\n" +
+	"P1\tS1\tAerobic\t70\t60\tTrained\t60\tNeutrophils\tBlood\t7500\tcells/μL\n" +
+	"P1\tS1\tAerobic\t70\t60\tTrained\t60\tIrisin\tBlood\t4\tng/mL\n" +
+	"P2\tS1\tHIIT\t\t\t\t30\tIL-6\tBlood\t4\tpg/mL\n"
+
+// Test the long format groups rows into subjects and sessions
+func TestLoad(t *testing.T) {
+	st, err := Load(strings.NewReader(sample), '\t')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.Subjects) != 2 || len(st.Sessions) != 2 || len(st.Observations) != 5 {
+		t.Fatalf("Unexpected study %d subjects, %d sessions, %d observations",
+			len(st.Subjects), len(st.Sessions), len(st.Observations))
+	}
+	if s, _ := st.Session("P1", "S1"); s.IntensityPercent != 70 || s.Protocol().TotalDuration().Minutes() != 60 {
+		t.Errorf("Unexpected session %+v", s)
+	}
+	if s, _ := st.Session("P2", "S1"); s.Protocol().Name != "4x4 HIIT" {
+		t.Errorf("Expected the representative HIIT session, got %q", s.Protocol().Name)
+	}
+	if st.Observations[1].Tissue != DefaultTissue {
+		t.Errorf("Expected empty tissue to default to %s", DefaultTissue)
+	}
+
+	bad := "subject,session,exercise_type,time_min,analyte,value,unit,intensity\n" +
+		"P1,S1,Aerobic,0,IL-6,high,pg/mL,70\n" +
+		"P1,S1,Aerobic,30,IL-6,2,pg/mL,80\n" +
+		"P1,S1,Aerobic,60,,2,pg/mL,70\n"
+	st, err = Load(strings.NewReader(bad), ',')
+	var verr *ingest.ValidationError
+	if !errors.As(err, &verr) || len(verr.Rows) != 2 {
+		t.Fatalf("Expected two row errors, got %v", err)
+	}
+	if verr.Rows[0].Line != 2 || verr.Rows[0].Column != "value" || verr.Rows[1].Line != 4 {
+		t.Errorf("Unexpected row errors %+v", verr.Rows)
+	}
+	if len(st.Observations) != 1 {
+		t.Errorf("Expected the valid row to load, got %+v", st.Observations)
+	}
+}
+
+// Test observations equal to the predictions fit exactly, and offsets show as bias
+func TestCompare(t *testing.T) {
+	st, err := Load(strings.NewReader(sample), '\t')
+	if err != nil {
+		t.Fatal(err)
+	}
+	il6, ok := FindPredictor(Predictors(), "Interleukin-6", "blood")
+	if !ok {
+		t.Fatal("Expected an IL-6 predictor")
+	}
+
+	// Replace IL-6 observations with model output plus 1 pg/mL
+	for i, o := range st.Observations {
+		if o.Analyte != "IL-6" {
+			continue
+		}
+		s, _ := st.Session(o.Subject, o.Session)
+		subject, _ := st.Subject(o.Subject)
+		predicted := il6.Predict(s.Protocol(), subject, []float64{o.TimeMinutes})[0]
+		st.Observations[i].Value, st.Observations[i].Unit = predicted+1, "pg/mL"
+	}
+
+	cmp := Compare(st, nil)
+	if len(cmp.Unmatched) != 1 || cmp.Unmatched[0].Observation.Analyte != "Irisin" {
+		t.Errorf("Expected only irisin to be unmatched, got %+v", cmp.Unmatched)
+	}
+	var found bool
+	for _, m := range cmp.Metrics {
+		if m.Analyte != il6.Analyte {
+			continue
+		}
+		found = true
+		if m.N != 3 || math.Abs(m.Bias-1) > 1e-9 || math.Abs(m.RMSE-1) > 1e-9 || math.Abs(m.Correlation-1) > 1e-9 {
+			t.Errorf("Expected bias and RMSE of 1 with r=1, got %+v", m)
+		}
+	}
+	if !found {
+		t.Fatalf("Expected IL-6 metrics, got %+v", cmp.Metrics)
+	}
+	if len(cmp.Residuals) != 4 {
+		t.Errorf("Expected 4 residuals, got %d", len(cmp.Residuals))
+	}
+
+	var buf bytes.Buffer
+	if err := cmp.WriteMetrics(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Neutrophils\tBlood\tcells/μL") || !strings.Contains(buf.String(), "NA") {
+		t.Errorf("Unexpected metrics table:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,98 @@
+package uncertainty
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Distribution draws values for one uncertain input
+type Distribution interface {
+	Sample(r *rand.Rand) float64
+	String() string
+}
+
+// Uniform draws evenly from [Min, Max]
+type Uniform struct{ Min, Max float64 }
+
+func (d Uniform) Sample(r *rand.Rand) float64 { return d.Min + r.Float64()*(d.Max-d.Min) }
+func (d Uniform) String() string              { return fmt.Sprintf("Uniform(%g, %g)", d.Min, d.Max) }
+
+// Normal draws from a normal distribution
+type Normal struct{ Mean, SD float64 }
+
+func (d Normal) Sample(r *rand.Rand) float64 { return d.Mean + d.SD*r.NormFloat64() }
+func (d Normal) String() string              { return fmt.Sprintf("Normal(%g, %g)", d.Mean, d.SD) }
+
+// LogNormal draws values whose logarithm is Normal(Mu, Sigma); suited to
+// concentrations, which are positive and right-skewed
+type LogNormal struct{ Mu, Sigma float64 }
+
+func (d LogNormal) Sample(r *rand.Rand) float64 { return math.Exp(d.Mu + d.Sigma*r.NormFloat64()) }
+func (d LogNormal) String() string              { return fmt.Sprintf("LogNormal(%g, %g)", d.Mu, d.Sigma) }
+
+// Triangular draws from [Min, Max] with the density peaking at Mode
+type Triangular struct{ Min, Mode, Max float64 }
+
+func (d Triangular) Sample(r *rand.Rand) float64 {
+	u := r.Float64()
+	width := d.Max - d.Min
+	if width <= 0 {
+		return d.Mode
+	}
+	split := (d.Mode - d.Min) / width
+	if u < split {
+		return d.Min + math.Sqrt(u*width*(d.Mode-d.Min))
+	}
+	return d.Max - math.Sqrt((1-u)*width*(d.Max-d.Mode))
+}
+func (d Triangular) String() string {
+	return fmt.Sprintf("Triangular(%g, %g, %g)", d.Min, d.Mode, d.Max)
+}
+
+// Bernoulli draws 1 with probability P and 0 otherwise, for yes/no individual factors
+type Bernoulli struct{ P float64 }
+
+func (d Bernoulli) Sample(r *rand.Rand) float64 {
+	if r.Float64() < d.P {
+		return 1
+	}
+	return 0
+}
+func (d Bernoulli) String() string { return fmt.Sprintf("Bernoulli(%g)", d.P) }
+
+// Fixed always draws the same value
+type Fixed float64
+
+func (d Fixed) Sample(*rand.Rand) float64 { return float64(d) }
+func (d Fixed) String() string            { return fmt.Sprintf("Fixed(%g)", float64(d)) }
+
+// Truncated redraws from Dist until the value falls in [Min, Max], clamping
+// after a bounded number of attempts
+type Truncated struct {
+	Dist     Distribution
+	Min, Max float64
+}
+
+func (d Truncated) Sample(r *rand.Rand) float64 {
+	v := d.Dist.Sample(r)
+	for i := 0; i < 100 && (v < d.Min || v > d.Max); i++ {
+		v = d.Dist.Sample(r)
+	}
+	return math.Min(math.Max(v, d.Min), d.Max)
+}
+func (d Truncated) String() string { return fmt.Sprintf("%s in [%g, %g]", d.Dist, d.Min, d.Max) }
+
+// FromRange reads a catalog range as a 95% interval: log-normal for positive
+// ranges, whose width is usually multiplicative, and normal otherwise. Empty
+// ranges draw their single value.
+func FromRange(min, max float64) Distribution {
+	switch {
+	case max <= min:
+		return Fixed(min)
+	case min > 0:
+		return LogNormal{Mu: (math.Log(min) + math.Log(max)) / 2, Sigma: (math.Log(max) - math.Log(min)) / (2 * 1.96)}
+	default:
+		return Normal{Mean: (min + max) / 2, SD: (max - min) / (2 * 1.96)}
+	}
+}
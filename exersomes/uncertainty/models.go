@@ -0,0 +1,124 @@
+package uncertainty
+
+import (
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/immune"
+	"exersomes/components/metabolic/liver"
+	"exersomes/session"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Numeric keeps the numeric values of a predictor's output map
+func Numeric(response map[string]interface{}) map[string]float64 {
+	out := make(map[string]float64, len(response))
+	for k, v := range response {
+		switch n := v.(type) {
+		case float64:
+			out[k] = n
+		case float32:
+			out[k] = float64(n)
+		case int:
+			out[k] = float64(n)
+		}
+	}
+	return out
+}
+
+// LiverModel wraps PredictLiverResponse. Intensity and duration vary around the
+// prescription, adherence shortens the effective weeks, NAFLD and insulin
+// resistance occur with the given probabilities, and hepatokine baselines are
+// drawn from their catalog ranges. Hepatokine levels are proportional to the
+// baseline, so the drawn baselines rescale the "_level" outputs.
+func LiverModel(prescription liver.ExercisePrescription, weeks int, pNAFLD, pInsulinResistance float64) ([]Input, Model) {
+	inputs := []Input{
+		{Name: "intensity_percent", Dist: Truncated{Dist: Normal{Mean: float64(prescription.IntensityPercent), SD: 5}, Min: 20, Max: 100}},
+		{Name: "duration_minutes", Dist: Truncated{Dist: Normal{Mean: float64(prescription.DurationMinutes),
+			SD: 0.1 * float64(prescription.DurationMinutes)}, Min: 5, Max: 3 * float64(prescription.DurationMinutes)}},
+		{Name: "adherence", Dist: Uniform{Min: 0.6, Max: 1}},
+		{Name: "has_nafld", Dist: Bernoulli{P: pNAFLD}},
+		{Name: "has_insulin_resistance", Dist: Bernoulli{P: pInsulinResistance}},
+	}
+	midpoints := make(map[string]float64)
+	for _, h := range liver.GetExerciseResponsiveHepatokines() {
+		midpoints[h.GeneID] = (h.BaselineRange.Min + h.BaselineRange.Max) / 2
+		inputs = append(inputs, Input{Name: "baseline/" + h.GeneID, Dist: FromRange(h.BaselineRange.Min, h.BaselineRange.Max)})
+	}
+
+	model := func(d Draw, _ Sets) (map[string]float64, error) {
+		p := prescription
+		p.IntensityPercent = int(math.Round(d["intensity_percent"]))
+		p.DurationMinutes = int(math.Round(d["duration_minutes"]))
+		effectiveWeeks := int(math.Round(float64(weeks) * d["adherence"]))
+		out := Numeric(liver.PredictLiverResponse(p, effectiveWeeks, d["has_nafld"] == 1, d["has_insulin_resistance"] == 1))
+		for gene, mid := range midpoints {
+			if mid > 0 {
+				out[gene+"_level"] *= d["baseline/"+gene] / mid
+			}
+		}
+		return out, nil
+	}
+	return inputs, model
+}
+
+// CirculatingModel wraps a circulating factor's session simulation, drawing the
+// resting level from BaselineRange and the sustained-effort level from
+// ExerciseInducedRange. Outputs are keyed by minutes from session start.
+func CirculatingModel(cf bloodstream.CirculatingFactor, s session.Session, timePoints []time.Duration) ([]Input, Model) {
+	inputs := []Input{
+		{Name: "baseline", Dist: FromRange(cf.BaselineRange.Min, cf.BaselineRange.Max)},
+		{Name: "exercise_level", Dist: FromRange(cf.ExerciseInducedRange.Min, cf.ExerciseInducedRange.Max)},
+	}
+	model := func(d Draw, _ Sets) (map[string]float64, error) {
+		c := cf
+		c.BaselineRange.Min, c.BaselineRange.Max = d["baseline"], d["baseline"]
+		c.ExerciseInducedRange.Min, c.ExerciseInducedRange.Max = d["exercise_level"], d["exercise_level"]
+		values := c.Model().Simulate(s, timePoints)
+		out := make(map[string]float64, len(values))
+		for i, t := range timePoints {
+			out[TimeKey(t)] = values[i]
+		}
+		return out, nil
+	}
+	return inputs, model
+}
+
+// TimeKey is the output key of a time point, e.g. "t=45min"
+func TimeKey(t time.Duration) string {
+	return fmt.Sprintf("t=%gmin", t.Minutes())
+}
+
+// CytokineModel wraps CalculateAcuteResponse, drawing its coefficients around
+// their current values and the resting level from the catalog baseline range
+func CytokineModel(c immune.Cytokine, intensityPercent, durationMinutes int, trainingStatus string,
+	coefficientSD float64) ([]Input, Model) {
+	inputs := Coefficients(immune.AcuteResponseParams, coefficientSD)
+	baseline := c.ConcentrationRange.Baseline
+	inputs = append(inputs, Input{Name: "baseline", Dist: FromRange(baseline.Min, baseline.Max)})
+
+	model := func(d Draw, sets Sets) (map[string]float64, error) {
+		fold := immune.CalculateAcuteResponseWithParams(sets.Get(immune.AcuteResponseParams),
+			c, intensityPercent, durationMinutes, trainingStatus)
+		return map[string]float64{
+			"fold_change":   fold,
+			"concentration": d["baseline"] * fold,
+		}, nil
+	}
+	return inputs, model
+}
+
+// AcuteModel wraps any acute predictor of the form used by
+// placenta.CalculateMitokineResponse, drawing the session's intensity and
+// duration from the given distributions. The output key is "fold_change".
+func AcuteModel(predict func(intensityPercent, durationMinutes float64) float64,
+	intensity, duration Distribution) ([]Input, Model) {
+	inputs := []Input{
+		{Name: "intensity_percent", Dist: intensity},
+		{Name: "duration_minutes", Dist: duration},
+	}
+	model := func(d Draw, _ Sets) (map[string]float64, error) {
+		return map[string]float64{"fold_change": predict(d["intensity_percent"], d["duration_minutes"])}, nil
+	}
+	return inputs, model
+}
@@ -0,0 +1,222 @@
+package uncertainty
+
+import (
+	"errors"
+	"exersomes/params"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Input is an uncertain quantity drawn for each run. Inputs created by
+// Coefficients also set a coefficient in the run's copy of their parameter set.
+type Input struct {
+	Name string
+	Dist Distribution
+
+	set   *params.Set
+	param string
+}
+
+// Draw holds one sampled value per input, by name
+type Draw map[string]float64
+
+// Model runs a predictor on one draw and returns its numeric outputs by key.
+// Predictors read coefficients from sets.Get so that each run sees its own draw.
+type Model func(d Draw, sets Sets) (map[string]float64, error)
+
+// Sets maps parameter sets to one run's copies holding the drawn coefficients
+type Sets map[*params.Set]*params.Set
+
+// Get returns the run's copy of a set, or the set itself when no input draws from it
+func (s Sets) Get(set *params.Set) *params.Set {
+	if c, ok := s[set]; ok {
+		return c
+	}
+	return set
+}
+
+// Coefficients declares a parameter set's free coefficients as inputs named
+// "set/param", drawn normally around their current values with the given
+// relative standard deviation and truncated to their bounds
+func Coefficients(set *params.Set, relativeSD float64) []Input {
+	var inputs []Input
+	for _, p := range set.Parameters() {
+		if p.Fixed {
+			continue
+		}
+		sd := math.Abs(p.Value) * relativeSD
+		inputs = append(inputs, Input{
+			Name:  set.Name + "/" + p.Name,
+			Dist:  Truncated{Dist: Normal{Mean: p.Value, SD: sd}, Min: p.Min, Max: p.Max},
+			set:   set,
+			param: p.Name,
+		})
+	}
+	return inputs
+}
+
+// Options control a Monte Carlo run
+type Options struct {
+	N       int       // Number of draws; 0 uses 1000
+	Seed    int64     // Draws are identical for the same seed regardless of Workers
+	Workers int       // Parallel model runs; 0 uses GOMAXPROCS
+	Levels  []float64 // Credible interval levels; nil uses 0.5, 0.9 and 0.95
+}
+
+// Interval is an equal-tailed credible interval
+type Interval struct {
+	Level float64
+	Lower float64
+	Upper float64
+}
+
+// Summary describes the distribution of one output key across draws
+type Summary struct {
+	Key       string
+	N         int
+	Mean      float64
+	SD        float64
+	Median    float64
+	Intervals []Interval
+}
+
+// Result summarizes every output key, ordered by key
+type Result struct {
+	Summaries []Summary
+	Draws     int
+	Seed      int64
+	Inputs    map[string]string // Input name -> distribution
+}
+
+// Summary returns the summary of one output key
+func (r Result) Summary(key string) (Summary, bool) {
+	i := sort.Search(len(r.Summaries), func(i int) bool { return r.Summaries[i].Key >= key })
+	if i < len(r.Summaries) && r.Summaries[i].Key == key {
+		return r.Summaries[i], true
+	}
+	return Summary{}, false
+}
+
+// Run draws every input N times and runs the model on each draw in parallel.
+// Draws are generated up front from one seeded source, so results are
+// reproducible. Coefficient inputs are applied to per-run copies of their
+// sets, so runs stay parallel and the registered sets are never changed.
+func Run(inputs []Input, model Model, opts Options) (Result, error) {
+	if opts.N == 0 {
+		opts.N = 1000
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	if opts.Levels == nil {
+		opts.Levels = []float64{0.5, 0.9, 0.95}
+	}
+
+	result := Result{Draws: opts.N, Seed: opts.Seed, Inputs: make(map[string]string, len(inputs))}
+	var coefficients []Input
+	for _, in := range inputs {
+		if _, dup := result.Inputs[in.Name]; dup {
+			return result, fmt.Errorf("duplicate input %s", in.Name)
+		}
+		result.Inputs[in.Name] = in.Dist.String()
+		if in.set != nil {
+			coefficients = append(coefficients, in)
+		}
+	}
+
+	r := rand.New(rand.NewSource(opts.Seed))
+	draws := make([]Draw, opts.N)
+	for i := range draws {
+		draws[i] = make(Draw, len(inputs))
+		for _, in := range inputs {
+			draws[i][in.Name] = in.Dist.Sample(r)
+		}
+	}
+
+	runOne := func(d Draw) (map[string]float64, error) {
+		sets := make(Sets)
+		for _, c := range coefficients {
+			if _, ok := sets[c.set]; !ok {
+				sets[c.set] = c.set.Clone()
+			}
+			if err := sets[c.set].Set(c.param, d[c.Name]); err != nil {
+				return nil, fmt.Errorf("%s: %w", c.Name, err)
+			}
+		}
+		return model(d, sets)
+	}
+
+	outputs := make([]map[string]float64, opts.N)
+	errs := make([]error, opts.N)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				outputs[i], errs[i] = runOne(draws[i])
+			}
+		}()
+	}
+	for i := range draws {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return result, err
+	}
+
+	samples := make(map[string][]float64)
+	for _, out := range outputs {
+		for k, v := range out {
+			if !math.IsNaN(v) {
+				samples[k] = append(samples[k], v)
+			}
+		}
+	}
+	for key, values := range samples {
+		result.Summaries = append(result.Summaries, summarize(key, values, opts.Levels))
+	}
+	sort.Slice(result.Summaries, func(i, j int) bool { return result.Summaries[i].Key < result.Summaries[j].Key })
+	return result, nil
+}
+
+func summarize(key string, values []float64, levels []float64) Summary {
+	sort.Float64s(values)
+	n := float64(len(values))
+	s := Summary{Key: key, N: len(values)}
+	for _, v := range values {
+		s.Mean += v / n
+	}
+	for _, v := range values {
+		s.SD += (v - s.Mean) * (v - s.Mean)
+	}
+	if len(values) > 1 {
+		s.SD = math.Sqrt(s.SD / (n - 1))
+	} else {
+		s.SD = 0
+	}
+	s.Median = Quantile(values, 0.5)
+	for _, level := range levels {
+		tail := (1 - level) / 2
+		s.Intervals = append(s.Intervals, Interval{Level: level, Lower: Quantile(values, tail), Upper: Quantile(values, 1-tail)})
+	}
+	return s
+}
+
+// Quantile interpolates the q-th quantile of sorted values
+func Quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (pos-float64(lo))*(sorted[hi]-sorted[lo])
+}
@@ -0,0 +1,122 @@
+package uncertainty
+
+import (
+	"exersomes/components/cardiovascular/bloodstream"
+	"exersomes/components/immune"
+	"exersomes/components/metabolic/liver"
+	"exersomes/session"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test summaries of a known distribution and reproducibility across worker counts
+func TestRun(t *testing.T) {
+	inputs := []Input{{Name: "x", Dist: Uniform{Min: 0, Max: 10}}, {Name: "flag", Dist: Bernoulli{P: 0.25}}}
+	model := func(d Draw, _ Sets) (map[string]float64, error) {
+		return map[string]float64{"x": d["x"], "double": 2 * d["x"], "flag": d["flag"]}, nil
+	}
+
+	serial, err := Run(inputs, model, Options{N: 20000, Seed: 7, Workers: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := Run(inputs, model, Options{N: 20000, Seed: 7, Workers: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Error("Expected identical results for the same seed with different worker counts")
+	}
+
+	x, ok := serial.Summary("x")
+	if !ok {
+		t.Fatal("Expected a summary for x")
+	}
+	if math.Abs(x.Mean-5) > 0.1 || math.Abs(x.Median-5) > 0.15 || math.Abs(x.SD-10/math.Sqrt(12)) > 0.05 {
+		t.Errorf("Unexpected uniform summary %+v", x)
+	}
+	for _, iv := range x.Intervals {
+		if iv.Level == 0.9 && (math.Abs(iv.Lower-0.5) > 0.15 || math.Abs(iv.Upper-9.5) > 0.15) {
+			t.Errorf("Expected 90%% interval near [0.5, 9.5], got %+v", iv)
+		}
+	}
+	if flag, _ := serial.Summary("flag"); math.Abs(flag.Mean-0.25) > 0.02 {
+		t.Errorf("Expected Bernoulli mean near 0.25, got %g", flag.Mean)
+	}
+
+	other, _ := Run(inputs, model, Options{N: 20000, Seed: 8})
+	if reflect.DeepEqual(serial.Summaries, other.Summaries) {
+		t.Error("Expected a different seed to change the draws")
+	}
+}
+
+// Test catalog ranges read as 95% intervals
+func TestFromRange(t *testing.T) {
+	inputs := []Input{{Name: "v", Dist: FromRange(10, 100)}}
+	r, err := Run(inputs, func(d Draw, _ Sets) (map[string]float64, error) {
+		return map[string]float64{"v": d["v"]}, nil
+	}, Options{N: 40000, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _ := r.Summary("v")
+	if math.Abs(v.Median-math.Sqrt(10*100)) > 1 {
+		t.Errorf("Expected the geometric midpoint as median, got %g", v.Median)
+	}
+	for _, iv := range v.Intervals {
+		if iv.Level == 0.95 && (math.Abs(iv.Lower-10) > 1 || math.Abs(iv.Upper-100) > 8) {
+			t.Errorf("Expected 95%% interval near [10, 100], got %+v", iv)
+		}
+	}
+}
+
+// Test the predictor wrappers run and coefficient draws leave the registered sets alone
+func TestModels(t *testing.T) {
+	inputs, model := LiverModel(liver.NAFLDReduction, 12, 0.5, 0.3)
+	r, err := Run(inputs, model, Options{N: 200, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fat, ok := r.Summary("liver_fat_percent_change")
+	if !ok || fat.Mean >= 0 || fat.Intervals[2].Lower >= fat.Intervals[2].Upper {
+		t.Errorf("Expected a spread of liver fat reductions, got %+v", fat)
+	}
+
+	inputs, model = CirculatingModel(bloodstream.IL6Circ, session.ContinuousSession("Aerobic", 70, 60),
+		[]time.Duration{0, 60 * time.Minute})
+	r, err = Run(inputs, model, Options{N: 200, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest, _ := r.Summary(TimeKey(0))
+	peak, _ := r.Summary(TimeKey(60 * time.Minute))
+	if peak.Median <= rest.Median {
+		t.Errorf("Expected IL-6 to rise during exercise, got %g -> %g", rest.Median, peak.Median)
+	}
+
+	before := immune.AcuteResponseParams.Parameters()
+	inputs, model = CytokineModel(immune.IL10, 75, 60, "Untrained", 0.2)
+	checked := func(d Draw, sets Sets) (map[string]float64, error) {
+		if !reflect.DeepEqual(before, immune.AcuteResponseParams.Parameters()) {
+			t.Error("Expected the registered coefficients to stay unchanged during runs")
+		}
+		return model(d, sets)
+	}
+	r, err = Run(inputs, checked, Options{N: 200, Seed: 3, Workers: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fold, _ := r.Summary("fold_change"); fold.SD == 0 {
+		t.Error("Expected sampled coefficients to spread the fold change")
+	}
+	if !reflect.DeepEqual(before, immune.AcuteResponseParams.Parameters()) {
+		t.Error("Expected the registered coefficients to be unchanged after the run")
+	}
+
+	outside := []Input{{Name: "gain", Dist: Uniform{Min: 30, Max: 40}, set: immune.AcuteResponseParams, param: "il10.gain"}}
+	if _, err := Run(outside, model, Options{N: 10}); err == nil {
+		t.Error("Expected an out-of-bounds coefficient draw to fail the run")
+	}
+}
@@ -0,0 +1,85 @@
+package vesicles
+
+import (
+	"strings"
+)
+
+// HumanSpecies is the species kept by Filter
+const HumanSpecies = "Homo sapiens"
+
+// sampleTissues maps sample keywords to exercise-relevant tissues, spelled as in
+// the vesicle catalog. Earlier keywords win, so specific ones come first.
+var sampleTissues = []struct {
+	keyword string
+	tissue  string
+}{
+	{"smooth muscle", ""},
+	{"skeletal muscle", "Skeletal muscle"},
+	{"myotube", "Skeletal muscle"},
+	{"myoblast", "Skeletal muscle"},
+	{"satellite cell", "Skeletal muscle"},
+	{"muscle", "Skeletal muscle"},
+	{"adipocyte", "Adipose tissue"},
+	{"adipose", "Adipose tissue"},
+	{"hepatocyte", "Liver"},
+	{"liver", "Liver"},
+	{"hepg2", "Liver"},
+	{"huvec", "Endothelial cells"},
+	{"endothelial", "Endothelial cells"},
+	{"cardiomyocyte", "Heart"},
+	{"cardiac", "Heart"},
+	{"heart", "Heart"},
+	{"osteoblast", "Bone"},
+	{"osteocyte", "Bone"},
+	{"bone marrow", ""},
+	{"bone", "Bone"},
+	{"neuron", "Brain"},
+	{"astrocyte", "Brain"},
+	{"brain", "Brain"},
+	{"macrophage", "Immune cells"},
+	{"monocyte", "Immune cells"},
+	{"lymphocyte", "Immune cells"},
+	{"dendritic", "Immune cells"},
+	{"mononuclear", "Immune cells"},
+	{"platelet", "Platelets"},
+	{"plasma", "Plasma"},
+	{"serum", "Plasma"},
+	{"blood", "Plasma"},
+}
+
+// SampleTissue returns the exercise-relevant tissue a sample comes from, or ""
+// when it matches none, e.g. urine, saliva or smooth muscle
+func SampleTissue(sample string) string {
+	s := strings.ToLower(sample)
+	for _, st := range sampleTissues {
+		if strings.Contains(s, st.keyword) {
+			return st.tissue
+		}
+	}
+	return ""
+}
+
+// Filter keeps human experiments on exercise-relevant samples, setting each
+// experiment's Tissue, and the entries they report. Entries with their own
+// species column must also be human.
+func (d Dataset) Filter() Dataset {
+	out := Dataset{Database: d.Database, Experiments: make(map[string]Experiment)}
+	for id, e := range d.Experiments {
+		if !isHuman(e.Species) {
+			continue
+		}
+		if e.Tissue = SampleTissue(e.Sample); e.Tissue != "" {
+			out.Experiments[id] = e
+		}
+	}
+	for _, e := range d.Entries {
+		if _, ok := out.Experiments[e.Experiment]; ok && (e.Species == "" || isHuman(e.Species)) {
+			out.Entries = append(out.Entries, e)
+		}
+	}
+	return out
+}
+
+func isHuman(species string) bool {
+	return strings.EqualFold(strings.TrimSpace(species), HumanSpecies)
+}
@@ -0,0 +1,258 @@
+package vesicles
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Databases read by the importers
+const (
+	ExoCarta     = "ExoCarta"
+	Vesiclepedia = "Vesiclepedia"
+)
+
+// Content types of database entries
+const (
+	KindProtein = "Protein"
+	KindMRNA    = "mRNA"
+	KindMiRNA   = "miRNA"
+)
+
+// Experiment is one vesicle preparation from one sample
+type Experiment struct {
+	Database    string
+	ID          string
+	PubMedID    string
+	Species     string
+	Sample      string // Sample or cell type as the database gives it
+	VesicleType string // "Exosome", "Microvesicle", ...; ExoCarta lists exosomes only
+	Tissue      string // Exercise-relevant tissue matched from Sample by Filter
+}
+
+// Entry is a molecule reported in an experiment
+type Entry struct {
+	Experiment string
+	Kind       string // KindProtein, KindMRNA or KindMiRNA
+	Symbol     string // Gene symbol, or miRNA name for miRNAs
+	EntrezID   string
+	Species    string
+}
+
+// Dataset is a database download: experiments and the molecules they report
+type Dataset struct {
+	Database    string
+	Experiments map[string]Experiment // By experiment ID
+	Entries     []Entry
+}
+
+// ReadExoCarta imports an ExoCarta download directory holding the tab-separated
+// EXOCARTA_EXPERIMENT_DETAILS, EXOCARTA_PROTEIN_MRNA_DETAILS and
+// EXOCARTA_MIRNA_DETAILS files (any release suffix)
+func ReadExoCarta(dir string) (Dataset, error) {
+	return readDataset(dir, ExoCarta)
+}
+
+// ReadVesiclepedia imports a Vesiclepedia download directory holding the
+// tab-separated VESICLEPEDIA_EXPERIMENT_DETAILS, VESICLEPEDIA_PROTEIN_MRNA_DETAILS
+// and VESICLEPEDIA_MIRNA_DETAILS files. Lipid files are ignored.
+func ReadVesiclepedia(dir string) (Dataset, error) {
+	return readDataset(dir, Vesiclepedia)
+}
+
+// readDataset reads a download directory. Entries naming an experiment missing
+// from the experiment details are skipped.
+func readDataset(dir, database string) (Dataset, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return Dataset{}, err
+	}
+	find := func(part string) string {
+		for _, f := range files {
+			name := strings.ToUpper(f.Name())
+			if !f.IsDir() && strings.HasPrefix(name, strings.ToUpper(database)) && strings.Contains(name, part) {
+				return filepath.Join(dir, f.Name())
+			}
+		}
+		return ""
+	}
+
+	d := Dataset{Database: database, Experiments: make(map[string]Experiment)}
+	experiments := find("EXPERIMENT_DETAILS")
+	if experiments == "" {
+		return Dataset{}, fmt.Errorf("%s: no %s experiment details file", dir, database)
+	}
+	t, err := readTable(experiments)
+	if err != nil {
+		return Dataset{}, err
+	}
+	if err := t.require("experiment id", "species", "sample"); err != nil {
+		return Dataset{}, err
+	}
+	for _, row := range t.rows {
+		e := Experiment{
+			Database:    database,
+			ID:          t.get(row, "experiment id"),
+			PubMedID:    t.get(row, "pubmed id"),
+			Species:     t.get(row, "species"),
+			Sample:      t.get(row, "sample"),
+			VesicleType: VesicleType(t.get(row, "vesicle type")),
+		}
+		if e.VesicleType == "" {
+			e.VesicleType = "Exosome"
+		}
+		if e.ID != "" {
+			d.Experiments[e.ID] = e
+		}
+	}
+
+	content := []string{find("PROTEIN_MRNA_DETAILS"), find("MIRNA_DETAILS")}
+	if content[0] == "" && content[1] == "" {
+		return Dataset{}, fmt.Errorf("%s: no %s protein/mRNA or miRNA details file", dir, database)
+	}
+	for _, path := range content {
+		if path == "" {
+			continue
+		}
+		t, err := readTable(path)
+		if err != nil {
+			return Dataset{}, err
+		}
+		if err := t.require("experiment id", "content type"); err != nil {
+			return Dataset{}, err
+		}
+		for _, row := range t.rows {
+			e := Entry{
+				Experiment: t.get(row, "experiment id"),
+				Kind:       contentKind(t.get(row, "content type")),
+				Symbol:     t.get(row, "gene symbol"),
+				EntrezID:   t.get(row, "entrez gene id"),
+				Species:    t.get(row, "species"),
+			}
+			if e.Kind == KindMiRNA {
+				if id := t.get(row, "mirna id"); id != "" {
+					e.Symbol = id
+				}
+			}
+			// Entries without a known experiment cannot be placed in a sample
+			if _, ok := d.Experiments[e.Experiment]; !ok || e.Kind == "" || e.Symbol == "" {
+				continue
+			}
+			d.Entries = append(d.Entries, e)
+		}
+	}
+	return d, nil
+}
+
+// contentKind maps a database content type to an entry kind, or "" for lipids
+// and other content that is not imported
+func contentKind(content string) string {
+	switch strings.ToLower(content) {
+	case "protein":
+		return KindProtein
+	case "mrna":
+		return KindMRNA
+	case "mirna":
+		return KindMiRNA
+	}
+	return ""
+}
+
+// VesicleType maps a database vesicle type such as "Exosomes" to the singular
+// used by ExerciseVesicle.Type
+func VesicleType(name string) string {
+	switch n := strings.ToLower(strings.TrimSpace(name)); n {
+	case "":
+		return ""
+	case "exosomes", "exosome":
+		return "Exosome"
+	case "microvesicles", "microvesicle", "microparticles":
+		return "Microvesicle"
+	case "apoptotic bodies", "apoptotic body":
+		return "Apoptotic body"
+	case "extracellular vesicles", "extracellular vesicle", "evs":
+		return "Extracellular vesicle"
+	default:
+		return strings.ToUpper(n[:1]) + n[1:]
+	}
+}
+
+// SortedExperiments returns the dataset's experiments ordered by ID
+func (d Dataset) SortedExperiments() []Experiment {
+	out := make([]Experiment, 0, len(d.Experiments))
+	for _, e := range d.Experiments {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// table is a tab-separated file with columns looked up by header name
+type table struct {
+	path    string
+	columns map[string]int
+	rows    [][]string
+}
+
+// readTable reads a TSV file with a header row. Header names are matched
+// case-insensitively, with underscores read as spaces.
+func readTable(path string) (*table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = '\t'
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%s: empty file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	t := &table{path: path, columns: make(map[string]int)}
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\uFEFF")
+		name = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(name, "_", " ")))
+		t.columns[name] = i
+	}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+// require returns an error naming the first missing column
+func (t *table) require(columns ...string) error {
+	for _, c := range columns {
+		if _, ok := t.columns[c]; !ok {
+			return fmt.Errorf("%s: missing column %q", t.path, c)
+		}
+	}
+	return nil
+}
+
+// get returns a trimmed cell by column name, or "" if the column or cell is absent
+func (t *table) get(row []string, column string) string {
+	i, ok := t.columns[column]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
@@ -0,0 +1,212 @@
+package vesicles
+
+import (
+	"exersomes/molecular_types"
+	"exersomes/network"
+	"strings"
+)
+
+// Options control how datasets merge into vesicle records
+type Options struct {
+	RequireMarkers bool // Skip experiments whose proteins fail the MISEV check, including miRNA-only ones
+}
+
+// Summary reports what a merge contributed to one vesicle record
+type Summary struct {
+	Type        string
+	Tissue      string
+	Experiments int         // Experiments merged
+	Skipped     int         // Experiments failing the marker check, with RequireMarkers
+	Markers     MarkerCheck // Over the proteins of every merged experiment
+	Cargo       int         // Distinct catalog molecules reported
+	Unresolved  int         // Distinct reported molecules outside the catalog
+}
+
+// cargoTypes names each entry kind as in ExerciseVesicle.CargoTypes
+var cargoTypes = map[string]string{
+	KindProtein: "Proteins",
+	KindMRNA:    "mRNAs",
+	KindMiRNA:   "miRNAs",
+}
+
+// Merge adds datasets, as returned by Filter, to a vesicle catalog. Experiments
+// are grouped by vesicle type and tissue; each group merges into the record of
+// the same type whose primary source normalizes to the same tissue, or into a
+// new record once one of its experiments is merged. Positive MISEV markers join
+// MarkerProteins, and reported molecules in the miRNA, RNA and protein catalogs
+// join KeyCargo, each with the experiments reporting them. Loading is not
+// recorded by the databases, so new cargo has CopiesPerVesicle 0. The catalog
+// passed in is not modified.
+func Merge(catalog []molecular_types.ExerciseVesicle, datasets []Dataset, opts Options) ([]molecular_types.ExerciseVesicle, []Summary) {
+	vesicles := make([]molecular_types.ExerciseVesicle, len(catalog))
+	index := make(map[string]int)
+	for i, v := range catalog {
+		v.MarkerProteins = append([]string(nil), v.MarkerProteins...)
+		v.CargoTypes = append([]string(nil), v.CargoTypes...)
+		v.Studies = append([]molecular_types.Provenance(nil), v.Studies...)
+		v.KeyCargo = append([]molecular_types.Cargo(nil), v.KeyCargo...)
+		for j := range v.KeyCargo {
+			v.KeyCargo[j].Studies = append([]molecular_types.Provenance(nil), v.KeyCargo[j].Studies...)
+		}
+		vesicles[i] = v
+		index[recordKey(v.Type, v.PrimarySource)] = i
+	}
+
+	type progress struct {
+		Summary
+		proteins   []string
+		cargo      map[string]bool
+		unresolved map[string]bool
+	}
+	var order []string
+	touched := make(map[string]*progress)
+
+	for _, d := range datasets {
+		entries := make(map[string][]Entry)
+		for _, e := range d.Entries {
+			entries[e.Experiment] = append(entries[e.Experiment], e)
+		}
+
+		for _, x := range d.SortedExperiments() {
+			if x.Tissue == "" {
+				continue
+			}
+			key := recordKey(x.VesicleType, x.Tissue)
+			p, ok := touched[key]
+			if !ok {
+				p = &progress{
+					Summary:    Summary{Type: x.VesicleType, Tissue: x.Tissue},
+					cargo:      make(map[string]bool),
+					unresolved: make(map[string]bool),
+				}
+				if i, ok := index[key]; ok {
+					p.Type, p.Tissue = vesicles[i].Type, vesicles[i].PrimarySource
+				}
+				touched[key] = p
+				order = append(order, key)
+			}
+
+			var proteins []string
+			for _, e := range entries[x.ID] {
+				if e.Kind == KindProtein {
+					proteins = append(proteins, e.Symbol)
+				}
+			}
+			check := CheckMarkers(proteins)
+			if opts.RequireMarkers && !check.Pass() {
+				p.Skipped++
+				continue
+			}
+			p.Experiments++
+			p.proteins = append(p.proteins, proteins...)
+
+			i, ok := index[key]
+			if !ok {
+				i = len(vesicles)
+				index[key] = i
+				vesicles = append(vesicles, molecular_types.ExerciseVesicle{Type: x.VesicleType, PrimarySource: x.Tissue})
+			}
+			v := &vesicles[i]
+			study := molecular_types.Provenance{Database: x.Database, Experiment: x.ID, PubMedID: x.PubMedID, Sample: x.Sample}
+			v.Studies = appendStudy(v.Studies, study)
+			v.MarkerProteins = appendUnique(v.MarkerProteins, check.Positive()...)
+			for _, e := range entries[x.ID] {
+				v.CargoTypes = appendUnique(v.CargoTypes, cargoTypes[e.Kind])
+				c, ok := resolve(e)
+				if !ok {
+					p.unresolved[e.Kind+"|"+strings.ToUpper(e.Symbol)] = true
+					continue
+				}
+				p.cargo[c.Kind+"|"+c.ID] = true
+				v.KeyCargo = addCargo(v.KeyCargo, c, study)
+			}
+		}
+	}
+
+	summaries := make([]Summary, 0, len(order))
+	for _, key := range order {
+		p := touched[key]
+		p.Markers = CheckMarkers(p.proteins)
+		p.Cargo = len(p.cargo)
+		p.Unresolved = len(p.unresolved)
+		summaries = append(summaries, p.Summary)
+	}
+	return vesicles, summaries
+}
+
+// recordKey matches vesicle records by type and normalized source tissue
+func recordKey(vesicleType, tissue string) string {
+	return strings.ToLower(vesicleType) + "|" + network.NormalizeTissue(tissue)
+}
+
+// resolve maps a database entry to cargo in the miRNA, RNA or protein catalog.
+// miRNA names without an arm suffix, as in older miRBase releases, match the
+// catalog's mature miRNA.
+func resolve(e Entry) (molecular_types.Cargo, bool) {
+	switch e.Kind {
+	case KindMiRNA:
+		name := strings.ToLower(e.Symbol)
+		if !strings.HasPrefix(name, "hsa-") {
+			name = "hsa-" + name
+		}
+		for _, m := range molecular_types.MiRNACatalog {
+			id := strings.ToLower(m.ID)
+			if name == id || name == strings.TrimSuffix(strings.TrimSuffix(id, "-3p"), "-5p") {
+				return molecular_types.Cargo{Kind: molecular_types.CargoMiRNA, ID: m.ID}, true
+			}
+		}
+	case KindMRNA:
+		for _, r := range molecular_types.RNACatalog {
+			if strings.EqualFold(r.Name, e.Symbol) {
+				return molecular_types.Cargo{Kind: molecular_types.CargoRNA, ID: r.Name}, true
+			}
+		}
+	case KindProtein:
+		for _, p := range molecular_types.ProteinCatalog {
+			if p.GeneSymbol != "" && strings.EqualFold(p.GeneSymbol, e.Symbol) {
+				return molecular_types.Cargo{Kind: molecular_types.CargoProtein, ID: p.UniprotID}, true
+			}
+		}
+	}
+	return molecular_types.Cargo{}, false
+}
+
+// addCargo records a study for cargo already listed, or lists it
+func addCargo(cargo []molecular_types.Cargo, c molecular_types.Cargo, study molecular_types.Provenance) []molecular_types.Cargo {
+	for i := range cargo {
+		if cargo[i].Kind == c.Kind && cargo[i].ID == c.ID {
+			cargo[i].Studies = appendStudy(cargo[i].Studies, study)
+			return cargo
+		}
+	}
+	c.Studies = []molecular_types.Provenance{study}
+	return append(cargo, c)
+}
+
+func appendStudy(studies []molecular_types.Provenance, study molecular_types.Provenance) []molecular_types.Provenance {
+	for _, s := range studies {
+		if s.Database == study.Database && s.Experiment == study.Experiment {
+			return studies
+		}
+	}
+	return append(studies, study)
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
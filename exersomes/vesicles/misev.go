@@ -0,0 +1,99 @@
+package vesicles
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Marker categories follow MISEV2018 (Théry et al., 2018). An EV preparation
+// should show at least one transmembrane (category 1) and one cytosolic
+// (category 2) protein, and be assessed for co-isolated non-EV structures
+// (category 3) and proteins of other compartments (category 4).
+var (
+	transmembraneMarkers = []string{"CD9", "CD63", "CD81", "CD82", "CD37", "CD53", "LAMP1", "LAMP2", "ITGB1"}
+	cytosolicMarkers     = []string{"TSG101", "PDCD6IP", "SDCBP", "FLOT1", "FLOT2", "CAV1", "HSPA8", "HSP90AB1", "ANXA2", "ANXA5"}
+	coIsolatedMarkers    = []string{"APOA1", "APOA2", "APOB", "ALB", "UMOD"}
+	intracellularMarkers = []string{"CANX", "HSPA5", "GOLGA2", "CYC1", "TOMM20", "IMMT", "LMNA", "LMNB1", "ATG9A"}
+)
+
+// markerAliases maps common protein names to the gene symbols the categories use
+var markerAliases = map[string]string{
+	"ALIX":      "PDCD6IP",
+	"SYNTENIN":  "SDCBP",
+	"HSC70":     "HSPA8",
+	"GRP78":     "HSPA5",
+	"GM130":     "GOLGA2",
+	"CALNEXIN":  "CANX",
+	"ALBUMIN":   "ALB",
+	"APOB100":   "APOB",
+	"FLOTILLIN": "FLOT1",
+}
+
+// MarkerCheck is a MISEV-style characterisation of the proteins reported for
+// a vesicle preparation
+type MarkerCheck struct {
+	Transmembrane []string // Category 1 found, e.g. CD9, CD63, CD81
+	Cytosolic     []string // Category 2 found, e.g. TSG101, ALIX (PDCD6IP)
+	CoIsolated    []string // Category 3 found: lipoproteins, albumin, uromodulin
+	Intracellular []string // Category 4 found: ER, Golgi, mitochondrial and nuclear proteins
+}
+
+// CheckMarkers sorts reported protein gene symbols into the MISEV categories
+func CheckMarkers(proteins []string) MarkerCheck {
+	found := make(map[string]bool)
+	for _, p := range proteins {
+		symbol := strings.ToUpper(strings.TrimSpace(p))
+		if alias, ok := markerAliases[symbol]; ok {
+			symbol = alias
+		}
+		found[symbol] = true
+	}
+	present := func(markers []string) []string {
+		var out []string
+		for _, m := range markers {
+			if found[m] {
+				out = append(out, m)
+			}
+		}
+		return out
+	}
+	return MarkerCheck{
+		Transmembrane: present(transmembraneMarkers),
+		Cytosolic:     present(cytosolicMarkers),
+		CoIsolated:    present(coIsolatedMarkers),
+		Intracellular: present(intracellularMarkers),
+	}
+}
+
+// Pass reports whether the preparation shows both a transmembrane and a
+// cytosolic EV marker. Negative markers do not fail a check: plasma EVs always
+// carry some lipoprotein, so they are reported by Findings instead.
+func (c MarkerCheck) Pass() bool {
+	return len(c.Transmembrane) > 0 && len(c.Cytosolic) > 0
+}
+
+// Positive returns the category 1 and 2 markers found, sorted
+func (c MarkerCheck) Positive() []string {
+	out := append(append([]string(nil), c.Transmembrane...), c.Cytosolic...)
+	sort.Strings(out)
+	return out
+}
+
+// Findings describes missing positive markers and any negative markers found
+func (c MarkerCheck) Findings() []string {
+	var findings []string
+	if len(c.Transmembrane) == 0 {
+		findings = append(findings, "no transmembrane EV marker (e.g. CD9, CD63, CD81)")
+	}
+	if len(c.Cytosolic) == 0 {
+		findings = append(findings, "no cytosolic EV marker (e.g. TSG101, ALIX)")
+	}
+	if len(c.CoIsolated) > 0 {
+		findings = append(findings, fmt.Sprintf("co-isolated non-EV proteins %s", strings.Join(c.CoIsolated, ", ")))
+	}
+	if len(c.Intracellular) > 0 {
+		findings = append(findings, fmt.Sprintf("proteins of other compartments %s", strings.Join(c.Intracellular, ", ")))
+	}
+	return findings
+}
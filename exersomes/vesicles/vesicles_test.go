@@ -0,0 +1,172 @@
+package vesicles
+
+import (
+	"exersomes/molecular_types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testExoCarta writes an ExoCarta download with human muscle, plasma and urine
+// experiments and a mouse one
+func testExoCarta(t *testing.T) string {
+	dir := t.TempDir()
+	writeFile(t, dir, "EXOCARTA_EXPERIMENT_DETAILS_5.txt",
+		"Experiment ID\tPubMed ID\tSpecies\tSample\tMethods\n"+
+			"1\t111\tHomo sapiens\tSkeletal muscle myotubes\tWestern blotting\n"+
+			"2\t222\tMus musculus\tSkeletal muscle\tMass spectrometry\n"+
+			"3\t333\tHomo sapiens\tUrine\tMass spectrometry\n"+
+			"4\t444\tHomo sapiens\tPlasma\tMass spectrometry\n")
+	writeFile(t, dir, "EXOCARTA_PROTEIN_MRNA_DETAILS_5.txt",
+		"Experiment ID\tContent Type\tEntrez Gene ID\tGene Symbol\tSpecies\n"+
+			"1\tprotein\t928\tCD9\tHomo sapiens\n"+
+			"1\tprotein\t7251\tTSG101\tHomo sapiens\n"+
+			"1\tprotein\t2660\tMSTN\tHomo sapiens\n"+
+			"1\tprotein\t821\tCANX\tHomo sapiens\n"+
+			"1\tmRNA\t10891\tPPARGC1A\tHomo sapiens\n"+
+			"2\tprotein\t12526\tCd63\tMus musculus\n"+
+			"3\tprotein\t975\tCD81\tHomo sapiens\n"+
+			"4\tprotein\t967\tCD63\tHomo sapiens\n"+
+			"4\tprotein\t335\tAPOA1\tHomo sapiens\n")
+	writeFile(t, dir, "EXOCARTA_MIRNA_DETAILS_5.txt",
+		"Experiment ID\tContent Type\tmiRNA ID\tSpecies\n"+
+			"1\tmiRNA\thsa-miR-1\tHomo sapiens\n"+
+			"1\tmiRNA\thsa-miR-21\tHomo sapiens\n"+
+			"4\tmiRNA\thsa-miR-486-5p\tHomo sapiens\n"+
+			"9\tmiRNA\thsa-miR-1\tHomo sapiens\n")
+	return dir
+}
+
+// Test downloads are read, unknown experiments skipped and non-human or
+// irrelevant samples filtered out
+func TestReadAndFilter(t *testing.T) {
+	d, err := ReadExoCarta(testExoCarta(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Experiments) != 4 || len(d.Entries) != 12 {
+		t.Fatalf("Expected 4 experiments and 12 entries, got %d and %d", len(d.Experiments), len(d.Entries))
+	}
+	if e := d.Experiments["1"]; e.VesicleType != "Exosome" || e.PubMedID != "111" {
+		t.Errorf("Unexpected experiment %+v", e)
+	}
+
+	f := d.Filter()
+	var tissues []string
+	for _, e := range f.SortedExperiments() {
+		tissues = append(tissues, e.Tissue)
+	}
+	if !reflect.DeepEqual(tissues, []string{"Skeletal muscle", "Plasma"}) {
+		t.Errorf("Expected human muscle and plasma experiments, got %v", tissues)
+	}
+	if len(f.Entries) != 10 {
+		t.Errorf("Expected 10 entries from kept experiments, got %d", len(f.Entries))
+	}
+
+	for sample, want := range map[string]string{
+		"Vascular smooth muscle cells": "",
+		"HUVEC":                        "Endothelial cells",
+		"Peripheral blood mononuclear": "Immune cells",
+		"Serum":                        "Plasma",
+	} {
+		if got := SampleTissue(sample); got != want {
+			t.Errorf("SampleTissue(%q) = %q, want %q", sample, got, want)
+		}
+	}
+
+	if _, err := ReadVesiclepedia(t.TempDir()); err == nil {
+		t.Error("Expected an error for a directory without Vesiclepedia files")
+	}
+}
+
+// Test MISEV categories, aliases and findings
+func TestCheckMarkers(t *testing.T) {
+	c := CheckMarkers([]string{"cd63", "ALIX", "APOA1", "GM130", "MYH7"})
+	if !c.Pass() {
+		t.Errorf("Expected CD63 with ALIX to pass, got %+v", c)
+	}
+	if !reflect.DeepEqual(c.Positive(), []string{"CD63", "PDCD6IP"}) {
+		t.Errorf("Unexpected positive markers %v", c.Positive())
+	}
+	if !reflect.DeepEqual(c.CoIsolated, []string{"APOA1"}) || !reflect.DeepEqual(c.Intracellular, []string{"GOLGA2"}) {
+		t.Errorf("Unexpected negative markers %+v", c)
+	}
+	if len(c.Findings()) != 2 {
+		t.Errorf("Expected two negative-marker findings, got %v", c.Findings())
+	}
+
+	if c := CheckMarkers([]string{"CD9", "CD81"}); c.Pass() || len(c.Findings()) != 1 {
+		t.Errorf("Expected tetraspanins alone to fail for want of a cytosolic marker, got %v", c.Findings())
+	}
+}
+
+// Test experiments merge into the catalog with provenance, new tissues become
+// new records and RequireMarkers drops uncharacterised preparations
+func TestMerge(t *testing.T) {
+	exo, err := ReadExoCarta(testExoCarta(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	writeFile(t, dir, "VESICLEPEDIA_EXPERIMENT_DETAILS_5.1.txt",
+		"EXPERIMENT_ID\tPUBMED_ID\tVESICLE_TYPE\tSPECIES\tSAMPLE\n10\t555\tMicrovesicles\tHomo sapiens\tAdipocytes\n")
+	writeFile(t, dir, "VESICLEPEDIA_PROTEIN_MRNA_DETAILS_5.1.txt",
+		"EXPERIMENT_ID\tCONTENT_TYPE\tENTREZ_GENE_ID\tGENE_SYMBOL\tSPECIES\n10\tprotein\t3569\tIL6\tHomo sapiens\n")
+	vp, err := ReadVesiclepedia(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, summaries := Merge(molecular_types.VesicleCatalog, []Dataset{exo.Filter(), vp.Filter()}, Options{})
+	if len(merged) != 3 || len(summaries) != 3 {
+		t.Fatalf("Expected muscle plus new plasma and adipose records, got %d records and %d summaries", len(merged), len(summaries))
+	}
+
+	muscle := merged[0]
+	if muscle.Kinetics != molecular_types.MuscleDerivedExosomes.Kinetics || len(muscle.KeyCargo) != len(molecular_types.MuscleDerivedExosomes.KeyCargo) {
+		t.Errorf("Expected the hand-written muscle record to keep its kinetics and cargo, got %d cargo", len(muscle.KeyCargo))
+	}
+	want := molecular_types.Provenance{Database: ExoCarta, Experiment: "1", PubMedID: "111", Sample: "Skeletal muscle myotubes"}
+	for _, c := range muscle.KeyCargo {
+		name, _ := molecular_types.CargoName(c)
+		reported := name == "miR-1" || name == "PPARGC1A" || name == "Myostatin"
+		if reported != reflect.DeepEqual(c.Studies, []molecular_types.Provenance{want}) {
+			t.Errorf("%s: unexpected provenance %+v", name, c.Studies)
+		}
+	}
+	if !reflect.DeepEqual(muscle.Studies, []molecular_types.Provenance{want}) {
+		t.Errorf("Unexpected record provenance %+v", muscle.Studies)
+	}
+	if muscle.MarkerProteins[len(muscle.MarkerProteins)-1] != "TSG101" {
+		t.Errorf("Expected TSG101 to join the muscle markers, got %v", muscle.MarkerProteins)
+	}
+	if s := summaries[0]; s.Cargo != 3 || s.Unresolved != 4 || !s.Markers.Pass() || len(s.Markers.Intracellular) != 1 {
+		t.Errorf("Unexpected muscle summary %+v", s)
+	}
+	if len(molecular_types.MuscleDerivedExosomes.KeyCargo[0].Studies) != 0 {
+		t.Error("Expected the catalog record to be left unmodified")
+	}
+
+	plasma, adipose := merged[1], merged[2]
+	if plasma.Type != "Exosome" || plasma.PrimarySource != "Plasma" || len(plasma.KeyCargo) != 1 ||
+		plasma.KeyCargo[0].ID != "hsa-miR-486-5p" || plasma.KeyCargo[0].CopiesPerVesicle != 0 {
+		t.Errorf("Unexpected plasma record %+v", plasma)
+	}
+	if adipose.Type != "Microvesicle" || adipose.PrimarySource != "Adipose tissue" ||
+		adipose.KeyCargo[0].Studies[0].Database != Vesiclepedia {
+		t.Errorf("Unexpected adipose record %+v", adipose)
+	}
+
+	records, strict := Merge(molecular_types.VesicleCatalog, []Dataset{exo.Filter()}, Options{RequireMarkers: true})
+	if len(records) != 1 || len(strict) != 2 || strict[0].Experiments != 1 || strict[1].Experiments != 0 || strict[1].Skipped != 1 {
+		t.Errorf("Expected the plasma experiment without a cytosolic marker to be skipped, got %+v", strict)
+	}
+}
@@ -0,0 +1,177 @@
+package visualize
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Anchors for Text, as in SVG text-anchor
+const (
+	AnchorStart  = "start"
+	AnchorMiddle = "middle"
+	AnchorEnd    = "end"
+)
+
+type opKind int
+
+const (
+	opRect opKind = iota
+	opLine
+	opCircle
+	opText
+)
+
+// op is one recorded drawing operation
+type op struct {
+	kind         opKind
+	x1, y1       float64
+	x2, y2       float64 // Width and height for rectangles, end point for lines
+	size         float64 // Stroke width, circle radius or font size
+	color        color.RGBA
+	text, anchor string
+	bold         bool
+	title        string // Tooltip in SVG output
+}
+
+// Figure records drawing operations once and renders them as SVG or PNG.
+// Coordinates are pixels from the top left.
+type Figure struct {
+	Width  float64
+	Height float64
+	ops    []op
+}
+
+// NewFigure returns a blank figure with a white background
+func NewFigure(width, height float64) *Figure {
+	f := &Figure{Width: width, Height: height}
+	f.Rect(0, 0, width, height, White)
+	return f
+}
+
+// Rect fills a rectangle
+func (f *Figure) Rect(x, y, w, h float64, fill color.RGBA) {
+	f.ops = append(f.ops, op{kind: opRect, x1: x, y1: y, x2: w, y2: h, color: fill})
+}
+
+// RectWithTitle fills a rectangle that shows a tooltip in SVG viewers
+func (f *Figure) RectWithTitle(x, y, w, h float64, fill color.RGBA, title string) {
+	f.ops = append(f.ops, op{kind: opRect, x1: x, y1: y, x2: w, y2: h, color: fill, title: title})
+}
+
+// Line strokes a straight line
+func (f *Figure) Line(x1, y1, x2, y2, width float64, stroke color.RGBA) {
+	f.ops = append(f.ops, op{kind: opLine, x1: x1, y1: y1, x2: x2, y2: y2, size: width, color: stroke})
+}
+
+// Polyline strokes connected line segments through the points
+func (f *Figure) Polyline(xs, ys []float64, width float64, stroke color.RGBA) {
+	for i := 1; i < len(xs) && i < len(ys); i++ {
+		f.Line(xs[i-1], ys[i-1], xs[i], ys[i], width, stroke)
+	}
+}
+
+// Circle fills a circle
+func (f *Figure) Circle(cx, cy, r float64, fill color.RGBA, title string) {
+	f.ops = append(f.ops, op{kind: opCircle, x1: cx, y1: cy, size: r, color: fill, title: title})
+}
+
+// Text draws a label with its baseline at y
+func (f *Figure) Text(x, y float64, s string, size float64, anchor string, fill color.RGBA) {
+	f.ops = append(f.ops, op{kind: opText, x1: x, y1: y, text: s, size: size, anchor: anchor, color: fill})
+}
+
+// BoldText draws a bold label with its baseline at y
+func (f *Figure) BoldText(x, y float64, s string, size float64, anchor string, fill color.RGBA) {
+	f.ops = append(f.ops, op{kind: opText, x1: x, y1: y, text: s, size: size, anchor: anchor, color: fill, bold: true})
+}
+
+// TextWidth estimates the rendered width of a label, for layout
+func TextWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * size * 0.6
+}
+
+// WriteSVG renders the figure as an SVG document
+func (f *Figure) WriteSVG(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		f.Width, f.Height, f.Width, f.Height)
+	for _, o := range f.ops {
+		switch o.kind {
+		case opRect:
+			fmt.Fprintf(bw, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"%s>`, o.x1, o.y1, o.x2, o.y2, hex(o.color), opacity("fill", o.color))
+			writeTitle(bw, o.title)
+			fmt.Fprintln(bw, "</rect>")
+		case opLine:
+			fmt.Fprintf(bw, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s" stroke-width="%g"%s stroke-linecap="round"/>`+"\n",
+				o.x1, o.y1, o.x2, o.y2, hex(o.color), o.size, opacity("stroke", o.color))
+		case opCircle:
+			fmt.Fprintf(bw, `<circle cx="%.2f" cy="%.2f" r="%.2f" fill="%s"%s>`, o.x1, o.y1, o.size, hex(o.color), opacity("fill", o.color))
+			writeTitle(bw, o.title)
+			fmt.Fprintln(bw, "</circle>")
+		case opText:
+			weight := ""
+			if o.bold {
+				weight = ` font-weight="bold"`
+			}
+			fmt.Fprintf(bw, `<text x="%.2f" y="%.2f" font-size="%g" text-anchor="%s" fill="%s"%s>%s</text>`+"\n",
+				o.x1, o.y1, o.size, o.anchor, hex(o.color), weight, escape(o.text))
+		}
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// WriteFile writes the figure as SVG or PNG, chosen by the path's extension
+func (f *Figure) WriteFile(path string) error {
+	var write func(io.Writer) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		write = f.WriteSVG
+	case ".png":
+		write = f.WritePNG
+	default:
+		return fmt.Errorf("%s: unsupported image format, use .svg or .png", path)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Close()
+}
+
+func writeTitle(w io.Writer, title string) {
+	if title != "" {
+		fmt.Fprintf(w, "<title>%s</title>", escape(title))
+	}
+}
+
+// hex formats a color without its alpha; Figure colors are premultiplied
+func hex(c color.RGBA) string {
+	if c.A == 0 {
+		return "none"
+	}
+	unmultiply := func(v uint8) uint8 { return uint8(min(255, int(v)*255/int(c.A))) }
+	return fmt.Sprintf("#%02x%02x%02x", unmultiply(c.R), unmultiply(c.G), unmultiply(c.B))
+}
+
+func opacity(attr string, c color.RGBA) string {
+	if c.A == 255 {
+		return ""
+	}
+	return fmt.Sprintf(` %s-opacity="%.3f"`, attr, float64(c.A)/255)
+}
+
+var svgEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func escape(s string) string {
+	return svgEscaper.Replace(s)
+}
@@ -0,0 +1,154 @@
+package visualize
+
+import (
+	"exersomes/molecular_types"
+	"exersomes/network"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Heatmap is a matrix of signed values with labelled rows and columns
+type Heatmap struct {
+	Title   string
+	Rows    []string
+	Columns []string
+	Values  [][]float64 // Values[row][column]; NaN where there is no data
+	Label   string      // Legend caption, e.g. "log2 fold change"
+	Limit   float64     // The color scale saturates at ±Limit; 0 uses the largest |value|
+}
+
+// ExerkineTissueHeatmap places each exerkine's predicted log2 fold change after
+// a session of exerciseType under the tissues that secrete it
+func ExerkineTissueHeatmap(net molecular_types.ExerkineNetwork, exerciseType string) Heatmap {
+	response := network.ExerciseResponse(exerciseType)
+	sources := make(map[string]map[string]bool) // ligand -> source tissues
+	addSource := func(ligand, tissue string) {
+		if tissue == "" {
+			return
+		}
+		if sources[ligand] == nil {
+			sources[ligand] = make(map[string]bool)
+		}
+		sources[ligand][network.NormalizeTissue(tissue)] = true
+	}
+	for _, n := range net.Nodes {
+		for _, t := range n.TissueSources {
+			addSource(n.Name, t)
+		}
+	}
+	for _, in := range net.Interactions {
+		addSource(in.Ligand, in.SourceTissue)
+	}
+
+	h := Heatmap{
+		Title: fmt.Sprintf("Exerkine response to %s exercise by source tissue", exerciseType),
+		Label: "log2 fold change",
+	}
+	tissues := make(map[string]bool)
+	for ligand, ts := range sources {
+		if _, ok := response[network.CanonicalLigand(ligand)]; !ok {
+			continue
+		}
+		h.Rows = append(h.Rows, ligand)
+		for t := range ts {
+			tissues[t] = true
+		}
+	}
+	sort.Strings(h.Rows)
+	for t := range tissues {
+		h.Columns = append(h.Columns, t)
+	}
+	sort.Strings(h.Columns)
+
+	for _, ligand := range h.Rows {
+		row := make([]float64, len(h.Columns))
+		change := response[network.CanonicalLigand(ligand)]
+		for j, t := range h.Columns {
+			row[j] = math.NaN()
+			if sources[ligand][t] {
+				row[j] = change
+			}
+		}
+		h.Values = append(h.Values, row)
+	}
+	return h
+}
+
+// Heatmap layout, in pixels
+const (
+	cellHeight  = 18
+	labelSize   = 11
+	titleSize   = 16
+	marginSize  = 20
+	legendWidth = 90
+)
+
+// Figure draws the heatmap with a diverging color scale and legend. Column
+// labels alternate between two lines so narrow cells keep readable labels.
+func (h Heatmap) Figure() *Figure {
+	limit := h.Limit
+	if limit <= 0 {
+		for _, row := range h.Values {
+			for _, v := range row {
+				if !math.IsNaN(v) {
+					limit = math.Max(limit, math.Abs(v))
+				}
+			}
+		}
+	}
+
+	rowLabelWidth, columnLabelWidth := 0.0, 0.0
+	for _, r := range h.Rows {
+		rowLabelWidth = math.Max(rowLabelWidth, TextWidth(r, labelSize))
+	}
+	for _, c := range h.Columns {
+		columnLabelWidth = math.Max(columnLabelWidth, TextWidth(c, labelSize))
+	}
+	cellWidth := math.Max(cellHeight*1.5, columnLabelWidth/2+6)
+
+	left := marginSize + rowLabelWidth + 8
+	top := float64(marginSize + titleSize + 12 + 2*(labelSize+4))
+	gridWidth := cellWidth * float64(len(h.Columns))
+	gridHeight := cellHeight * float64(len(h.Rows))
+	legend := math.Max(legendWidth, 24+TextWidth(h.Label, labelSize))
+	width := math.Max(left+gridWidth+legend+marginSize, marginSize*2+TextWidth(h.Title, titleSize))
+	height := math.Max(top+gridHeight+marginSize, top+160)
+
+	f := NewFigure(width, height)
+	f.BoldText(marginSize, marginSize+titleSize, h.Title, titleSize, AnchorStart, Black)
+
+	for j, c := range h.Columns {
+		x := left + (float64(j)+0.5)*cellWidth
+		y := top - 6 - float64(1-j%2)*(labelSize+4)
+		f.Text(x, y, c, labelSize, AnchorMiddle, Black)
+	}
+	for i, r := range h.Rows {
+		y := top + float64(i)*cellHeight
+		f.Text(left-8, y+cellHeight-5, r, labelSize, AnchorEnd, Black)
+		for j, c := range h.Columns {
+			v := math.NaN()
+			if i < len(h.Values) && j < len(h.Values[i]) {
+				v = h.Values[i][j]
+			}
+			title := fmt.Sprintf("%s in %s: no data", r, c)
+			if !math.IsNaN(v) {
+				title = fmt.Sprintf("%s in %s: %.3g", r, c, v)
+			}
+			f.RectWithTitle(left+float64(j)*cellWidth, y, cellWidth-1, cellHeight-1, Diverging(v, limit), title)
+		}
+	}
+
+	// Legend: a vertical color bar from +limit to -limit
+	lx, ly := left+gridWidth+24, top
+	const steps, barHeight = 20, 120.0
+	for s := 0; s < steps; s++ {
+		v := limit - 2*limit*(float64(s)+0.5)/steps
+		f.Rect(lx, ly+float64(s)*barHeight/steps, 14, barHeight/steps+0.5, Diverging(v, limit))
+	}
+	f.Text(lx+18, ly+labelSize-2, fmt.Sprintf("%+.2g", limit), labelSize, AnchorStart, Black)
+	f.Text(lx+18, ly+barHeight/2+labelSize/2-1, "0", labelSize, AnchorStart, Black)
+	f.Text(lx+18, ly+barHeight, fmt.Sprintf("%+.2g", -limit), labelSize, AnchorStart, Black)
+	f.Text(lx, ly+barHeight+labelSize+8, h.Label, labelSize, AnchorStart, Gray)
+	return f
+}
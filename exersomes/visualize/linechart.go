@@ -0,0 +1,171 @@
+package visualize
+
+import (
+	"exersomes/components/cardiovascular/bloodstream"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Series is one named line of a chart, with a value per X
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// LineChart plots series against shared X values
+type LineChart struct {
+	Title  string
+	XLabel string
+	YLabel string
+	X      []float64
+	Series []Series
+}
+
+// CirculatingTimeCourse charts PredictCirculatingProfileDuringExercise for each
+// circulating factor as fold change from its level at the start of exercise,
+// so hormones, metabolites and cytokines share one axis
+func CirculatingTimeCourse(exerciseType string, intensityPercent, durationMinutes float64, points int) LineChart {
+	profile := bloodstream.PredictCirculatingProfileDuringExercise(exerciseType, intensityPercent, durationMinutes, points)
+	c := LineChart{
+		Title:  fmt.Sprintf("Circulating factors during %s exercise (%g%%, %g min)", exerciseType, intensityPercent, durationMinutes),
+		XLabel: "Time (min)",
+		YLabel: "Fold change from rest",
+	}
+	for i := 0; i < points; i++ {
+		c.X = append(c.X, durationMinutes*float64(i)/float64(points-1))
+	}
+	names := make([]string, 0, len(profile))
+	for name := range profile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := profile[name]
+		if len(values) == 0 || values[0] <= 0 {
+			continue
+		}
+		s := Series{Name: name}
+		for _, v := range values {
+			s.Values = append(s.Values, v/values[0])
+		}
+		c.Series = append(c.Series, s)
+	}
+	return c
+}
+
+// Line chart layout, in pixels
+const (
+	plotWidth  = 640
+	plotHeight = 360
+	axisMargin = 56
+)
+
+// Figure draws the chart with axes, gridlines and a legend
+func (c LineChart) Figure() *Figure {
+	xMin, xMax := bounds(c.X)
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	for _, s := range c.Series {
+		lo, hi := bounds(s.Values)
+		yMin, yMax = math.Min(yMin, lo), math.Max(yMax, hi)
+	}
+	if math.IsInf(yMin, 0) {
+		yMin, yMax = 0, 1
+	}
+	xTicks := niceTicks(xMin, xMax, 6)
+	yTicks := niceTicks(yMin, yMax, 6)
+	xMin, xMax = math.Min(xMin, xTicks[0]), math.Max(xMax, xTicks[len(xTicks)-1])
+	yMin, yMax = math.Min(yMin, yTicks[0]), math.Max(yMax, yTicks[len(yTicks)-1])
+
+	legendWidth := 0.0
+	for _, s := range c.Series {
+		legendWidth = math.Max(legendWidth, TextWidth(s.Name, labelSize)+28)
+	}
+	left, top := float64(marginSize+axisMargin), float64(marginSize+titleSize+20)
+	width := math.Max(left+plotWidth+legendWidth+2*marginSize, 2*marginSize+TextWidth(c.Title, titleSize))
+	height := top + plotHeight + axisMargin + marginSize
+
+	px := func(x float64) float64 { return left + (x-xMin)/span(xMin, xMax)*plotWidth }
+	py := func(y float64) float64 { return top + plotHeight - (y-yMin)/span(yMin, yMax)*plotHeight }
+
+	f := NewFigure(width, height)
+	f.BoldText(marginSize, marginSize+titleSize, c.Title, titleSize, AnchorStart, Black)
+	for _, t := range yTicks {
+		f.Line(left, py(t), left+plotWidth, py(t), 1, LightGray)
+		f.Text(left-6, py(t)+4, formatTick(t), labelSize, AnchorEnd, Gray)
+	}
+	for _, t := range xTicks {
+		f.Line(px(t), top+plotHeight, px(t), top+plotHeight+4, 1, Gray)
+		f.Text(px(t), top+plotHeight+labelSize+8, formatTick(t), labelSize, AnchorMiddle, Gray)
+	}
+	f.Line(left, top, left, top+plotHeight, 1, Gray)
+	f.Line(left, top+plotHeight, left+plotWidth, top+plotHeight, 1, Gray)
+	f.Text(left+plotWidth/2, top+plotHeight+labelSize*2+18, c.XLabel, labelSize+1, AnchorMiddle, Black)
+	f.Text(marginSize, top-8, c.YLabel, labelSize+1, AnchorStart, Black)
+
+	for i, s := range c.Series {
+		var xs, ys []float64
+		for j, v := range s.Values {
+			if j < len(c.X) && !math.IsNaN(v) {
+				xs, ys = append(xs, px(c.X[j])), append(ys, py(v))
+			}
+		}
+		f.Polyline(xs, ys, 2, Category(i))
+
+		ly := top + float64(i)*(labelSize+8)
+		lx := left + plotWidth + 16
+		f.Line(lx, ly+labelSize/2, lx+18, ly+labelSize/2, 3, Category(i))
+		f.Text(lx+24, ly+labelSize-1, s.Name, labelSize, AnchorStart, Black)
+	}
+	return f
+}
+
+// bounds returns the smallest and largest non-NaN values
+func bounds(values []float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	return lo, hi
+}
+
+func span(lo, hi float64) float64 {
+	if hi > lo {
+		return hi - lo
+	}
+	return 1
+}
+
+// niceTicks returns about n evenly spaced round values covering [lo, hi]
+func niceTicks(lo, hi float64, n int) []float64 {
+	if math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		return []float64{0, 1}
+	}
+	if hi <= lo {
+		lo, hi = lo-1, hi+1
+	}
+	raw := (hi - lo) / float64(n-1)
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := magnitude * 10
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if m*magnitude >= raw {
+			step = m * magnitude
+			break
+		}
+	}
+	var ticks []float64
+	for t := math.Floor(lo/step) * step; t <= hi+step*1e-9; t += step {
+		ticks = append(ticks, math.Round(t/step)*step)
+	}
+	if ticks[len(ticks)-1] < hi {
+		ticks = append(ticks, ticks[len(ticks)-1]+step)
+	}
+	return ticks
+}
+
+func formatTick(v float64) string {
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
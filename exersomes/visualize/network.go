@@ -0,0 +1,177 @@
+package visualize
+
+import (
+	"exersomes/graph"
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// Point is a node position in figure pixels
+type Point struct {
+	X float64
+	Y float64
+}
+
+// LayoutOptions control the force-directed network layout
+type LayoutOptions struct {
+	Width      float64 // Drawing area; 0 uses 900
+	Height     float64 // 0 uses 700
+	Iterations int     // 0 uses 300
+	Seed       int64   // Layouts are identical for the same seed
+}
+
+// ForceLayout places nodes with the Fruchterman–Reingold algorithm: every pair
+// repels, edges attract in proportion to their weight, and a weak pull toward
+// the centre keeps disconnected components in frame. Positions are scaled to
+// fill the drawing area.
+func ForceLayout(g *graph.Graph, opts LayoutOptions) []Point {
+	if opts.Width == 0 {
+		opts.Width = 900
+	}
+	if opts.Height == 0 {
+		opts.Height = 700
+	}
+	if opts.Iterations == 0 {
+		opts.Iterations = 300
+	}
+	n := g.Len()
+	pos := make([]Point, n)
+	if n == 0 {
+		return pos
+	}
+	r := rand.New(rand.NewSource(opts.Seed))
+	for i := range pos {
+		pos[i] = Point{r.Float64() * opts.Width, r.Float64() * opts.Height}
+	}
+
+	edges := g.Edges()
+	k := math.Sqrt(opts.Width * opts.Height / float64(n))
+	temperature := opts.Width / 10
+	cooling := temperature / float64(opts.Iterations+1)
+	center := Point{opts.Width / 2, opts.Height / 2}
+	disp := make([]Point, n)
+	for iter := 0; iter < opts.Iterations; iter++ {
+		for i := range disp {
+			disp[i] = Point{}
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				dx, dy := pos[i].X-pos[j].X, pos[i].Y-pos[j].Y
+				d := math.Max(math.Hypot(dx, dy), 0.01)
+				force := k * k / d
+				disp[i].X += dx / d * force
+				disp[i].Y += dy / d * force
+				disp[j].X -= dx / d * force
+				disp[j].Y -= dy / d * force
+			}
+		}
+		for _, e := range edges {
+			dx, dy := pos[e.From].X-pos[e.To].X, pos[e.From].Y-pos[e.To].Y
+			d := math.Max(math.Hypot(dx, dy), 0.01)
+			force := d * d / k * math.Max(e.Weight, 0.1)
+			disp[e.From].X -= dx / d * force
+			disp[e.From].Y -= dy / d * force
+			disp[e.To].X += dx / d * force
+			disp[e.To].Y += dy / d * force
+		}
+		for i := range pos {
+			disp[i].X += (center.X - pos[i].X) * 0.05 * k / 10
+			disp[i].Y += (center.Y - pos[i].Y) * 0.05 * k / 10
+			d := math.Hypot(disp[i].X, disp[i].Y)
+			if d > 0 {
+				step := math.Min(d, temperature)
+				pos[i].X += disp[i].X / d * step
+				pos[i].Y += disp[i].Y / d * step
+			}
+		}
+		temperature -= cooling
+	}
+	return fit(pos, opts.Width, opts.Height)
+}
+
+// fit scales positions into [0, width] × [0, height], keeping the aspect ratio
+func fit(pos []Point, width, height float64) []Point {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range pos {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	scale := math.Min(width/span(minX, maxX), height/span(minY, maxY))
+	offsetX := (width - (maxX-minX)*scale) / 2
+	offsetY := (height - (maxY-minY)*scale) / 2
+	out := make([]Point, len(pos))
+	for i, p := range pos {
+		out[i] = Point{offsetX + (p.X-minX)*scale, offsetY + (p.Y-minY)*scale}
+	}
+	return out
+}
+
+// kindStyles gives each node kind a color and radius
+var kindStyles = []struct {
+	kind   graph.NodeKind
+	color  color.RGBA
+	radius float64
+}{
+	{graph.KindTissue, Category(0), 11},
+	{graph.KindLigand, Category(1), 6},
+	{graph.KindReceptor, Category(4), 4},
+}
+
+// NetworkFigure draws a graph at the given positions: tissues, ligands and
+// receptors in distinct colors, edges shaded by weight, and labels on tissues
+// and ligands. Receptors are left unlabelled to keep dense networks legible.
+func NetworkFigure(g *graph.Graph, pos []Point, title string) *Figure {
+	// Reserve room for the legend above the drawing and for ligand labels,
+	// which extend right of their nodes
+	maxX, maxY, labelRight := 0.0, 0.0, 0.0
+	for i, p := range pos {
+		maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+		if g.Nodes[i].Kind == graph.KindLigand {
+			labelRight = math.Max(labelRight, p.X+10+TextWidth(g.Nodes[i].Name, labelSize-1))
+		}
+	}
+	left, top := float64(marginSize+60), float64(marginSize+titleSize+56)
+	f := NewFigure(left+math.Max(maxX+60, labelRight)+marginSize, top+maxY+marginSize+20)
+	f.BoldText(marginSize, marginSize+titleSize, title, titleSize, AnchorStart, Black)
+	x := float64(marginSize) + 12
+	for _, s := range kindStyles {
+		y := float64(marginSize + titleSize + 24)
+		f.Circle(x, y, s.radius, s.color, "")
+		f.Text(x+s.radius+6, y+4, string(s.kind), labelSize, AnchorStart, Black)
+		x += s.radius + 6 + TextWidth(string(s.kind), labelSize) + 24
+	}
+
+	maxWeight := 0.0
+	edges := g.Edges()
+	for _, e := range edges {
+		maxWeight = math.Max(maxWeight, e.Weight)
+	}
+	for _, e := range edges {
+		a, b := pos[e.From], pos[e.To]
+		f.Line(left+a.X, top+a.Y, left+b.X, top+b.Y, 0.5+1.5*e.Weight/maxWeight, Fade(Gray, 0.35))
+	}
+
+	for _, s := range kindStyles {
+		for i, n := range g.Nodes {
+			if n.Kind != s.kind {
+				continue
+			}
+			p := pos[i]
+			label := n.Name
+			if n.Tissue != "" {
+				label = fmt.Sprintf("%s (%s)", n.Name, n.Tissue)
+			}
+			f.Circle(left+p.X, top+p.Y, s.radius, s.color, label)
+			switch n.Kind {
+			case graph.KindTissue:
+				f.BoldText(left+p.X, top+p.Y-s.radius-4, n.Name, labelSize+1, AnchorMiddle, Black)
+			case graph.KindLigand:
+				f.Text(left+p.X+s.radius+3, top+p.Y+4, n.Name, labelSize-1, AnchorStart, Gray)
+			}
+		}
+	}
+	return f
+}
@@ -0,0 +1,61 @@
+package visualize
+
+import (
+	"image/color"
+	"math"
+)
+
+// Colors shared by the charts
+var (
+	White     = color.RGBA{255, 255, 255, 255}
+	Black     = color.RGBA{33, 33, 33, 255}
+	Gray      = color.RGBA{117, 117, 117, 255}
+	LightGray = color.RGBA{224, 224, 224, 255}
+	Missing   = color.RGBA{245, 245, 245, 255}
+)
+
+// Categorical holds distinguishable colors for series and node kinds (Tableau 10)
+var Categorical = []color.RGBA{
+	{78, 121, 167, 255}, {242, 142, 43, 255}, {225, 87, 89, 255}, {118, 183, 178, 255},
+	{89, 161, 79, 255}, {237, 201, 72, 255}, {176, 122, 161, 255}, {255, 157, 167, 255},
+	{156, 117, 95, 255}, {186, 176, 172, 255},
+}
+
+// Category returns the i-th categorical color, cycling
+func Category(i int) color.RGBA {
+	return Categorical[i%len(Categorical)]
+}
+
+// Fade scales a color's opacity, keeping it premultiplied
+func Fade(c color.RGBA, alpha float64) color.RGBA {
+	alpha = math.Max(0, math.Min(1, alpha))
+	scale := func(v uint8) uint8 { return uint8(math.Round(float64(v) * alpha)) }
+	return color.RGBA{scale(c.R), scale(c.G), scale(c.B), scale(c.A)}
+}
+
+var (
+	divergingLow  = color.RGBA{33, 102, 172, 255}
+	divergingMid  = color.RGBA{247, 247, 247, 255}
+	divergingHigh = color.RGBA{178, 24, 43, 255}
+)
+
+// Diverging maps v in [-limit, limit] from blue through white to red, for
+// signed values such as log2 fold changes. NaN maps to Missing.
+func Diverging(v, limit float64) color.RGBA {
+	if math.IsNaN(v) {
+		return Missing
+	}
+	if limit <= 0 {
+		return divergingMid
+	}
+	t := math.Max(-1, math.Min(1, v/limit))
+	if t < 0 {
+		return mix(divergingMid, divergingLow, -t)
+	}
+	return mix(divergingMid, divergingHigh, t)
+}
+
+func mix(a, b color.RGBA, t float64) color.RGBA {
+	lerp := func(x, y uint8) uint8 { return uint8(math.Round(float64(x) + t*(float64(y)-float64(x)))) }
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), 255}
+}
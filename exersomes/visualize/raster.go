@@ -0,0 +1,110 @@
+package visualize
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Image rasterizes the figure. Lines and circles are antialiased; text uses a
+// built-in 5×7 bitmap font, scaled to the nearest whole multiple of its size.
+func (f *Figure) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(f.Width)), int(math.Ceil(f.Height))))
+	for _, o := range f.ops {
+		switch o.kind {
+		case opRect:
+			r := image.Rect(int(math.Round(o.x1)), int(math.Round(o.y1)),
+				int(math.Round(o.x1+o.x2)), int(math.Round(o.y1+o.y2)))
+			draw.Draw(img, r, image.NewUniform(o.color), image.Point{}, draw.Over)
+		case opLine:
+			strokeLine(img, o.x1, o.y1, o.x2, o.y2, o.size/2, o.color)
+		case opCircle:
+			fillCircle(img, o.x1, o.y1, o.size, o.color)
+		case opText:
+			drawText(img, o.x1, o.y1, o.text, o.size, o.anchor, o.bold, o.color)
+		}
+	}
+	return img
+}
+
+// WritePNG renders the figure as a PNG image
+func (f *Figure) WritePNG(w io.Writer) error {
+	return png.Encode(w, f.Image())
+}
+
+// blend composites a premultiplied color over a pixel with partial coverage
+func blend(img *image.RGBA, x, y int, c color.RGBA, coverage float64) {
+	if !(image.Point{x, y}.In(img.Rect)) || coverage <= 0 {
+		return
+	}
+	coverage = math.Min(coverage, 1)
+	i := img.PixOffset(x, y)
+	keep := 1 - float64(c.A)*coverage/255
+	for k, v := range [4]uint8{c.R, c.G, c.B, c.A} {
+		img.Pix[i+k] = uint8(math.Round(float64(v)*coverage + float64(img.Pix[i+k])*keep))
+	}
+}
+
+func strokeLine(img *image.RGBA, x1, y1, x2, y2, half float64, c color.RGBA) {
+	minX, maxX := int(math.Floor(math.Min(x1, x2)-half-1)), int(math.Ceil(math.Max(x1, x2)+half+1))
+	minY, maxY := int(math.Floor(math.Min(y1, y2)-half-1)), int(math.Ceil(math.Max(y1, y2)+half+1))
+	dx, dy := x2-x1, y2-y1
+	length2 := dx*dx + dy*dy
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			t := 0.0
+			if length2 > 0 {
+				t = math.Max(0, math.Min(1, ((px-x1)*dx+(py-y1)*dy)/length2))
+			}
+			d := math.Hypot(px-(x1+t*dx), py-(y1+t*dy))
+			blend(img, x, y, c, half+0.5-d)
+		}
+	}
+}
+
+func fillCircle(img *image.RGBA, cx, cy, r float64, c color.RGBA) {
+	for y := int(math.Floor(cy - r - 1)); y <= int(math.Ceil(cy+r+1)); y++ {
+		for x := int(math.Floor(cx - r - 1)); x <= int(math.Ceil(cx+r+1)); x++ {
+			d := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy)
+			blend(img, x, y, c, r+0.5-d)
+		}
+	}
+}
+
+func drawText(img *image.RGBA, x, baseline float64, s string, size float64, anchor string, bold bool, c color.RGBA) {
+	scale := max(1, int(math.Round(size/9)))
+	runes := []rune(s)
+	width := float64(len(runes)*glyphAdvance*scale - scale)
+	switch anchor {
+	case AnchorMiddle:
+		x -= width / 2
+	case AnchorEnd:
+		x -= width
+	}
+	left := int(math.Round(x))
+	top := int(math.Round(baseline)) - glyphAscent*scale
+	for i, r := range runes {
+		columns := glyph(r)
+		for col, bits := range columns {
+			for row := 0; row < 8; row++ {
+				if bits&(1<<row) == 0 {
+					continue
+				}
+				for sy := 0; sy < scale; sy++ {
+					for sx := 0; sx < scale; sx++ {
+						px := left + (i*glyphAdvance+col)*scale + sx
+						py := top + row*scale + sy
+						blend(img, px, py, c, 1)
+						if bold {
+							blend(img, px+1, py, c, 1)
+						}
+					}
+				}
+			}
+		}
+	}
+}
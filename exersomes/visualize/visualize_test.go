@@ -0,0 +1,145 @@
+package visualize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"exersomes/graph"
+	"exersomes/molecular_types"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testNetwork is a small crosstalk network between muscle, liver and adipose
+func testNetwork() molecular_types.ExerkineNetwork {
+	edge := func(ligand, receptor, source, target string) molecular_types.Interaction {
+		return molecular_types.Interaction{
+			Ligand: ligand, Receptor: receptor, Tissue: target,
+			SourceTissue: source, TargetTissue: target, Strength: 1.0,
+		}
+	}
+	return molecular_types.ExerkineNetwork{
+		Nodes: []molecular_types.Exerkine{
+			{Name: "IL-6", TissueSources: []string{"Muscle"}},
+			{Name: "BDNF", TissueSources: []string{"Brain", "Muscle"}},
+			{Name: "Unpredicted"},
+		},
+		Interactions: []molecular_types.Interaction{
+			edge("IL-6", "IL6R", "Muscle", "Liver"),
+			edge("IL-6", "IL6R", "Muscle", "Adipose"),
+			edge("BDNF", "TrkB", "Brain", "Brain"),
+		},
+	}
+}
+
+// wellFormed reports whether an SVG document parses as XML
+func wellFormed(t *testing.T, svg []byte) {
+	t.Helper()
+	d := xml.NewDecoder(bytes.NewReader(svg))
+	for {
+		if _, err := d.Token(); err == io.EOF {
+			return
+		} else if err != nil {
+			t.Fatalf("Malformed SVG: %v", err)
+		}
+	}
+}
+
+// Test the heatmap places predicted fold changes under source tissues
+func TestHeatmap(t *testing.T) {
+	h := ExerkineTissueHeatmap(testNetwork(), "Aerobic")
+	if !reflect.DeepEqual(h.Rows, []string{"BDNF", "IL-6"}) {
+		t.Fatalf("Expected the predicted ligands as rows, got %v", h.Rows)
+	}
+	for i, row := range h.Values {
+		for j, v := range row {
+			secreted := h.Columns[j] == "Muscle" || (h.Rows[i] == "BDNF" && h.Columns[j] == "Brain")
+			if secreted == math.IsNaN(v) {
+				t.Errorf("%s in %s: unexpected value %g", h.Rows[i], h.Columns[j], v)
+			}
+		}
+	}
+
+	var svg bytes.Buffer
+	if err := h.Figure().WriteSVG(&svg); err != nil {
+		t.Fatal(err)
+	}
+	wellFormed(t, svg.Bytes())
+	if !strings.Contains(svg.String(), "IL-6 in Muscle: ") {
+		t.Error("Expected cell tooltips in the SVG")
+	}
+}
+
+// Test the time course and its PNG rendering
+func TestTimeCourse(t *testing.T) {
+	c := CirculatingTimeCourse("Aerobic", 70, 60, 13)
+	if len(c.X) != 13 || c.X[12] != 60 || len(c.Series) == 0 {
+		t.Fatalf("Unexpected chart %+v", c)
+	}
+	for _, s := range c.Series {
+		if s.Values[0] != 1 {
+			t.Errorf("%s: expected fold change 1 at rest, got %g", s.Name, s.Values[0])
+		}
+	}
+
+	f := c.Figure()
+	var buf bytes.Buffer
+	if err := f.WritePNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != int(math.Ceil(f.Width)) {
+		t.Errorf("Expected width %g, got %d", f.Width, img.Bounds().Dx())
+	}
+	// The first series color must appear among the plotted lines
+	want := Category(0)
+	found := false
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y && !found; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			if color.RGBAModel.Convert(img.At(x, y)) == want {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected the first series to be drawn in the PNG")
+	}
+}
+
+// Test the layout is reproducible, fills the area and pulls neighbours together
+func TestForceLayout(t *testing.T) {
+	g := graph.FromNetwork(testNetwork())
+	opts := LayoutOptions{Width: 400, Height: 300, Seed: 3}
+	pos := ForceLayout(g, opts)
+	if !reflect.DeepEqual(pos, ForceLayout(g, opts)) {
+		t.Error("Expected identical layouts for the same seed")
+	}
+	for i, p := range pos {
+		if p.X < -1e-9 || p.X > 400+1e-9 || p.Y < -1e-9 || p.Y > 300+1e-9 {
+			t.Errorf("%s placed outside the area at %+v", g.Nodes[i].ID, p)
+		}
+	}
+
+	distance := func(a, b string) float64 {
+		i, _ := g.Lookup(a)
+		j, _ := g.Lookup(b)
+		return math.Hypot(pos[i].X-pos[j].X, pos[i].Y-pos[j].Y)
+	}
+	if distance(graph.LigandID("IL-6"), graph.TissueID("Muscle")) >= distance(graph.LigandID("IL-6"), graph.LigandID("Unpredicted")) {
+		t.Error("Expected IL-6 nearer its source tissue than an unconnected ligand")
+	}
+
+	var svg bytes.Buffer
+	if err := NetworkFigure(g, pos, "Test network").WriteSVG(&svg); err != nil {
+		t.Fatal(err)
+	}
+	wellFormed(t, svg.Bytes())
+}